@@ -0,0 +1,262 @@
+//go:build compare
+
+package compare
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/go-playground/validator/v10"
+	"github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/benchmarks/corpus"
+)
+
+var report = flag.String("report", "", "write a Markdown comparison table to this file")
+
+// check validates one corpus value with one library
+type check func() error
+
+// shape is a corpus entry with a check per library; a nil check means the
+// library has no implementation for the shape
+type shape struct {
+	name   string
+	checks map[string]check
+}
+
+// libraries are the report columns in order
+var libraries = []string{"go-validation (generated)", "go-validation", "go-playground/validator", "ozzo-validation"}
+
+// shapes builds the corpus checks
+func shapes() []shape {
+	playground := validator.New(validator.WithRequiredStructEnabled())
+	// go-playground spells minitems as min on collections
+	_ = playground.RegisterValidation("minitems", func(fl validator.FieldLevel) bool {
+		var n int
+		fmt.Sscan(fl.Param(), &n)
+		return fl.Field().Len() >= n
+	})
+
+	small, medium, large := corpus.NewSmall(), corpus.NewMedium(), corpus.NewLarge()
+	nested, cross := corpus.NewNested(), corpus.NewCrossField()
+	dive10, dive100 := corpus.NewDive(10), corpus.NewDive(100)
+	smallValidator, mediumValidator, largeValidator := corpus.NewSmallValidator(), corpus.NewMediumValidator(), corpus.NewLargeValidator()
+
+	entry := func(name string, value interface{}, generated, handwritten check) shape {
+		return shape{name: name, checks: map[string]check{
+			"go-validation (generated)": generated,
+			"go-validation":             func() error { return validation.Struct(value) },
+			"go-playground/validator":   func() error { return playground.Struct(value) },
+			"ozzo-validation":           handwritten,
+		}}
+	}
+	return []shape{
+		entry("Small", small, func() error { return smallValidator.Validate(small) }, func() error { return ozzoSmall(small) }),
+		entry("Medium", medium, func() error { return mediumValidator.Validate(medium) }, func() error { return ozzoMedium(medium) }),
+		entry("Large", large, func() error { return largeValidator.Validate(large) }, func() error { return ozzoLarge(large) }),
+		entry("Nested", nested, nil, func() error { return ozzoNested(nested) }),
+		entry("CrossField", cross, nil, func() error { return ozzoCrossField(cross) }),
+		entry("Dive10", dive10, nil, func() error { return ozzoDive(dive10) }),
+		entry("Dive100", dive100, nil, func() error { return ozzoDive(dive100) }),
+	}
+}
+
+func ozzoSmall(s *corpus.Small) error {
+	return ozzo.ValidateStruct(s,
+		ozzo.Field(&s.Name, ozzo.Required, ozzo.Length(3, 32)),
+		ozzo.Field(&s.Email, ozzo.Required, is.Email),
+		ozzo.Field(&s.Age, ozzo.Min(0), ozzo.Max(150)),
+		ozzo.Field(&s.Role, ozzo.In("admin", "user", "guest")),
+	)
+}
+
+func ozzoMedium(m *corpus.Medium) error {
+	return ozzo.ValidateStruct(m,
+		ozzo.Field(&m.Service, ozzo.Required, is.Alphanumeric, ozzo.Length(2, 64)),
+		ozzo.Field(&m.Host, ozzo.Required, is.DNSName),
+		ozzo.Field(&m.Port, ozzo.Required, ozzo.Min(1), ozzo.Max(65535)),
+		ozzo.Field(&m.AdminPort, ozzo.Min(1), ozzo.Max(65535)),
+		ozzo.Field(&m.BindAddress, ozzo.Required, is.IP),
+		ozzo.Field(&m.PublicURL, ozzo.Required, is.URL),
+		ozzo.Field(&m.Environment, ozzo.Required, ozzo.In("development", "staging", "production")),
+		ozzo.Field(&m.LogLevel, ozzo.In("debug", "info", "warn", "error")),
+		ozzo.Field(&m.Workers, ozzo.Min(1), ozzo.Max(1024)),
+		ozzo.Field(&m.QueueSize, ozzo.Min(0), ozzo.Max(1000000)),
+		ozzo.Field(&m.InstanceID, ozzo.Required, is.UUID),
+		ozzo.Field(&m.Owner, ozzo.Required, is.Email),
+	)
+}
+
+func ozzoLarge(l *corpus.Large) error {
+	text := []ozzo.Rule{ozzo.Required, ozzo.Length(1, 64)}
+	percent := []ozzo.Rule{ozzo.Min(0), ozzo.Max(100)}
+	return ozzo.ValidateStruct(l,
+		ozzo.Field(&l.Field01, text...),
+		ozzo.Field(&l.Field02, text...),
+		ozzo.Field(&l.Field03, text...),
+		ozzo.Field(&l.Field04, text...),
+		ozzo.Field(&l.Field05, text...),
+		ozzo.Field(&l.Field06, ozzo.Required, is.Email),
+		ozzo.Field(&l.Field07, ozzo.Required, is.URL),
+		ozzo.Field(&l.Field08, ozzo.Required, is.DNSName),
+		ozzo.Field(&l.Field09, ozzo.Required, is.IP),
+		ozzo.Field(&l.Field10, ozzo.Required, is.UUID),
+		ozzo.Field(&l.Field11, percent...),
+		ozzo.Field(&l.Field12, percent...),
+		ozzo.Field(&l.Field13, percent...),
+		ozzo.Field(&l.Field14, percent...),
+		ozzo.Field(&l.Field15, percent...),
+		ozzo.Field(&l.Field16, ozzo.Min(int64(0)), ozzo.Max(int64(1000000))),
+		ozzo.Field(&l.Field17, ozzo.Min(int64(0)), ozzo.Max(int64(1000000))),
+		ozzo.Field(&l.Field18, ozzo.Min(0.0), ozzo.Max(1.0)),
+		ozzo.Field(&l.Field19, ozzo.Min(0.0), ozzo.Max(1.0)),
+		ozzo.Field(&l.Field20, ozzo.In("a", "b", "c", "d")),
+		ozzo.Field(&l.Field21, ozzo.In("a", "b", "c", "d")),
+		ozzo.Field(&l.Field22, is.Alpha),
+		ozzo.Field(&l.Field23, is.Alphanumeric),
+		ozzo.Field(&l.Field24, is.Digit),
+		ozzo.Field(&l.Field25, ozzo.Required, ozzo.Length(8, 8)),
+	)
+}
+
+func ozzoNested(n *corpus.Nested) error {
+	server := func(value interface{}) error {
+		s := value.(corpus.NestedServer)
+		return ozzo.ValidateStruct(&s,
+			ozzo.Field(&s.Host, ozzo.Required, is.DNSName),
+			ozzo.Field(&s.Port, ozzo.Required, ozzo.Min(1), ozzo.Max(65535)),
+			ozzo.Field(&s.TLS, ozzo.By(func(value interface{}) error {
+				t := value.(corpus.NestedTLS)
+				return ozzo.ValidateStruct(&t,
+					ozzo.Field(&t.CertFile, ozzo.Required),
+					ozzo.Field(&t.KeyFile, ozzo.Required),
+				)
+			})),
+		)
+	}
+	database := func(value interface{}) error {
+		d := value.(corpus.NestedDatabase)
+		return ozzo.ValidateStruct(&d,
+			ozzo.Field(&d.Driver, ozzo.Required, ozzo.In("postgres", "mysql", "sqlite")),
+			ozzo.Field(&d.DSN, ozzo.Required, ozzo.Length(10, 0)),
+		)
+	}
+	return ozzo.ValidateStruct(n,
+		ozzo.Field(&n.Name, ozzo.Required),
+		ozzo.Field(&n.Server, ozzo.By(server)),
+		ozzo.Field(&n.Database, ozzo.By(database)),
+	)
+}
+
+func ozzoCrossField(c *corpus.CrossField) error {
+	return ozzo.ValidateStruct(c,
+		ozzo.Field(&c.Password, ozzo.Required, ozzo.Length(8, 0)),
+		ozzo.Field(&c.ConfirmPassword, ozzo.Required, ozzo.In(c.Password)),
+		ozzo.Field(&c.MinReplicas, ozzo.Min(1)),
+		ozzo.Field(&c.MaxReplicas, ozzo.Min(c.MinReplicas).Exclusive()),
+		ozzo.Field(&c.Primary, ozzo.Required),
+		ozzo.Field(&c.Secondary, ozzo.NotIn(c.Primary)),
+		ozzo.Field(&c.CertFile, ozzo.When(c.TLSEnabled, ozzo.Required)),
+		ozzo.Field(&c.Driver, ozzo.Required, ozzo.In("postgres", "sqlite")),
+		ozzo.Field(&c.Host, ozzo.When(c.Driver != "", ozzo.Required)),
+	)
+}
+
+func ozzoDive(d *corpus.Dive) error {
+	item := ozzo.By(func(value interface{}) error {
+		i := value.(corpus.DiveItem)
+		return ozzo.ValidateStruct(&i,
+			ozzo.Field(&i.ID, ozzo.Required, is.UUID),
+			ozzo.Field(&i.Name, ozzo.Required, ozzo.Length(1, 64)),
+			ozzo.Field(&i.Count, ozzo.Min(0), ozzo.Max(1000)),
+		)
+	})
+	return ozzo.ValidateStruct(d,
+		ozzo.Field(&d.Tags, ozzo.Length(1, 0), ozzo.Each(ozzo.Required, is.Alphanumeric)),
+		ozzo.Field(&d.Emails, ozzo.Each(is.Email)),
+		ozzo.Field(&d.Ports, ozzo.Each(ozzo.Min(1), ozzo.Max(65535))),
+		ozzo.Field(&d.Labels, ozzo.Each(ozzo.Required, ozzo.Length(0, 63))),
+		ozzo.Field(&d.Items, ozzo.Each(item)),
+	)
+}
+
+// TestCorpusAcceptedByAll keeps the comparison fair: every library must
+// accept every valid corpus value
+func TestCorpusAcceptedByAll(t *testing.T) {
+	for _, s := range shapes() {
+		for _, library := range libraries {
+			if c := s.checks[library]; c != nil {
+				if err := c(); err != nil {
+					t.Errorf("expected %s to accept %s, got %v", library, s.name, err)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCompare(b *testing.B) {
+	for _, s := range shapes() {
+		b.Run(s.name, func(b *testing.B) {
+			for _, library := range libraries {
+				c := s.checks[library]
+				if c == nil {
+					continue
+				}
+				b.Run(benchName(library), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						_ = c()
+					}
+				})
+			}
+		})
+	}
+}
+
+// TestComparisonReport writes a Markdown table of ns/op and allocs/op per
+// shape and library when -report is set
+func TestComparisonReport(t *testing.T) {
+	if *report == "" {
+		t.Skip("set -report to write the comparison table")
+	}
+
+	var table strings.Builder
+	table.WriteString("| Shape |")
+	for _, library := range libraries {
+		table.WriteString(" " + library + " |")
+	}
+	table.WriteString("\n|----|" + strings.Repeat("----|", len(libraries)) + "\n")
+
+	for _, s := range shapes() {
+		table.WriteString("| " + s.name + " |")
+		for _, library := range libraries {
+			c := s.checks[library]
+			if c == nil {
+				table.WriteString(" n/a |")
+				continue
+			}
+			result := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_ = c()
+				}
+			})
+			fmt.Fprintf(&table, " %d ns, %d allocs |", result.NsPerOp(), result.AllocsPerOp())
+		}
+		table.WriteString("\n")
+	}
+
+	if err := os.WriteFile(*report, []byte(table.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("comparison:\n%s", table.String())
+}
+
+// benchName turns a library name into a benchmark name
+func benchName(library string) string {
+	return strings.NewReplacer("/", "-", " ", "", "(", "_", ")", "").Replace(library)
+}
@@ -0,0 +1,9 @@
+// Package compare benchmarks the corpus in benchmarks/corpus against
+// go-playground/validator and ozzo-validation. It is a separate module so
+// the root module does not depend on either library, and its benchmarks
+// build only with the compare tag:
+//
+//	cd benchmarks/compare
+//	go test -tags compare -run '^$' -bench . -benchmem
+//	go test -tags compare -run TestComparisonReport -report comparison.md
+package compare
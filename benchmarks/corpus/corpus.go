@@ -0,0 +1,206 @@
+// Package corpus holds representative validation workloads, shared by the
+// reflection and generated benchmarks so their results compare like for
+// like. Each shape comes with a constructor returning a valid instance.
+//
+// Generated validators are committed for the shapes configvalidator
+// compiles faithfully today: Small, Medium and Large. It does not yet emit
+// code for nested validators, dive rules or cross-field rules, so Nested,
+// Dive and CrossField are measured through reflection only. Run go generate
+// after changing a struct.
+package corpus
+
+import "fmt"
+
+//go:generate go run ../../cmd/configvalidator -input=. -output=. -package=corpus -strategies=false -types=Small,Medium,Large
+
+// Small is a flat struct with a handful of string and numeric rules
+type Small struct {
+	Name  string `yaml:"name" validate:"required,min=3,max=32"`
+	Email string `yaml:"email" validate:"required,email"`
+	Age   int    `yaml:"age" validate:"min=0,max=150"`
+	Role  string `yaml:"role" validate:"oneof=admin user guest"`
+}
+
+// Medium is a service configuration with network and format rules
+type Medium struct {
+	Service     string `yaml:"service" validate:"required,alphanum,min=2,max=64"`
+	Host        string `yaml:"host" validate:"required,hostname"`
+	Port        int    `yaml:"port" validate:"required,min=1,max=65535"`
+	AdminPort   int    `yaml:"admin_port" validate:"min=1,max=65535"`
+	BindAddress string `yaml:"bind_address" validate:"required,ip"`
+	PublicURL   string `yaml:"public_url" validate:"required,url"`
+	Environment string `yaml:"environment" validate:"required,oneof=development staging production"`
+	LogLevel    string `yaml:"log_level" validate:"oneof=debug info warn error"`
+	Workers     int    `yaml:"workers" validate:"min=1,max=1024"`
+	QueueSize   int    `yaml:"queue_size" validate:"min=0,max=1000000"`
+	InstanceID  string `yaml:"instance_id" validate:"required,uuid"`
+	Owner       string `yaml:"owner" validate:"required,email"`
+}
+
+// Large is a wide struct of independent fields, measuring per-field cost
+type Large struct {
+	Field01 string  `yaml:"field01" validate:"required,min=1,max=64"`
+	Field02 string  `yaml:"field02" validate:"required,min=1,max=64"`
+	Field03 string  `yaml:"field03" validate:"required,min=1,max=64"`
+	Field04 string  `yaml:"field04" validate:"required,min=1,max=64"`
+	Field05 string  `yaml:"field05" validate:"required,min=1,max=64"`
+	Field06 string  `yaml:"field06" validate:"required,email"`
+	Field07 string  `yaml:"field07" validate:"required,url"`
+	Field08 string  `yaml:"field08" validate:"required,hostname"`
+	Field09 string  `yaml:"field09" validate:"required,ip"`
+	Field10 string  `yaml:"field10" validate:"required,uuid"`
+	Field11 int     `yaml:"field11" validate:"min=0,max=100"`
+	Field12 int     `yaml:"field12" validate:"min=0,max=100"`
+	Field13 int     `yaml:"field13" validate:"min=0,max=100"`
+	Field14 int     `yaml:"field14" validate:"min=0,max=100"`
+	Field15 int     `yaml:"field15" validate:"min=0,max=100"`
+	Field16 int64   `yaml:"field16" validate:"min=0,max=1000000"`
+	Field17 int64   `yaml:"field17" validate:"min=0,max=1000000"`
+	Field18 float64 `yaml:"field18" validate:"min=0,max=1"`
+	Field19 float64 `yaml:"field19" validate:"min=0,max=1"`
+	Field20 string  `yaml:"field20" validate:"oneof=a b c d"`
+	Field21 string  `yaml:"field21" validate:"oneof=a b c d"`
+	Field22 string  `yaml:"field22" validate:"alpha"`
+	Field23 string  `yaml:"field23" validate:"alphanum"`
+	Field24 string  `yaml:"field24" validate:"numeric"`
+	Field25 string  `yaml:"field25" validate:"required,len=8"`
+}
+
+// Nested is three levels of nested structs, measuring descent cost
+type Nested struct {
+	Name     string         `yaml:"name" validate:"required"`
+	Server   NestedServer   `yaml:"server" validate:"required"`
+	Database NestedDatabase `yaml:"database" validate:"required"`
+}
+
+// NestedServer is the server section of Nested
+type NestedServer struct {
+	Host string    `yaml:"host" validate:"required,hostname"`
+	Port int       `yaml:"port" validate:"required,min=1,max=65535"`
+	TLS  NestedTLS `yaml:"tls"`
+}
+
+// NestedTLS is the innermost level of Nested
+type NestedTLS struct {
+	CertFile string `yaml:"cert_file" validate:"required,min=1"`
+	KeyFile  string `yaml:"key_file" validate:"required,min=1"`
+}
+
+// NestedDatabase is the database section of Nested
+type NestedDatabase struct {
+	Driver string `yaml:"driver" validate:"required,oneof=postgres mysql sqlite"`
+	DSN    string `yaml:"dsn" validate:"required,min=10"`
+}
+
+// CrossField is dominated by rules comparing fields with each other
+type CrossField struct {
+	Password        string `yaml:"password" validate:"required,min=8"`
+	ConfirmPassword string `yaml:"confirm_password" validate:"required,eqfield=Password"`
+	MinReplicas     int    `yaml:"min_replicas" validate:"min=1"`
+	MaxReplicas     int    `yaml:"max_replicas" validate:"gtfield=MinReplicas"`
+	Primary         string `yaml:"primary" validate:"required"`
+	Secondary       string `yaml:"secondary" validate:"nefield=Primary"`
+	TLSEnabled      bool   `yaml:"tls_enabled"`
+	CertFile        string `yaml:"cert_file" validate:"required_if=TLSEnabled true"`
+	Driver          string `yaml:"driver" validate:"required,oneof=postgres sqlite"`
+	Host            string `yaml:"host" validate:"required_with=Driver"`
+}
+
+// DiveItem is an element of Dive
+type DiveItem struct {
+	ID    string `yaml:"id" validate:"required,uuid"`
+	Name  string `yaml:"name" validate:"required,min=1,max=64"`
+	Count int    `yaml:"count" validate:"min=0,max=1000"`
+}
+
+// Dive is dominated by rules applied to every collection element
+type Dive struct {
+	Tags   []string          `yaml:"tags" validate:"minitems=1,dive,required,alphanum"`
+	Emails []string          `yaml:"emails" validate:"dive,email"`
+	Ports  []int             `yaml:"ports" validate:"dive,min=1,max=65535"`
+	Labels map[string]string `yaml:"labels" validate:"dive,required,max=63"`
+	Items  []DiveItem        `yaml:"items" validate:"dive"`
+}
+
+// NewSmall returns a valid Small
+func NewSmall() *Small {
+	return &Small{Name: "alice", Email: "alice@example.com", Age: 34, Role: "admin"}
+}
+
+// NewMedium returns a valid Medium
+func NewMedium() *Medium {
+	return &Medium{
+		Service:     "billing",
+		Host:        "billing.internal.example.com",
+		Port:        8080,
+		AdminPort:   9090,
+		BindAddress: "10.0.0.12",
+		PublicURL:   "https://billing.example.com/api",
+		Environment: "production",
+		LogLevel:    "info",
+		Workers:     16,
+		QueueSize:   4096,
+		InstanceID:  "3f0c1b9e-8d2a-4c55-9a1e-2b7f4e6d8c01",
+		Owner:       "payments@example.com",
+	}
+}
+
+// NewLarge returns a valid Large
+func NewLarge() *Large {
+	return &Large{
+		Field01: "alpha", Field02: "bravo", Field03: "charlie", Field04: "delta", Field05: "echo",
+		Field06: "ops@example.com",
+		Field07: "https://example.com",
+		Field08: "db.example.com",
+		Field09: "192.168.1.10",
+		Field10: "9b2e7c4a-1d3f-4e8b-a6c2-5f0d9e8b7a61",
+		Field11: 10, Field12: 20, Field13: 30, Field14: 40, Field15: 50,
+		Field16: 100000, Field17: 200000,
+		Field18: 0.25, Field19: 0.75,
+		Field20: "a", Field21: "c",
+		Field22: "letters", Field23: "abc123", Field24: "12345",
+		Field25: "ABCD1234",
+	}
+}
+
+// NewNested returns a valid Nested
+func NewNested() *Nested {
+	return &Nested{
+		Name: "orders",
+		Server: NestedServer{
+			Host: "orders.example.com",
+			Port: 8443,
+			TLS:  NestedTLS{CertFile: "/etc/tls/cert.pem", KeyFile: "/etc/tls/key.pem"},
+		},
+		Database: NestedDatabase{Driver: "postgres", DSN: "postgres://orders@db/orders"},
+	}
+}
+
+// NewCrossField returns a valid CrossField
+func NewCrossField() *CrossField {
+	return &CrossField{
+		Password:        "correct-horse",
+		ConfirmPassword: "correct-horse",
+		MinReplicas:     2,
+		MaxReplicas:     8,
+		Primary:         "us-east-1",
+		Secondary:       "us-west-2",
+		TLSEnabled:      true,
+		CertFile:        "/etc/tls/cert.pem",
+		Driver:          "postgres",
+		Host:            "db.example.com",
+	}
+}
+
+// NewDive returns a valid Dive with n elements in each collection
+func NewDive(n int) *Dive {
+	d := &Dive{Labels: make(map[string]string, n)}
+	for i := 0; i < n; i++ {
+		d.Tags = append(d.Tags, fmt.Sprintf("tag%d", i))
+		d.Emails = append(d.Emails, fmt.Sprintf("user%d@example.com", i))
+		d.Ports = append(d.Ports, 8000+i)
+		d.Labels[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+		d.Items = append(d.Items, DiveItem{ID: "c2f1a7d4-6b3e-4f9a-8e5d-1a2b3c4d5e6f", Name: fmt.Sprintf("item%d", i), Count: i})
+	}
+	return d
+}
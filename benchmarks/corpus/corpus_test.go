@@ -0,0 +1,74 @@
+package corpus
+
+import (
+	"testing"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// shapes lists the corpus for the reflection benchmarks
+var shapes = []struct {
+	name  string
+	value func() interface{}
+}{
+	{"Small", func() interface{} { return NewSmall() }},
+	{"Medium", func() interface{} { return NewMedium() }},
+	{"Large", func() interface{} { return NewLarge() }},
+	{"Nested", func() interface{} { return NewNested() }},
+	{"CrossField", func() interface{} { return NewCrossField() }},
+	{"Dive10", func() interface{} { return NewDive(10) }},
+	{"Dive100", func() interface{} { return NewDive(100) }},
+}
+
+func TestCorpusIsValid(t *testing.T) {
+	for _, shape := range shapes {
+		if err := validation.Struct(shape.value()); err != nil {
+			t.Errorf("expected %s to be valid, got %v", shape.name, err)
+		}
+	}
+	if err := NewSmallValidator().Validate(NewSmall()); err != nil {
+		t.Errorf("expected generated Small validation to pass, got %v", err)
+	}
+	if err := NewMediumValidator().Validate(NewMedium()); err != nil {
+		t.Errorf("expected generated Medium validation to pass, got %v", err)
+	}
+	if err := NewLargeValidator().Validate(NewLarge()); err != nil {
+		t.Errorf("expected generated Large validation to pass, got %v", err)
+	}
+}
+
+func BenchmarkReflection(b *testing.B) {
+	for _, shape := range shapes {
+		value := shape.value()
+		b.Run(shape.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = validation.Struct(value)
+			}
+		})
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		v, cfg := NewSmallValidator(), NewSmall()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Validate(cfg)
+		}
+	})
+	b.Run("Medium", func(b *testing.B) {
+		v, cfg := NewMediumValidator(), NewMedium()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Validate(cfg)
+		}
+	})
+	b.Run("Large", func(b *testing.B) {
+		v, cfg := NewLargeValidator(), NewLarge()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Validate(cfg)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+//go:build !lite
+
+package validation
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// BindQuery sets the fields of the struct dst points to from the request's
+// query parameters, named by query tags, then form tags, then the field
+// name, and validates it. Values are converted to the field types: strings,
+// bools, numbers, time.Duration, encoding.TextUnmarshaler implementations
+// such as time.Time, pointers to these, and slices filled from repeated
+// parameters. Embedded structs are bound too. Parameters that cannot be
+// converted are reported like validation errors, with tag "type" and code
+// invalid_type, and all errors name the query keys.
+func (v *Validator) BindQuery(r *http.Request, dst interface{}, opts ...ValidateOption) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("BindQuery requires a non-nil pointer to a struct, got %T", dst)
+	}
+
+	var bindErrs ValidationErrors
+	if err := bindValues(r.URL.Query(), target.Elem(), &bindErrs); err != nil {
+		return err
+	}
+
+	call := v.current().withOptions(opts).clone()
+	call.fieldNameFunc = QueryFieldName
+	err := call.StructCtx(r.Context(), dst)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		if err != nil {
+			return err
+		}
+		if len(bindErrs) > 0 {
+			return bindErrs
+		}
+		return nil
+	}
+
+	// A parameter that could not be converted left its field zero, so only
+	// its conversion error is reported
+	failed := make(map[string]bool, len(bindErrs))
+	for _, e := range bindErrs {
+		failed[e.Field] = true
+	}
+	for _, e := range errs {
+		if e.Namespace == "" && failed[e.Field] {
+			continue
+		}
+		bindErrs = append(bindErrs, e)
+	}
+	return bindErrs
+}
+
+// BindQuery binds and validates query parameters using the default validator
+func BindQuery(r *http.Request, dst interface{}, opts ...ValidateOption) error {
+	return defaultValidator.BindQuery(r, dst, opts...)
+}
+
+// bindValues sets the exported fields of a struct from values, recording
+// conversion failures; it fails for field types that cannot be bound
+func bindValues(values url.Values, val reflect.Value, errs *ValidationErrors) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("query") == "-" {
+			continue
+		}
+		fieldVal := val.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := bindValues(values, fieldVal, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := QueryFieldName(field)
+		raw, ok := values[key]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		if err := bindField(fieldVal, raw); err != nil {
+			if errors.Is(err, errUnsupportedBinding) {
+				return fmt.Errorf("cannot bind query parameter %q: %w", key, err)
+			}
+			errs.Add(ValidationError{
+				Field:       key,
+				StructField: field.Name,
+				Tag:         "type",
+				Param:       field.Type.String(),
+				Value:       raw[0],
+				Message:     fmt.Sprintf(ErrorMsgInvalidType, key, field.Type),
+				Code:        ErrCodeInvalidType,
+			})
+		}
+	}
+	return nil
+}
+
+// errUnsupportedBinding reports a field type query values cannot be converted to
+var errUnsupportedBinding = errors.New("unsupported field type")
+
+// bindField converts raw into a field, using every value for slices and the
+// first one otherwise
+func bindField(field reflect.Value, raw []string) error {
+	typ := field.Type()
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 && !reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(typ, len(raw), len(raw))
+		for i, value := range raw {
+			if err := bindScalar(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return bindScalar(field, raw[0])
+}
+
+// bindScalar converts a single value into a field
+func bindScalar(field reflect.Value, value string) error {
+	typ := field.Type()
+	if typ.Kind() == reflect.Ptr {
+		elem := reflect.New(typ.Elem())
+		if err := bindScalar(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if typ == durationType {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedBinding, typ)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build !lite
+
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindQuery(t *testing.T) {
+	type Paging struct {
+		Page  int `query:"page" validate:"omitempty,min=1"`
+		Limit int `query:"limit" validate:"max=100"`
+	}
+	type Search struct {
+		Paging
+		Term    string        `query:"q" validate:"required,min=2"`
+		Tags    []string      `query:"tag" validate:"maxitems=3"`
+		Since   *time.Time    `query:"since"`
+		Timeout time.Duration `query:"timeout"`
+		Exact   bool          `form:"exact"`
+		Score   float64       `validate:"min=0"`
+		Secret  string        `query:"-"`
+	}
+
+	request := func(query string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, "/search?"+query, nil)
+	}
+
+	var s Search
+	err := BindQuery(request("q=golang&tag=a&tag=b&page=2&limit=50&since=2024-01-02T15:04:05Z&timeout=5s&exact=true&Score=1.5&-=x&Secret=y"), &s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Term != "golang" || len(s.Tags) != 2 || s.Page != 2 || s.Limit != 50 || s.Since == nil || s.Since.Year() != 2024 ||
+		s.Timeout != 5*time.Second || !s.Exact || s.Score != 1.5 || s.Secret != "" {
+		t.Errorf("unexpected binding: %+v", s)
+	}
+
+	// Conversion and validation errors are combined and named by query key
+	s = Search{}
+	err = BindQuery(request("q=g&page=two&limit=500&Score=-1&since=yesterday"), &s)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Field+":"+e.Tag)
+	}
+	if want := "page:type since:type limit:max q:min Score:min"; strings.Join(got, " ") != want {
+		t.Errorf("expected %s, got %v", want, got)
+	}
+	if errs[0].Code != ErrCodeInvalidType || errs[0].Value != "two" || errs[0].Error() != "field 'page' must be a valid int" {
+		t.Errorf("unexpected conversion error: %+v", errs[0])
+	}
+
+	if err := BindQuery(request("q=ok"), s); err == nil || errors.As(err, &errs) {
+		t.Errorf("expected an error for a non-pointer destination, got %v", err)
+	}
+	var unsupported struct {
+		Filter map[string]string `query:"filter"`
+	}
+	if err := BindQuery(request("filter=x"), &unsupported); err == nil || errors.As(err, &errs) {
+		t.Errorf("expected an error for an unsupported field type, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrUnknownBlocklist is returned when checking text against a word list name
+// that was never registered
+var ErrUnknownBlocklist = errors.New("unknown blocklist")
+
+// blocklist holds the entries of a registered list
+type blocklist struct {
+	entries []blocklistEntry
+}
+
+// blocklistEntry is a registered word or phrase with its normalized tokens
+type blocklistEntry struct {
+	word   string
+	tokens []string
+}
+
+// blocklistLeet maps the digits and symbols standing in for letters in leet
+// speak to the letters they replace
+var blocklistLeet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's',
+}
+
+// blocklistTokens splits text into normalized words: lowercased, with leet
+// speak replaced and runs of single letters joined ("b a d" reads "bad").
+// Repeated letters are kept; matchesBlocklistToken accounts for them.
+func blocklistTokens(text string) []string {
+	var tokens []string
+	var current []rune
+	joining := false // the last token was made of single letters
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		if single := len(current) == 1; single && joining {
+			tokens[len(tokens)-1] += string(current)
+		} else {
+			tokens = append(tokens, string(current))
+			joining = single
+		}
+		current = current[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		if letter, ok := blocklistLeet[r]; ok {
+			r = letter
+		}
+		if !unicode.IsLetter(r) {
+			flush()
+			continue
+		}
+		current = append(current, r)
+	}
+	flush()
+	return tokens
+}
+
+// matchesBlocklistToken reports whether a token of the checked text is the
+// list token with letters repeated as often or more ("baaad" reads "bad"),
+// so stretched words match while shorter ones do not ("but" is not "butt")
+func matchesBlocklistToken(token, listed string) bool {
+	t, l := []rune(token), []rune(listed)
+	i, j := 0, 0
+	for j < len(l) {
+		if i == len(t) || t[i] != l[j] {
+			return false
+		}
+		r := l[j]
+		runT, runL := 0, 0
+		for ; i < len(t) && t[i] == r; i++ {
+			runT++
+		}
+		for ; j < len(l) && l[j] == r; j++ {
+			runL++
+		}
+		if runT < runL {
+			return false
+		}
+	}
+	return i == len(t)
+}
+
+// RegisterBlocklist registers a list of words and phrases under name for the
+// notcontains_blocklist rule (e.g. `validate:"notcontains_blocklist=profanity"`).
+// Matching is by whole words and normalizes case, leet speak, repeated
+// letters and spaced-out letters, so "B4D w0rd" matches "bad word" and
+// "baaad" matches "bad", but "but" does not match "butt".
+func (v *Validator) RegisterBlocklist(name string, words []string) error {
+	if name == "" {
+		return fmt.Errorf("blocklist name cannot be empty")
+	}
+	list := &blocklist{}
+	for _, word := range words {
+		if tokens := blocklistTokens(word); len(tokens) > 0 {
+			list.entries = append(list.entries, blocklistEntry{word: word, tokens: tokens})
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	blocklists := make(map[string]*blocklist, len(v.blocklists)+1)
+	for listName, l := range v.blocklists {
+		blocklists[listName] = l
+	}
+	blocklists[name] = list
+	v.blocklists = blocklists
+	v.publish()
+	return nil
+}
+
+// RegisterBlocklist registers a word list on the default validator
+func RegisterBlocklist(name string, words []string) error {
+	return defaultValidator.RegisterBlocklist(name, words)
+}
+
+// MatchBlocklist returns the word or phrase of a registered list found in
+// text, as registered, or "" when it contains none
+func (v *Validator) MatchBlocklist(name, text string) (string, error) {
+	list, ok := v.current().blocklists[name]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownBlocklist, name)
+	}
+	tokens := blocklistTokens(text)
+	for _, entry := range list.entries {
+		for start := 0; start+len(entry.tokens) <= len(tokens); start++ {
+			matched := true
+			for i, listed := range entry.tokens {
+				if !matchesBlocklistToken(tokens[start+i], listed) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return entry.word, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// MatchBlocklist checks text against a word list of the default validator
+func MatchBlocklist(name, text string) (string, error) {
+	return defaultValidator.MatchBlocklist(name, text)
+}
+
+// isNotBlocklisted validates text containing no word or phrase of the list
+// named by the parameter. Text checked against an unknown list is invalid.
+func isNotBlocklisted(fl FieldLevel) bool {
+	impl, ok := fl.(*fieldLevel)
+	if !ok || impl.validator == nil {
+		return false
+	}
+	match, err := impl.validator.MatchBlocklist(fl.Param(), getString(fl.Field()))
+	return err == nil && match == ""
+}
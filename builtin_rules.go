@@ -1,32 +1,62 @@
 package validation
 
 import (
+	"cmp"
+	"encoding"
+	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // registerBuiltInRules registers all built-in validation rules
 func (v *Validator) registerBuiltInRules() {
 	// Basic validation rules
 	v.customRules["required"] = isRequired
+	v.customRules["defined"] = isDefined
 	v.customRules["omitempty"] = isOmitEmpty
+	v.customRules["boolean"] = isBoolean
 	
 	// String validation rules
 	v.customRules["min"] = hasMinOf
 	v.customRules["max"] = hasMaxOf
 	v.customRules["len"] = hasLengthOf
+	v.customRules["minbytes"] = hasMinBytes
+	v.customRules["maxbytes"] = hasMaxBytes
+	v.customRules["minrunes"] = hasMinRunes
+	v.customRules["maxrunes"] = hasMaxRunes
 	v.customRules["eq"] = isEq
 	v.customRules["ne"] = isNe
 	v.customRules["oneof"] = isOneOf
+	v.customRules["typeof"] = isTypeOf
+	
+	// Collection rules apply to a slice, array or map itself, even in dive tags
+	v.customRules["minitems"] = hasMinItems
+	v.customRules["maxitems"] = hasMaxItems
+	v.customRules["notempty"] = isNotEmpty
+	v.customRules["sorted"] = isSorted
+	v.customRules["sorted_desc"] = isSortedDesc
+	v.customRules["haskeys"] = hasKeys
+	v.customRules["allowedkeys"] = hasAllowedKeys
 	
 	// String format rules
 	v.customRules["alpha"] = isAlpha
 	v.customRules["alphanum"] = isAlphaNumeric
 	v.customRules["numeric"] = isNumeric
 	v.customRules["email"] = isEmail
+	v.customRules["email_not_disposable"] = isNotDisposableEmail
+	// email_mx is evaluated by checkEmailMX with the call's context; the
+	// registration lets policies and explanations know the rule
+	v.customRules["email_mx"] = isEmail
+	v.customRules["username"] = isUsername
 	v.customRules["url"] = isURL
 	v.customRules["uri"] = isURI
+	v.customRules["url_scheme"] = hasURLScheme
+	v.customRules["url_host_in"] = hasURLHostIn
+	v.customRules["url_no_userinfo"] = hasNoURLUserinfo
+	v.customRules["url_no_fragment"] = hasNoURLFragment
 	
 	// Network validation rules
 	v.customRules["ip"] = isIP
@@ -35,6 +65,9 @@ func (v *Validator) registerBuiltInRules() {
 	v.customRules["cidr"] = isCIDR
 	v.customRules["mac"] = isMAC
 	v.customRules["hostname"] = isHostname
+	v.customRules["domain"] = isDomain
+	v.customRules["tld"] = isTLD
+	v.customRules["fqdn_public_suffix"] = isFQDNPublicSuffix
 	
 	// UUID validation
 	v.customRules["uuid"] = isUUID
@@ -44,13 +77,69 @@ func (v *Validator) registerBuiltInRules() {
 	v.customRules["datetime"] = isDateTime
 	v.customRules["date"] = isDate
 	v.customRules["time"] = isTime
+	v.customRules["expires_after"] = isExpiresAfter
+	v.customRules["not_before_now"] = isNotBeforeNow
+	v.customRules["within"] = isWithin
+	v.customRules["timezone"] = isTimezone
+	
+	// Locale rules
+	v.customRules["locale"] = isLocale
+	v.customRules["currency_matches_locale"] = currencyMatchesLocale
 	
 	// Other format validation
 	v.customRules["json"] = isJSON
+	v.customRules["jsonschema"] = isJSONSchema
+	v.customRules["notcontains_blocklist"] = isNotBlocklisted
+	v.customRules["no_html"] = isNoHTML
+	v.customRules["no_script"] = isNoScript
+	v.customRules["safe_text"] = isSafeText
+	v.customRules["safe_path"] = isSafePath
+	v.customRules["no_shell_meta"] = isNoShellMeta
+	v.customRules["sql_identifier"] = isSQLIdentifier
+	v.customRules["sql_like_escape_required"] = isSQLLikeEscaped
 	v.customRules["base64"] = isBase64
 	v.customRules["creditcard"] = isCreditCard
 	v.customRules["phone"] = isPhone
 	
+	// Kubernetes object rules
+	v.customRules["k8s_name"] = isK8sName
+	v.customRules["k8s_label_key"] = isK8sLabelKey
+	v.customRules["k8s_label_value"] = isK8sLabelValue
+	v.customRules["k8s_qty"] = isK8sQuantity
+	v.customRules["k8s_selector"] = isK8sSelector
+	
+	// Container image rules
+	v.customRules["image_ref"] = isImageRef
+	v.customRules["image_tag"] = isImageTag
+	v.customRules["sha256_digest"] = isSHA256Digest
+	
+	// Git repository rules
+	v.customRules["git_url"] = isGitURL
+	v.customRules["ssh_url"] = isSSHURL
+	v.customRules["commit_sha"] = isCommitSHA
+	v.customRules["branch_name"] = isBranchName
+	
+	// Check digit rules
+	for tag := range checksumSchemes {
+		v.customRules[tag] = checksumRule(tag)
+	}
+	
+	// Proportion rules
+	v.customRules["percent"] = isPercent
+	v.customRules["ratio"] = isRatio
+	v.customRules["sum_to"] = hasSumTo
+	
+	// Monetary rules
+	v.customRules["decimal"] = isDecimal
+	v.customRules["positive"] = isPositive
+	v.customRules["nonnegative"] = isNonNegative
+	v.customRules["minor_units"] = hasMinorUnits
+	
+	// Uploaded file rules
+	v.customRules["file_ext"] = hasFileExt
+	v.customRules["min_file_size"] = hasMinFileSize
+	v.customRules["max_file_size"] = hasMaxFileSize
+	
 	// Cross-field validation
 	v.customRules["eqfield"] = isEqField
 	v.customRules["nefield"] = isNeField
@@ -64,6 +153,9 @@ func (v *Validator) registerBuiltInRules() {
 	v.customRules["required_unless"] = isRequiredUnless
 	v.customRules["required_with"] = isRequiredWith
 	v.customRules["required_without"] = isRequiredWithout
+	
+	// Compared by StructUpdate
+	v.customRules["immutable"] = isImmutable
 }
 
 // validateBuiltInRule validates using built-in rules that need special handling
@@ -103,6 +195,8 @@ func (v *Validator) validateBuiltInRule(fl *fieldLevel) error {
 		return ValidateCreditCard(fl.fieldName, getString(fl.field))
 	case "phone":
 		return ValidatePhone(fl.fieldName, getString(fl.field))
+	case "luhn", "imei", "ean13", "gtin", "isbn13", "isbn10", "vin":
+		return validateChecksum(fl.fieldName, fl.tag, getString(fl.field))
 	}
 	return nil
 }
@@ -111,9 +205,44 @@ func (v *Validator) validateBuiltInRule(fl *fieldLevel) error {
 
 // isRequired validates that the field is not empty
 func isRequired(fl FieldLevel) bool {
+	if f, ok := fl.(*fieldLevel); ok && f.validator.config.RequiredSemantics == RequiredPresence {
+		switch fl.Field().Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+			return true
+		}
+	}
 	return HasValue(fl)
 }
 
+// isDefined validates that a pointer, interface, slice, map, channel or
+// function is not nil, accepting a pointer to a zero value such as false or
+// 0. Other kinds are always defined.
+func isDefined(fl FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Invalid:
+		return false
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return !field.IsNil()
+	}
+	return true
+}
+
+// isBoolean validates a bool, or a string strconv.ParseBool accepts
+func isBoolean(fl FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Bool:
+		return true
+	case reflect.String:
+		_, err := strconv.ParseBool(field.String())
+		return err == nil
+	}
+	return false
+}
+
 // isOmitEmpty allows empty values to pass validation
 func isOmitEmpty(fl FieldLevel) bool {
 	return true // Always passes, used to skip validation on empty values
@@ -121,74 +250,227 @@ func isOmitEmpty(fl FieldLevel) bool {
 
 // hasMinOf validates minimum value/length
 func hasMinOf(fl FieldLevel) bool {
-	field := fl.Field()
-	param := fl.Param()
+	cmp, ok := compareParam(fl.Field(), fl.Param())
+	return ok && cmp >= 0
+}
+
+// hasMaxOf validates maximum value/length
+func hasMaxOf(fl FieldLevel) bool {
+	cmp, ok := compareParam(fl.Field(), fl.Param())
+	return ok && cmp <= 0
+}
+
+// hasLengthOf validates exact length
+func hasLengthOf(fl FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		cmp, ok := compareParam(fl.Field(), fl.Param())
+		return ok && cmp == 0
+	}
 	
-	min, err := ParseIntParam(param)
+	return false
+}
+
+// hasMinBytes validates the minimum byte length of a string or []byte
+func hasMinBytes(fl FieldLevel) bool {
+	cmp, ok := compareMeasuredSize(fl, SizeBytes)
+	return ok && cmp >= 0
+}
+
+// hasMaxBytes validates the maximum byte length of a string or []byte
+func hasMaxBytes(fl FieldLevel) bool {
+	cmp, ok := compareMeasuredSize(fl, SizeBytes)
+	return ok && cmp <= 0
+}
+
+// hasMinRunes validates the minimum number of runes in a string or []byte
+func hasMinRunes(fl FieldLevel) bool {
+	cmp, ok := compareMeasuredSize(fl, SizeRunes)
+	return ok && cmp >= 0
+}
+
+// hasMaxRunes validates the maximum number of runes in a string or []byte
+func hasMaxRunes(fl FieldLevel) bool {
+	cmp, ok := compareMeasuredSize(fl, SizeRunes)
+	return ok && cmp <= 0
+}
+
+// compareMeasuredSize compares the size of the field in the given unit with
+// the rule's integer parameter
+func compareMeasuredSize(fl FieldLevel, sizeType SizeType) (int, bool) {
+	size, ok := measureSize(fl.Field(), sizeType)
+	if !ok {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(strings.TrimSpace(fl.Param()), 10, 64)
 	if err != nil {
+		return 0, false
+	}
+	return cmp.Compare(size, limit), true
+}
+
+// collectionRules are evaluated against the collection rather than its
+// elements when they appear in a dive tag
+var collectionRules = map[string]bool{
+	"minitems":    true,
+	"maxitems":    true,
+	"notempty":    true,
+	"sorted":      true,
+	"sorted_desc": true,
+	"haskeys":     true,
+	"allowedkeys": true,
+	"sum_to":      true,
+}
+
+// isCollectionRule reports whether a rule applies to a collection rather than
+// its elements. keys= and values= carry their own per-entry rules.
+func isCollectionRule(name, param string) bool {
+	if isMapEntryRule(name) {
+		return param != ""
+	}
+	return collectionRules[name]
+}
+
+// isMapEntryRule reports whether a rule is the keys=/values= shorthand that
+// applies |-separated rules to every key or value of a map
+func isMapEntryRule(name string) bool {
+	return name == "keys" || name == "values"
+}
+
+// hasMinItems validates the minimum number of items in a slice, array or map
+func hasMinItems(fl FieldLevel) bool {
+	cmp, ok := compareItems(fl)
+	return ok && cmp >= 0
+}
+
+// hasMaxItems validates the maximum number of items in a slice, array or map
+func hasMaxItems(fl FieldLevel) bool {
+	cmp, ok := compareItems(fl)
+	return ok && cmp <= 0
+}
+
+// isNotEmpty validates that a slice, array or map has at least one item
+func isNotEmpty(fl FieldLevel) bool {
+	items, ok := countItems(fl.Field())
+	return ok && items > 0
+}
+
+// isSorted validates that a slice or array is in ascending order
+func isSorted(fl FieldLevel) bool {
+	return isOrdered(fl.Field(), 1)
+}
+
+// isSortedDesc validates that a slice or array is in descending order
+func isSortedDesc(fl FieldLevel) bool {
+	return isOrdered(fl.Field(), -1)
+}
+
+// hasKeys validates that a map contains every space-separated key in the param
+func hasKeys(fl FieldLevel) bool {
+	keys, ok := mapKeySet(fl.Field())
+	if !ok {
 		return false
 	}
-	
-	switch field.Kind() {
-	case reflect.String:
-		return int64(len(field.String())) >= min
-	case reflect.Slice, reflect.Map, reflect.Array:
-		return int64(field.Len()) >= min
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return field.Int() >= min
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return int64(field.Uint()) >= min
-	case reflect.Float32, reflect.Float64:
-		return int64(field.Float()) >= min
+	for _, key := range strings.Fields(fl.Param()) {
+		if !keys[key] {
+			return false
+		}
 	}
-	
-	return false
+	return true
 }
 
-// hasMaxOf validates maximum value/length
-func hasMaxOf(fl FieldLevel) bool {
-	field := fl.Field()
-	param := fl.Param()
-	
-	max, err := ParseIntParam(param)
-	if err != nil {
+// hasAllowedKeys validates that a map only contains space-separated keys
+// listed in the param
+func hasAllowedKeys(fl FieldLevel) bool {
+	keys, ok := mapKeySet(fl.Field())
+	if !ok {
 		return false
 	}
-	
-	switch field.Kind() {
-	case reflect.String:
-		return int64(len(field.String())) <= max
-	case reflect.Slice, reflect.Map, reflect.Array:
-		return int64(field.Len()) <= max
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return field.Int() <= max
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return int64(field.Uint()) <= max
-	case reflect.Float32, reflect.Float64:
-		return int64(field.Float()) <= max
+	allowed := make(map[string]bool)
+	for _, key := range strings.Fields(fl.Param()) {
+		allowed[key] = true
 	}
-	
-	return false
+	for key := range keys {
+		if !allowed[key] {
+			return false
+		}
+	}
+	return true
 }
 
-// hasLengthOf validates exact length
-func hasLengthOf(fl FieldLevel) bool {
-	field := fl.Field()
-	param := fl.Param()
-	
-	length, err := ParseIntParam(param)
+// mapKeySet returns the keys of a map as strings
+func mapKeySet(field reflect.Value) (map[string]bool, bool) {
+	field = indirectValue(field)
+	if field.Kind() != reflect.Map {
+		return nil, false
+	}
+	keys := make(map[string]bool, field.Len())
+	for _, key := range field.MapKeys() {
+		keys[getString(indirectValue(key))] = true
+	}
+	return keys, true
+}
+
+// compareItems compares the number of items in the field with the rule's
+// integer parameter
+func compareItems(fl FieldLevel) (int, bool) {
+	items, ok := countItems(fl.Field())
+	if !ok {
+		return 0, false
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(fl.Param()))
 	if err != nil {
-		return false
+		return 0, false
 	}
-	
+	return cmp.Compare(items, limit), true
+}
+
+// countItems returns the number of items in a slice, array or map
+func countItems(field reflect.Value) (int, bool) {
+	field = indirectValue(field)
 	switch field.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return field.Len(), true
+	}
+	return 0, false
+}
+
+// isOrdered reports whether no adjacent pair of elements is out of order for
+// direction (1 ascending, -1 descending). Equal neighbours are allowed.
+func isOrdered(field reflect.Value, direction int) bool {
+	field = indirectValue(field)
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return false
+	}
+	for i := 1; i < field.Len(); i++ {
+		c, ok := compareElements(field.Index(i-1), field.Index(i))
+		if !ok || c == direction {
+			return false
+		}
+	}
+	return true
+}
+
+// compareElements compares two values of the same ordered kind
+func compareElements(a, b reflect.Value) (int, bool) {
+	a, b = indirectValue(a), indirectValue(b)
+	if a.Kind() != b.Kind() {
+		return 0, false
+	}
+	switch a.Kind() {
 	case reflect.String:
-		return int64(len(field.String())) == length
-	case reflect.Slice, reflect.Map, reflect.Array:
-		return int64(field.Len()) == length
+		return cmp.Compare(a.String(), b.String()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(a.Float()) || math.IsNaN(b.Float()) {
+			return 0, false
+		}
+		return cmp.Compare(a.Float(), b.Float()), true
 	}
-	
-	return false
+	return 0, false
 }
 
 // isEq validates equality
@@ -238,6 +520,63 @@ func isOneOf(fl FieldLevel) bool {
 	return false
 }
 
+// textFormatRules validate string formats and see the text of
+// fmt.Stringer and encoding.TextMarshaler fields when TextValues is enabled
+var textFormatRules = map[string]bool{
+	"alpha": true, "alphanum": true, "numeric": true, "oneof": true,
+	"email": true, "url": true, "uri": true, "hostname": true,
+	"ip": true, "ipv4": true, "ipv6": true, "cidr": true, "mac": true,
+	"uuid": true, "uuid4": true, "datetime": true, "date": true, "time": true,
+	"json": true, "base64": true, "creditcard": true, "phone": true,
+}
+
+// textValue returns the text of a non-string value implementing
+// encoding.TextMarshaler or fmt.Stringer, preferring TextMarshaler. Values
+// whose MarshalText fails are returned unchanged.
+func textValue(val reflect.Value) reflect.Value {
+	if !val.IsValid() || val.Kind() == reflect.String {
+		return val
+	}
+	
+	candidates := []reflect.Value{val}
+	if val.CanAddr() {
+		candidates = append(candidates, val.Addr())
+	}
+	for _, candidate := range candidates {
+		if !candidate.CanInterface() {
+			continue
+		}
+		switch t := candidate.Interface().(type) {
+		case encoding.TextMarshaler:
+			if text, err := t.MarshalText(); err == nil {
+				return reflect.ValueOf(string(text))
+			}
+			return val
+		case fmt.Stringer:
+			return reflect.ValueOf(t.String())
+		}
+	}
+	return val
+}
+
+// isTypeOf validates the dynamic type of a value against |-separated type
+// names (e.g. "string|int"). A name matches the full type such as
+// "config.Redis" or "[]string", or its kind such as "map" or "struct".
+func isTypeOf(fl FieldLevel) bool {
+	field := fl.Field()
+	if !field.IsValid() {
+		return false
+	}
+	
+	for _, name := range strings.Split(fl.Param(), "|") {
+		name = strings.TrimSpace(name)
+		if name == field.Type().String() || name == field.Kind().String() {
+			return true
+		}
+	}
+	return false
+}
+
 // isAlpha validates alphabetic characters only
 func isAlpha(fl FieldLevel) bool {
 	field := getString(fl.Field())
@@ -375,16 +714,16 @@ func isEqField(fl FieldLevel) bool {
 		return false
 	}
 	
-	currentValue := fl.Field().Interface()
-	targetValue := field.Interface()
-	result := currentValue == targetValue
+	if !fl.Field().CanInterface() || !field.CanInterface() {
+		return false
+	}
 	
-	// Debug output - remove this later
-	if !result {
-		// Add debug info but don't print to avoid breaking tests
+	// Slices, maps and funcs are not comparable with ==
+	if !fl.Field().Type().Comparable() || !field.Type().Comparable() {
+		return reflect.DeepEqual(fl.Field().Interface(), field.Interface())
 	}
 	
-	return result
+	return fl.Field().Interface() == field.Interface()
 }
 
 // isNeField validates that field does not equal another field
@@ -446,7 +785,7 @@ func isRequiredIf(fl FieldLevel) bool {
 	fieldName := strings.TrimSpace(parts[0])
 	expectedValue := strings.TrimSpace(parts[1])
 	
-	field, _, found := fl.(*fieldLevel).getStructFieldOK(fl.Parent(), fieldName)
+	field, _, found := siblingField(fl, fieldName)
 	if !found {
 		return true // If comparison field doesn't exist, this field is not required
 	}
@@ -468,7 +807,7 @@ func isRequiredUnless(fl FieldLevel) bool {
 	fieldName := params[0]
 	expectedValue := params[1]
 	
-	field, _, found := fl.(*fieldLevel).getStructFieldOK(fl.Parent(), fieldName)
+	field, _, found := siblingField(fl, fieldName)
 	if !found {
 		return HasValue(fl) // If comparison field doesn't exist, this field is required
 	}
@@ -483,7 +822,7 @@ func isRequiredUnless(fl FieldLevel) bool {
 // isRequiredWith validates that field is required if another field has any value
 func isRequiredWith(fl FieldLevel) bool {
 	fieldName := fl.Param()
-	field, _, found := fl.(*fieldLevel).getStructFieldOK(fl.Parent(), fieldName)
+	field, _, found := siblingField(fl, fieldName)
 	if !found {
 		return true // If comparison field doesn't exist, this field is not required
 	}
@@ -498,7 +837,7 @@ func isRequiredWith(fl FieldLevel) bool {
 // isRequiredWithout validates that field is required if another field is empty
 func isRequiredWithout(fl FieldLevel) bool {
 	fieldName := fl.Param()
-	field, _, found := fl.(*fieldLevel).getStructFieldOK(fl.Parent(), fieldName)
+	field, _, found := siblingField(fl, fieldName)
 	if !found {
 		return HasValue(fl) // If comparison field doesn't exist, this field is required
 	}
@@ -512,6 +851,145 @@ func isRequiredWithout(fl FieldLevel) bool {
 
 // Helper functions
 
+// compareParam compares a number, or the length of a string, slice, map or
+// array, with a numeric rule parameter and returns -1, 0 or +1. Parameters
+// keep their float semantics (min=0.5, max=1e3) for every numeric kind and
+// are compared exactly; float32 fields are compared with the parameter
+// rounded to float32. An empty parameter means zero. ok is false for other
+// kinds, unparsable parameters and NaN, so min and max rules fail.
+func compareParam(field reflect.Value, param string) (result int, ok bool) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		param = "0"
+	}
+	if isSizeSpecParam(param) {
+		return compareSizeParam(field, param)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return compareIntParam(int64(len(field.String())), param)
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return compareIntParam(int64(field.Len()), param)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareIntParam(field.Int(), param)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareUintParam(field.Uint(), param)
+	case reflect.Float32, reflect.Float64:
+		limit, err := strconv.ParseFloat(param, 64)
+		value := field.Float()
+		if err != nil || math.IsNaN(limit) || math.IsNaN(value) {
+			return 0, false
+		}
+		if field.Kind() == reflect.Float32 {
+			limit = float64(float32(limit))
+		}
+		return cmp.Compare(value, limit), true
+	}
+
+	return 0, false
+}
+
+// isSizeSpecParam reports whether a length parameter carries a unit, like
+// "5:chars" or "10KB", rather than being a plain number
+func isSizeSpecParam(param string) bool {
+	if strings.Contains(param, ":") {
+		return true
+	}
+	_, ok := parseByteUnit(param)
+	return ok
+}
+
+// compareSizeParam compares the size of a string or []byte, measured in the
+// unit of a size parameter such as "5:chars" or "10KB", with its value
+func compareSizeParam(field reflect.Value, param string) (int, bool) {
+	spec, err := ParseSizeSpec(param)
+	if err != nil {
+		return 0, false
+	}
+	size, ok := measureSize(field, spec.Type)
+	if !ok {
+		return 0, false
+	}
+	return cmp.Compare(size, spec.Value), true
+}
+
+// measureSize returns the length of a string or []byte in bytes or runes
+func measureSize(field reflect.Value, sizeType SizeType) (int64, bool) {
+	var data []byte
+	switch {
+	case field.Kind() == reflect.String:
+		s := field.String()
+		switch sizeType {
+		case SizeBytes, SizeDefault:
+			return int64(len(s)), true
+		case SizeChars, SizeRunes, SizeCodePoints:
+			return int64(utf8.RuneCountInString(s)), true
+		}
+		return 0, false
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		data = field.Bytes()
+	default:
+		return 0, false
+	}
+
+	switch sizeType {
+	case SizeBytes, SizeDefault:
+		return int64(len(data)), true
+	case SizeChars, SizeRunes, SizeCodePoints:
+		return int64(utf8.RuneCount(data)), true
+	}
+	return 0, false
+}
+
+// compareIntParam compares n with an integer or float parameter
+func compareIntParam(n int64, param string) (int, bool) {
+	if limit, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return cmp.Compare(n, limit), true
+	}
+
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil || math.IsNaN(limit) {
+		return 0, false
+	}
+	switch {
+	case limit >= math.MaxInt64:
+		return -1, true
+	case limit < math.MinInt64:
+		return 1, true
+	}
+
+	whole := math.Trunc(limit)
+	if result := cmp.Compare(n, int64(whole)); result != 0 {
+		return result, true
+	}
+	return cmp.Compare(whole, limit), true
+}
+
+// compareUintParam compares n with an unsigned, negative or float parameter
+func compareUintParam(n uint64, param string) (int, bool) {
+	if limit, err := strconv.ParseUint(param, 10, 64); err == nil {
+		return cmp.Compare(n, limit), true
+	}
+
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil || math.IsNaN(limit) {
+		return 0, false
+	}
+	switch {
+	case limit < 0:
+		return 1, true
+	case limit >= math.MaxUint64:
+		return -1, true
+	}
+
+	whole := math.Trunc(limit)
+	if result := cmp.Compare(n, uint64(whole)); result != 0 {
+		return result, true
+	}
+	return cmp.Compare(whole, limit), true
+}
+
 // getString safely converts a reflect.Value to string
 func getString(field reflect.Value) string {
 	switch field.Kind() {
@@ -529,8 +1007,42 @@ func getString(field reflect.Value) string {
 	return ""
 }
 
+// isNilValue reports whether a value is invalid or a nil pointer/interface
+func isNilValue(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Interface:
+		return val.IsNil()
+	}
+	return false
+}
+
+// indirectValue follows pointers and interfaces until a concrete value is reached
+func indirectValue(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return val
+		}
+		val = val.Elem()
+	}
+	return val
+}
+
+// valueInterface safely extracts the underlying value for error reporting
+func valueInterface(val reflect.Value) interface{} {
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+	return val.Interface()
+}
+
 // compareFields compares two fields based on their type
 func compareFields(field1, field2 reflect.Value, kind reflect.Kind, expected int) bool {
+	if field1.Kind() != kind || field2.Kind() != kind {
+		return false
+	}
+	
 	switch kind {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		val1, val2 := field1.Int(), field2.Int()
@@ -573,8 +1085,26 @@ func compareFields(field1, field2 reflect.Value, kind reflect.Kind, expected int
 	}
 }
 
+// siblingField looks up a field by name on the parent struct of the field
+// being validated without assuming a concrete FieldLevel implementation
+func siblingField(fl FieldLevel, fieldName string) (reflect.Value, reflect.Kind, bool) {
+	if impl, ok := fl.(*fieldLevel); ok {
+		return impl.getStructFieldOK(fl.Parent(), fieldName)
+	}
+	return (&fieldLevel{}).getStructFieldOK(fl.Parent(), fieldName)
+}
+
 // getStructFieldOK helper for fieldLevel
 func (fl *fieldLevel) getStructFieldOK(val reflect.Value, fieldName string) (reflect.Value, reflect.Kind, bool) {
+	field, kind, ok := fl.lookupStructField(val, fieldName)
+	if fl.validator != nil && fl.validator.config.Debug {
+		fl.validator.debugLookup(fl, fieldName, field, ok)
+	}
+	return field, kind, ok
+}
+
+// lookupStructField resolves a named field on a struct value
+func (fl *fieldLevel) lookupStructField(val reflect.Value, fieldName string) (reflect.Value, reflect.Kind, bool) {
 	val, kind, ok := fl.ExtractType(val)
 	if !ok || kind != reflect.Struct {
 		return reflect.Value{}, kind, false
@@ -0,0 +1,207 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checksumScheme describes an identifier ending in, or carrying, a check
+// digit computed from its other characters
+type checksumScheme struct {
+	name      string // Name used in messages, e.g. "ISBN-10"
+	lengths   []int  // Allowed numbers of characters once separators are removed
+	separated bool   // Spaces and dashes may group the characters, as in 978-0-306-40615-7
+	values    func(s string) ([]int, bool)
+	valid     func(values []int) bool
+}
+
+// checksumSchemes are the built-in checksum rules by tag
+var checksumSchemes = map[string]checksumScheme{
+	"luhn":   {name: "Luhn number", separated: true, values: decimalDigits, valid: luhnValid},
+	"imei":   {name: "IMEI", lengths: []int{15}, separated: true, values: decimalDigits, valid: luhnValid},
+	"ean13":  {name: "EAN-13", lengths: []int{13}, values: decimalDigits, valid: gs1Valid},
+	"gtin":   {name: "GTIN", lengths: []int{8, 12, 13, 14}, values: decimalDigits, valid: gs1Valid},
+	"isbn13": {name: "ISBN-13", lengths: []int{13}, separated: true, values: decimalDigits, valid: isbn13Valid},
+	"isbn10": {name: "ISBN-10", lengths: []int{10}, separated: true, values: isbn10Digits, valid: isbn10Valid},
+	"vin":    {name: "VIN", lengths: []int{17}, values: vinValues, valid: vinValid},
+}
+
+// validateChecksum checks value against the scheme of a checksum rule
+func validateChecksum(field, tag, value string) error {
+	scheme := checksumSchemes[tag]
+	s := value
+	if scheme.separated {
+		s = strings.NewReplacer(" ", "", "-", "").Replace(s)
+	}
+	values, ok := scheme.values(s)
+	if ok && len(scheme.lengths) > 0 {
+		ok = false
+		for _, length := range scheme.lengths {
+			ok = ok || len(values) == length
+		}
+	}
+	if !ok || len(values) < 2 || !scheme.valid(values) {
+		return ValidationError{
+			Field:   field,
+			Tag:     tag,
+			Value:   value,
+			Message: fmt.Sprintf(ErrorMsgChecksum, field, scheme.name),
+		}
+	}
+	return nil
+}
+
+// ValidateLuhn validates a number of any length passing the Luhn (mod 10) check
+func ValidateLuhn(field string, value string) error {
+	return validateChecksum(field, "luhn", value)
+}
+
+// ValidateIMEI validates a 15-digit device IMEI
+func ValidateIMEI(field string, value string) error {
+	return validateChecksum(field, "imei", value)
+}
+
+// ValidateEAN13 validates a 13-digit EAN barcode number
+func ValidateEAN13(field string, value string) error {
+	return validateChecksum(field, "ean13", value)
+}
+
+// ValidateGTIN validates a GTIN-8, GTIN-12 (UPC-A), GTIN-13 or GTIN-14
+func ValidateGTIN(field string, value string) error {
+	return validateChecksum(field, "gtin", value)
+}
+
+// ValidateISBN13 validates a 13-digit ISBN starting with 978 or 979
+func ValidateISBN13(field string, value string) error {
+	return validateChecksum(field, "isbn13", value)
+}
+
+// ValidateISBN10 validates a 10-character ISBN, whose check digit may be X
+func ValidateISBN10(field string, value string) error {
+	return validateChecksum(field, "isbn10", value)
+}
+
+// ValidateVIN validates a 17-character vehicle identification number with
+// the North American check digit in position 9
+func ValidateVIN(field string, value string) error {
+	return validateChecksum(field, "vin", value)
+}
+
+// checksumRule returns the rule function of a checksum tag
+func checksumRule(tag string) ValidationFunc {
+	return func(fl FieldLevel) bool {
+		return validateChecksum(fl.FieldName(), tag, getString(fl.Field())) == nil
+	}
+}
+
+// weightedSum adds up the terms of values, given each value and its
+// position counted from the rightmost, which holds the check digit
+func weightedSum(values []int, term func(value, fromRight int) int) int {
+	sum := 0
+	for i, value := range values {
+		sum += term(value, len(values)-1-i)
+	}
+	return sum
+}
+
+// decimalDigits returns the digits of s
+func decimalDigits(s string) ([]int, bool) {
+	values := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil, false
+		}
+		values[i] = int(s[i] - '0')
+	}
+	return values, true
+}
+
+// luhnValid applies the Luhn check of card numbers and IMEIs: every second
+// digit left of the check digit is doubled
+func luhnValid(values []int) bool {
+	return weightedSum(values, func(value, fromRight int) int {
+		if fromRight%2 == 1 {
+			value *= 2
+			if value > 9 {
+				value -= 9
+			}
+		}
+		return value
+	})%10 == 0
+}
+
+// gs1Valid applies the GS1 mod 10 check of GTINs: weights 3 and 1 alternate
+// from the digit left of the check digit
+func gs1Valid(values []int) bool {
+	return weightedSum(values, func(value, fromRight int) int {
+		if fromRight%2 == 1 {
+			return value * 3
+		}
+		return value
+	})%10 == 0
+}
+
+// isbn13Valid checks an ISBN-13, a GTIN-13 in the 978 and 979 Bookland prefixes
+func isbn13Valid(values []int) bool {
+	return values[0] == 9 && values[1] == 7 && (values[2] == 8 || values[2] == 9) && gs1Valid(values)
+}
+
+// isbn10Digits returns the digits of an ISBN-10, its check digit X counting 10
+func isbn10Digits(s string) ([]int, bool) {
+	if n := len(s); n > 0 && (s[n-1] == 'X' || s[n-1] == 'x') {
+		values, ok := decimalDigits(s[:n-1])
+		return append(values, 10), ok
+	}
+	return decimalDigits(s)
+}
+
+// isbn10Valid applies the mod 11 check of ISBN-10: weights 10 down to 1
+func isbn10Valid(values []int) bool {
+	return weightedSum(values, func(value, fromRight int) int {
+		return value * (fromRight + 1)
+	})%11 == 0
+}
+
+// vinWeights are the weights of the 17 characters of a VIN
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// vinValues transliterates the characters of a VIN to numbers. I, O and Q,
+// easily mistaken for digits, are not allowed. The check digit in position 9
+// is a digit, or X for 10.
+func vinValues(s string) ([]int, bool) {
+	values := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		switch {
+		case c >= '0' && c <= '9':
+			values[i] = int(c - '0')
+		case i == 8 && c == 'X':
+			values[i] = 10
+		case i == 8:
+			return nil, false
+		case c == 'I' || c == 'O' || c == 'Q':
+			return nil, false
+		case c >= 'A' && c <= 'H':
+			values[i] = int(c-'A') + 1
+		case c >= 'J' && c <= 'R':
+			values[i] = int(c-'J') + 1
+		case c >= 'S' && c <= 'Z':
+			values[i] = int(c-'S') + 2
+		default:
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// vinValid compares the check digit in position 9, where X stands for 10,
+// with the weighted sum of the characters mod 11
+func vinValid(values []int) bool {
+	sum := weightedSum(values, func(value, fromRight int) int {
+		return value * vinWeights[len(vinWeights)-1-fromRight]
+	})
+	return sum%11 == values[8]
+}
@@ -0,0 +1,209 @@
+// Command benchgate compares benchmark results against a stored baseline and
+// exits non-zero when any benchmark regressed beyond the tolerance, so CI
+// pipelines can gate on performance.
+//
+// Usage:
+//
+//	go test -run=^$ -bench=. -benchmem ./... | benchgate -baseline=bench/baseline.json
+//
+// Results are read as `go test -bench` output or as the JSON written by
+// go-bench's SaveResults. Exit status is 1 on regressions and 2 on errors.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	bench "github.com/mateothegreat/go-bench"
+)
+
+// errRegressed is returned by run when the gate fails
+var errRegressed = errors.New("performance regressed")
+
+// options holds the parsed command line flags
+type options struct {
+	baseline  string
+	current   string
+	tolerance float64
+	benchstat string
+	html      string
+	sinks     []resultSink
+	job       string
+	update    bool
+	profile   profileOptions
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case errors.Is(err, errRegressed):
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "benchgate:", err)
+		os.Exit(2)
+	}
+}
+
+// run reads the current results, exports them and writes them to the sinks
+// when asked, and compares them with the baseline, reporting regressions to
+// stdout
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts, err := parseFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	current, err := readResultsFile(opts.current, stdin)
+	if err != nil {
+		return fmt.Errorf("reading current results: %w", err)
+	}
+	if len(current) == 0 {
+		return fmt.Errorf("no benchmark results in %s", displayName(opts.current))
+	}
+
+	if opts.benchstat != "" {
+		if err := writeBenchstatFile(opts.benchstat, current); err != nil {
+			return err
+		}
+	}
+
+	if opts.html != "" {
+		if err := writeHTMLReportFile(opts.html, current); err != nil {
+			return err
+		}
+	}
+
+	// Sinks record every run, including ones that fail the gate
+	for _, sink := range opts.sinks {
+		if err := sink.Write(ctx, current); err != nil {
+			return err
+		}
+	}
+
+	if opts.update {
+		if err := bench.SaveResults(current, opts.baseline); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "updated baseline %s with %d benchmarks\n", opts.baseline, len(current))
+		return nil
+	}
+
+	baseline, err := readResultsFile(opts.baseline, nil)
+	if err != nil {
+		return fmt.Errorf("reading baseline: %w", err)
+	}
+
+	regressed := regressions(baseline, current, opts.tolerance)
+	for _, regression := range regressed {
+		fmt.Fprintln(stdout, formatRegression(regression))
+	}
+	for _, name := range missing(baseline, current) {
+		fmt.Fprintf(stderr, "benchgate: %s is in the baseline but was not run\n", name)
+	}
+	if opts.profile.dir != "" {
+		if err := captureProfiles(ctx, opts.profile, flaggedCases(regressed, current, opts.profile.slowest), stderr); err != nil {
+			return err
+		}
+	}
+	if len(regressed) > 0 {
+		fmt.Fprintf(stdout, "%d of %d benchmarks regressed beyond %.1f%%\n", len(regressed), len(current), opts.tolerance)
+		return errRegressed
+	}
+	fmt.Fprintf(stdout, "%d benchmarks within %.1f%% of the baseline\n", len(current), opts.tolerance)
+	return nil
+}
+
+// parseFlags parses the command line into options
+func parseFlags(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+	var sinks []string
+	fs := flag.NewFlagSet("benchgate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&opts.baseline, "baseline", "", "Baseline results file (JSON or go test -bench output)")
+	fs.StringVar(&opts.current, "current", "-", "Current results file, or - for stdin")
+	fs.Float64Var(&opts.tolerance, "tolerance", 10, "Allowed slowdown or allocation growth in percent")
+	fs.StringVar(&opts.benchstat, "benchstat", "", "Also write the current results in benchstat format to this file")
+	fs.StringVar(&opts.html, "html", "", "Also write an HTML report with charts of the current results to this file")
+	fs.Func("sink", "Also write the current results to a sink, as file:path, s3:URL or gcs:URL (pre-signed PUT URLs), or pushgateway:URL; repeatable", func(value string) error {
+		sinks = append(sinks, value)
+		return nil
+	})
+	fs.StringVar(&opts.job, "job", "benchgate", "Job name for pushgateway sinks")
+	fs.StringVar(&opts.profile.dir, "profile-dir", "", "Capture CPU and heap profiles of regressed benchmarks into this directory")
+	fs.StringVar(&opts.profile.pkg, "profile-pkg", ".", "Package whose benchmarks -profile-dir reruns")
+	fs.StringVar(&opts.profile.benchtime, "profile-benchtime", "1s", "Benchmark time of each profiled rerun")
+	fs.IntVar(&opts.profile.slowest, "profile-slowest", 0, "Also profile this many of the slowest benchmarks")
+	fs.BoolVar(&opts.update, "update", false, "Replace the baseline with the current results instead of comparing")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if opts.baseline == "" {
+		return nil, errors.New("-baseline is required")
+	}
+	if opts.tolerance < 0 {
+		return nil, fmt.Errorf("invalid tolerance %g", opts.tolerance)
+	}
+	if opts.profile.slowest < 0 {
+		return nil, fmt.Errorf("invalid -profile-slowest %d", opts.profile.slowest)
+	}
+	for _, value := range sinks {
+		sink, err := parseSink(value, opts.job)
+		if err != nil {
+			return nil, err
+		}
+		opts.sinks = append(opts.sinks, sink)
+	}
+	return opts, nil
+}
+
+// readResultsFile reads results from a file, or from stdin for -
+func readResultsFile(path string, stdin io.Reader) ([]bench.BenchmarkResult, error) {
+	if path == "-" {
+		if stdin == nil {
+			return nil, errors.New("stdin cannot be read twice")
+		}
+		return readResults(stdin)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readResults(file)
+}
+
+// writeBenchstatFile exports results for benchstat
+func writeBenchstatFile(path string, results []bench.BenchmarkResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeBenchstat(file, results); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// displayName names a results file in messages
+func displayName(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	return path
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const benchOutput = `goos: linux
+goarch: amd64
+pkg: github.com/mateothegreat/go-validation
+BenchmarkRange-8      	500000000	         2.00 ns/op	       0 B/op	       0 allocs/op
+BenchmarkRange-8      	500000000	         4.00 ns/op	       0 B/op	       0 allocs/op
+BenchmarkLength/short-8	100000000	        10.0 ns/op	      16 B/op	       1 allocs/op
+PASS
+ok  	github.com/mateothegreat/go-validation	3.2s
+`
+
+func TestParseBenchOutput(t *testing.T) {
+	results, err := parseBenchOutput(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 benchmarks, got %+v", results)
+	}
+	if results[0].Name != "Range" || results[0].NsPerOp != 3 {
+		t.Errorf("expected Range runs to be averaged to 3 ns/op, got %+v", results[0])
+	}
+	if results[1].Name != "Length/short" || results[1].BytesPerOp != 16 || results[1].AllocsPerOp != 1 {
+		t.Errorf("expected Length/short with 16 B/op and 1 alloc/op, got %+v", results[1])
+	}
+
+	var out bytes.Buffer
+	if err := writeBenchstat(&out, results); err != nil {
+		t.Fatal(err)
+	}
+	expected := "BenchmarkRange\t1\t3 ns/op\t0 B/op\t0 allocs/op\nBenchmarkLength/short\t1\t10 ns/op\t16 B/op\t1 allocs/op\n"
+	if out.String() != expected {
+		t.Errorf("expected benchstat output:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestRun_Gate(t *testing.T) {
+	dir := t.TempDir()
+	baseline := filepath.Join(dir, "baseline.json")
+
+	var stdout bytes.Buffer
+	if err := run(context.Background(), []string{"-baseline", baseline, "-update"}, strings.NewReader(benchOutput), &stdout, io.Discard); err != nil {
+		t.Fatalf("updating the baseline failed: %v", err)
+	}
+
+	stdout.Reset()
+	same := "BenchmarkRange-8 1000 3.1 ns/op 0 B/op 0 allocs/op\nBenchmarkLength/short-8 1000 8.0 ns/op 16 B/op 1 allocs/op\n"
+	if err := run(context.Background(), []string{"-baseline", baseline}, strings.NewReader(same), &stdout, io.Discard); err != nil {
+		t.Fatalf("expected results within tolerance to pass, got %v:\n%s", err, stdout.String())
+	}
+
+	stdout.Reset()
+	slower := "BenchmarkRange-8 1000 3.6 ns/op 0 B/op 0 allocs/op\nBenchmarkLength/short-8 1000 10.0 ns/op 32 B/op 1 allocs/op\n"
+	stat := filepath.Join(dir, "new.txt")
+	err := run(context.Background(), []string{"-baseline", baseline, "-benchstat", stat}, strings.NewReader(slower), &stdout, io.Discard)
+	if !errors.Is(err, errRegressed) {
+		t.Fatalf("expected a 20%% slowdown to fail the gate, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Range: 3.00 ns/op -> 3.60 ns/op (+20.0%)") {
+		t.Errorf("expected the regression to be reported, got:\n%s", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "Length/short:") {
+		t.Errorf("expected unchanged benchmarks not to be reported, got:\n%s", stdout.String())
+	}
+	if content, err := os.ReadFile(stat); err != nil || !strings.HasPrefix(string(content), "BenchmarkRange\t1\t3.6 ns/op") {
+		t.Errorf("expected benchstat export, got %q (%v)", content, err)
+	}
+
+	if err := run(context.Background(), []string{"-baseline", baseline, "-tolerance", "25"}, strings.NewReader(slower), io.Discard, io.Discard); err != nil {
+		t.Errorf("expected a 20%% slowdown to pass with 25%% tolerance, got %v", err)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	for name, args := range map[string][]string{
+		"missing baseline flag": {},
+		"negative tolerance":    {"-baseline", "b.json", "-tolerance", "-1"},
+		"missing baseline file": {"-baseline", filepath.Join(t.TempDir(), "missing.json")},
+	} {
+		err := run(context.Background(), args, strings.NewReader(benchOutput), io.Discard, io.Discard)
+		if err == nil || errors.Is(err, errRegressed) {
+			t.Errorf("%s: expected an error, got %v", name, err)
+		}
+	}
+	if err := run(context.Background(), []string{"-baseline", "b.json"}, strings.NewReader("PASS\n"), io.Discard, io.Discard); err == nil {
+		t.Error("expected input without benchmarks to fail")
+	}
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	output := `BenchmarkRange_Size10_Conc1-8 1000 2.0 ns/op 0 B/op 0 allocs/op
+BenchmarkRange_Size100_Conc1-8 1000 4.0 ns/op 0 B/op 0 allocs/op
+BenchmarkRange_Size100_Conc4-8 1000 6.0 ns/op 0 B/op 0 allocs/op
+BenchmarkLength<script>-8 1000 9.0 ns/op 0 B/op 0 allocs/op
+`
+	results, err := parseBenchOutput(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[1].InputSize != 100 || results[1].Concurrency != 1 {
+		t.Fatalf("expected size and concurrency from the name, got %+v", results[1])
+	}
+
+	var out bytes.Buffer
+	if err := writeHTMLReport(&out, results); err != nil {
+		t.Fatal(err)
+	}
+	report := out.String()
+	for _, expected := range []string{
+		"<h2>ns/op by input size</h2>",
+		"<h2>ns/op by concurrency</h2>",
+		`<polyline fill="none" stroke="#1f77b4" stroke-width="2" points="64.0,148.0 624.0,16.0"/>`,
+		`points="64.0,104.0 624.0,16.0"`,
+		"<td>Range_Size100_Conc4</td><td>6.00</td>",
+		"Length&lt;script&gt;",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("expected %q in the report, got:\n%s", expected, report)
+		}
+	}
+	if strings.Contains(report, "<script") {
+		t.Error("expected benchmark names to be escaped")
+	}
+}
+
+func TestRun_Sinks(t *testing.T) {
+	requests := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests[r.Method+" "+r.URL.RequestURI()] = string(body)
+		if r.URL.Path == "/denied" {
+			http.Error(w, "signature expired", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "nightly", "results.json")
+	args := []string{
+		"-baseline", filepath.Join(dir, "baseline.json"), "-update",
+		"-sink", "file:" + file,
+		"-sink", "s3:" + server.URL + "/bench/results.json?X-Amz-Signature=abc",
+		"-sink", "pushgateway:" + server.URL,
+		"-job", "nightly",
+	}
+	if err := run(context.Background(), args, strings.NewReader(benchOutput), io.Discard, io.Discard); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	if saved, err := readResultsFile(file, nil); err != nil || len(saved) != 2 {
+		t.Errorf("expected the file sink to save 2 results, got %v (%v)", saved, err)
+	}
+	if object := requests["PUT /bench/results.json?X-Amz-Signature=abc"]; !strings.Contains(object, `"name": "Range"`) {
+		t.Errorf("expected the results to be uploaded to the pre-signed URL, got %v", requests)
+	}
+	metrics := requests["PUT /metrics/job/nightly"]
+	for _, expected := range []string{
+		"# TYPE benchmark_ns_per_op gauge\n",
+		`benchmark_ns_per_op{benchmark="Range"} 3` + "\n",
+		`benchmark_bytes_per_op{benchmark="Length/short"} 16` + "\n",
+		`benchmark_allocs_per_op{benchmark="Length/short"} 1` + "\n",
+	} {
+		if !strings.Contains(metrics, expected) {
+			t.Errorf("expected %q in the pushed metrics, got:\n%s", expected, metrics)
+		}
+	}
+
+	denied := []string{"-baseline", filepath.Join(dir, "baseline.json"), "-update", "-sink", "gcs:" + server.URL + "/denied"}
+	err := run(context.Background(), denied, strings.NewReader(benchOutput), io.Discard, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "uploading results to gcs: 403 Forbidden: signature expired") {
+		t.Errorf("expected the rejected upload to be reported, got %v", err)
+	}
+
+	for _, sink := range []string{"ftp:host", "s3:bucket/key", "file:"} {
+		if _, err := parseSink(sink, "job"); err == nil {
+			t.Errorf("expected sink %q to be rejected", sink)
+		}
+	}
+}
+
+func TestRun_Profiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	module := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod": "module example.com/slow\n\ngo 1.24\n",
+		"slow_test.go": `package slow
+
+import "testing"
+
+var sink []byte
+
+func BenchmarkSlow(b *testing.B) {
+	b.Run("alloc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sink = make([]byte, 64)
+		}
+	})
+}
+
+func BenchmarkFast(b *testing.B) {}
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(module, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir := t.TempDir()
+	baseline := filepath.Join(dir, "baseline.json")
+	if err := run(context.Background(), []string{"-baseline", baseline, "-update"}, strings.NewReader("BenchmarkSlow/alloc-8 1000 10 ns/op\nBenchmarkFast-8 1000 1 ns/op\n"), io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles := filepath.Join(dir, "profiles")
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(module); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"-baseline", baseline, "-profile-dir", profiles, "-profile-benchtime", "10x", "-profile-slowest", "1"}
+	err := run(context.Background(), args, strings.NewReader("BenchmarkSlow/alloc-8 1000 20 ns/op\nBenchmarkFast-8 1000 1 ns/op\n"), io.Discard, io.Discard)
+	if !errors.Is(err, errRegressed) {
+		t.Fatalf("expected the regression to fail the gate, got %v", err)
+	}
+	for _, name := range []string{"Slow_alloc.cpu.pprof", "Slow_alloc.mem.pprof"} {
+		if info, err := os.Stat(filepath.Join(profiles, name)); err != nil || info.Size() == 0 {
+			t.Errorf("expected profile %s, got %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(profiles, "Fast.cpu.pprof")); err == nil {
+		t.Error("expected only flagged benchmarks to be profiled")
+	}
+}
+
+func TestBenchPattern(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Range":         "^BenchmarkRange$",
+		"Length/short":  "^BenchmarkLength$/^short$",
+		"Suite/a.b(c)":  `^BenchmarkSuite$/^a\.b\(c\)$`,
+		"BenchmarkFast": "^BenchmarkFast$",
+	} {
+		if pattern := benchPattern(name); pattern != expected {
+			t.Errorf("expected %s for %s, got %s", expected, name, pattern)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	bench "github.com/mateothegreat/go-bench"
+)
+
+// unsafeFileChars matches characters replaced in profile file names
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// profileOptions controls profile capture for flagged benchmarks
+type profileOptions struct {
+	dir       string // where profiles are written; empty disables capture
+	pkg       string // package whose benchmarks produced the results
+	benchtime string
+	slowest   int // also profile this many of the slowest benchmarks
+}
+
+// flaggedCases returns the regressed benchmarks followed by the slowest
+// current ones, without duplicates
+func flaggedCases(regressed []bench.RegressionResult, current []bench.BenchmarkResult, slowest int) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, regression := range regressed {
+		if !seen[regression.Name] {
+			seen[regression.Name] = true
+			names = append(names, regression.Name)
+		}
+	}
+
+	bySpeed := append([]bench.BenchmarkResult(nil), current...)
+	sort.SliceStable(bySpeed, func(i, j int) bool { return bySpeed[i].NsPerOp > bySpeed[j].NsPerOp })
+	for _, result := range bySpeed[:min(slowest, len(bySpeed))] {
+		if !seen[result.Name] {
+			seen[result.Name] = true
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
+
+// captureProfiles reruns each benchmark alone with go test, writing its CPU
+// and heap profiles to <dir>/<name>.cpu.pprof and <dir>/<name>.mem.pprof.
+// Names are those of go test output without the Benchmark prefix, such as
+// Length/short.
+func captureProfiles(ctx context.Context, opts profileOptions, names []string, stderr io.Writer) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(opts.dir, 0o755); err != nil {
+		return err
+	}
+	dir, err := filepath.Abs(opts.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		base := filepath.Join(dir, strings.Trim(unsafeFileChars.ReplaceAllString(name, "_"), "_"))
+		cmd := exec.CommandContext(ctx, "go", "test",
+			"-run", "^$",
+			"-bench", benchPattern(name),
+			"-benchtime", opts.benchtime,
+			"-benchmem",
+			"-cpuprofile", base+".cpu.pprof",
+			"-memprofile", base+".mem.pprof",
+			"-o", filepath.Join(dir, "bench.test"),
+			opts.pkg)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("profiling %s: %w\n%s", name, err, output)
+		}
+		fmt.Fprintf(stderr, "benchgate: profiled %s into %s.{cpu,mem}.pprof\n", name, base)
+	}
+	return nil
+}
+
+// benchPattern returns the -bench pattern selecting exactly one benchmark
+func benchPattern(name string) string {
+	parts := strings.Split(name, "/")
+	parts[0] = "Benchmark" + strings.TrimPrefix(parts[0], "Benchmark")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	bench "github.com/mateothegreat/go-bench"
+)
+
+// dimensionSuffix matches the size and concurrency go-bench appends to case names
+var dimensionSuffix = regexp.MustCompile(`_Size(\d+)_Conc(\d+)$`)
+
+// Chart geometry in SVG user units
+const (
+	chartWidth  = 640
+	chartHeight = 320
+	chartLeft   = 64
+	chartRight  = 16
+	chartTop    = 16
+	chartBottom = 40
+)
+
+// chartColors are assigned to series in order
+var chartColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"}
+
+// chart is a line chart of ns/op against one benchmark dimension
+type chart struct {
+	Title  string
+	XLabel string
+	XTicks []point // labels along the bottom, Y unused
+	YTicks []point // gridlines and their labels, X unused
+	Series []series
+}
+
+// point is a labelled position in SVG user units
+type point struct {
+	X, Y  float64
+	Label string
+}
+
+// series is one case's line
+type series struct {
+	Name   string
+	Color  string
+	Points string // SVG polyline points
+	Dots   []point
+}
+
+// geometry exposes the chart layout to reportTemplate
+type geometry struct {
+	Width, Height   int
+	PlotLeft        int
+	PlotRight       int
+	YLabelX         int
+	XTickY, XLabelY int
+	XLabelX         int
+}
+
+// reportData is rendered by reportTemplate
+type reportData struct {
+	Title   string
+	Layout  geometry
+	Charts  []chart
+	Results []bench.BenchmarkResult
+}
+
+// writeHTMLReportFile writes the HTML report of results to path
+func writeHTMLReportFile(path string, results []bench.BenchmarkResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeHTMLReport(file, results); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeHTMLReport writes a self-contained HTML page with inline SVG charts
+// of ns/op against input size and concurrency, and a table of all results
+func writeHTMLReport(w io.Writer, results []bench.BenchmarkResult) error {
+	data := reportData{
+		Title: "Benchmark Report",
+		Layout: geometry{
+			Width:     chartWidth,
+			Height:    chartHeight,
+			PlotLeft:  chartLeft,
+			PlotRight: chartWidth - chartRight,
+			YLabelX:   chartLeft - 6,
+			XTickY:    chartHeight - chartBottom + 16,
+			XLabelX:   (chartWidth + chartLeft) / 2,
+			XLabelY:   chartHeight - 6,
+		},
+		Results: results,
+	}
+	size := func(r bench.BenchmarkResult) int { return r.InputSize }
+	concurrency := func(r bench.BenchmarkResult) int { return r.Concurrency }
+	for _, dim := range []struct {
+		title, label string
+		value, fixed func(bench.BenchmarkResult) int
+	}{
+		{"ns/op by input size", "input size", size, concurrency},
+		{"ns/op by concurrency", "goroutines", concurrency, size},
+	} {
+		if c, ok := buildChart(results, dim.title, dim.label, dim.value, dim.fixed); ok {
+			data.Charts = append(data.Charts, c)
+		}
+	}
+	return reportTemplate.Execute(w, data)
+}
+
+// buildChart plots every case measured at two or more values of a
+// dimension, holding the fixed dimension at the value with the most
+// measurements and averaging repeated results. Values are spaced evenly
+// since sizes usually grow geometrically.
+func buildChart(results []bench.BenchmarkResult, title, label string, value, fixed func(bench.BenchmarkResult) int) (chart, bool) {
+	type sums struct{ total, count float64 }
+	// case name, then fixed value, then plotted value
+	measured := make(map[string]map[int]map[int]*sums)
+	var cases []string
+	for _, result := range results {
+		name := caseName(result.Name)
+		if measured[name] == nil {
+			measured[name] = make(map[int]map[int]*sums)
+			cases = append(cases, name)
+		}
+		f, x := fixed(result), value(result)
+		if measured[name][f] == nil {
+			measured[name][f] = make(map[int]*sums)
+		}
+		if measured[name][f][x] == nil {
+			measured[name][f][x] = &sums{}
+		}
+		measured[name][f][x].total += result.NsPerOp
+		measured[name][f][x].count++
+	}
+
+	points := make(map[string]map[int]*sums, len(cases))
+	for _, name := range cases {
+		best := 0
+		for f, line := range measured[name] {
+			current := points[name]
+			if current == nil || len(line) > len(current) || (len(line) == len(current) && f < best) {
+				points[name], best = line, f
+			}
+		}
+	}
+
+	xs := make(map[int]bool)
+	maxY := 0.0
+	var plotted []string
+	for _, name := range cases {
+		if len(points[name]) < 2 {
+			continue
+		}
+		plotted = append(plotted, name)
+		for x, s := range points[name] {
+			xs[x] = true
+			maxY = max(maxY, s.total/s.count)
+		}
+	}
+	if len(plotted) == 0 {
+		return chart{}, false
+	}
+
+	values := make([]int, 0, len(xs))
+	for x := range xs {
+		values = append(values, x)
+	}
+	sort.Ints(values)
+	plotWidth := float64(chartWidth - chartLeft - chartRight)
+	plotHeight := float64(chartHeight - chartTop - chartBottom)
+	xPos := make(map[int]float64, len(values))
+	c := chart{Title: title, XLabel: label}
+	for i, x := range values {
+		xPos[x] = chartLeft + plotWidth*float64(i)/float64(max(len(values)-1, 1))
+		c.XTicks = append(c.XTicks, point{X: xPos[x], Label: strconv.Itoa(x)})
+	}
+	if maxY == 0 {
+		maxY = 1
+	}
+	yPos := func(y float64) float64 { return chartTop + plotHeight*(1-y/maxY) }
+	for i := 0; i <= 4; i++ {
+		y := maxY * float64(i) / 4
+		c.YTicks = append(c.YTicks, point{Y: yPos(y), Label: formatNs(y)})
+	}
+
+	for i, name := range plotted {
+		s := series{Name: name, Color: chartColors[i%len(chartColors)]}
+		var coords []string
+		for _, x := range values {
+			sum, exists := points[name][x]
+			if !exists {
+				continue
+			}
+			y := sum.total / sum.count
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", xPos[x], yPos(y)))
+			s.Dots = append(s.Dots, point{X: xPos[x], Y: yPos(y), Label: formatNs(y)})
+		}
+		s.Points = strings.Join(coords, " ")
+		c.Series = append(c.Series, s)
+	}
+	return c, true
+}
+
+// caseName strips the size and concurrency go-bench appends to a result name
+func caseName(name string) string {
+	return dimensionSuffix.ReplaceAllString(name, "")
+}
+
+// setDimensions fills in the input size and concurrency encoded in a
+// go-bench result name
+func setDimensions(result *bench.BenchmarkResult) {
+	match := dimensionSuffix.FindStringSubmatch(result.Name)
+	if match == nil {
+		return
+	}
+	result.InputSize, _ = strconv.Atoi(match[1])
+	result.Concurrency, _ = strconv.Atoi(match[2])
+}
+
+// formatNs renders a duration in nanoseconds compactly
+func formatNs(ns float64) string {
+	return strconv.FormatFloat(ns, 'g', 4, 64) + " ns"
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", sans-serif; margin: 2em; color: #222; }
+svg { background: #fff; border: 1px solid #ddd; margin-bottom: 0.5em; }
+svg text { font-size: 11px; fill: #444; }
+.legend span { display: inline-block; margin-right: 1.5em; }
+.legend i { display: inline-block; width: 12px; height: 3px; margin-right: 4px; vertical-align: middle; }
+table { border-collapse: collapse; margin-top: 2em; }
+th, td { padding: 4px 12px; border-bottom: 1px solid #eee; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{$layout := .Layout}}{{range .Charts}}
+<h2>{{.Title}}</h2>
+<svg width="{{$layout.Width}}" height="{{$layout.Height}}" role="img" aria-label="{{.Title}}">
+{{range .YTicks}}<line x1="{{$layout.PlotLeft}}" x2="{{$layout.PlotRight}}" y1="{{.Y}}" y2="{{.Y}}" stroke="#eee"/>
+<text x="{{$layout.YLabelX}}" y="{{.Y}}" dy="4" text-anchor="end">{{.Label}}</text>
+{{end}}{{range .XTicks}}<text x="{{.X}}" y="{{$layout.XTickY}}" text-anchor="middle">{{.Label}}</text>
+{{end}}<text x="{{$layout.XLabelX}}" y="{{$layout.XLabelY}}" text-anchor="middle">{{.XLabel}}</text>
+{{range .Series}}{{$series := .}}<polyline fill="none" stroke="{{.Color}}" stroke-width="2" points="{{.Points}}"/>
+{{range .Dots}}<circle cx="{{.X}}" cy="{{.Y}}" r="3" fill="{{$series.Color}}"><title>{{$series.Name}}: {{.Label}}</title></circle>
+{{end}}{{end}}</svg>
+<div class="legend">{{range .Series}}<span><i style="background: {{.Color}}"></i>{{.Name}}</span>{{end}}</div>
+{{end}}
+<table>
+<tr><th>Benchmark</th><th>ns/op</th><th>B/op</th><th>allocs/op</th><th>input size</th><th>concurrency</th></tr>
+{{range .Results}}<tr><td>{{.Name}}</td><td>{{printf "%.2f" .NsPerOp}}</td><td>{{.BytesPerOp}}</td><td>{{.AllocsPerOp}}</td><td>{{.InputSize}}</td><td>{{.Concurrency}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
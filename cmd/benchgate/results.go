@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	bench "github.com/mateothegreat/go-bench"
+)
+
+// procsSuffix matches the GOMAXPROCS suffix go test appends to benchmark names
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// readResults reads the JSON written by go-bench's SaveResults or the text
+// output of go test -bench, detected from the first non-space byte
+func readResults(r io.Reader) ([]bench.BenchmarkResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var results []bench.BenchmarkResult
+		if err := json.Unmarshal(trimmed, &results); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	return parseBenchOutput(bytes.NewReader(data))
+}
+
+// parseBenchOutput parses the benchmark lines of go test -bench output,
+// averaging benchmarks run several times with -count. Names lose their
+// Benchmark prefix and GOMAXPROCS suffix to match go-bench result names.
+func parseBenchOutput(r io.Reader) ([]bench.BenchmarkResult, error) {
+	var results []bench.BenchmarkResult
+	runs := make(map[string]int)
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+
+		result := bench.BenchmarkResult{Name: procsSuffix.ReplaceAllString(strings.TrimPrefix(fields[0], "Benchmark"), "")}
+		setDimensions(&result)
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s value %q", line, fields[i+1], fields[i])
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				result.NsPerOp = value
+			case "B/op":
+				result.BytesPerOp = int64(value)
+			case "allocs/op":
+				result.AllocsPerOp = int(value)
+			}
+		}
+
+		i, seen := index[result.Name]
+		if !seen {
+			index[result.Name] = len(results)
+			runs[result.Name] = 1
+			results = append(results, result)
+			continue
+		}
+		n := runs[result.Name]
+		runs[result.Name] = n + 1
+		mean := &results[i]
+		mean.NsPerOp = (mean.NsPerOp*float64(n) + result.NsPerOp) / float64(n+1)
+		mean.BytesPerOp = (mean.BytesPerOp*int64(n) + result.BytesPerOp) / int64(n+1)
+		mean.AllocsPerOp = (mean.AllocsPerOp*n + result.AllocsPerOp) / (n + 1)
+	}
+	return results, scanner.Err()
+}
+
+// writeBenchstat writes results in the Go benchmark format read by
+// golang.org/x/perf/cmd/benchstat. The iteration count is not recorded by
+// go-bench, so every line reports a single iteration.
+func writeBenchstat(w io.Writer, results []bench.BenchmarkResult) error {
+	for _, result := range results {
+		name := strings.Join(strings.Fields(result.Name), "_")
+		if !strings.HasPrefix(name, "Benchmark") {
+			name = "Benchmark" + name
+		}
+		_, err := fmt.Fprintf(w, "%s\t1\t%s ns/op\t%d B/op\t%d allocs/op\n",
+			name, strconv.FormatFloat(result.NsPerOp, 'f', -1, 64), result.BytesPerOp, result.AllocsPerOp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// regressions compares results by name and returns those slower, or
+// allocating more, than the baseline by more than tolerance percent.
+// Improvements never fail the gate, and any allocation where the baseline
+// had none counts as a regression.
+func regressions(baseline, current []bench.BenchmarkResult, tolerance float64) []bench.RegressionResult {
+	baselines := make(map[string]bench.BenchmarkResult, len(baseline))
+	for _, result := range baseline {
+		baselines[result.Name] = result
+	}
+
+	var regressed []bench.RegressionResult
+	for _, result := range current {
+		base, exists := baselines[result.Name]
+		if !exists {
+			continue
+		}
+		comparison := bench.RegressionResult{
+			Name:           result.Name,
+			BaselineNs:     base.NsPerOp,
+			CurrentNs:      result.NsPerOp,
+			TimeDiffNs:     result.NsPerOp - base.NsPerOp,
+			BaselineAllocs: base.AllocsPerOp,
+			CurrentAllocs:  result.AllocsPerOp,
+			AllocDiff:      result.AllocsPerOp - base.AllocsPerOp,
+		}
+		if base.NsPerOp > 0 {
+			comparison.TimeDiffPercent = comparison.TimeDiffNs / base.NsPerOp * 100
+		}
+		if base.AllocsPerOp > 0 {
+			comparison.AllocDiffPercent = float64(comparison.AllocDiff) / float64(base.AllocsPerOp) * 100
+		}
+		comparison.IsRegression = comparison.TimeDiffPercent > tolerance ||
+			comparison.AllocDiffPercent > tolerance ||
+			(base.AllocsPerOp == 0 && result.AllocsPerOp > 0)
+		if comparison.IsRegression {
+			regressed = append(regressed, comparison)
+		}
+	}
+	return regressed
+}
+
+// missing returns the baseline benchmarks absent from the current results
+func missing(baseline, current []bench.BenchmarkResult) []string {
+	ran := make(map[string]bool, len(current))
+	for _, result := range current {
+		ran[result.Name] = true
+	}
+	var names []string
+	for _, result := range baseline {
+		if !ran[result.Name] {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
+
+// formatRegression describes a regression on one line
+func formatRegression(r bench.RegressionResult) string {
+	return fmt.Sprintf("%s: %.2f ns/op -> %.2f ns/op (%+.1f%%), %d -> %d allocs/op",
+		r.Name, r.BaselineNs, r.CurrentNs, r.TimeDiffPercent, r.BaselineAllocs, r.CurrentAllocs)
+}
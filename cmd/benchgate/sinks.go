@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	bench "github.com/mateothegreat/go-bench"
+)
+
+// resultSink stores a run's results for long-term tracking
+type resultSink interface {
+	Write(ctx context.Context, results []bench.BenchmarkResult) error
+}
+
+// fileSink writes results as JSON with go-bench's SaveResults, which
+// LoadResults and -baseline read back
+type fileSink struct {
+	path string
+}
+
+// Write saves the results to the file
+func (s *fileSink) Write(ctx context.Context, results []bench.BenchmarkResult) error {
+	return bench.SaveResults(results, s.path)
+}
+
+// objectSink uploads results as JSON to object storage with an HTTP PUT to
+// a pre-signed URL, as issued by S3 (`aws s3 presign`) and GCS (`gcloud
+// storage sign-url`), so no cloud SDK or credentials are needed here
+type objectSink struct {
+	provider string // s3 or gcs, for messages
+	url      string
+	client   *http.Client
+}
+
+// Write uploads the results
+func (s *objectSink) Write(ctx context.Context, results []bench.BenchmarkResult) error {
+	body, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := send(s.client, req); err != nil {
+		return fmt.Errorf("uploading results to %s: %w", s.provider, err)
+	}
+	return nil
+}
+
+// pushgatewaySink pushes ns/op, B/op and allocs/op gauges, labelled by
+// benchmark, to a Prometheus Pushgateway under a job, replacing the job's
+// previous run
+type pushgatewaySink struct {
+	url    string // e.g. http://pushgateway:9091
+	job    string
+	client *http.Client
+}
+
+// Write pushes the results
+func (s *pushgatewaySink) Write(ctx context.Context, results []bench.BenchmarkResult) error {
+	var body bytes.Buffer
+	writeMetrics(&body, results)
+
+	target := strings.TrimSuffix(s.url, "/") + "/metrics/job/" + url.PathEscape(s.job)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	if err := send(s.client, req); err != nil {
+		return fmt.Errorf("pushing results to %s: %w", s.url, err)
+	}
+	return nil
+}
+
+// writeMetrics renders results in the Prometheus text exposition format
+func writeMetrics(w io.Writer, results []bench.BenchmarkResult) {
+	metrics := []struct {
+		name, help string
+		value      func(bench.BenchmarkResult) float64
+	}{
+		{"benchmark_ns_per_op", "Nanoseconds per operation.", func(r bench.BenchmarkResult) float64 { return r.NsPerOp }},
+		{"benchmark_bytes_per_op", "Bytes allocated per operation.", func(r bench.BenchmarkResult) float64 { return float64(r.BytesPerOp) }},
+		{"benchmark_allocs_per_op", "Allocations per operation.", func(r bench.BenchmarkResult) float64 { return float64(r.AllocsPerOp) }},
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", metric.name, metric.help, metric.name)
+		for _, result := range results {
+			fmt.Fprintf(w, "%s{benchmark=\"%s\"} %g\n", metric.name, labelValue.Replace(result.Name), metric.value(result))
+		}
+	}
+}
+
+// labelValue escapes Prometheus label values
+var labelValue = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// send performs a request, treating non-2xx responses as errors
+func send(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// parseSink creates a sink from a -sink value of the form kind:target, with
+// kind one of file, s3, gcs or pushgateway
+func parseSink(value, job string) (resultSink, error) {
+	kind, target, found := strings.Cut(value, ":")
+	if !found || target == "" {
+		return nil, fmt.Errorf("invalid sink %q, expected kind:target", value)
+	}
+	switch kind {
+	case "file":
+		return &fileSink{path: target}, nil
+	case "s3", "gcs":
+		if !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "http://") {
+			return nil, fmt.Errorf("invalid %s sink %q, expected a pre-signed URL", kind, target)
+		}
+		return &objectSink{provider: kind, url: target}, nil
+	case "pushgateway":
+		return &pushgatewaySink{url: target, job: job}, nil
+	}
+	return nil, fmt.Errorf("unknown sink kind %q in %q", kind, value)
+}
@@ -0,0 +1,333 @@
+// Command configvalidator generates zero-reflection validators for
+// configuration structs.
+//
+// Usage:
+//
+//	configvalidator -input=. -output=./generated -strategies -optimize
+//	configvalidator rules [-json] [-category=network]
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+	"github.com/mateothegreat/go-validation/internal/generator"
+)
+
+// options holds the parsed command line flags
+type options struct {
+	input         string
+	file          string
+	include       string
+	exclude       string
+	types         string
+	packageName   string
+	output        string
+	suffix        string
+	sourceImport  string
+	buildTags     string
+	typescript    string
+	optimize      bool
+	failFast      bool
+	strategies    bool
+	debugInfo     bool
+	pure          bool
+	tests         bool
+	verbose       bool
+	watch         bool
+	watchInterval time.Duration
+	debounce      time.Duration
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "rules" {
+		err = listRules(os.Args[2:], os.Stdout, os.Stderr)
+	} else {
+		err = run(ctx, os.Args[1:], os.Stderr)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "configvalidator:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the flags and generates the validators, once or until ctx is
+// done in watch mode
+func run(ctx context.Context, args []string, stderr io.Writer) error {
+	opts, err := parseFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+	if opts.watch {
+		return watch(ctx, opts, stderr)
+	}
+	return generate(opts, stderr)
+}
+
+// parseFlags parses the command line into options
+func parseFlags(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+	fs := flag.NewFlagSet("configvalidator", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&opts.input, "input", ".", "Directory containing Go files")
+	fs.StringVar(&opts.file, "file", "", "Specific Go file to analyze (overrides -input)")
+	fs.StringVar(&opts.include, "include", "", "Comma-separated globs of files or directories under -input to analyze")
+	fs.StringVar(&opts.exclude, "exclude", "", "Comma-separated globs of files or directories under -input to skip")
+	fs.StringVar(&opts.types, "types", "", "Comma-separated list of struct types to generate for")
+	fs.StringVar(&opts.packageName, "package", "", "Package name for generated code (auto-detected if empty)")
+	fs.StringVar(&opts.output, "output", ".", "Directory to write generated files")
+	fs.StringVar(&opts.suffix, "suffix", "_validator_gen", "Suffix for generated files")
+	fs.StringVar(&opts.sourceImport, "source-import", "", "Import path of the analyzed package when generating into another package (detected from go.mod if empty)")
+	fs.StringVar(&opts.buildTags, "build-tags", "", "Build constraint for generated files, e.g. '!novalidate'")
+	fs.StringVar(&opts.typescript, "typescript", "", "Also emit TypeScript validators to this file, relative to -output")
+	fs.BoolVar(&opts.optimize, "optimize", true, "Enable performance optimizations")
+	fs.BoolVar(&opts.failFast, "fail-fast", false, "Make generated validators stop on the first error by default")
+	fs.BoolVar(&opts.strategies, "strategies", true, "Generate go-config compatible strategies")
+	fs.BoolVar(&opts.debugInfo, "debug-info", false, "Include debug information in generated code")
+	fs.BoolVar(&opts.pure, "pure", false, "Import only the standard library and never reflect")
+	fs.BoolVar(&opts.tests, "tests", false, "Generate tests checking each validator against the reflection engine")
+	fs.BoolVar(&opts.verbose, "verbose", false, "Enable verbose logging")
+	fs.BoolVar(&opts.watch, "watch", false, "Regenerate validators when the input changes")
+	fs.DurationVar(&opts.watchInterval, "watch-interval", 500*time.Millisecond, "How often -watch checks the input for changes")
+	fs.DurationVar(&opts.debounce, "debounce", 300*time.Millisecond, "How long changes must settle before -watch regenerates")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	return opts, nil
+}
+
+// generate analyzes the input and writes the generated files
+func generate(opts *options, stderr io.Writer) error {
+	result, sourceDir, err := analyze(opts)
+	if err != nil {
+		return err
+	}
+	if err := filterTypes(result, opts.types); err != nil {
+		return err
+	}
+
+	genOptions := generator.GeneratorOptions{
+		PackageName:         opts.packageName,
+		OutputDir:           opts.output,
+		GenerateStrategies:  opts.strategies,
+		EnableOptimizations: opts.optimize,
+		IncludeDebugInfo:    opts.debugInfo,
+		FailFast:            opts.failFast,
+		Pure:                opts.pure,
+		GenerateTests:       opts.tests,
+		FileSuffix:          opts.suffix,
+		BuildTags:           opts.buildTags,
+		TypeScriptFile:      opts.typescript,
+	}
+	if err := resolvePackage(&genOptions, result, sourceDir, opts.sourceImport); err != nil {
+		return err
+	}
+
+	if opts.verbose {
+		fmt.Fprintf(stderr, "generating %d validators into %s (package %s)\n", len(result.Structs), genOptions.OutputDir, genOptions.PackageName)
+		if genOptions.SourceImportPath != "" {
+			fmt.Fprintf(stderr, "referencing config types from %s\n", genOptions.SourceImportPath)
+		}
+	}
+
+	gen := generator.NewCodeGenerator(result, genOptions)
+	if err := gen.Generate(); err != nil {
+		return err
+	}
+	if opts.verbose {
+		for _, rule := range gen.ServerOnlyRules() {
+			fmt.Fprintf(stderr, "TypeScript validators leave %s to the Go validators\n", rule)
+		}
+	}
+	return nil
+}
+
+// analyze runs the analyzer on the input file or directory, returning the
+// directory holding the analyzed sources
+func analyze(opts *options) (*analyzer.AnalysisResult, string, error) {
+	if opts.file != "" {
+		result, err := analyzer.NewConfigAnalyzer().AnalyzeFile(opts.file)
+		return result, filepath.Dir(opts.file), err
+	}
+
+	analyzerOptions := analyzer.AnalyzerOptions{
+		Include: splitList(opts.include),
+		Exclude: splitList(opts.exclude),
+	}
+	// Never analyze previously generated output nested in the input
+	if rel, err := filepath.Rel(absPath(opts.input), absPath(opts.output)); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+		analyzerOptions.Exclude = append(analyzerOptions.Exclude, filepath.ToSlash(rel))
+	}
+
+	result, err := analyzer.NewConfigAnalyzerWithOptions(analyzerOptions).AnalyzeDirectory(opts.input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Generated code targets a single package
+	switch len(result.Packages) {
+	case 0:
+		return result, opts.input, nil
+	case 1:
+		for dir := range result.Packages {
+			return result, filepath.Join(opts.input, filepath.FromSlash(dir)), nil
+		}
+	}
+	dirs := make([]string, 0, len(result.Packages))
+	for dir := range result.Packages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return nil, "", fmt.Errorf("input spans packages in %s, narrow it with -include or -exclude", strings.Join(dirs, ", "))
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// filterTypes keeps only the listed struct types
+func filterTypes(result *analyzer.AnalysisResult, types string) error {
+	if types == "" {
+		return nil
+	}
+	keep := make(map[string]bool)
+	for _, name := range strings.Split(types, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := result.Structs[name]; !ok {
+			return fmt.Errorf("type %s not found in analyzed sources", name)
+		}
+		keep[name] = true
+	}
+	for name := range result.Structs {
+		if !keep[name] {
+			delete(result.Structs, name)
+		}
+	}
+	return nil
+}
+
+// resolvePackage fills in the output package name and, when generated code
+// lives in a different package than the config structs, the import path of
+// the source package
+func resolvePackage(genOptions *generator.GeneratorOptions, result *analyzer.AnalysisResult, sourceDir, sourceImport string) error {
+	sameDir, err := sameDirectory(sourceDir, genOptions.OutputDir)
+	if err != nil {
+		return err
+	}
+	if genOptions.PackageName == "" {
+		genOptions.PackageName = result.PackageName
+		if !sameDir {
+			genOptions.PackageName = filepath.Base(absPath(genOptions.OutputDir))
+		}
+	}
+	if sameDir && genOptions.PackageName == result.PackageName {
+		return nil
+	}
+
+	if sourceImport == "" {
+		sourceImport, err = detectImportPath(sourceDir)
+		if err != nil {
+			return fmt.Errorf("output package differs from the source package, set -source-import: %w", err)
+		}
+	}
+	genOptions.SourceImportPath = sourceImport
+	return nil
+}
+
+// detectImportPath derives the import path of a directory from the
+// enclosing go.mod
+func detectImportPath(dir string) (string, error) {
+	dir = absPath(dir)
+	for current := dir; ; current = filepath.Dir(current) {
+		module, err := readModulePath(filepath.Join(current, "go.mod"))
+		if err == nil {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("no go.mod found above %s", dir)
+		}
+	}
+}
+
+// readModulePath returns the module path declared in a go.mod file
+func readModulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if module, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s has no module directive", goMod)
+}
+
+// sameDirectory reports whether two paths name the same directory
+func sameDirectory(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := os.Stat(b)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(infoA, infoB), nil
+}
+
+// absPath returns the absolute form of a path, or the path itself
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun_CrossPackageOutput(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n\ngo 1.24\n")
+	writeFile(t, filepath.Join(root, "config", "config.go"), `package config
+
+type AppConfig struct {
+	Name string `+"`yaml:\"name\" validate:\"required,min=3\"`"+`
+}
+`)
+	input := filepath.Join(root, "config")
+	output := filepath.Join(root, "internal", "validators")
+
+	err := run(context.Background(), []string{"-input", input, "-output", output, "-suffix", "_validate", "-build-tags", "!novalidate"}, io.Discard)
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(output, "appconfig_validate.go"))
+	if err != nil {
+		t.Fatalf("expected validator with custom suffix: %v", err)
+	}
+	code := string(content)
+	if !strings.HasPrefix(code, "//go:build !novalidate\n\n") {
+		t.Errorf("expected build constraint first, got:\n%s", code)
+	}
+	for _, expected := range []string{
+		"package validators",
+		`"example.com/app/config"`,
+		"func (v *AppConfigValidator) Validate(cfg *config.AppConfig) error {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("expected %q in generated code, got:\n%s", expected, code)
+		}
+	}
+
+	strategy, err := os.ReadFile(filepath.Join(output, "validation_strategy_gen.go"))
+	if err != nil {
+		t.Fatalf("expected strategy file: %v", err)
+	}
+	if !strings.Contains(string(strategy), "case *config.AppConfig:") {
+		t.Errorf("expected qualified dispatch cases, got:\n%s", strategy)
+	}
+}
+
+func TestRun_SamePackageOutput(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.go"), `package config
+
+type AppConfig struct {
+	Name string `+"`validate:\"required\"`"+`
+}
+`)
+
+	if err := run(context.Background(), []string{"-input", dir, "-output", dir, "-strategies=false"}, io.Discard); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "appconfig_validator_gen.go"))
+	if err != nil {
+		t.Fatalf("expected validator with default suffix: %v", err)
+	}
+	if code := string(content); !strings.Contains(code, "package config") || !strings.Contains(code, "Validate(cfg *AppConfig)") {
+		t.Errorf("expected unqualified same-package code, got:\n%s", code)
+	}
+}
+
+func TestRun_TypeScript(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.go"), `package config
+
+type AppConfig struct {
+	Name  string `+"`json:\"name\" validate:\"required,min=3\"`"+`
+	Owner string `+"`json:\"owner\" validate:\"unique_db=users\"`"+`
+}
+`)
+	var stderr strings.Builder
+	if err := run(context.Background(), []string{"-input", dir, "-output", dir, "-typescript", "web/validators.gen.ts", "-verbose"}, &stderr); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "web", "validators.gen.ts"))
+	if err != nil {
+		t.Fatalf("expected TypeScript validators: %v", err)
+	}
+	if code := string(content); !strings.Contains(code, "export function validateAppConfig(") || !strings.Contains(code, "byteLength(v) < 3") {
+		t.Errorf("expected TypeScript checks of the rules, got:\n%s", code)
+	}
+	if !strings.Contains(stderr.String(), "Owner (unique_db)") {
+		t.Errorf("expected rules left to Go to be reported, got:\n%s", stderr.String())
+	}
+}
+
+func TestRun_Tests(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.go"), "package config\n\ntype AppConfig struct {\n\tName string `validate:\"required\"`\n}\n")
+
+	if err := run(context.Background(), []string{"-input", dir, "-output", dir, "-strategies=false", "-tests"}, io.Discard); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "appconfig_validator_gen_test.go"))
+	if err != nil {
+		t.Fatalf("expected a generated test: %v", err)
+	}
+	if !strings.Contains(string(content), "validationtest.AssertRejectsMutants(t, validationtest.Fixture[AppConfig](t)") {
+		t.Errorf("expected a mutation test of the validator, got:\n%s", content)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.go"), "package config\n\ntype AppConfig struct {\n\tName string `validate:\"required\"`\n}\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"unknown type", []string{"-input", dir, "-output", dir, "-types", "Missing"}, "type Missing not found"},
+		{"no module for other package", []string{"-input", dir, "-output", filepath.Join(dir, "gen")}, "set -source-import"},
+		{"stray argument", []string{"extra"}, "unexpected arguments"},
+		{"watch interval", []string{"-input", dir, "-output", dir, "-watch", "-watch-interval", "0s"}, "invalid watch interval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), tt.args, io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRun_Watch(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "config.go")
+	writeFile(t, source, "package config\n\ntype AppConfig struct {\n\tName string `validate:\"required\"`\n}\n")
+	output := filepath.Join(dir, "appconfig_validator_gen.go")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{"-input", dir, "-output", dir, "-strategies=false", "-watch", "-watch-interval", "10ms", "-debounce", "30ms"}, io.Discard)
+	}()
+
+	waitFor := func(expected string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if content, err := os.ReadFile(output); err == nil && strings.Contains(string(content), expected) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("expected generated code to contain %q", expected)
+	}
+
+	waitFor("cfg.Name")
+	writeFile(t, source, "package config\n\ntype AppConfig struct {\n\tName string `validate:\"required\"`\n\tPort int    `validate:\"min=1\"`\n}\n")
+	waitFor("cfg.Port")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected watch to stop cleanly, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected watch to stop when the context is cancelled")
+	}
+}
+
+func TestRun_PackageSelection(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n\ngo 1.24\n")
+	writeFile(t, filepath.Join(root, "config", "config.go"), "package config\n\ntype AppConfig struct {\n\tName string `validate:\"required\"`\n}\n")
+	writeFile(t, filepath.Join(root, "db", "db.go"), "package db\n\ntype DBConfig struct {\n\tURL string `validate:\"required\"`\n}\n")
+	writeFile(t, filepath.Join(root, "vendor", "dep", "dep.go"), "package dep\n\ntype DepConfig struct {\n\tKey string `validate:\"required\"`\n}\n")
+
+	err := run(context.Background(), []string{"-input", root, "-output", root}, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "input spans packages in config, db") {
+		t.Fatalf("expected multi-package error, got %v", err)
+	}
+
+	output := filepath.Join(root, "generated")
+	args := []string{"-input", root, "-output", output, "-exclude", "db"}
+	if err := run(context.Background(), args, io.Discard); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(output, "appconfig_validator_gen.go"))
+	if err != nil {
+		t.Fatalf("expected validator for the remaining package: %v", err)
+	}
+	if !strings.Contains(string(content), `"example.com/app/config"`) {
+		t.Errorf("expected import of the remaining package, got:\n%s", content)
+	}
+
+	// The generated package below the input must not count as a second package
+	if err := run(context.Background(), args, io.Discard); err != nil {
+		t.Fatalf("regeneration failed: %v", err)
+	}
+}
+
+func TestListRules(t *testing.T) {
+	var out strings.Builder
+	if err := listRules([]string{"-category", "git"}, &out, io.Discard); err != nil {
+		t.Fatalf("listRules failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "RULE") || !strings.HasPrefix(lines[1], "branch_name ") {
+		t.Errorf("unexpected git rules:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := listRules([]string{"-json"}, &out, io.Discard); err != nil {
+		t.Fatalf("listRules -json failed: %v", err)
+	}
+	var rules []struct {
+		Name      string `json:"name"`
+		Generated bool   `json:"generated"`
+	}
+	if err := json.Unmarshal([]byte(out.String()), &rules); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	generated := map[string]bool{}
+	for _, rule := range rules {
+		generated[rule.Name] = rule.Generated
+	}
+	if !generated["email"] || generated["hostname"] {
+		t.Errorf("unexpected generated flags: email %v, hostname %v", generated["email"], generated["hostname"])
+	}
+
+	if err := listRules([]string{"extra"}, &out, io.Discard); err == nil {
+		t.Error("expected an error for unexpected arguments")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	validation "github.com/mateothegreat/go-validation"
+)
+
+// listRules prints the built-in rules with their parameters, categories and
+// whether generated validators check them without reflection
+func listRules(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("configvalidator rules", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "Print the rules as a JSON array")
+	category := fs.String("category", "", "Only list the rules in this category, e.g. network")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	rules := validation.Rules()
+	if *category != "" {
+		kept := rules[:0]
+		for _, rule := range rules {
+			for _, c := range rule.Categories {
+				if c == *category {
+					kept = append(kept, rule)
+					break
+				}
+			}
+		}
+		rules = kept
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rules)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tPARAM\tCATEGORY\tGENERATED\tDESCRIPTION")
+	for _, rule := range rules {
+		param := "-"
+		if rule.Param != nil && rule.Param.Type != validation.ParamNone {
+			param = string(rule.Param.Type)
+			if !rule.Param.Required {
+				param += "?"
+			}
+		}
+		generated := "no"
+		if rule.Generated {
+			generated = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", rule.Name, param, strings.Join(rule.Categories, ","), generated, rule.Description)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileState identifies a version of a watched file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watch generates once, then polls the input for changes to Go sources and
+// regenerates after they settle for the debounce interval. Generation errors
+// are reported without stopping the watch. It returns when ctx is done.
+func watch(ctx context.Context, opts *options, stderr io.Writer) error {
+	if opts.watchInterval <= 0 {
+		return fmt.Errorf("invalid watch interval %s", opts.watchInterval)
+	}
+
+	regenerate := func() {
+		start := time.Now()
+		if err := generate(opts, stderr); err != nil {
+			fmt.Fprintln(stderr, "configvalidator:", err)
+			return
+		}
+		fmt.Fprintf(stderr, "configvalidator: generated validators in %s\n", time.Since(start).Round(time.Millisecond))
+	}
+
+	last, err := snapshot(opts)
+	if err != nil {
+		return err
+	}
+	regenerate()
+	// Generating into the input directory must not trigger another run
+	if last, err = snapshot(opts); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(opts.watchInterval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := snapshot(opts)
+			if err != nil {
+				fmt.Fprintln(stderr, "configvalidator:", err)
+				continue
+			}
+			if !sameSnapshot(last, current) {
+				last, changedAt = current, now
+				continue
+			}
+			if changedAt.IsZero() || now.Sub(changedAt) < opts.debounce {
+				continue
+			}
+			changedAt = time.Time{}
+			regenerate()
+			if last, err = snapshot(opts); err != nil {
+				fmt.Fprintln(stderr, "configvalidator:", err)
+			}
+		}
+	}
+}
+
+// snapshot records the Go sources the generator may read, skipping tests,
+// generated files and the directories the analyzer skips
+func snapshot(opts *options) (map[string]fileState, error) {
+	states := make(map[string]fileState)
+	record := func(path string, info os.FileInfo) {
+		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") && !isGeneratedFile(path, opts) {
+			states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	if opts.file != "" {
+		info, err := os.Stat(opts.file)
+		if os.IsNotExist(err) {
+			return states, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		record(opts.file, info)
+		return states, nil
+	}
+
+	output := absPath(opts.output)
+	err := filepath.Walk(opts.input, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			record(path, info)
+			return nil
+		}
+		name := info.Name()
+		if path != opts.input && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || absPath(path) == output) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return states, err
+}
+
+// isGeneratedFile reports whether a path is written by the generator
+func isGeneratedFile(path string, opts *options) bool {
+	name := filepath.Base(path)
+	if name == "validation_strategy_gen.go" || name == "validation_support_gen.go" {
+		return true
+	}
+	suffix := strings.TrimSuffix(opts.suffix, ".go") + ".go"
+	return opts.suffix != "" && strings.HasSuffix(name, suffix)
+}
+
+// sameSnapshot reports whether two snapshots hold the same file versions
+func sameSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		if other, ok := b[path]; !ok || !other.modTime.Equal(state.modTime) || other.size != state.size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,269 @@
+// Package admission serves a Kubernetes validating admission webhook that
+// decodes the objects of AdmissionReview requests into annotated structs and
+// denies the invalid ones, e.g. to validate custom resources:
+//
+//	type Widget struct {
+//		Spec struct {
+//			Replicas int    `json:"replicas" validate:"min=1,max=10"`
+//			Image    string `json:"image" validate:"required"`
+//		} `json:"spec"`
+//	}
+//
+//	webhook := admission.New(admission.Options{})
+//	admission.Handle[Widget](webhook, admission.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+//	http.Handle("/validate", webhook)
+//	log.Fatal(http.ListenAndServeTLS(":8443", "tls.crt", "tls.key", nil))
+//
+// Denials carry a message naming each invalid field by its path in the
+// object, such as spec.replicas, and a status listing them as causes, which
+// kubectl prints. The package implements the admission.k8s.io/v1 wire format
+// and does not depend on the Kubernetes libraries.
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/internal/integration"
+)
+
+// MaxReviewBytes caps the size of the AdmissionReview requests ServeHTTP reads
+const MaxReviewBytes = 8 << 20
+
+// GroupVersionKind identifies the kind of an admitted object
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// Review is an admission.k8s.io/v1 AdmissionReview
+type Review struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Request    *Request  `json:"request,omitempty"`
+	Response   *Response `json:"response,omitempty"`
+}
+
+// Request is the admission request of a Review
+type Request struct {
+	UID       string           `json:"uid"`
+	Kind      GroupVersionKind `json:"kind"`
+	Name      string           `json:"name,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+	Operation string           `json:"operation"` // CREATE, UPDATE, DELETE or CONNECT
+	Object    json.RawMessage  `json:"object,omitempty"`
+	OldObject json.RawMessage  `json:"oldObject,omitempty"`
+	DryRun    *bool            `json:"dryRun,omitempty"`
+}
+
+// Response is the admission response of a Review
+type Response struct {
+	UID     string  `json:"uid"`
+	Allowed bool    `json:"allowed"`
+	Result  *Status `json:"status,omitempty"`
+}
+
+// Status explains a denial, like a Kubernetes metav1.Status
+type Status struct {
+	Status  string         `json:"status"` // "Failure"
+	Message string         `json:"message"`
+	Reason  string         `json:"reason"` // "Invalid" for invalid objects, "BadRequest" otherwise
+	Code    int32          `json:"code"`
+	Details *StatusDetails `json:"details,omitempty"`
+}
+
+// StatusDetails lists the invalid fields of a denied object
+type StatusDetails struct {
+	Name   string        `json:"name,omitempty"`
+	Group  string        `json:"group,omitempty"`
+	Kind   string        `json:"kind,omitempty"`
+	Causes []StatusCause `json:"causes,omitempty"`
+}
+
+// StatusCause is an invalid field
+type StatusCause struct {
+	Type    string `json:"reason"` // "FieldValueInvalid"
+	Message string `json:"message"`
+	Field   string `json:"field"` // Path in the object, e.g. "spec.replicas"
+}
+
+// Options configures a Webhook
+type Options struct {
+	Validator  *validation.Validator // Validates objects (default: validation.Default())
+	Operations []string              // Operations validated (default: CREATE and UPDATE); others are allowed
+}
+
+// handler decodes and validates the objects of one kind
+type handler func(ctx context.Context, object []byte) error
+
+// Webhook admits the objects of registered kinds that pass validation. It
+// allows objects of other kinds, which the webhook configuration should not
+// send.
+type Webhook struct {
+	validator  *validation.Validator
+	operations []string
+
+	mu       sync.RWMutex
+	handlers map[GroupVersionKind]handler
+}
+
+// New creates a Webhook
+func New(opts Options) *Webhook {
+	if opts.Validator == nil {
+		opts.Validator = validation.Default()
+	}
+	if len(opts.Operations) == 0 {
+		opts.Operations = []string{"CREATE", "UPDATE"}
+	}
+	return &Webhook{
+		validator:  opts.Validator,
+		operations: opts.Operations,
+		handlers:   make(map[GroupVersionKind]handler),
+	}
+}
+
+// Handle validates objects of kind as T, replacing any struct registered for
+// the kind before
+func Handle[T any](w *Webhook, kind GroupVersionKind, opts ...validation.ValidateOption) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers[kind] = func(ctx context.Context, object []byte) error {
+		var value T
+		if err := json.Unmarshal(object, &value); err != nil {
+			return &decodeError{err: err}
+		}
+		return w.validator.StructCtx(ctx, &value, opts...)
+	}
+}
+
+// decodeError reports an object that does not decode into its struct
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("cannot decode object: %v", e.err)
+}
+
+// Admit reviews a request, returning its response
+func (w *Webhook) Admit(ctx context.Context, req *Request) *Response {
+	response := &Response{UID: req.UID, Allowed: true}
+	if !containsOperation(w.operations, req.Operation) || len(req.Object) == 0 {
+		return response
+	}
+	w.mu.RLock()
+	validate, ok := w.handlers[req.Kind]
+	w.mu.RUnlock()
+	if !ok {
+		return response
+	}
+
+	err := validate(ctx, req.Object)
+	if err == nil {
+		return response
+	}
+	response.Allowed = false
+
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		response.Result = &Status{
+			Status:  "Failure",
+			Message: fmt.Sprintf("%s: %v", objectName(req), err),
+			Reason:  "BadRequest",
+			Code:    http.StatusBadRequest,
+		}
+		return response
+	}
+	response.Result = denial(req, enhance(errs))
+	return response
+}
+
+// ServeHTTP reads an AdmissionReview and writes the review with its response
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "admission reviews must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	var review Review
+	if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, MaxReviewBytes)).Decode(&review); err != nil {
+		http.Error(rw, fmt.Sprintf("invalid AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(rw, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	response := Review{APIVersion: review.APIVersion, Kind: "AdmissionReview", Response: w.Admit(r.Context(), review.Request)}
+	if response.APIVersion == "" {
+		response.APIVersion = "admission.k8s.io/v1"
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(response); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// enhance locates validation errors by their path in the object
+func enhance(errs validation.ValidationErrors) []integration.EnhancedValidationError {
+	enhanced := make([]integration.EnhancedValidationError, 0, len(errs))
+	for _, e := range errs {
+		path := e.Field
+		if e.Namespace != "" {
+			path = e.Namespace
+		}
+		enhanced = append(enhanced, integration.EnhancedValidationError{
+			ValidationError: e,
+			YAMLPath:        path,
+			Path:            path,
+			SourcePath:      integration.ParseSourcePath(path),
+			ConfigSource:    "admission",
+		})
+	}
+	return enhanced
+}
+
+// denial composes the status of an invalid object: Widget "w1" is invalid:
+// spec.replicas: ...; spec.image: ...
+func denial(req *Request, errs []integration.EnhancedValidationError) *Status {
+	details := &StatusDetails{Name: req.Name, Group: req.Kind.Group, Kind: req.Kind.Kind}
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		details.Causes = append(details.Causes, StatusCause{Type: "FieldValueInvalid", Message: e.Error(), Field: e.Path})
+		messages = append(messages, e.Path+": "+e.Error())
+	}
+	return &Status{
+		Status:  "Failure",
+		Message: fmt.Sprintf("%s is invalid: %s", objectName(req), strings.Join(messages, "; ")),
+		Reason:  "Invalid",
+		Code:    http.StatusUnprocessableEntity,
+		Details: details,
+	}
+}
+
+// objectName names the object of a request, e.g. Widget "w1"; objects
+// created with generateName have no name yet
+func objectName(req *Request) string {
+	if req.Name == "" {
+		return req.Kind.Kind
+	}
+	return fmt.Sprintf("%s %q", req.Kind.Kind, req.Name)
+}
+
+// containsOperation reports whether operations includes operation
+func containsOperation(operations []string, operation string) bool {
+	for _, candidate := range operations {
+		if strings.EqualFold(candidate, operation) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validation "github.com/mateothegreat/go-validation"
+)
+
+type widget struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas int    `json:"replicas" validate:"min=1,max=10"`
+		Image    string `json:"image" validate:"required"`
+	} `json:"spec"`
+}
+
+var widgetKind = GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+func newWebhook() *Webhook {
+	webhook := New(Options{Validator: validation.New()})
+	Handle[widget](webhook, widgetKind)
+	return webhook
+}
+
+func TestAdmit(t *testing.T) {
+	webhook := newWebhook()
+	ctx := context.Background()
+
+	valid := &Request{UID: "1", Kind: widgetKind, Name: "w1", Operation: "CREATE", Object: json.RawMessage(`{"spec":{"replicas":3,"image":"nginx"}}`)}
+	if response := webhook.Admit(ctx, valid); !response.Allowed || response.UID != "1" {
+		t.Errorf("expected a valid widget to be allowed, got %+v", response)
+	}
+
+	invalid := &Request{UID: "2", Kind: widgetKind, Name: "w1", Operation: "UPDATE", Object: json.RawMessage(`{"spec":{"replicas":0}}`)}
+	response := webhook.Admit(ctx, invalid)
+	if response.Allowed || response.Result == nil {
+		t.Fatalf("expected an invalid widget to be denied, got %+v", response)
+	}
+	status := response.Result
+	if status.Code != 422 || status.Reason != "Invalid" || !strings.HasPrefix(status.Message, `Widget "w1" is invalid: spec.replicas: `) {
+		t.Errorf("expected an Invalid status naming the fields, got %+v", status)
+	}
+	fields := map[string]bool{}
+	for _, cause := range status.Details.Causes {
+		fields[cause.Field] = true
+	}
+	if len(fields) != 2 || !fields["spec.replicas"] || !fields["spec.image"] {
+		t.Errorf("expected causes for spec.replicas and spec.image, got %+v", status.Details.Causes)
+	}
+
+	malformed := &Request{UID: "3", Kind: widgetKind, Operation: "CREATE", Object: json.RawMessage(`{"spec":{"replicas":"three"}}`)}
+	if response := webhook.Admit(ctx, malformed); response.Allowed || response.Result.Code != 400 {
+		t.Errorf("expected an undecodable widget to be denied as a bad request, got %+v", response)
+	}
+
+	for _, allowed := range []*Request{
+		{UID: "4", Kind: widgetKind, Operation: "DELETE", OldObject: json.RawMessage(`{"spec":{}}`)},
+		{UID: "5", Kind: GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, Operation: "CREATE", Object: json.RawMessage(`{}`)},
+	} {
+		if response := webhook.Admit(ctx, allowed); !response.Allowed {
+			t.Errorf("expected request %s to be allowed, got %+v", allowed.UID, response)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	server := httptest.NewServer(newWebhook())
+	defer server.Close()
+
+	body := `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"abc","kind":{"group":"example.com","version":"v1","kind":"Widget"},"operation":"CREATE","object":{"spec":{"replicas":11,"image":"nginx"}}}}`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var review Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		t.Fatal(err)
+	}
+	if review.APIVersion != "admission.k8s.io/v1" || review.Kind != "AdmissionReview" || review.Response == nil {
+		t.Fatalf("expected an AdmissionReview response, got %+v", review)
+	}
+	if review.Response.UID != "abc" || review.Response.Allowed || !strings.Contains(review.Response.Result.Message, "Widget is invalid: spec.replicas") {
+		t.Errorf("expected the widget to be denied, got %+v", review.Response.Result)
+	}
+
+	resp, err = http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET to be rejected, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,151 @@
+// Package cel adds a cel rule evaluating Common Expression Language (CEL)
+// expressions, with self bound to the struct holding the field:
+//
+//	type Listener struct {
+//		User string
+//		Port int `validate:"cel=self.Port > 1024 || self.User == 'root'"`
+//	}
+//
+//	if err := cel.Register(validator, cel.Options{}); err != nil {
+//		return err
+//	}
+//
+// Expressions are type-checked against the struct's exported fields, by Go
+// name, and compiled once per struct type. As rules are separated by commas,
+// an expression cannot contain one.
+package cel
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	validation "github.com/mateothegreat/go-validation"
+)
+
+// Options configures the cel rule
+type Options struct {
+	Tag        string          // Rule name (default: "cel")
+	CostLimit  uint64          // Abort expressions costing more than this to evaluate (0: no limit)
+	EnvOptions []cel.EnvOption // Extra declarations and libraries, e.g. ext.Strings()
+}
+
+// program is an expression compiled for one struct type
+type program struct {
+	program cel.Program
+	err     error
+}
+
+// programKey identifies a compiled expression
+type programKey struct {
+	typ        reflect.Type
+	expression string
+}
+
+// rule evaluates cel expressions, caching their programs
+type rule struct {
+	opts     Options
+	programs sync.Map // programKey -> *program
+}
+
+// Register adds the cel rule to v
+func Register(v *validation.Validator, opts Options) error {
+	if opts.Tag == "" {
+		opts.Tag = "cel"
+	}
+	r := &rule{opts: opts}
+	if err := v.RegisterValidationErr(opts.Tag, r.validate); err != nil {
+		return err
+	}
+	return v.RegisterParamSchema(opts.Tag, validation.ParamSchema{
+		Type:        validation.ParamString,
+		Required:    true,
+		Description: "CEL expression over self, the struct holding the field",
+	})
+}
+
+// validate evaluates the expression of a field's rule against its parent
+func (r *rule) validate(fl validation.FieldLevel) error {
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr || parent.Kind() == reflect.Interface {
+		if parent.IsNil() {
+			break
+		}
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return fmt.Errorf("field '%s' has no struct for the %s rule to evaluate", fl.FieldName(), r.opts.Tag)
+	}
+
+	prg, err := r.program(parent.Type(), fl.Param())
+	if err != nil {
+		return err
+	}
+	out, _, err := prg.Eval(map[string]interface{}{"self": parent.Interface()})
+	if err != nil {
+		return fmt.Errorf("field '%s' expression %q failed: %v", fl.FieldName(), fl.Param(), err)
+	}
+	passed, ok := out.Value().(bool)
+	if !ok {
+		return fmt.Errorf("field '%s' expression %q must return a bool, got %s", fl.FieldName(), fl.Param(), out.Type().TypeName())
+	}
+	if !passed {
+		return validation.ValidationError{Message: fmt.Sprintf("field '%s' must satisfy %s", fl.FieldName(), fl.Param())}
+	}
+	return nil
+}
+
+// program returns the compiled expression for typ, compiling it on first use
+func (r *rule) program(typ reflect.Type, expression string) (cel.Program, error) {
+	key := programKey{typ: typ, expression: expression}
+	if cached, ok := r.programs.Load(key); ok {
+		compiled := cached.(*program)
+		return compiled.program, compiled.err
+	}
+
+	compiled := &program{}
+	compiled.program, compiled.err = r.compile(typ, expression)
+	cached, _ := r.programs.LoadOrStore(key, compiled)
+	compiled = cached.(*program)
+	return compiled.program, compiled.err
+}
+
+// compile type-checks an expression against typ
+func (r *rule) compile(typ reflect.Type, expression string) (cel.Program, error) {
+	envOptions := append([]cel.EnvOption{
+		ext.NativeTypes(typ),
+		cel.Variable("self", cel.ObjectType(nativeTypeName(typ))),
+	}, r.opts.EnvOptions...)
+	env, err := cel.NewEnv(envOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("cel environment for %s: %w", typ, err)
+	}
+
+	checked, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("cel expression %q for %s does not compile: %w", expression, typ, issues.Err())
+	}
+	if !checked.OutputType().IsExactType(cel.BoolType) && !checked.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("cel expression %q for %s must return a bool, not %s", expression, typ, checked.OutputType())
+	}
+
+	var programOptions []cel.ProgramOption
+	if r.opts.CostLimit > 0 {
+		programOptions = append(programOptions, cel.CostLimit(r.opts.CostLimit))
+	}
+	return env.Program(checked, programOptions...)
+}
+
+// nativeTypeName is the name ext.NativeTypes declares typ under
+func nativeTypeName(typ reflect.Type) string {
+	pkg := typ.PkgPath()
+	for i := len(pkg) - 1; i >= 0; i-- {
+		if pkg[i] == '/' {
+			pkg = pkg[i+1:]
+			break
+		}
+	}
+	return pkg + "." + typ.Name()
+}
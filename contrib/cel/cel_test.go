@@ -0,0 +1,104 @@
+package cel
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	validation "github.com/mateothegreat/go-validation"
+)
+
+type listener struct {
+	User string `json:"user"`
+	Port int    `json:"port" validate:"cel=self.Port > 1024 || self.User == 'root'"`
+}
+
+type window struct {
+	Start int `json:"start"`
+	End   int `json:"end" validate:"cel=self.End - self.Start <= self.Limit"`
+	Limit int `json:"limit"`
+}
+
+func TestCelRule(t *testing.T) {
+	validator := validation.New()
+	if err := Register(validator, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []listener{{User: "app", Port: 8080}, {User: "root", Port: 80}} {
+		if err := validator.Struct(value); err != nil {
+			t.Errorf("expected %+v to pass, got %v", value, err)
+		}
+	}
+
+	err := validator.Struct(&listener{User: "app", Port: 80})
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", err)
+	}
+	if errs[0].Field != "port" || errs[0].Tag != "cel" || errs[0].Message != "field 'port' must satisfy self.Port > 1024 || self.User == 'root'" {
+		t.Errorf("expected a cel failure on port, got %+v", errs[0])
+	}
+
+	if err := validator.Struct(window{Start: 10, End: 15, Limit: 5}); err != nil {
+		t.Errorf("expected the window to pass, got %v", err)
+	}
+	if err := validator.Struct(window{Start: 10, End: 20, Limit: 5}); err == nil {
+		t.Error("expected the window to fail")
+	}
+
+	// Generated validators fall back to VarField for expressions they cannot translate
+	rule := "cel=self.Port > 1024 || self.User == 'root'"
+	if err := validator.VarField(&listener{User: "root", Port: 80}, "Port", rule); err != nil {
+		t.Errorf("expected VarField to evaluate against the struct, got %v", err)
+	}
+	if err := validator.VarField(&listener{User: "app", Port: 80}, "Port", rule); err == nil {
+		t.Error("expected VarField to fail")
+	}
+}
+
+func TestCelRuleReportsInvalidExpressions(t *testing.T) {
+	type typo struct {
+		Port int `validate:"cel=self.Prot > 1"`
+	}
+	type number struct {
+		Port int `validate:"cel=self.Port + 1"`
+	}
+
+	validator := validation.New()
+	if err := Register(validator, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		value   interface{}
+		wantMsg string
+	}{
+		{typo{}, `does not compile`},
+		{number{}, `must return a bool, not int`},
+	}
+	for _, tt := range tests {
+		err := validator.Struct(tt.value)
+		var errs validation.ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || !strings.Contains(errs[0].Message, tt.wantMsg) {
+			t.Errorf("expected an error containing %q for %T, got %v", tt.wantMsg, tt.value, err)
+		}
+	}
+
+	if err := validator.Var(1, "cel=self.Port > 1"); err == nil || !strings.Contains(err.Error(), "has no struct") {
+		t.Errorf("expected Var to report the missing struct, got %v", err)
+	}
+}
+
+func TestCelRuleCachesPrograms(t *testing.T) {
+	r := &rule{opts: Options{Tag: "cel"}}
+	first, err := r.program(reflect.TypeOf(listener{}), "self.Port > 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := r.program(reflect.TypeOf(listener{}), "self.Port > 1")
+	if first != second {
+		t.Error("expected the compiled program to be reused")
+	}
+}
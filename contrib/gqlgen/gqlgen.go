@@ -0,0 +1,172 @@
+// Package gqlgen validates GraphQL input objects resolved by gqlgen. Errors
+// are GraphQL errors with code BAD_USER_INPUT, listing each failed rule under
+// the "errors" extension with the input field's path in the schema, such as
+// "input.address.zip".
+//
+// Input structs generated by gqlgen name their fields by json tags matching
+// the schema, so they can carry validate tags through gqlgen's
+// models.*.extraFields or goTag directives and be checked in the resolver:
+//
+//	func (r *mutationResolver) CreateUser(ctx context.Context, input model.NewUser) (*model.User, error) {
+//		if err := validator.Validate(ctx, "input", input); err != nil {
+//			return nil, err
+//		}
+//		...
+//	}
+//
+// Rules can also be written in the schema with a directive on input fields:
+//
+//	directive @validate(rules: String!) on INPUT_FIELD_DEFINITION | ARGUMENT_DEFINITION
+//
+//	input NewUser {
+//		email: String! @validate(rules: "required,email")
+//	}
+//
+// The package does not depend on gqlgen: the directive is bound with a
+// one-line adapter, and FieldPath reads the field's path from gqlgen's
+// context:
+//
+//	validator := gqlgen.New(gqlgen.Options{FieldPath: func(ctx context.Context) string {
+//		return graphql.GetPathContext(ctx).Path().String()
+//	}})
+//	config.Directives.Validate = func(ctx context.Context, obj interface{}, next graphql.Resolver, rules string) (interface{}, error) {
+//		return validator.Directive(ctx, obj, next, rules)
+//	}
+package gqlgen
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// CodeBadUserInput is the default code of validation errors
+const CodeBadUserInput = "BAD_USER_INPUT"
+
+// Resolver resolves the value a directive validates, like gqlgen's graphql.Resolver
+type Resolver = func(ctx context.Context) (interface{}, error)
+
+// Options configures a Validator
+type Options struct {
+	Validator *validation.Validator            // Validates inputs (default: validation.Default())
+	FieldPath func(ctx context.Context) string // Path of the input field a directive validates, e.g. "input.email"
+	Code      string                           // Code extension of validation errors (default: CodeBadUserInput)
+}
+
+// Validator validates gqlgen inputs and reports GraphQL errors
+type Validator struct {
+	validator *validation.Validator
+	fieldPath func(ctx context.Context) string
+	code      string
+}
+
+// New creates a Validator
+func New(opts Options) *Validator {
+	if opts.Validator == nil {
+		opts.Validator = validation.Default()
+	}
+	if opts.Code == "" {
+		opts.Code = CodeBadUserInput
+	}
+	return &Validator{validator: opts.Validator, fieldPath: opts.FieldPath, code: opts.Code}
+}
+
+// Validate validates input, the value of the argument arg, returning a
+// GraphQL error for its validation errors
+func (g *Validator) Validate(ctx context.Context, arg string, input interface{}, opts ...validation.ValidateOption) error {
+	return g.Error(arg, g.validator.StructCtx(ctx, input, opts...))
+}
+
+// Directive implements a @validate(rules: String!) directive, validating the
+// resolved input field or argument against rules
+func (g *Validator) Directive(ctx context.Context, obj interface{}, next Resolver, rules string) (interface{}, error) {
+	value, err := next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.validator.VarCtx(ctx, value, rules)
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		return value, err
+	}
+	if g.fieldPath != nil {
+		if path := g.fieldPath(ctx); path != "" {
+			// Var names the value "field" in its messages
+			name := lastSegment(path)
+			for i := range errs {
+				errs[i].Message = strings.Replace(errs[i].Message, "'field'", "'"+name+"'", 1)
+				errs[i].Field = name
+				errs[i].Namespace = path
+			}
+		}
+	}
+	return nil, g.Error("", errs)
+}
+
+// Error converts the validation errors of an input, the value of the
+// argument arg, to a GraphQL error. Other errors are returned unchanged.
+func (g *Validator) Error(arg string, err error) error {
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	details := make([]map[string]interface{}, 0, len(errs))
+	for _, e := range errs {
+		detail := map[string]interface{}{
+			"rule":    e.Tag,
+			"message": e.Error(),
+		}
+		if path := fieldPath(arg, e); path != "" {
+			detail["field"] = path
+		}
+		if e.Param != "" {
+			detail["param"] = e.Param
+		}
+		details = append(details, detail)
+	}
+	return &gqlerror.Error{
+		Err:     err,
+		Message: errs.Error(),
+		Extensions: map[string]interface{}{
+			"code":   g.code,
+			"errors": details,
+		},
+	}
+}
+
+// fieldPath returns the path of a failed input field below arg
+func fieldPath(arg string, e validation.ValidationError) string {
+	path := e.Field
+	if e.Namespace != "" {
+		path = e.Namespace
+	}
+	switch {
+	case arg == "":
+		return path
+	case path == "":
+		return arg
+	case strings.HasPrefix(path, "["):
+		return arg + path
+	}
+	return arg + "." + path
+}
+
+// lastSegment returns the field name ending a path such as "input.items[0].name"
+func lastSegment(path string) string {
+	for strings.HasSuffix(path, "]") {
+		i := strings.LastIndexByte(path, '[')
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
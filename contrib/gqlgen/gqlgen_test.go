@@ -0,0 +1,90 @@
+package gqlgen
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type newAddress struct {
+	Zip string `json:"zip" validate:"required,numeric"`
+}
+
+type newUser struct {
+	Email     string       `json:"email" validate:"required,email"`
+	Addresses []newAddress `json:"addresses" validate:"dive"`
+}
+
+func details(t *testing.T, err error) []map[string]interface{} {
+	t.Helper()
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) {
+		t.Fatalf("expected a GraphQL error, got %v", err)
+	}
+	if gqlErr.Extensions["code"] != CodeBadUserInput {
+		t.Errorf("expected code %s, got %v", CodeBadUserInput, gqlErr.Extensions["code"])
+	}
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Error("expected the validation errors to be wrapped")
+	}
+	return gqlErr.Extensions["errors"].([]map[string]interface{})
+}
+
+func TestValidate(t *testing.T) {
+	validator := New(Options{Validator: validation.New()})
+	ctx := context.Background()
+
+	if err := validator.Validate(ctx, "input", newUser{Email: "a@example.com", Addresses: []newAddress{{Zip: "12345"}}}); err != nil {
+		t.Fatalf("expected a valid input to pass, got %v", err)
+	}
+
+	err := validator.Validate(ctx, "input", newUser{Email: "nope", Addresses: []newAddress{{Zip: "12345"}, {}}})
+	found := map[string][]string{}
+	for _, detail := range details(t, err) {
+		field := detail["field"].(string)
+		found[field] = append(found[field], detail["rule"].(string))
+	}
+	if len(found) != 2 || found["input.email"][0] != "email" || found["input.addresses[1].zip"][0] != "required" {
+		t.Errorf("expected errors at input.email and input.addresses[1].zip, got %v", found)
+	}
+
+	other := errors.New("database down")
+	if validator.Error("input", other) != other {
+		t.Error("expected other errors to be returned unchanged")
+	}
+}
+
+func TestDirective(t *testing.T) {
+	validator := New(Options{
+		Validator: validation.New(),
+		FieldPath: func(ctx context.Context) string { return "input.addresses[0].zip" },
+	})
+	ctx := context.Background()
+	next := func(value interface{}) Resolver {
+		return func(ctx context.Context) (interface{}, error) { return value, nil }
+	}
+
+	value, err := validator.Directive(ctx, nil, next("12345"), "required,numeric")
+	if err != nil || value != "12345" {
+		t.Fatalf("expected a valid field to resolve, got %v, %v", value, err)
+	}
+
+	_, err = validator.Directive(ctx, nil, next("abc"), "required,numeric")
+	found := details(t, err)
+	if len(found) != 1 || found[0]["field"] != "input.addresses[0].zip" || found[0]["rule"] != "numeric" {
+		t.Errorf("expected a numeric error at the field's path, got %v", found)
+	}
+	var errs validation.ValidationErrors
+	if errors.As(err, &errs); errs[0].Field != "zip" || errs[0].Message != "field 'zip' failed validation 'numeric'" {
+		t.Errorf("expected the error to name the field, got %+v", errs[0])
+	}
+
+	failed := errors.New("resolver failed")
+	if _, err := validator.Directive(ctx, nil, func(ctx context.Context) (interface{}, error) { return nil, failed }, "required"); err != failed {
+		t.Errorf("expected resolver errors to pass through, got %v", err)
+	}
+}
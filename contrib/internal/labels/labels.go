@@ -0,0 +1,48 @@
+// Package labels derives bounded metric label values from validation results.
+package labels
+
+import (
+	"strings"
+
+	validation "github.com/mateothegreat/go-validation"
+)
+
+// Result returns "valid" or "invalid" for a finished call
+func Result(errs validation.ValidationErrors) string {
+	if len(errs) == 0 {
+		return "valid"
+	}
+	return "invalid"
+}
+
+// Field returns the failing field path with slice indexes and map keys
+// collapsed to "[]", keeping label cardinality bounded by the struct schema
+func Field(err validation.ValidationError) string {
+	field := err.Namespace
+	if field == "" {
+		field = err.Field
+	}
+	if !strings.Contains(field, "[") {
+		return field
+	}
+
+	var b strings.Builder
+	b.Grow(len(field))
+	depth := 0
+	for _, r := range field {
+		switch {
+		case r == '[':
+			if depth == 0 {
+				b.WriteString("[]")
+			}
+			depth++
+		case r == ']':
+			if depth > 0 {
+				depth--
+			}
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
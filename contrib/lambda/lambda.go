@@ -0,0 +1,214 @@
+// Package lambda validates the payloads of AWS Lambda events against
+// annotated structs. Request bodies from API Gateway are decoded and
+// validated, and failures become 400 responses shaped like those of the
+// HTTP middleware:
+//
+//	func handle(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+//		var body CreateUser
+//		if err := lambda.BindRequest(ctx, nil, req, &body); err != nil {
+//			return lambda.Response(err), nil
+//		}
+//		...
+//	}
+//
+// SQS and SNS message bodies are decoded one record at a time, so a batch
+// reports only its failed messages:
+//
+//	func handle(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+//		orders, failures := lambda.DecodeSQS[Order](ctx, nil, event)
+//		for _, order := range orders {
+//			...
+//		}
+//		return lambda.BatchResponse(failures), nil
+//	}
+//
+// A nil *validation.Validator uses validation.Default().
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	validation "github.com/mateothegreat/go-validation"
+)
+
+// ErrEmptyBody is reported for requests and messages without a body
+var ErrEmptyBody = errors.New("body is empty")
+
+// BodyError reports a body that could not be decoded into the target struct
+type BodyError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *BodyError) Error() string {
+	return fmt.Sprintf("invalid request body: %v", e.Err)
+}
+
+// Unwrap returns the decoding error
+func (e *BodyError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorBody is the JSON body of the responses built by Response
+type ErrorBody struct {
+	Error   string                                  `json:"error"`
+	Details map[string][]validation.ValidationError `json:"details,omitempty"` // Validation errors by field
+}
+
+// BindRequest decodes the JSON body of a REST API, or HTTP API payload
+// version 1.0, request into dst and validates it. It returns a *BodyError
+// when the body cannot be decoded and validation.ValidationErrors when dst
+// is invalid.
+func BindRequest(ctx context.Context, v *validation.Validator, req events.APIGatewayProxyRequest, dst interface{}, opts ...validation.ValidateOption) error {
+	return bind(ctx, v, req.Body, req.IsBase64Encoded, dst, opts)
+}
+
+// BindHTTPRequest decodes and validates the JSON body of an HTTP API
+// payload version 2.0 request, like BindRequest
+func BindHTTPRequest(ctx context.Context, v *validation.Validator, req events.APIGatewayV2HTTPRequest, dst interface{}, opts ...validation.ValidateOption) error {
+	return bind(ctx, v, req.Body, req.IsBase64Encoded, dst, opts)
+}
+
+// Response returns the API Gateway response for an error of BindRequest:
+// 400 with the validation errors by field, or without details for bodies
+// that cannot be decoded, and 500 for other errors, whose text is not
+// exposed
+func Response(err error) events.APIGatewayProxyResponse {
+	status, body := errorResponse(err)
+	return events.APIGatewayProxyResponse{StatusCode: status, Headers: jsonHeaders(), Body: body}
+}
+
+// HTTPResponse returns the HTTP API payload version 2.0 response for an
+// error of BindHTTPRequest, like Response
+func HTTPResponse(err error) events.APIGatewayV2HTTPResponse {
+	status, body := errorResponse(err)
+	return events.APIGatewayV2HTTPResponse{StatusCode: status, Headers: jsonHeaders(), Body: body}
+}
+
+// Record is a decoded and valid event message
+type Record[T any] struct {
+	ID    string // SQS or SNS message ID
+	Value T
+}
+
+// RecordError reports a message whose body could not be decoded or is invalid
+type RecordError struct {
+	ID  string // SQS or SNS message ID
+	Err error  // *BodyError or validation.ValidationErrors
+}
+
+// Error implements the error interface
+func (e RecordError) Error() string {
+	return fmt.Sprintf("message %s: %v", e.ID, e.Err)
+}
+
+// Unwrap returns the decoding or validation error
+func (e RecordError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeSQS decodes and validates the JSON body of each message of event,
+// returning the valid messages and the errors of the others in event order
+func DecodeSQS[T any](ctx context.Context, v *validation.Validator, event events.SQSEvent, opts ...validation.ValidateOption) ([]Record[T], []RecordError) {
+	var records []Record[T]
+	var failures []RecordError
+	for _, message := range event.Records {
+		var value T
+		if err := bind(ctx, v, message.Body, false, &value, opts); err != nil {
+			failures = append(failures, RecordError{ID: message.MessageId, Err: err})
+			continue
+		}
+		records = append(records, Record[T]{ID: message.MessageId, Value: value})
+	}
+	return records, failures
+}
+
+// DecodeSNS decodes and validates the JSON message of each record of event,
+// like DecodeSQS
+func DecodeSNS[T any](ctx context.Context, v *validation.Validator, event events.SNSEvent, opts ...validation.ValidateOption) ([]Record[T], []RecordError) {
+	var records []Record[T]
+	var failures []RecordError
+	for _, record := range event.Records {
+		var value T
+		if err := bind(ctx, v, record.SNS.Message, false, &value, opts); err != nil {
+			failures = append(failures, RecordError{ID: record.SNS.MessageID, Err: err})
+			continue
+		}
+		records = append(records, Record[T]{ID: record.SNS.MessageID, Value: value})
+	}
+	return records, failures
+}
+
+// BatchResponse lists the failed messages of an SQS batch, so that only
+// they are retried or sent to the dead-letter queue. The function's event
+// source mapping must report batch item failures.
+func BatchResponse(failures []RecordError) events.SQSEventResponse {
+	response := events.SQSEventResponse{BatchItemFailures: make([]events.SQSBatchItemFailure, 0, len(failures))}
+	for _, failure := range failures {
+		response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: failure.ID})
+	}
+	return response
+}
+
+// bind decodes a JSON body into dst and validates it
+func bind(ctx context.Context, v *validation.Validator, body string, base64Encoded bool, dst interface{}, opts []validation.ValidateOption) error {
+	if v == nil {
+		v = validation.Default()
+	}
+	data := []byte(body)
+	if base64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return &BodyError{Err: err}
+		}
+		data = decoded
+	}
+	if len(data) == 0 {
+		return &BodyError{Err: ErrEmptyBody}
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return &BodyError{Err: err}
+	}
+	return v.StructCtx(ctx, dst, opts...)
+}
+
+// errorResponse returns the status and JSON body of an error response
+func errorResponse(err error) (int, string) {
+	status := http.StatusInternalServerError
+	payload := ErrorBody{Error: "internal error"}
+
+	var bodyErr *BodyError
+	var validationErrs validation.ValidationErrors
+	switch {
+	case errors.As(err, &bodyErr):
+		status = http.StatusBadRequest
+		payload.Error = bodyErr.Error()
+	case errors.As(err, &validationErrs):
+		status = http.StatusBadRequest
+		payload.Error = "validation failed"
+		payload.Details = validationErrs.AsMap()
+	}
+
+	data, marshalErr := json.Marshal(payload)
+	if marshalErr != nil {
+		// Values of the validation errors that do not marshal are left out
+		for _, errs := range payload.Details {
+			for i := range errs {
+				errs[i].Value = nil
+			}
+		}
+		data, _ = json.Marshal(payload)
+	}
+	return status, string(data)
+}
+
+// jsonHeaders are the headers of error responses
+func jsonHeaders() map[string]string {
+	return map[string]string{"Content-Type": "application/json"}
+}
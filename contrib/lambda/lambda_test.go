@@ -0,0 +1,110 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	validation "github.com/mateothegreat/go-validation"
+)
+
+type order struct {
+	ID       string `json:"id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+func TestBindRequest(t *testing.T) {
+	ctx := context.Background()
+	v := validation.New()
+	v.SetFieldNameTags("json")
+
+	var valid order
+	if err := BindRequest(ctx, v, events.APIGatewayProxyRequest{Body: `{"id":"a1","quantity":2}`}, &valid); err != nil {
+		t.Fatalf("expected a valid body to bind, got %v", err)
+	}
+	if valid.ID != "a1" || valid.Quantity != 2 {
+		t.Errorf("expected the body to be decoded, got %+v", valid)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(`{"id":"a2","quantity":1}`))
+	var fromBase64 order
+	if err := BindHTTPRequest(ctx, v, events.APIGatewayV2HTTPRequest{Body: encoded, IsBase64Encoded: true}, &fromBase64); err != nil || fromBase64.ID != "a2" {
+		t.Errorf("expected a base64 body to bind, got %+v, %v", fromBase64, err)
+	}
+
+	var invalid order
+	err := BindRequest(ctx, v, events.APIGatewayProxyRequest{Body: `{"quantity":0}`}, &invalid)
+	response := Response(err)
+	if response.StatusCode != 400 || response.Headers["Content-Type"] != "application/json" {
+		t.Fatalf("expected a 400 JSON response, got %+v", response)
+	}
+	var body ErrorBody
+	if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Error != "validation failed" || len(body.Details["id"]) != 1 || len(body.Details["quantity"]) != 1 {
+		t.Errorf("expected errors for id and quantity, got %s", response.Body)
+	}
+
+	for _, raw := range []string{"", "{", `{"quantity":"two"}`} {
+		err := BindRequest(ctx, v, events.APIGatewayProxyRequest{Body: raw}, &invalid)
+		var bodyErr *BodyError
+		if !errors.As(err, &bodyErr) {
+			t.Errorf("expected a BodyError for %q, got %v", raw, err)
+		}
+		if response := HTTPResponse(err); response.StatusCode != 400 {
+			t.Errorf("expected a 400 for %q, got %d", raw, response.StatusCode)
+		}
+	}
+
+	if response := Response(errors.New("database down")); response.StatusCode != 500 || response.Body != `{"error":"internal error"}` {
+		t.Errorf("expected other errors to be hidden behind a 500, got %+v", response)
+	}
+}
+
+func TestDecodeSQS(t *testing.T) {
+	event := events.SQSEvent{Records: []events.SQSMessage{
+		{MessageId: "m1", Body: `{"id":"a1","quantity":1}`},
+		{MessageId: "m2", Body: `{"id":"a2","quantity":0}`},
+		{MessageId: "m3", Body: `not json`},
+		{MessageId: "m4", Body: `{"id":"a4","quantity":4}`},
+	}}
+
+	records, failures := DecodeSQS[order](context.Background(), nil, event)
+	if len(records) != 2 || records[0].ID != "m1" || records[1].Value.Quantity != 4 {
+		t.Errorf("expected m1 and m4 to decode, got %+v", records)
+	}
+	if len(failures) != 2 || failures[0].ID != "m2" || failures[1].ID != "m3" {
+		t.Fatalf("expected m2 and m3 to fail, got %+v", failures)
+	}
+	var errs validation.ValidationErrors
+	if !errors.As(failures[0], &errs) {
+		t.Errorf("expected m2 to fail validation, got %v", failures[0])
+	}
+
+	response := BatchResponse(failures)
+	if len(response.BatchItemFailures) != 2 || response.BatchItemFailures[1].ItemIdentifier != "m3" {
+		t.Errorf("expected m2 and m3 to be reported, got %+v", response)
+	}
+	if response := BatchResponse(nil); response.BatchItemFailures == nil {
+		t.Error("expected an empty batch to report an empty list")
+	}
+}
+
+func TestDecodeSNS(t *testing.T) {
+	event := events.SNSEvent{Records: []events.SNSEventRecord{
+		{SNS: events.SNSEntity{MessageID: "n1", Message: `{"id":"a1","quantity":1}`}},
+		{SNS: events.SNSEntity{MessageID: "n2", Message: `{"quantity":1}`}},
+	}}
+
+	records, failures := DecodeSNS[order](context.Background(), nil, event)
+	if len(records) != 1 || records[0].ID != "n1" {
+		t.Errorf("expected n1 to decode, got %+v", records)
+	}
+	if len(failures) != 1 || failures[0].ID != "n2" {
+		t.Errorf("expected n2 to fail, got %+v", failures)
+	}
+}
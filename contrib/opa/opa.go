@@ -0,0 +1,329 @@
+// Package opa validates structs against Open Policy Agent (Rego) policies
+// during struct-level validation. The struct is marshaled to JSON and passed
+// as the policy input; each denial the query returns becomes a
+// validation.ValidationError at the JSON path it names.
+//
+// The package does not depend on OPA: an EvalFunc runs the prepared query,
+// so any OPA version, or a remote OPA server, can be used:
+//
+//	query, err := rego.New(rego.Query("data.users.deny"), rego.Module("users.rego", module)).PrepareForEval(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	policy, err := opa.New(opa.Options{Eval: func(ctx context.Context, input interface{}) (interface{}, error) {
+//		results, err := query.Eval(ctx, rego.EvalInput(input))
+//		if err != nil || len(results) == 0 {
+//			return nil, err
+//		}
+//		return results[0].Expressions[0].Value, nil
+//	}})
+//	if err != nil {
+//		return err
+//	}
+//	policy.Register(validator, User{})
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	validation "github.com/mateothegreat/go-validation"
+)
+
+// ErrCodePolicyFailed marks errors for structs whose policy could not be evaluated
+const ErrCodePolicyFailed = "policy_failed"
+
+// EvalFunc evaluates a policy query with input, the struct decoded from its
+// JSON form, and returns the query's value: see Denials for the values
+// understood
+type EvalFunc func(ctx context.Context, input interface{}) (interface{}, error)
+
+// Options configures a Policy
+type Options struct {
+	Eval EvalFunc // Runs the policy query (required)
+	Tag  string   // Tag of reported errors (default: "policy")
+}
+
+// Policy reports the denials of a Rego query as validation errors
+type Policy struct {
+	eval EvalFunc
+	tag  string
+}
+
+// New creates a Policy
+func New(opts Options) (*Policy, error) {
+	if opts.Eval == nil {
+		return nil, errors.New("opa: Options.Eval is required")
+	}
+	if opts.Tag == "" {
+		opts.Tag = "policy"
+	}
+	return &Policy{eval: opts.Eval, tag: opts.Tag}, nil
+}
+
+// Register makes the policy the struct-level validation of types,
+// replacing any registered before
+func (p *Policy) Register(v *validation.Validator, types ...interface{}) {
+	v.RegisterStructValidation(p.Validate, types...)
+}
+
+// Validate evaluates the policy against the current struct and reports its
+// denials. It is a validation.StructLevelValidationFunc, so it can also be
+// called from another struct-level validation.
+func (p *Policy) Validate(sl validation.StructLevel) {
+	current, _, ok := sl.ExtractType(sl.Current())
+	if !ok {
+		return
+	}
+
+	result, err := p.evaluate(sl.Context(), current.Interface())
+	var denials []Denial
+	if err == nil {
+		denials, err = Denials(result)
+	}
+	if err != nil {
+		sl.ReportValidationError(validation.ValidationError{
+			Field:     current.Type().Name(),
+			Namespace: sl.Namespace(),
+			Tag:       p.tag,
+			Message:   fmt.Sprintf("%s could not be checked against its policy: %v", current.Type().Name(), err),
+			Code:      ErrCodePolicyFailed,
+		})
+		return
+	}
+
+	for _, denial := range denials {
+		sl.ReportValidationError(p.validationError(sl, current.Type(), denial))
+	}
+}
+
+// evaluate runs the query with value as its JSON input
+func (p *Policy) evaluate(ctx context.Context, value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, err
+	}
+	return p.eval(ctx, input)
+}
+
+// validationError places a denial at its path. A path naming a field of the
+// current struct is reported like a failed rule of that field; deeper paths
+// set the namespace and the last field name only, leaving the value out.
+func (p *Policy) validationError(sl validation.StructLevel, typ reflect.Type, denial Denial) validation.ValidationError {
+	reported := validation.ValidationError{Tag: denial.Tag, Code: denial.Code, Message: denial.Message}
+	if reported.Tag == "" {
+		reported.Tag = p.tag
+	}
+
+	switch {
+	case len(denial.Path) == 0:
+		reported.Field = typ.Name()
+		reported.Namespace = sl.Namespace()
+	case len(denial.Path) == 1:
+		reported.StructField = structField(typ, denial.Path[0])
+		if reported.StructField == "" {
+			reported.Field = denial.Path[0]
+		}
+	default:
+		reported.Namespace = namespace(sl.Namespace(), denial.Path)
+		for i := len(denial.Path) - 1; i >= 0; i-- {
+			if _, err := strconv.Atoi(denial.Path[i]); err != nil {
+				reported.Field = denial.Path[i]
+				break
+			}
+		}
+	}
+	if reported.Message == "" {
+		reported.Message = "denied by policy"
+		if len(denial.Path) > 0 {
+			reported.Message = fmt.Sprintf("field '%s' is denied by policy", denial.Path[len(denial.Path)-1])
+		}
+	}
+	return reported
+}
+
+// structField returns the Go name of the field of typ marshaled under name
+func structField(typ reflect.Type, name string) string {
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" || (jsonName == "" && field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		if jsonName == name {
+			return field.Name
+		}
+	}
+	return ""
+}
+
+// namespace renders a path after the current struct's namespace, indexes in
+// brackets: "servers[0].port"
+func namespace(parent string, path []string) string {
+	var b strings.Builder
+	b.WriteString(parent)
+	for _, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil {
+			fmt.Fprintf(&b, "[%s]", segment)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// Denial is a policy violation
+type Denial struct {
+	Path    []string // JSON path of the offending value, e.g. ["servers", "0", "port"]; empty for the whole struct
+	Message string
+	Tag     string
+	Code    string
+}
+
+// Denials decodes the value of a policy query. The struct passes when the
+// value is nil, true, or an empty set or object. false denies the whole
+// struct. A string is a message, and an object may set "msg" or "message",
+// "path", "tag" and "code". A path is a JSON Pointer ("/servers/0/port"), a
+// dotted path ("servers[0].port") or an array of segments. Sets, arrays and
+// objects of such values, e.g. deny or violation rules, list several denials.
+func Denials(result interface{}) ([]Denial, error) {
+	switch value := result.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if value {
+			return nil, nil
+		}
+		return []Denial{{}}, nil
+	case string:
+		return []Denial{{Message: value}}, nil
+	case []interface{}:
+		var denials []Denial
+		for _, item := range value {
+			found, err := Denials(item)
+			if err != nil {
+				return nil, err
+			}
+			denials = append(denials, found...)
+		}
+		return denials, nil
+	case map[string]interface{}:
+		if denial, ok, err := decodeDenial(value); err != nil {
+			return nil, err
+		} else if ok {
+			return []Denial{denial}, nil
+		}
+		// An object of rule results, e.g. {"deny": [...]} for data.users
+		var denials []Denial
+		for _, key := range sortedKeys(value) {
+			found, err := Denials(value[key])
+			if err != nil {
+				return nil, err
+			}
+			denials = append(denials, found...)
+		}
+		return denials, nil
+	}
+	return nil, fmt.Errorf("unexpected policy result %T", result)
+}
+
+// decodeDenial decodes a denial object, reporting false for objects that
+// are not one
+func decodeDenial(value map[string]interface{}) (Denial, bool, error) {
+	var denial Denial
+	found := false
+	for _, key := range []string{"msg", "message", "tag", "code"} {
+		raw, ok := value[key]
+		if !ok {
+			continue
+		}
+		text, ok := raw.(string)
+		if !ok {
+			return Denial{}, true, fmt.Errorf("policy denial %s must be a string, got %T", key, raw)
+		}
+		switch key {
+		case "msg", "message":
+			denial.Message = text
+		case "tag":
+			denial.Tag = text
+		case "code":
+			denial.Code = text
+		}
+		found = true
+	}
+	if raw, ok := value["path"]; ok {
+		path, err := parsePath(raw)
+		if err != nil {
+			return Denial{}, true, err
+		}
+		denial.Path = path
+		found = true
+	}
+	return denial, found, nil
+}
+
+// parsePath splits a denial path into its segments
+func parsePath(raw interface{}) ([]string, error) {
+	switch path := raw.(type) {
+	case []interface{}:
+		segments := make([]string, 0, len(path))
+		for _, segment := range path {
+			switch s := segment.(type) {
+			case string:
+				segments = append(segments, s)
+			case float64:
+				segments = append(segments, strconv.FormatFloat(s, 'f', -1, 64))
+			case json.Number:
+				segments = append(segments, s.String())
+			default:
+				return nil, fmt.Errorf("policy denial path segment must be a string or number, got %T", segment)
+			}
+		}
+		return segments, nil
+	case string:
+		if strings.HasPrefix(path, "/") {
+			segments := strings.Split(path[1:], "/")
+			for i, segment := range segments {
+				segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+			}
+			return segments, nil
+		}
+		var segments []string
+		for _, part := range strings.Split(strings.ReplaceAll(path, "]", ""), ".") {
+			for _, segment := range strings.Split(part, "[") {
+				if segment != "" {
+					segments = append(segments, segment)
+				}
+			}
+		}
+		return segments, nil
+	}
+	return nil, fmt.Errorf("policy denial path must be a string or array, got %T", raw)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,120 @@
+package opa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	validation "github.com/mateothegreat/go-validation"
+)
+
+type server struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type deployment struct {
+	Name    string   `json:"name" validate:"required"`
+	Owner   string   `json:"owner,omitempty"`
+	Servers []server `json:"servers"`
+}
+
+// denyPublicPorts stands in for a Rego query: deployments need an owner,
+// and servers may only listen on high ports
+func denyPublicPorts(ctx context.Context, input interface{}) (interface{}, error) {
+	doc := input.(map[string]interface{})
+	var deny []interface{}
+	if _, ok := doc["owner"]; !ok {
+		deny = append(deny, map[string]interface{}{"msg": "deployments need an owner", "path": "owner", "code": "OWNER"})
+	}
+	for i, s := range doc["servers"].([]interface{}) {
+		if s.(map[string]interface{})["port"].(float64) < 1024 {
+			deny = append(deny, map[string]interface{}{"msg": "ports below 1024 are reserved", "path": []interface{}{"servers", float64(i), "port"}})
+		}
+	}
+	return deny, nil
+}
+
+func TestPolicyReportsDenialsAtTheirPaths(t *testing.T) {
+	policy, err := New(Options{Eval: denyPublicPorts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator := validation.New()
+	policy.Register(validator, deployment{})
+
+	err = validator.Struct(deployment{Name: "api", Servers: []server{{Host: "a", Port: 8080}, {Host: "b", Port: 80}}})
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected two denials, got %v", err)
+	}
+
+	owner := errs[0]
+	if owner.Field != "owner" || owner.StructField != "Owner" || owner.Namespace != "" || owner.Tag != "policy" || owner.Code != "OWNER" || owner.Message != "deployments need an owner" {
+		t.Errorf("expected the owner denial on the Owner field, got %+v", owner)
+	}
+	port := errs[1]
+	if port.Field != "port" || port.Namespace != "servers[1].port" || port.Message != "ports below 1024 are reserved" {
+		t.Errorf("expected the port denial at servers[1].port, got %+v", port)
+	}
+
+	if err := validator.Struct(deployment{Name: "api", Owner: "ops", Servers: []server{{Port: 8080}}}); err != nil {
+		t.Errorf("expected an allowed deployment to pass, got %v", err)
+	}
+}
+
+func TestPolicyReportsEvaluationFailures(t *testing.T) {
+	policy, _ := New(Options{Tag: "rego", Eval: func(ctx context.Context, input interface{}) (interface{}, error) {
+		return nil, errors.New("undefined function")
+	}})
+	validator := validation.New()
+	policy.Register(validator, deployment{})
+
+	err := validator.Struct(deployment{Name: "api"})
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", err)
+	}
+	if errs[0].Code != ErrCodePolicyFailed || errs[0].Tag != "rego" || errs[0].Message != "deployment could not be checked against its policy: undefined function" {
+		t.Errorf("expected an evaluation failure, got %+v", errs[0])
+	}
+
+	if _, err := New(Options{}); err == nil {
+		t.Error("expected New to require Eval")
+	}
+}
+
+func TestDenials(t *testing.T) {
+	tests := []struct {
+		name   string
+		result interface{}
+		want   []Denial
+	}{
+		{"undefined", nil, nil},
+		{"allowed", true, nil},
+		{"denied", false, []Denial{{}}},
+		{"messages", []interface{}{"a", "b"}, []Denial{{Message: "a"}, {Message: "b"}}},
+		{"json pointer", map[string]interface{}{"message": "m", "path": "/a~1b/0/c"}, []Denial{{Message: "m", Path: []string{"a/b", "0", "c"}}}},
+		{"dotted path", map[string]interface{}{"msg": "m", "path": "servers[2].host", "tag": "host"}, []Denial{{Message: "m", Tag: "host", Path: []string{"servers", "2", "host"}}}},
+		{"rule results", map[string]interface{}{"deny": []interface{}{"x"}, "allow": true}, []Denial{{Message: "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Denials(tt.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := Denials(map[string]interface{}{"msg": 1}); err == nil {
+		t.Error("expected a non-string message to be rejected")
+	}
+	if _, err := Denials(42.0); err == nil {
+		t.Error("expected an unexpected result to be rejected")
+	}
+}
@@ -0,0 +1,89 @@
+// Package otel records validation metrics and traces with OpenTelemetry.
+//
+//	hooks, err := otel.New(otel.Options{Meter: provider.Meter("validation")})
+//	if err != nil {
+//		return err
+//	}
+//	validator.SetHooks(hooks)
+package otel
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/contrib/internal/labels"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ScopeName is the instrumentation scope used when no meter is supplied
+const ScopeName = "github.com/mateothegreat/go-validation"
+
+// Options configures the instruments created by New
+type Options struct {
+	Meter metric.Meter // Default: otel.Meter(ScopeName)
+}
+
+// Hooks implements validation.Hooks by recording OpenTelemetry metrics
+type Hooks struct {
+	duration metric.Float64Histogram
+	calls    metric.Int64Counter
+	failures metric.Int64Counter
+}
+
+// New creates the validation instruments
+func New(opts Options) (*Hooks, error) {
+	meter := opts.Meter
+	if meter == nil {
+		meter = otel.Meter(ScopeName)
+	}
+
+	duration, err := meter.Float64Histogram("validation.duration",
+		metric.WithDescription("Latency of Struct and Var validation calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	calls, err := meter.Int64Counter("validation.calls",
+		metric.WithDescription("Validation calls by type and result."))
+	if err != nil {
+		return nil, err
+	}
+	failures, err := meter.Int64Counter("validation.rule_failures",
+		metric.WithDescription("Rule failures by struct type, field and rule."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hooks{duration: duration, calls: calls, failures: failures}, nil
+}
+
+// OnValidateStart implements validation.Hooks
+func (h *Hooks) OnValidateStart(ctx context.Context, info validation.ValidationInfo) context.Context {
+	return ctx
+}
+
+// OnValidateEnd records the call latency and result
+func (h *Hooks) OnValidateEnd(ctx context.Context, info validation.ValidationInfo, duration time.Duration, errs validation.ValidationErrors) {
+	attrs := metric.WithAttributes(
+		attribute.String("validation.kind", info.Kind),
+		attribute.String("validation.type", info.TypeName),
+	)
+	h.duration.Record(ctx, duration.Seconds(), attrs)
+	h.calls.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("validation.kind", info.Kind),
+		attribute.String("validation.type", info.TypeName),
+		attribute.String("validation.result", labels.Result(errs)),
+	))
+}
+
+// OnRuleFail counts the failed rule against its struct type and field
+func (h *Hooks) OnRuleFail(ctx context.Context, info validation.ValidationInfo, err validation.ValidationError) {
+	h.failures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("validation.type", info.TypeName),
+		attribute.String("validation.field", labels.Field(err)),
+		attribute.String("validation.rule", err.Tag),
+	))
+}
@@ -0,0 +1,90 @@
+package otel
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/mateothegreat/go-validation"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerOptions configures the spans emitted by a Tracer
+type TracerOptions struct {
+	Tracer        trace.Tracer  // Default: otel.Tracer(ScopeName)
+	RuleThreshold time.Duration // Rules taking at least this long get a child span (default: 1ms)
+}
+
+// Tracer implements validation.RuleHooks by emitting a span per Struct or Var
+// call and a child span for every rule evaluation slower than RuleThreshold.
+//
+//	validator.SetHooks(otel.NewTracer(otel.TracerOptions{}))
+//	err := validator.StructCtx(ctx, cfg)
+type Tracer struct {
+	tracer        trace.Tracer
+	ruleThreshold time.Duration
+}
+
+// NewTracer creates a Tracer
+func NewTracer(opts TracerOptions) *Tracer {
+	if opts.Tracer == nil {
+		opts.Tracer = otel.Tracer(ScopeName)
+	}
+	if opts.RuleThreshold <= 0 {
+		opts.RuleThreshold = time.Millisecond
+	}
+	return &Tracer{tracer: opts.Tracer, ruleThreshold: opts.RuleThreshold}
+}
+
+// OnValidateStart starts the call span and returns a context carrying it
+func (t *Tracer) OnValidateStart(ctx context.Context, info validation.ValidationInfo) context.Context {
+	attrs := []attribute.KeyValue{
+		attribute.String("validation.kind", info.Kind),
+		attribute.String("validation.type", info.TypeName),
+	}
+	if info.Kind == validation.KindStruct {
+		attrs = append(attrs, attribute.Int("validation.field_count", info.FieldCount))
+	} else {
+		attrs = append(attrs, attribute.String("validation.tag", info.Tag))
+	}
+
+	ctx, _ = t.tracer.Start(ctx, "validation."+info.Kind+" "+info.TypeName, trace.WithAttributes(attrs...))
+	return ctx
+}
+
+// OnValidateEnd ends the call span, marking it as failed when errors were found
+func (t *Tracer) OnValidateEnd(ctx context.Context, info validation.ValidationInfo, duration time.Duration, errs validation.ValidationErrors) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("validation.error_count", len(errs)))
+	if len(errs) > 0 {
+		span.SetStatus(codes.Error, "validation failed")
+	}
+	span.End()
+}
+
+// OnRuleFail records the failure as a span event
+func (t *Tracer) OnRuleFail(ctx context.Context, info validation.ValidationInfo, err validation.ValidationError) {
+	trace.SpanFromContext(ctx).AddEvent("rule failed", trace.WithAttributes(
+		attribute.String("validation.field", err.Field),
+		attribute.String("validation.rule", err.Tag),
+	))
+}
+
+// OnRuleEvaluated emits a child span for rules slower than the threshold
+func (t *Tracer) OnRuleEvaluated(ctx context.Context, info validation.ValidationInfo, eval validation.RuleEvaluation) {
+	if eval.Duration < t.ruleThreshold {
+		return
+	}
+
+	_, span := t.tracer.Start(ctx, "validation.rule "+eval.Tag,
+		trace.WithTimestamp(eval.Start),
+		trace.WithAttributes(
+			attribute.String("validation.field", eval.Field),
+			attribute.String("validation.rule", eval.Tag),
+			attribute.String("validation.param", eval.Param),
+			attribute.Bool("validation.passed", eval.Passed),
+		))
+	span.End(trace.WithTimestamp(eval.Start.Add(eval.Duration)))
+}
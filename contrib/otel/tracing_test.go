@@ -0,0 +1,63 @@
+package otel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	validation "github.com/mateothegreat/go-validation"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type payment struct {
+	Reference string `validate:"required,slow"`
+	Amount    int    `validate:"min=1"`
+}
+
+func TestTracerEmitsCallAndSlowRuleSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	validator := validation.New()
+	_ = validator.RegisterValidation("slow", func(fl validation.FieldLevel) bool {
+		time.Sleep(2 * time.Millisecond)
+		return true
+	})
+	validator.SetHooks(NewTracer(TracerOptions{Tracer: provider.Tracer("test")}))
+
+	if err := validator.StructCtx(context.Background(), payment{Reference: "abc"}); err == nil {
+		t.Fatal("expected zero amount to fail")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected call span and one slow rule span, got %d", len(spans))
+	}
+
+	rule, call := spans[0], spans[1]
+	if rule.Name() != "validation.rule slow" {
+		t.Errorf("expected slow rule span, got %q", rule.Name())
+	}
+	if rule.Parent().SpanID() != call.SpanContext().SpanID() {
+		t.Error("expected rule span to be a child of the call span")
+	}
+	if call.Name() != "validation.struct otel.payment" {
+		t.Errorf("expected struct call span, got %q", call.Name())
+	}
+	if call.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", call.Status().Code)
+	}
+
+	attrs := map[string]int64{}
+	for _, attr := range call.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsInt64()
+	}
+	if attrs["validation.field_count"] != 2 || attrs["validation.error_count"] != 1 {
+		t.Errorf("expected field_count 2 and error_count 1, got %v", attrs)
+	}
+	if len(call.Events()) != 1 {
+		t.Errorf("expected one rule failure event, got %d", len(call.Events()))
+	}
+}
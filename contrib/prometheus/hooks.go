@@ -0,0 +1,87 @@
+// Package prometheus records validation metrics with Prometheus collectors.
+//
+//	hooks, err := prometheus.New(prometheus.Options{Registerer: registry})
+//	if err != nil {
+//		return err
+//	}
+//	validator.SetHooks(hooks)
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/contrib/internal/labels"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options configures the collectors created by New
+type Options struct {
+	Registerer prometheus.Registerer // Default: prometheus.DefaultRegisterer
+	Namespace  string                // Metric namespace (default: "validation")
+	Buckets    []float64             // Latency histogram buckets in seconds
+}
+
+// Hooks implements validation.Hooks by updating Prometheus collectors
+type Hooks struct {
+	duration *prometheus.HistogramVec
+	calls    *prometheus.CounterVec
+	failures *prometheus.CounterVec
+}
+
+// New creates the validation collectors and registers them with opts.Registerer
+func New(opts Options) (*Hooks, error) {
+	if opts.Registerer == nil {
+		opts.Registerer = prometheus.DefaultRegisterer
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "validation"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1}
+	}
+
+	h := &Hooks{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace,
+			Name:      "duration_seconds",
+			Help:      "Latency of Struct and Var validation calls.",
+			Buckets:   opts.Buckets,
+		}, []string{"kind", "type"}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Name:      "calls_total",
+			Help:      "Validation calls by type and result.",
+		}, []string{"kind", "type", "result"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Name:      "rule_failures_total",
+			Help:      "Rule failures by struct type, field and rule.",
+		}, []string{"type", "field", "rule"}),
+	}
+
+	for _, collector := range []prometheus.Collector{h.duration, h.calls, h.failures} {
+		if err := opts.Registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// OnValidateStart implements validation.Hooks
+func (h *Hooks) OnValidateStart(ctx context.Context, info validation.ValidationInfo) context.Context {
+	return ctx
+}
+
+// OnValidateEnd records the call latency and result
+func (h *Hooks) OnValidateEnd(ctx context.Context, info validation.ValidationInfo, duration time.Duration, errs validation.ValidationErrors) {
+	h.duration.WithLabelValues(info.Kind, info.TypeName).Observe(duration.Seconds())
+	h.calls.WithLabelValues(info.Kind, info.TypeName, labels.Result(errs)).Inc()
+}
+
+// OnRuleFail counts the failed rule against its struct type and field
+func (h *Hooks) OnRuleFail(ctx context.Context, info validation.ValidationInfo, err validation.ValidationError) {
+	h.failures.WithLabelValues(info.TypeName, labels.Field(err), err.Tag).Inc()
+}
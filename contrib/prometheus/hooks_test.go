@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"testing"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type order struct {
+	Email string   `validate:"required,email"`
+	Items []string `validate:"dive,min=2"`
+}
+
+func TestHooksRecordFailures(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	hooks, err := New(Options{Registerer: registry})
+	if err != nil {
+		t.Fatalf("expected collectors to register, got: %v", err)
+	}
+
+	validator := validation.New()
+	validator.SetHooks(hooks)
+	_ = validator.Struct(order{Email: "bad", Items: []string{"a", "b", "ok"}})
+	_ = validator.Struct(order{Email: "a@example.com"})
+
+	if got := testutil.ToFloat64(hooks.failures.WithLabelValues("prometheus.order", "Items[]", "min")); got != 2 {
+		t.Errorf("expected 2 failures for Items[] min, got %v", got)
+	}
+	if got := testutil.ToFloat64(hooks.calls.WithLabelValues("struct", "prometheus.order", "valid")); got != 1 {
+		t.Errorf("expected 1 valid call, got %v", got)
+	}
+	if got := testutil.CollectAndCount(hooks.duration); got != 1 {
+		t.Errorf("expected 1 duration series, got %d", got)
+	}
+
+	if _, err := New(Options{Registerer: registry}); err == nil {
+		t.Error("expected duplicate registration to fail")
+	}
+}
@@ -0,0 +1,201 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Coverage records which rules validation calls evaluate and whether they
+// passed or failed, so test suites can find constraints they never exercise.
+// It implements RuleHooks; install it for the test run with SetHooks, or
+// next to other hooks with MultiHooks. It is safe for concurrent use.
+//
+//	func TestMain(m *testing.M) {
+//		coverage := validation.NewCoverage()
+//		validation.SetHooks(coverage)
+//		code := m.Run()
+//		fmt.Print(validation.CoverageReportFor(coverage, Config{}))
+//		os.Exit(code)
+//	}
+type Coverage struct {
+	mu     sync.Mutex
+	counts map[coverageKey]*RuleCoverage
+	order  []coverageKey
+}
+
+// coverageKey identifies a rule of a field of a validated type
+type coverageKey struct {
+	typ, field, rule, param string
+}
+
+// RuleCoverage counts the outcomes of a rule of a field. Field is the path
+// Explain reports, with "[]" for collection elements.
+type RuleCoverage struct {
+	Type   string `json:"type"`
+	Field  string `json:"field"`
+	Rule   string `json:"rule"`
+	Param  string `json:"param,omitempty"`
+	Passed int    `json:"passed"`
+	Failed int    `json:"failed"`
+}
+
+// String renders the rule as "Type Field:rule=param"
+func (r RuleCoverage) String() string {
+	rule := r.Rule
+	if r.Param != "" {
+		rule += "=" + r.Param
+	}
+	return fmt.Sprintf("%s %s:%s", r.Type, r.Field, rule)
+}
+
+// NewCoverage creates an empty coverage recorder
+func NewCoverage() *Coverage {
+	return &Coverage{counts: make(map[coverageKey]*RuleCoverage)}
+}
+
+// OnValidateStart implements Hooks
+func (c *Coverage) OnValidateStart(ctx context.Context, info ValidationInfo) context.Context {
+	return ctx
+}
+
+// OnValidateEnd implements Hooks
+func (c *Coverage) OnValidateEnd(ctx context.Context, info ValidationInfo, duration time.Duration, errs ValidationErrors) {
+}
+
+// OnRuleFail implements Hooks
+func (c *Coverage) OnRuleFail(ctx context.Context, info ValidationInfo, err ValidationError) {}
+
+// OnRuleEvaluated records the outcome of a rule
+func (c *Coverage) OnRuleEvaluated(ctx context.Context, info ValidationInfo, eval RuleEvaluation) {
+	key := coverageKey{
+		typ:   info.TypeName,
+		field: elementIndexes.ReplaceAllString(eval.Namespace, "[]"),
+		rule:  eval.Tag,
+		param: eval.Param,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rule, ok := c.counts[key]
+	if !ok {
+		rule = &RuleCoverage{Type: key.typ, Field: key.field, Rule: key.rule, Param: key.param}
+		c.counts[key] = rule
+		c.order = append(c.order, key)
+	}
+	if eval.Passed {
+		rule.Passed++
+	} else {
+		rule.Failed++
+	}
+}
+
+// elementIndexes matches the slice indexes and map keys of a field path
+var elementIndexes = regexp.MustCompile(`\[[^\]]*\]`)
+
+// Rules returns the recorded rules in the order they were first evaluated
+func (c *Coverage) Rules() []RuleCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rules := make([]RuleCoverage, 0, len(c.order))
+	for _, key := range c.order {
+		rules = append(rules, *c.counts[key])
+	}
+	return rules
+}
+
+// Reset discards the recorded rules
+func (c *Coverage) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = make(map[coverageKey]*RuleCoverage)
+	c.order = nil
+}
+
+// CoverageReport lists every rule declared by a set of types with the
+// outcomes recorded for it
+type CoverageReport struct {
+	Rules []RuleCoverage `json:"rules"`
+}
+
+// CoverageReportFor reports the coverage of the rules the validator would
+// apply to each sample, in the order Explain lists them. Rules that were
+// never evaluated have zero counts.
+func (v *Validator) CoverageReportFor(coverage *Coverage, samples ...interface{}) CoverageReport {
+	report := CoverageReport{Rules: []RuleCoverage{}}
+	coverage.mu.Lock()
+	defer coverage.mu.Unlock()
+	for _, sample := range samples {
+		desc := v.Explain(sample)
+		for _, field := range desc.Fields {
+			for _, rule := range field.Rules {
+				key := coverageKey{typ: desc.Type, field: field.Field, rule: rule.Name, param: rule.Param}
+				if recorded, ok := coverage.counts[key]; ok {
+					report.Rules = append(report.Rules, *recorded)
+					continue
+				}
+				report.Rules = append(report.Rules, RuleCoverage{Type: key.typ, Field: key.field, Rule: key.rule, Param: key.param})
+			}
+		}
+	}
+	return report
+}
+
+// CoverageReportFor reports coverage using the default validator
+func CoverageReportFor(coverage *Coverage, samples ...interface{}) CoverageReport {
+	return defaultValidator.CoverageReportFor(coverage, samples...)
+}
+
+// Unexercised returns the rules that were never evaluated
+func (r CoverageReport) Unexercised() []RuleCoverage {
+	return r.filter(func(rule RuleCoverage) bool { return rule.Passed+rule.Failed == 0 })
+}
+
+// NeverFailed returns the rules that were evaluated but never failed, whose
+// failure paths no test covers
+func (r CoverageReport) NeverFailed() []RuleCoverage {
+	return r.filter(func(rule RuleCoverage) bool { return rule.Passed > 0 && rule.Failed == 0 })
+}
+
+// NeverPassed returns the rules that were evaluated but never passed
+func (r CoverageReport) NeverPassed() []RuleCoverage {
+	return r.filter(func(rule RuleCoverage) bool { return rule.Failed > 0 && rule.Passed == 0 })
+}
+
+func (r CoverageReport) filter(keep func(RuleCoverage) bool) []RuleCoverage {
+	var rules []RuleCoverage
+	for _, rule := range r.Rules {
+		if keep(rule) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// String summarizes the report and lists the rules never evaluated and never failed
+func (r CoverageReport) String() string {
+	unexercised, neverFailed := r.Unexercised(), r.NeverFailed()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "validation rule coverage: %d rules, %d never evaluated, %d never failed\n",
+		len(r.Rules), len(unexercised), len(neverFailed))
+	for _, section := range []struct {
+		title string
+		rules []RuleCoverage
+	}{
+		{"never evaluated", unexercised},
+		{"never failed", neverFailed},
+	} {
+		if len(section.rules) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:\n", section.title)
+		for _, rule := range section.rules {
+			fmt.Fprintf(&b, "  %s\n", rule)
+		}
+	}
+	return b.String()
+}
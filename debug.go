@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+)
+
+// defaultDebugLogger writes debug records to stderr when ValidatorConfig.Debug
+// is set without a DebugLogger
+var defaultDebugLogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+// WithDebug enables debug logging for this call, writing to logger when it is
+// non-nil and to the configured DebugLogger otherwise
+func WithDebug(logger *slog.Logger) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.Debug = true
+		if logger != nil {
+			config.DebugLogger = logger
+		}
+	}
+}
+
+// debugLogger returns the logger receiving debug records
+func (v *Validator) debugLogger() *slog.Logger {
+	if v.config.DebugLogger != nil {
+		return v.config.DebugLogger
+	}
+	return defaultDebugLogger
+}
+
+// debugContext returns the context debug records are logged with
+func (v *Validator) debugContext() context.Context {
+	if v.ctx != nil {
+		return v.ctx
+	}
+	return context.Background()
+}
+
+// debug logs a debug record. Callers check v.config.Debug first so that
+// disabled logging costs nothing.
+func (v *Validator) debug(msg string, attrs ...slog.Attr) {
+	v.debugLogger().LogAttrs(v.debugContext(), slog.LevelDebug, msg, attrs...)
+}
+
+// debugField records the rule chain about to be evaluated for a field
+func (v *Validator) debugField(field, tag string, val reflect.Value) {
+	v.debug("validating field",
+		slog.String("field", field),
+		slog.String("rules", tag),
+		slog.String("type", typeName(val)))
+}
+
+// debugSkip records why a field or rule was not evaluated
+func (v *Validator) debugSkip(field, rule, reason string) {
+	attrs := []slog.Attr{slog.String("field", field), slog.String("reason", reason)}
+	if rule != "" {
+		attrs = append(attrs, slog.String("rule", rule))
+	}
+	v.debug("skipped", attrs...)
+}
+
+// debugRule records the outcome of a single rule evaluation
+func (v *Validator) debugRule(fl *fieldLevel, passed bool) {
+	v.debug("rule evaluated",
+		slog.String("field", fl.fieldName),
+		slog.String("rule", fl.tag),
+		slog.String("param", fl.param),
+		slog.Bool("passed", passed))
+}
+
+// debugLookup records a cross-field lookup performed by a rule
+func (v *Validator) debugLookup(fl *fieldLevel, target string, value reflect.Value, found bool) {
+	attrs := []slog.Attr{
+		slog.String("field", fl.fieldName),
+		slog.String("rule", fl.tag),
+		slog.String("target", target),
+		slog.Bool("found", found),
+	}
+	if found && v.isSensitiveField(fl.parent, target) {
+		attrs = append(attrs, slog.String("value", RedactedValue))
+	} else if found && value.CanInterface() {
+		attrs = append(attrs, slog.Any("value", value.Interface()))
+	}
+	v.debug("cross-field lookup", attrs...)
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/mateothegreat/go-validation/internal/publicsuffix"
+)
+
+// The domain rules look names up in an embedded snapshot of the public
+// suffix list, e.g. to accept the custom domains of tenants: example.co.uk
+// can be registered, co.uk cannot, and example.notatld does not resolve.
+// Internationalized names are matched in their ASCII (xn--) form.
+
+// hostnameOf returns a name without the trailing dot of an FQDN, if it is a
+// valid hostname
+func hostnameOf(value string) (string, bool) {
+	name := strings.TrimSuffix(value, ".")
+	return name, ValidateHostname("", name) == nil
+}
+
+// isDomain validates a registrable domain such as example.co.uk: one label
+// under a listed public suffix
+func isDomain(fl FieldLevel) bool {
+	name, ok := hostnameOf(getString(fl.Field()))
+	if !ok {
+		return false
+	}
+	registrable, err := publicsuffix.Registrable(name)
+	return err == nil && strings.EqualFold(registrable, name)
+}
+
+// isTLD validates a top-level domain such as com or uk
+func isTLD(fl FieldLevel) bool {
+	return publicsuffix.IsTLD(getString(fl.Field()))
+}
+
+// isFQDNPublicSuffix validates a hostname under a listed public suffix, such
+// as app.example.co.uk, that is not a public suffix itself
+func isFQDNPublicSuffix(fl FieldLevel) bool {
+	name, ok := hostnameOf(getString(fl.Field()))
+	if !ok {
+		return false
+	}
+	_, err := publicsuffix.Registrable(name)
+	return err == nil
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mateothegreat/go-validation/internal/disposable"
+)
+
+// The email deliverability rules go beyond the syntax checked by email:
+// email_mx looks up the MX records of the address's domain and
+// email_not_disposable rejects domains of throwaway-inbox providers. Both
+// accept only valid addresses, so they can be used without email.
+
+// MXLookupFunc reports whether a domain has MX records accepting mail. An
+// error marks a failed lookup rather than a missing record.
+type MXLookupFunc func(ctx context.Context, domain string) (bool, error)
+
+// DisposableEmailFunc reports whether an email domain hands out disposable
+// addresses
+type DisposableEmailFunc func(domain string) bool
+
+// errMXLookupUnavailable is returned by the lite build's default MX lookup
+var errMXLookupUnavailable = errors.New("no MX lookup available, install one with SetMXLookup")
+
+// SetMXLookup installs the lookup used by email_mx, e.g. a caching resolver
+// or a stub in tests. nil restores the DNS lookup of the net package.
+func (v *Validator) SetMXLookup(fn MXLookupFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.mxLookup = fn
+	v.publish()
+}
+
+// SetMXLookup installs the MX lookup of the default validator
+func SetMXLookup(fn MXLookupFunc) {
+	defaultValidator.SetMXLookup(fn)
+}
+
+// SetDisposableEmailFunc installs the check used by email_not_disposable,
+// e.g. to extend or replace the embedded list. nil restores
+// IsDisposableEmailDomain.
+func (v *Validator) SetDisposableEmailFunc(fn DisposableEmailFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.disposableFunc = fn
+	v.publish()
+}
+
+// SetDisposableEmailFunc installs the disposable domain check of the default
+// validator
+func SetDisposableEmailFunc(fn DisposableEmailFunc) {
+	defaultValidator.SetDisposableEmailFunc(fn)
+}
+
+// IsDisposableEmailDomain reports whether a domain, or a domain it is under,
+// is in the embedded list of disposable email providers
+func IsDisposableEmailDomain(domain string) bool {
+	return disposable.Contains(domain)
+}
+
+// emailDomain returns the lowercased domain of a valid email address
+func emailDomain(value string) (string, bool) {
+	if ValidateEmail("", value) != nil {
+		return "", false
+	}
+	domain := value[strings.LastIndexByte(value, '@')+1:]
+	return strings.ToLower(strings.TrimSuffix(domain, ".")), true
+}
+
+// isNotDisposableEmail validates an email address whose domain does not hand
+// out disposable addresses
+func isNotDisposableEmail(fl FieldLevel) bool {
+	domain, ok := emailDomain(getString(fl.Field()))
+	if !ok {
+		return false
+	}
+	isDisposable := IsDisposableEmailDomain
+	if impl, ok := fl.(*fieldLevel); ok && impl.validator != nil && impl.validator.disposableFunc != nil {
+		isDisposable = impl.validator.disposableFunc
+	}
+	return !isDisposable(domain)
+}
+
+// checkEmailMX evaluates email_mx, whose lookup runs with the call's context.
+// A failed lookup is reported with code resolver_failed.
+func (v *Validator) checkEmailMX(fl *fieldLevel, collector *ErrorCollector) {
+	lookup := v.mxLookup
+	if lookup == nil {
+		lookup = lookupMX
+	}
+	ctx := collector.context()
+	v.runCustomRule(func(FieldLevel) bool {
+		domain, ok := emailDomain(getString(fl.field))
+		if !ok {
+			return false
+		}
+		found, err := lookup(ctx, domain)
+		if err != nil {
+			fl.failure = ValidationError{
+				Message: fmt.Sprintf(ErrorMsgEmailMXFailed, fl.fieldName, domain, err),
+				Code:    ErrCodeResolverFailed,
+			}
+			return false
+		}
+		return found
+	}, fl, collector)
+}
@@ -0,0 +1,28 @@
+//go:build !lite
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// lookupMX reports whether a domain has MX records. Unknown domains have
+// none, and a null MX (RFC 7505) declares that the domain accepts no mail.
+func lookupMX(ctx context.Context, domain string) (bool, error) {
+	records, err := net.DefaultResolver.LookupMX(ctx, domain)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, mx := range records {
+		if mx.Host != "" && mx.Host != "." {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/tabwriter"
+)
+
+// The renderers below are deterministic for a given error set, so their
+// output can be compared against golden files. Values are left out of Text
+// and Table, which are meant for humans; YAML carries every field JSON does.
+
+// Text returns the errors as plain text, one per line, as the field (the
+// namespace when set), the tag with its parameter, and the message:
+//
+//	Name min=2: field 'Name' must be at least 2
+//	Address.City required: field 'City' is required
+//
+// Line breaks in messages are escaped so every error stays on its own line.
+func (ve ValidationErrors) Text() string {
+	var b strings.Builder
+	for _, err := range ve {
+		b.WriteString(errorPath(err))
+		b.WriteByte(' ')
+		b.WriteString(errorRule(err))
+		b.WriteString(": ")
+		b.WriteString(singleLine(err.Message))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// YAML returns the errors as a YAML sequence with the keys and omissions of
+// JSON. Strings and values are written in JSON syntax, which YAML reads
+// as-is, so no quoting rules need to be applied.
+func (ve ValidationErrors) YAML() ([]byte, error) {
+	if len(ve) == 0 {
+		return []byte("[]\n"), nil
+	}
+
+	var buf bytes.Buffer
+	for _, err := range ve {
+		fields := []struct {
+			key   string
+			value interface{}
+			keep  bool
+		}{
+			{"field", err.Field, true},
+			{"tag", err.Tag, true},
+			{"value", err.Value, err.Value != nil},
+			{"param", err.Param, err.Param != ""},
+			{"message", err.Message, true},
+			{"code", err.Code, err.Code != ""},
+			{"namespace", err.Namespace, err.Namespace != ""},
+			{"struct_field", err.StructField, err.StructField != ""},
+		}
+		prefix := "- "
+		for _, field := range fields {
+			if !field.keep {
+				continue
+			}
+			encoded, marshalErr := json.Marshal(field.value)
+			if marshalErr != nil {
+				return nil, marshalErr
+			}
+			buf.WriteString(prefix)
+			buf.WriteString(field.key)
+			buf.WriteString(": ")
+			buf.Write(encoded)
+			buf.WriteByte('\n')
+			prefix = "  "
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Table returns the errors as an aligned table with FIELD, TAG, PARAM and
+// MESSAGE columns, for CLI output. Empty parameters are shown as "-".
+func (ve ValidationErrors) Table() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	w.Write([]byte("FIELD\tTAG\tPARAM\tMESSAGE\n"))
+	for _, err := range ve {
+		param := err.Param
+		if param == "" {
+			param = "-"
+		}
+		w.Write([]byte(strings.Join([]string{
+			tableCell(errorPath(err)), tableCell(err.Tag), tableCell(param), tableCell(err.Message),
+		}, "\t") + "\n"))
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// errorPath returns the field an error belongs to: the namespace when set,
+// as in AsMap
+func errorPath(err ValidationError) string {
+	if err.Namespace != "" {
+		return err.Namespace
+	}
+	return err.Field
+}
+
+// errorRule returns the tag of an error with its parameter, as in the
+// validate tag
+func errorRule(err ValidationError) string {
+	if err.Param == "" {
+		return err.Tag
+	}
+	return err.Tag + "=" + err.Param
+}
+
+// singleLine escapes line breaks so text stays on one line
+func singleLine(s string) string {
+	return strings.NewReplacer("\r", `\r`, "\n", `\n`).Replace(s)
+}
+
+// tableCell keeps text within its table cell
+func tableCell(s string) string {
+	return strings.ReplaceAll(singleLine(s), "\t", " ")
+}
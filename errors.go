@@ -1,8 +1,13 @@
 package validation
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -104,6 +109,34 @@ func (ve ValidationErrors) AsMap() map[string][]ValidationError {
 	return result
 }
 
+// All returns an iterator over the errors in order
+func (ve ValidationErrors) All() iter.Seq[ValidationError] {
+	return func(yield func(ValidationError) bool) {
+		for _, err := range ve {
+			if !yield(err) {
+				return
+			}
+		}
+	}
+}
+
+// ByField returns an iterator over the errors in order, paired with the field
+// they belong to (the namespace when set, as in AsMap). Unlike AsMap it
+// builds no intermediate map, so large error sets can be consumed lazily.
+func (ve ValidationErrors) ByField() iter.Seq2[string, ValidationError] {
+	return func(yield func(string, ValidationError) bool) {
+		for _, err := range ve {
+			field := err.Field
+			if err.Namespace != "" {
+				field = err.Namespace
+			}
+			if !yield(field, err) {
+				return
+			}
+		}
+	}
+}
+
 // JSON returns the errors as JSON bytes
 func (ve ValidationErrors) JSON() ([]byte, error) {
 	return json.Marshal(ve)
@@ -151,14 +184,41 @@ func (ve *ValidationErrors) Merge(other ValidationErrors) {
 
 // Merge combines multiple ValidationErrors into one (for ErrorCollector)
 func (ec *ErrorCollector) Merge(other ValidationErrors) {
-	ec.errors.Merge(other)
+	if ec.maxErrors <= 0 {
+		ec.errors.Merge(other)
+		return
+	}
+	for i, err := range other {
+		if ec.limitReached() {
+			ec.suppressed += len(other) - i
+			break
+		}
+		ec.errors = append(ec.errors, err)
+	}
 }
 
 // ErrorCollector provides a convenient way to collect validation errors
 type ErrorCollector struct {
-	errors    ValidationErrors
-	namespace string
-	failFast  bool
+	errors     ValidationErrors
+	namespace  string
+	failFast   bool
+	maxErrors  int // 0 collects every error
+	suppressed int // errors dropped after maxErrors was reached
+	sensitive  bool // a sensitive field is being validated
+	done       <-chan struct{} // closed when the call's context is done
+	canceled   bool            // traversal stopped because done was closed
+	depth      int             // nesting of the struct being validated
+	path       []structVisit   // addressable structs being validated, outermost first
+	pathBuf    [8]structVisit  // backs path for typical nesting without allocating
+	pending    []uniqueCheck   // unique_db rules resolved at the end of the call
+	ctx        context.Context // the call's context, passed to secret resolvers
+}
+
+// structVisit identifies a struct value by address and type; a struct and
+// its first field share an address
+type structVisit struct {
+	addr uintptr
+	typ  reflect.Type
 }
 
 // NewErrorCollector creates a new error collector
@@ -181,6 +241,61 @@ func (ec *ErrorCollector) SetFailFast(failFast bool) {
 	ec.failFast = failFast
 }
 
+// SetMaxErrors caps the number of collected errors. Further errors are
+// counted and summarized by a single trailing error. Zero disables the cap.
+func (ec *ErrorCollector) SetMaxErrors(maxErrors int) {
+	ec.maxErrors = maxErrors
+}
+
+// limitReached reports whether no further errors may be collected
+func (ec *ErrorCollector) limitReached() bool {
+	return ec.maxErrors > 0 && len(ec.errors) >= ec.maxErrors
+}
+
+// SetContext stops collection once ctx is done, checked between fields and
+// collection elements
+func (ec *ErrorCollector) SetContext(ctx context.Context) {
+	ec.ctx = ctx
+	ec.done = ctx.Done()
+}
+
+// context returns the context set by SetContext, or context.Background
+func (ec *ErrorCollector) context() context.Context {
+	if ec.ctx == nil {
+		return context.Background()
+	}
+	return ec.ctx
+}
+
+// expired reports whether the context set by SetContext is done, remembering
+// that traversal stopped early
+func (ec *ErrorCollector) expired() bool {
+	if ec.done == nil {
+		return false
+	}
+	select {
+	case <-ec.done:
+		ec.canceled = true
+		return true
+	default:
+		return false
+	}
+}
+
+// leaveStruct undoes the enterStruct of a struct whose validation finished
+func (ec *ErrorCollector) leaveStruct(val reflect.Value) {
+	ec.depth--
+	if val.CanAddr() {
+		ec.path = ec.path[:len(ec.path)-1]
+	}
+}
+
+// Canceled reports whether collection stopped early because the context set
+// by SetContext was done
+func (ec *ErrorCollector) Canceled() bool {
+	return ec.canceled
+}
+
 // SetNamespace sets the namespace for collected errors
 func (ec *ErrorCollector) SetNamespace(namespace string) {
 	ec.namespace = namespace
@@ -188,6 +303,11 @@ func (ec *ErrorCollector) SetNamespace(namespace string) {
 
 // Add adds a validation error
 func (ec *ErrorCollector) Add(err ValidationError) {
+	if ec.limitReached() {
+		ec.suppressed++
+		return
+	}
+	
 	// Add namespace if not already present
 	if ec.namespace != "" && err.Namespace == "" {
 		if err.Field != "" {
@@ -234,14 +354,115 @@ func (ec *ErrorCollector) HasErrors() bool {
 	return len(ec.errors) > 0
 }
 
-// ShouldStop returns true if collection should stop (fail fast mode and has errors)
+// ShouldStop returns true if collection should stop (fail fast mode and has
+// errors, or the context set by SetContext is done)
 func (ec *ErrorCollector) ShouldStop() bool {
-	return ec.failFast && ec.HasErrors()
+	return ec.failFast && ec.HasErrors() || ec.expired()
 }
 
-// Errors returns the collected validation errors
+// Errors returns the collected validation errors, followed by a summary
+// error when errors were suppressed by SetMaxErrors
 func (ec *ErrorCollector) Errors() ValidationErrors {
-	return ec.errors
+	if ec.suppressed == 0 {
+		return ec.errors
+	}
+	return append(ec.errors[:len(ec.errors):len(ec.errors)], ValidationError{
+		Tag:     "max_errors",
+		Param:   strconv.Itoa(ec.maxErrors),
+		Message: fmt.Sprintf(ErrorMsgErrorsSuppressed, ec.suppressed),
+		Code:    ErrCodeErrorsSuppressed,
+	})
+}
+
+// Suppressed returns the number of errors dropped after the limit was reached
+func (ec *ErrorCollector) Suppressed() int {
+	return ec.suppressed
+}
+
+// setStructField sets the struct field name of errors collected since index
+// from that do not carry one yet
+func (ec *ErrorCollector) setStructField(from int, structField string) {
+	for i := from; i < len(ec.errors); i++ {
+		if ec.errors[i].StructField == "" {
+			ec.errors[i].StructField = structField
+		}
+	}
+	for i := range ec.pending {
+		if ec.pending[i].at >= from && ec.pending[i].err.StructField == "" {
+			ec.pending[i].err.StructField = structField
+		}
+	}
+}
+
+// redact replaces the values of errors collected since index from
+func (ec *ErrorCollector) redact(from int) {
+	for i := from; i < len(ec.errors); i++ {
+		ec.errors[i].Value = RedactedValue
+	}
+	for i := range ec.pending {
+		if ec.pending[i].at >= from {
+			ec.pending[i].err.Value = RedactedValue
+		}
+	}
+}
+
+// insert places an error at index i, reporting false when it was suppressed
+// by SetMaxErrors
+func (ec *ErrorCollector) insert(i int, err ValidationError) bool {
+	if ec.limitReached() {
+		ec.suppressed++
+		return false
+	}
+	ec.errors = slices.Insert(ec.errors, min(i, len(ec.errors)), err)
+	return true
+}
+
+// fieldSpan is the range of errors, and of pending unique checks, added
+// while validating one field of a struct
+type fieldSpan struct {
+	index       int // declaration index of the field
+	from, to    int
+	pendingFrom int
+	pendingTo   int
+}
+
+// startSpan ends the last span and starts one for the field at index
+func (ec *ErrorCollector) startSpan(spans []fieldSpan, index int) []fieldSpan {
+	spans = ec.endSpan(spans)
+	return append(spans, fieldSpan{index: index, from: len(ec.errors), pendingFrom: len(ec.pending)})
+}
+
+// endSpan ends the last span at the errors and checks collected so far
+func (ec *ErrorCollector) endSpan(spans []fieldSpan) []fieldSpan {
+	if n := len(spans); n > 0 {
+		spans[n-1].to, spans[n-1].pendingTo = len(ec.errors), len(ec.pending)
+	}
+	return spans
+}
+
+// reorderSpans moves the errors of consecutive spans, collected in
+// evaluation order, into the declaration order of their fields, keeping the
+// errors of each field in rule order
+func (ec *ErrorCollector) reorderSpans(spans []fieldSpan) {
+	spans = ec.endSpan(spans)
+	if len(spans) < 2 {
+		return
+	}
+	byField := slices.Clone(spans)
+	slices.SortStableFunc(byField, func(a, b fieldSpan) int { return a.index - b.index })
+	
+	first, last := spans[0], spans[len(spans)-1]
+	errs := make(ValidationErrors, 0, last.to-first.from)
+	pending := make([]uniqueCheck, 0, last.pendingTo-first.pendingFrom)
+	for _, span := range byField {
+		for _, check := range ec.pending[span.pendingFrom:span.pendingTo] {
+			check.at += first.from + len(errs) - span.from
+			pending = append(pending, check)
+		}
+		errs = append(errs, ec.errors[span.from:span.to]...)
+	}
+	copy(ec.errors[first.from:], errs)
+	copy(ec.pending[first.pendingFrom:], pending)
 }
 
 // Count returns the number of errors collected
@@ -314,6 +535,27 @@ var (
 	// ErrorMsgRequired is used when a required field is missing
 	ErrorMsgRequired = "field '%s' is required"
 	
+	// ErrorMsgDefined is used when a defined field is nil
+	ErrorMsgDefined = "field '%s' must be set"
+	
+	// ErrorMsgBoolean is used when a value is not a boolean
+	ErrorMsgBoolean = "field '%s' must be a boolean"
+	
+	// ErrorMsgNotUnique is used when a unique_db resolver reports a value taken
+	ErrorMsgNotUnique = "field '%s' is already taken"
+	
+	// ErrorMsgFileExt is used when a file name has none of the allowed extensions
+	ErrorMsgFileExt = "field '%s' must have one of the extensions: %s"
+	
+	// ErrorMsgMinFileSize is used when an uploaded file is too small
+	ErrorMsgMinFileSize = "field '%s' must be a file of at least %s"
+	
+	// ErrorMsgMaxFileSize is used when an uploaded file is too large
+	ErrorMsgMaxFileSize = "field '%s' must be a file of at most %s"
+	
+	// ErrorMsgInvalidType is used when a query parameter cannot be converted to its field's type
+	ErrorMsgInvalidType = "field '%s' must be a valid %s"
+	
 	// ErrorMsgMin is used when a value is below minimum
 	ErrorMsgMin = "field '%s' must be at least %s"
 	
@@ -332,6 +574,42 @@ var (
 	// ErrorMsgMaxLength is used when length exceeds maximum
 	ErrorMsgMaxLength = "field '%s' must be at most %s characters"
 	
+	// ErrorMsgMinBytes is used when byte length is below minimum
+	ErrorMsgMinBytes = "field '%s' must be at least %s bytes"
+	
+	// ErrorMsgMaxBytes is used when byte length exceeds maximum
+	ErrorMsgMaxBytes = "field '%s' must be at most %s bytes"
+	
+	// ErrorMsgMinItems is used when a collection has too few items
+	ErrorMsgMinItems = "field '%s' must contain at least %s items"
+	
+	// ErrorMsgMaxItems is used when a collection has too many items
+	ErrorMsgMaxItems = "field '%s' must contain at most %s items"
+	
+	// ErrorMsgNotEmpty is used when a collection has no items
+	ErrorMsgNotEmpty = "field '%s' must not be empty"
+	
+	// ErrorMsgSorted is used when a collection is not in ascending order
+	ErrorMsgSorted = "field '%s' must be sorted in ascending order"
+	
+	// ErrorMsgSortedDesc is used when a collection is not in descending order
+	ErrorMsgSortedDesc = "field '%s' must be sorted in descending order"
+	
+	// ErrorMsgJSONSchema is used when a JSON value does not match its registered schema
+	ErrorMsgJSONSchema = "field '%s' must match JSON schema '%s'"
+	
+	// ErrorMsgTypeOf is used when a dynamic value has none of the allowed types
+	ErrorMsgTypeOf = "field '%s' must be of type %s"
+	
+	// ErrorMsgHasKeys is used when a map is missing required keys
+	ErrorMsgHasKeys = "field '%s' must contain keys [%s]"
+	
+	// ErrorMsgAllowedKeys is used when a map contains keys outside the allowed set
+	ErrorMsgAllowedKeys = "field '%s' may only contain keys [%s]"
+	
+	// ErrorMsgNotMap is used when keys= or values= is applied to a non-map field
+	ErrorMsgNotMap = "field '%s' must be a map"
+	
 	// ErrorMsgEmail is used for invalid email format
 	ErrorMsgEmail = "field '%s' must be a valid email address"
 	
@@ -352,4 +630,229 @@ var (
 	
 	// ErrorMsgNumeric is used when value contains non-numeric characters
 	ErrorMsgNumeric = "field '%s' must contain only numeric characters"
+	
+	// ErrorMsgRulePanic is used when a rule panics while evaluating a value
+	ErrorMsgRulePanic = "field '%s' could not be validated by rule '%s': %v"
+	
+	// ErrorMsgErrorsSuppressed summarizes the errors dropped once MaxErrors was reached
+	ErrorMsgErrorsSuppressed = "%d additional validation errors suppressed"
+	
+	// ErrorMsgInputTooLong is used when a value exceeds MaxInputLength
+	ErrorMsgInputTooLong = "field '%s' exceeds the maximum input length of %d bytes"
+	
+	// ErrorMsgMaxDepth is used when structs are nested deeper than MaxDepth
+	ErrorMsgMaxDepth = "field '%s' is nested deeper than the maximum depth of %d"
+	
+	// ErrorMsgResolverFailed is used when a unique_db resolver returned an error
+	ErrorMsgResolverFailed = "field '%s' could not be checked for uniqueness: %v"
+	
+	// ErrorMsgSecretUnresolved is used when a secret reference could not be resolved
+	ErrorMsgSecretUnresolved = "field '%s' secret %s could not be resolved: %v"
+	
+	// ErrorMsgParamUnresolved is used when a $Field or ${ENV} rule parameter could not be resolved
+	ErrorMsgParamUnresolved = "field '%s' could not be validated by rule '%s': %v"
+	
+	// ErrorMsgExpiresAfter is used when a time is not far enough in the future
+	ErrorMsgExpiresAfter = "field '%s' must be later than %s from now"
+	
+	// ErrorMsgNotBeforeNow is used when a time has not passed yet
+	ErrorMsgNotBeforeNow = "field '%s' must not be in the future"
+	
+	// ErrorMsgWithin is used when a time is too far from now
+	ErrorMsgWithin = "field '%s' must be within %s of now"
+	
+	// ErrorMsgDomain is used when a name is not a registrable domain
+	ErrorMsgDomain = "field '%s' must be a registrable domain"
+	
+	// ErrorMsgTLD is used when a name is not a top-level domain
+	ErrorMsgTLD = "field '%s' must be a top-level domain"
+	
+	// ErrorMsgFQDNPublicSuffix is used when a hostname is not under a public suffix
+	ErrorMsgFQDNPublicSuffix = "field '%s' must be a hostname under a public suffix"
+	
+	// ErrorMsgEmailMX is used when the domain of an email address has no MX records
+	ErrorMsgEmailMX = "field '%s' must be an email address whose domain accepts mail"
+	
+	// ErrorMsgEmailMXFailed is used when the MX lookup of an email domain returned an error
+	ErrorMsgEmailMXFailed = "field '%s' could not be checked for mail servers of %s: %v"
+	
+	// ErrorMsgEmailDisposable is used when an email address belongs to a disposable provider
+	ErrorMsgEmailDisposable = "field '%s' must not be a disposable email address"
+	
+	// ErrorMsgUsername is used when a username has the wrong length or characters
+	ErrorMsgUsername = "field '%s' must be %d to %d letters or digits, separated by single '.', '_' or '-'"
+	
+	// ErrorMsgUsernameReserved is used when a username is reserved or looks like a reserved name
+	ErrorMsgUsernameReserved = "field '%s' is a reserved username"
+	
+	// ErrorMsgNoHTML is used when a value contains markup
+	ErrorMsgNoHTML = "field '%s' must not contain HTML"
+	
+	// ErrorMsgNoScript is used when a value contains a script tag, event handler or script URL
+	ErrorMsgNoScript = "field '%s' must not contain scripts"
+	
+	// ErrorMsgSafeText is used when a value contains markup, control characters or invalid UTF-8
+	ErrorMsgSafeText = "field '%s' must be plain text without markup or control characters"
+	
+	// ErrorMsgSafePath is used when a path has '..' segments or control characters
+	ErrorMsgSafePath = "field '%s' must be a safe path"
+	
+	// ErrorMsgSafePathMode is used when a path is unsafe or not relative or absolute as required
+	ErrorMsgSafePathMode = "field '%s' must be a safe %s path"
+	
+	// ErrorMsgNoShellMeta is used when a value contains shell metacharacters
+	ErrorMsgNoShellMeta = "field '%s' must not contain shell metacharacters"
+	
+	// ErrorMsgSQLIdentifier is used when a value is not an unquoted SQL identifier
+	ErrorMsgSQLIdentifier = "field '%s' must be a valid SQL identifier"
+	
+	// ErrorMsgSQLLikeEscape is used when a LIKE pattern has unescaped wildcards
+	ErrorMsgSQLLikeEscape = "field '%s' must escape LIKE wildcards"
+	
+	// ErrorMsgK8sName is used when a value is not a DNS-1123 subdomain
+	ErrorMsgK8sName = "field '%s' must be a valid Kubernetes name"
+	
+	// ErrorMsgK8sLabelName is used when a value is not a DNS-1123 label
+	ErrorMsgK8sLabelName = "field '%s' must be a valid Kubernetes name of at most 63 characters without dots"
+	
+	// ErrorMsgK8sLabelKey is used when a value is not a label key
+	ErrorMsgK8sLabelKey = "field '%s' must be a valid Kubernetes label key"
+	
+	// ErrorMsgK8sLabelValue is used when a value is not a label value
+	ErrorMsgK8sLabelValue = "field '%s' must be a valid Kubernetes label value"
+	
+	// ErrorMsgK8sQuantity is used when a value is not a resource quantity
+	ErrorMsgK8sQuantity = "field '%s' must be a valid Kubernetes quantity such as 500m or 1Gi"
+	
+	// ErrorMsgK8sSelector is used when a value is not a label selector
+	ErrorMsgK8sSelector = "field '%s' must be a valid Kubernetes label selector"
+	
+	// ErrorMsgImageRef is used when a value is not a container image reference
+	ErrorMsgImageRef = "field '%s' must be a valid image reference"
+	
+	// ErrorMsgImageRefTagged is used when an image reference has neither a tag nor a digest
+	ErrorMsgImageRefTagged = "field '%s' must be a valid image reference with a tag or digest"
+	
+	// ErrorMsgImageRefDigest is used when an image reference is not pinned by digest
+	ErrorMsgImageRefDigest = "field '%s' must be a valid image reference with a digest"
+	
+	// ErrorMsgImageTag is used when a value is not an image tag
+	ErrorMsgImageTag = "field '%s' must be a valid image tag"
+	
+	// ErrorMsgSHA256Digest is used when a value is not a sha256 digest
+	ErrorMsgSHA256Digest = "field '%s' must be a sha256 digest"
+	
+	// ErrorMsgGitURL is used when a value is not a remote git repository URL
+	ErrorMsgGitURL = "field '%s' must be a valid git repository URL"
+	
+	// ErrorMsgSSHURL is used when a value is not an ssh:// URL
+	ErrorMsgSSHURL = "field '%s' must be a valid SSH URL"
+	
+	// ErrorMsgCommitSHA is used when a value is not a full or abbreviated commit SHA
+	ErrorMsgCommitSHA = "field '%s' must be a commit SHA of 7 to 40 hex digits"
+	
+	// ErrorMsgBranchName is used when a value is not a valid git branch name
+	ErrorMsgBranchName = "field '%s' must be a valid branch name"
+	
+	// ErrorMsgURLScheme is used when a URL has a scheme outside the allowed ones
+	ErrorMsgURLScheme = "field '%s' must be a URL with scheme [%s]"
+	
+	// ErrorMsgURLHostIn is used when the host of a URL matches none of the allowed patterns
+	ErrorMsgURLHostIn = "field '%s' must be a URL with a host in [%s]"
+	
+	// ErrorMsgURLNoUserinfo is used when a URL contains credentials
+	ErrorMsgURLNoUserinfo = "field '%s' must be a URL without credentials"
+	
+	// ErrorMsgURLNoFragment is used when a URL contains a fragment
+	ErrorMsgURLNoFragment = "field '%s' must be a URL without a fragment"
+	
+	// ErrorMsgTimezone is used when a value is not an IANA time zone name
+	ErrorMsgTimezone = "field '%s' must be a valid time zone"
+	
+	// ErrorMsgLocale is used when a value is not a BCP 47 language tag
+	ErrorMsgLocale = "field '%s' must be a valid locale"
+	
+	// ErrorMsgCurrencyLocale is used when a currency is not used in the country of a locale
+	ErrorMsgCurrencyLocale = "field '%s' must be a currency used in the country of %s"
+	
+	// ErrorMsgMinMax is used when the minimum of a pair exceeds its maximum
+	ErrorMsgMinMax = "field '%s' must be at least %s"
+	
+	// ErrorMsgTimeWindow is used when a time window ends before it starts
+	ErrorMsgTimeWindow = "field '%s' must be after %s"
+	
+	// ErrorMsgWeightsSum is used when weight fields do not add up to their total
+	ErrorMsgWeightsSum = "fields %s must sum to %s"
+	
+	// ErrorMsgAtLeastOneOf is used when none of a group of fields is set
+	ErrorMsgAtLeastOneOf = "at least one of the fields %s is required"
+	
+	// ErrorMsgMutuallyExclusive is used when more than one field of a group is set
+	ErrorMsgMutuallyExclusive = "only one of the fields %s may be set"
+	
+	// ErrorMsgContrast is used when two colors do not contrast enough
+	ErrorMsgContrast = "field '%s' must have a contrast ratio of at least %s with %s"
+	
+	// ErrorMsgBlocklist is used when text contains a word of a registered blocklist
+	ErrorMsgBlocklist = "field '%s' contains a blocked word"
+	
+	// ErrorMsgChecksum is used when an identifier such as an ISBN fails its check digit
+	ErrorMsgChecksum = "field '%s' must be a valid %s"
+	
+	// ErrorMsgPercent is used when a number is not a percentage
+	ErrorMsgPercent = "field '%s' must be a percentage between 0 and 100"
+	
+	// ErrorMsgRatio is used when a number is not a ratio
+	ErrorMsgRatio = "field '%s' must be a ratio between 0 and 1"
+	
+	// ErrorMsgSumTo is used when numbers do not add up to their total
+	ErrorMsgSumTo = "field '%s' must sum to %s"
+	
+	// ErrorMsgDecimal is used when an amount has too many integer or fractional digits
+	ErrorMsgDecimal = "field '%s' must have at most %s integer and %s fractional digits"
+	
+	// ErrorMsgPositive is used when a number is zero or negative
+	ErrorMsgPositive = "field '%s' must be positive"
+	
+	// ErrorMsgNonNegative is used when a number is negative
+	ErrorMsgNonNegative = "field '%s' must not be negative"
+	
+	// ErrorMsgMinorUnits is used when an amount has more decimal places than its currency
+	ErrorMsgMinorUnits = "field '%s' has more decimal places than the currency in %s allows"
+	
+	// ErrorMsgImmutable is used when StructUpdate finds an immutable field changed
+	ErrorMsgImmutable = "field '%s' cannot be changed"
+)
+
+// Error codes for programmatic handling
+const (
+	// ErrCodeRulePanic marks errors produced when a rule panicked instead of returning a result
+	ErrCodeRulePanic = "rule_panic"
+	
+	// ErrCodeErrorsSuppressed marks the summary error appended when MaxErrors was reached
+	ErrCodeErrorsSuppressed = "errors_suppressed"
+	
+	// ErrCodeInputTooLong marks errors for values rejected by MaxInputLength before any rule ran
+	ErrCodeInputTooLong = "input_too_long"
+	
+	// ErrCodeMaxDepth marks errors for structs left unvalidated because they are nested deeper than MaxDepth
+	ErrCodeMaxDepth = "max_depth"
+	
+	// ErrCodeResolverFailed marks unique_db and email_mx errors for values whose resolver or lookup failed rather than reporting them invalid
+	ErrCodeResolverFailed = "resolver_failed"
+	
+	// ErrCodeUsernameReserved marks username errors for names that are reserved or look like a reserved name
+	ErrCodeUsernameReserved = "username_reserved"
+	
+	// ErrCodeInvalidType marks errors for query parameters that could not be converted to their field's type
+	ErrCodeInvalidType = "invalid_type"
+	
+	// ErrCodeSecretUnresolved marks errors for secret references whose resolver failed
+	ErrCodeSecretUnresolved = "secret_unresolved"
+	
+	// ErrCodeParamUnresolved marks errors for rules whose $Field or ${ENV} parameter could not be resolved
+	ErrCodeParamUnresolved = "param_unresolved"
+	
+	// ErrCodeImmutable marks errors for immutable fields changed by an update
+	ErrCodeImmutable = "immutable"
 )
\ No newline at end of file
@@ -63,7 +63,7 @@ type CacheConfig struct {
 	Host     string `yaml:"host" validate:"required_if=Enabled true,hostname"`
 	Port     int    `yaml:"port" validate:"required_if=Enabled true,min=1,max=65535"`
 	Password string `yaml:"password" validate:"omitempty,min=6"`
-	Database int    `yaml:"database" validate:"min=0,max=15"`
+	Database *int   `yaml:"database" validate:"defined,min=0,max=15"`
 }
 
 // LoggingConfig represents logging configuration
@@ -143,7 +143,7 @@ func createValidConfig() *AppConfig {
 			Host:     "cache.example.com",
 			Port:     6379,
 			Password: "cache123",
-			Database: 0,
+			Database: new(int), // 0 is a valid index, defined only rejects nil
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
@@ -217,7 +217,8 @@ func createConditionalConfig() *AppConfig {
 			// Host, Port, Username, Password not required for sqlite
 		},
 		Cache: CacheConfig{
-			Enabled: false,
+			Enabled:  false,
+			Database: new(int),
 			// Host and Port not required when disabled
 		},
 		Logging: LoggingConfig{
@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+// Command wasm validates browser forms with the rules the server uses. Build
+// it with the lite core, which leaves out net, net/http and google/uuid:
+//
+//	GOOS=js GOARCH=wasm go build -tags lite -o validate.wasm ./examples/wasm
+//	tinygo build -tags lite -target wasm -o validate.wasm ./examples/wasm
+//
+// and call validateForm(formElement) from JavaScript once the module runs.
+package main
+
+import (
+	"errors"
+	"net/url"
+	"syscall/js"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// signupRules are shared with the server, which validates the same form
+var signupRules = map[string]string{
+	"email":    "required,email",
+	"username": "required,min=3,max=32,alphanum",
+	"age":      "omitempty,numeric",
+	"website":  "omitempty,url",
+}
+
+func main() {
+	js.Global().Set("validateForm", js.FuncOf(validateForm))
+	select {}
+}
+
+// validateForm returns an object mapping each failing field to its message
+func validateForm(this js.Value, args []js.Value) any {
+	values := url.Values{}
+	data := js.Global().Get("FormData").New(args[0])
+	entries := data.Call("entries")
+	for entry := entries.Call("next"); !entry.Get("done").Bool(); entry = entries.Call("next") {
+		pair := entry.Get("value")
+		values.Add(pair.Index(0).String(), pair.Index(1).String())
+	}
+
+	result := map[string]any{}
+	var errs validation.ValidationErrors
+	if err := validation.ValidateValues(values, signupRules); errors.As(err, &errs) {
+		for _, e := range errs {
+			if _, seen := result[e.Field]; !seen {
+				result[e.Field] = e.Message
+			}
+		}
+	}
+	return js.ValueOf(result)
+}
@@ -0,0 +1,252 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValidationPlanDescription describes what validating a type would check,
+// without running any rules
+type ValidationPlanDescription struct {
+	Type        string      `json:"type"`
+	StructLevel bool        `json:"struct_level,omitempty"` // a struct-level validation is registered
+	Groups      []string    `json:"groups,omitempty"`       // field groups declared with the groups tag, e.g. "at_least_one_of=APIKey Token"
+	Fields      []FieldPlan `json:"fields"`
+}
+
+// FieldPlan describes the rules applied to a single field. Nested struct
+// fields are flattened into dotted paths and dive elements use "[]".
+type FieldPlan struct {
+	Field     string     `json:"field"` // Full path (e.g., "Servers[].Port")
+	Type      string     `json:"type"`
+	Tag       string     `json:"tag"`
+	OmitEmpty bool       `json:"omitempty,omitempty"`
+	Sensitive bool       `json:"sensitive,omitempty"` // error values are redacted
+	Secret    bool       `json:"secret,omitempty"`    // rules validate the secret a reference resolves to
+	Rules     []RulePlan `json:"rules"`
+	DependsOn []string   `json:"depends_on,omitempty"` // Fields referenced by cross-field rules
+}
+
+// RulePlan describes a single rule in the order it is evaluated
+type RulePlan struct {
+	Name      string   `json:"name"`
+	Param     string   `json:"param,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"`
+	Unknown   bool     `json:"unknown,omitempty"` // no rule is registered under this name
+	Invalid   string   `json:"invalid,omitempty"` // why the parameter does not match the rule's ParamSchema
+}
+
+// Explain describes how sample would be validated: every field, its ordered
+// rules, their parameters and the fields they depend on
+func (v *Validator) Explain(sample interface{}) ValidationPlanDescription {
+	v = v.current()
+
+	typ := reflect.TypeOf(sample)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil {
+		return ValidationPlanDescription{Type: "nil"}
+	}
+
+	desc := ValidationPlanDescription{Type: typ.String(), Fields: []FieldPlan{}}
+	if typ.Kind() != reflect.Struct {
+		return desc
+	}
+
+	_, desc.StructLevel = v.structRules[typ]
+	desc.Groups = fieldGroups(typ)
+	v.explainStruct(typ, "", map[reflect.Type]bool{}, &desc)
+	return desc
+}
+
+// Explain describes validation of sample using the default validator
+func Explain(sample interface{}) ValidationPlanDescription {
+	return defaultValidator.Explain(sample)
+}
+
+// explainStruct appends the plans of typ's fields in evaluation order,
+// mirroring validateStruct
+func (v *Validator) explainStruct(typ reflect.Type, namespace string, visiting map[reflect.Type]bool, desc *ValidationPlanDescription) {
+	if visiting[typ] {
+		return
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	order, _ := v.planFor(typ).orderFor(v.config.Scenario)
+	for _, i := range order {
+		fieldType := typ.Field(i)
+		if !fieldType.IsExported() || v.isIgnoredField(fieldType.Name) {
+			continue
+		}
+
+		fieldName := v.fieldNameFunc(fieldType)
+		fullPath := fieldName
+		if namespace != "" {
+			fullPath = namespace + "." + fieldName
+		}
+
+		tag := v.scenarioTag(v.fieldTag(typ, fieldType))
+		if tag == "-" {
+			tag = ""
+		}
+
+		if strings.Contains(tag, "dive") {
+			collectionTag, elemTag := splitDiveTag(tag)
+			if collectionTag != "" {
+				desc.Fields = append(desc.Fields, v.explainField(fullPath, namespace, fieldType.Type, collectionTag))
+			}
+			elemType := indirectType(fieldType.Type)
+			if elemType.Kind() != reflect.Slice && elemType.Kind() != reflect.Array && elemType.Kind() != reflect.Map {
+				continue
+			}
+			elemType = elemType.Elem()
+			elemPath := fullPath + "[]"
+			if elemTag != "" {
+				desc.Fields = append(desc.Fields, v.explainField(elemPath, namespace, elemType, elemTag))
+			} else if elemType.Kind() == reflect.Struct {
+				v.explainStruct(elemType, elemPath, visiting, desc)
+			}
+			continue
+		}
+
+		if tag != "" {
+			desc.Fields = append(desc.Fields, v.explainField(fullPath, namespace, fieldType.Type, tag))
+		}
+
+		if nested := indirectType(fieldType.Type); nested.Kind() == reflect.Struct {
+			v.explainStruct(nested, fullPath, visiting, desc)
+		}
+	}
+}
+
+// explainField parses a field's tag into its ordered rule plans
+func (v *Validator) explainField(path, namespace string, typ reflect.Type, tag string) FieldPlan {
+	plan := FieldPlan{Field: path, Type: typ.String(), Tag: tag, Rules: []RulePlan{}}
+
+	for _, rule := range SplitRules(tag) {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if rule == "omitempty" {
+			plan.OmitEmpty = true
+			continue
+		}
+		if rule == "sensitive" {
+			plan.Sensitive = true
+			continue
+		}
+		if rule == "secret" {
+			plan.Sensitive = true
+			plan.Secret = true
+			continue
+		}
+
+		name, param, _ := strings.Cut(rule, "=")
+		rulePlan := RulePlan{Name: name, Param: param}
+		if _, exists := v.customRules[name]; !exists && !isMapEntryRule(name) {
+			rulePlan.Unknown = true
+		}
+		if err := v.CheckRuleParam(name, param); err != nil {
+			rulePlan.Invalid = err.Error()
+		}
+		for _, dep := range crossFieldDependencies(name, param) {
+			if namespace != "" {
+				dep = namespace + "." + dep
+			}
+			rulePlan.DependsOn = append(rulePlan.DependsOn, dep)
+			plan.DependsOn = appendUnique(plan.DependsOn, dep)
+		}
+		plan.Rules = append(plan.Rules, rulePlan)
+	}
+
+	return plan
+}
+
+// crossFieldDependencies returns the sibling fields a rule reads, including
+// one named by a $Field parameter
+func crossFieldDependencies(rule, param string) []string {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return nil
+	}
+	if field, ok := placeholderField(param); ok {
+		return []string{field}
+	}
+
+	switch rule {
+	case "eqfield", "nefield", "gtfield", "gtefield", "gtefiled", "ltfield", "ltefield",
+		"required_with", "required_without":
+		return []string{param}
+	case "required_if", "required_unless":
+		return strings.Fields(param)[:1]
+	}
+	return nil
+}
+
+// indirectType strips pointer indirections from a type
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// JSON serializes the description
+func (d ValidationPlanDescription) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// String renders the description as an indented, human-readable listing
+func (d ValidationPlanDescription) String() string {
+	var b strings.Builder
+	b.WriteString(d.Type)
+	if d.StructLevel {
+		b.WriteString(" (struct-level validation)")
+	}
+	b.WriteString("\n")
+
+	for _, field := range d.Fields {
+		fmt.Fprintf(&b, "  %s (%s)", field.Field, field.Type)
+		if field.OmitEmpty {
+			b.WriteString(" omitempty")
+		}
+		if field.Secret {
+			b.WriteString(" secret")
+		} else if field.Sensitive {
+			b.WriteString(" sensitive")
+		}
+		b.WriteString("\n")
+		for i, rule := range field.Rules {
+			fmt.Fprintf(&b, "    %d. %s", i+1, rule.Name)
+			if rule.Param != "" {
+				fmt.Fprintf(&b, "=%s", rule.Param)
+			}
+			if len(rule.DependsOn) > 0 {
+				fmt.Fprintf(&b, " -> %s", strings.Join(rule.DependsOn, ", "))
+			}
+			if rule.Unknown {
+				b.WriteString(" (unknown rule)")
+			}
+			if rule.Invalid != "" {
+				fmt.Fprintf(&b, " (invalid parameter: %s)", rule.Invalid)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
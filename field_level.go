@@ -1,10 +1,12 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FieldLevel provides access to the field being validated and its context
@@ -50,6 +52,7 @@ type fieldLevel struct {
 	structField   string
 	param         string
 	tag           string
+	failure       error // returned by a ValidationErrFunc rule
 }
 
 // Top returns the top level struct being validated
@@ -100,6 +103,8 @@ func (fl *fieldLevel) ExtractType(field reflect.Value) (reflect.Value, reflect.K
 			return field, field.Kind(), false
 		}
 		return fl.ExtractType(field.Elem())
+	case reflect.Invalid:
+		return field, reflect.Invalid, false
 	default:
 		return field, field.Kind(), true
 	}
@@ -135,17 +140,76 @@ type StructLevel interface {
 	
 	// ReportValidationErrors reports validation errors
 	ReportValidationErrors(field, structField, tag string, errs ValidationErrors)
+	
+	// Namespace returns the path of the current struct, e.g. "servers[0]",
+	// or "" for the top level struct
+	Namespace() string
+	
+	// Context returns the context of the call, as passed to StructCtx
+	Context() context.Context
+	
+	// ReportValidationError reports an error exactly as a failed field rule
+	// would: when StructField names a field of the current struct, Field
+	// and Value default to its name and value, and Namespace and Message
+	// are filled in like those of field rules
+	ReportValidationError(err ValidationError)
+	
+	// Errorf reports that the struct field failed tag with a formatted message
+	Errorf(structField, tag, format string, args ...interface{})
+	
+	// FieldByName returns a field of the current struct by Go name, following
+	// pointers; dotted names reach nested structs, e.g. "Address.City". The
+	// zero Value is returned when the field is missing or a pointer is nil.
+	FieldByName(name string) reflect.Value
+	
+	// Changed reports whether the named field differs between old, a
+	// previous version of the current struct or a pointer to one, and the
+	// current struct
+	Changed(old interface{}, field string) bool
+	
+	// FieldString returns a string field, false when it is missing or not a string
+	FieldString(name string) (string, bool)
+	
+	// FieldInt returns a signed integer field, such as an int or a time.Duration
+	FieldInt(name string) (int64, bool)
+	
+	// FieldUint returns an unsigned integer field
+	FieldUint(name string) (uint64, bool)
+	
+	// FieldFloat returns a floating point field
+	FieldFloat(name string) (float64, bool)
+	
+	// FieldBool returns a bool field
+	FieldBool(name string) (bool, bool)
+	
+	// FieldTime returns a time.Time field
+	FieldTime(name string) (time.Time, bool)
 }
 
 // structLevel implements StructLevel interface
 type structLevel struct {
 	validator *Validator
+	call      *Validator // snapshot of the call, rendering messages with its locale
 	top       reflect.Value
 	current   reflect.Value
 	namespace string
+	ctx       context.Context
 	errors    ValidationErrors
 }
 
+// Namespace returns the path of the current struct
+func (sl *structLevel) Namespace() string {
+	return sl.namespace
+}
+
+// Context returns the context of the call, or context.Background
+func (sl *structLevel) Context() context.Context {
+	if sl.ctx == nil {
+		return context.Background()
+	}
+	return sl.ctx
+}
+
 // Validator returns the validator instance
 func (sl *structLevel) Validator() *Validator {
 	return sl.validator
@@ -174,6 +238,8 @@ func (sl *structLevel) ExtractType(field reflect.Value) (reflect.Value, reflect.
 			return field, field.Kind(), false
 		}
 		return sl.ExtractType(field.Elem())
+	case reflect.Invalid:
+		return field, reflect.Invalid, false
 	default:
 		return field, field.Kind(), true
 	}
@@ -212,6 +278,145 @@ func (sl *structLevel) ReportValidationErrors(field, structField, tag string, er
 	}
 }
 
+// ReportValidationError reports an error shaped like those of field rules
+func (sl *structLevel) ReportValidationError(err ValidationError) {
+	call := sl.call
+	if call == nil {
+		call = sl.validator
+	}
+	
+	value := reflect.ValueOf(err.Value)
+	sensitive := false
+	if current, _, ok := sl.ExtractType(sl.current); ok && current.Kind() == reflect.Struct && err.StructField != "" {
+		if field, found := current.Type().FieldByName(err.StructField); found && field.IsExported() {
+			if err.Field == "" {
+				err.Field = call.fieldNameFunc(field)
+			}
+			if err.Value == nil {
+				value = current.FieldByIndex(field.Index)
+				err.Value = valueInterface(value)
+			}
+			sensitive = hasSensitiveTag(call.fieldTag(current.Type(), field))
+		}
+	}
+	if err.Field == "" {
+		err.Field = err.StructField
+	}
+	if err.Namespace == "" && sl.namespace != "" {
+		err.Namespace = sl.namespace + "." + err.Field
+	}
+	if err.Message == "" {
+		fl := &fieldLevel{field: value, fieldName: err.Field, param: err.Param, tag: err.Tag}
+		err.Message = call.errorMessage(fl, &ErrorCollector{namespace: sl.namespace, sensitive: sensitive})
+	}
+	if sensitive {
+		err.Value = RedactedValue
+	}
+	sl.errors.Add(err)
+}
+
+// Errorf reports a struct field failure with a formatted message
+func (sl *structLevel) Errorf(structField, tag, format string, args ...interface{}) {
+	sl.ReportValidationError(ValidationError{
+		StructField: structField,
+		Tag:         tag,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+// FieldByName returns a field of the current struct by Go name or dotted path
+func (sl *structLevel) FieldByName(name string) reflect.Value {
+	return sl.fieldByPath(sl.current, name)
+}
+
+// fieldByPath resolves a dotted path of exported Go field names on a struct
+func (sl *structLevel) fieldByPath(val reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		current, kind, ok := sl.ExtractType(val)
+		if !ok || kind != reflect.Struct {
+			return reflect.Value{}
+		}
+		field, found := current.Type().FieldByName(name)
+		if !found || !field.IsExported() {
+			return reflect.Value{}
+		}
+		val, _ = current.FieldByIndexErr(field.Index)
+	}
+	val, _, ok := sl.ExtractType(val)
+	if !ok {
+		return reflect.Value{}
+	}
+	return val
+}
+
+// Changed reports whether a field differs from its value in old
+func (sl *structLevel) Changed(old interface{}, field string) bool {
+	previous := sl.fieldByPath(reflect.ValueOf(old), field)
+	current := sl.FieldByName(field)
+	if !previous.IsValid() || !current.IsValid() {
+		return previous.IsValid() != current.IsValid()
+	}
+	return !reflect.DeepEqual(valueInterface(previous), valueInterface(current))
+}
+
+// FieldString returns a string field
+func (sl *structLevel) FieldString(name string) (string, bool) {
+	field := sl.FieldByName(name)
+	if field.Kind() != reflect.String {
+		return "", false
+	}
+	return field.String(), true
+}
+
+// FieldInt returns a signed integer field
+func (sl *structLevel) FieldInt(name string) (int64, bool) {
+	field := sl.FieldByName(name)
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), true
+	}
+	return 0, false
+}
+
+// FieldUint returns an unsigned integer field
+func (sl *structLevel) FieldUint(name string) (uint64, bool) {
+	field := sl.FieldByName(name)
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return field.Uint(), true
+	}
+	return 0, false
+}
+
+// FieldFloat returns a floating point field
+func (sl *structLevel) FieldFloat(name string) (float64, bool) {
+	field := sl.FieldByName(name)
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	}
+	return 0, false
+}
+
+// FieldBool returns a bool field
+func (sl *structLevel) FieldBool(name string) (bool, bool) {
+	field := sl.FieldByName(name)
+	if field.Kind() != reflect.Bool {
+		return false, false
+	}
+	return field.Bool(), true
+}
+
+// FieldTime returns a time.Time field
+func (sl *structLevel) FieldTime(name string) (time.Time, bool) {
+	field := sl.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return time.Time{}, false
+	}
+	t, ok := field.Interface().(time.Time)
+	return t, ok
+}
+
 // Utility functions for common validation tasks
 
 // ParseParam parses validation parameters
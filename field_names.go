@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNameFromTags returns a FieldNameFunc resolving the wire name of a
+// field from the first of the given struct tags that names it, in priority
+// order. Tags set to "-" or with an empty name are skipped, and the Go field
+// name is used when no tag applies.
+func FieldNameFromTags(tags ...string) FieldNameFunc {
+	tags = append([]string(nil), tags...)
+	return func(fld reflect.StructField) string {
+		return tagFieldNameOr(fld, tags...)
+	}
+}
+
+// JSONFieldName resolves field names from json tags
+func JSONFieldName(fld reflect.StructField) string {
+	return tagFieldNameOr(fld, "json")
+}
+
+// YAMLFieldName resolves field names from yaml tags
+func YAMLFieldName(fld reflect.StructField) string {
+	return tagFieldNameOr(fld, "yaml")
+}
+
+// TOMLFieldName resolves field names from toml tags
+func TOMLFieldName(fld reflect.StructField) string {
+	return tagFieldNameOr(fld, "toml")
+}
+
+// MapstructureFieldName resolves field names from mapstructure tags
+func MapstructureFieldName(fld reflect.StructField) string {
+	return tagFieldNameOr(fld, "mapstructure")
+}
+
+// QueryFieldName resolves field names from query tags, then form tags
+func QueryFieldName(fld reflect.StructField) string {
+	return tagFieldNameOr(fld, "query", "form")
+}
+
+// SetFieldNameTags resolves error field names from the given struct tags in
+// priority order, e.g. SetFieldNameTags("yaml", "json")
+func (v *Validator) SetFieldNameTags(tags ...string) {
+	v.SetFieldNameFunc(FieldNameFromTags(tags...))
+}
+
+// SetFieldNameTags sets the field name tags of the default validator
+func SetFieldNameTags(tags ...string) {
+	defaultValidator.SetFieldNameTags(tags...)
+}
+
+// tagFieldNameOr returns the name from the first of tags naming the field,
+// or the Go field name
+func tagFieldNameOr(fld reflect.StructField, tags ...string) string {
+	for _, tag := range tags {
+		if name := tagFieldName(fld, tag); name != "" {
+			return name
+		}
+	}
+	return fld.Name
+}
+
+// tagFieldName returns the name part of a struct tag such as `json:"name,omitempty"`
+func tagFieldName(fld reflect.StructField, tag string) string {
+	value, ok := fld.Tag.Lookup(tag)
+	if !ok || value == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(value, ",")
+	return strings.TrimSpace(name)
+}
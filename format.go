@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MessageParam describes a rule parameter about to be rendered into an error
+// message
+type MessageParam struct {
+	Rule   string        // rule tag, e.g. "max"
+	Param  string        // parameter, e.g. "1048576"; size specs such as "1KB" arrive rendered
+	Field  reflect.Value // validated value, may be invalid
+	Locale string        // locale of the call, empty for the default messages
+}
+
+// ParamFormatter renders a rule parameter for an error message, e.g. "1 MB"
+// instead of "1048576". Returning p.Param keeps the default rendering. The
+// ValidationError.Param field always carries the raw parameter.
+type ParamFormatter func(p MessageParam) string
+
+// SetParamFormatter installs a formatter for parameters embedded in error
+// messages, including registered locale messages. Pass nil to remove it.
+func (v *Validator) SetParamFormatter(fn ParamFormatter) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.paramFormatter = fn
+	v.publish()
+}
+
+// SetParamFormatter installs a parameter formatter on the default validator
+func SetParamFormatter(fn ParamFormatter) {
+	defaultValidator.SetParamFormatter(fn)
+}
+
+// ChainParamFormatters returns a formatter trying each formatter in order
+// until one changes the parameter
+func ChainParamFormatters(formatters ...ParamFormatter) ParamFormatter {
+	return func(p MessageParam) string {
+		for _, format := range formatters {
+			if formatted := format(p); formatted != p.Param {
+				return formatted
+			}
+		}
+		return p.Param
+	}
+}
+
+// HumanParams renders durations, byte sizes and large numbers for humans by
+// chaining FormatDurations, FormatByteSizes and FormatThousands
+func HumanParams(p MessageParam) string {
+	return ChainParamFormatters(FormatDurations, FormatByteSizes, FormatThousands)(p)
+}
+
+// FormatDurations renders integer parameters of time.Duration fields as
+// durations, e.g. "1m30s" for 90000000000
+func FormatDurations(p MessageParam) string {
+	if !p.Field.IsValid() || indirectValue(p.Field).Type() != reflect.TypeOf(time.Duration(0)) {
+		return p.Param
+	}
+	n, err := strconv.ParseInt(p.Param, 10, 64)
+	if err != nil {
+		return p.Param
+	}
+	return time.Duration(n).String()
+}
+
+// byteSizeUnits lists binary units from largest to smallest, matching the
+// units accepted by size parameters
+var byteSizeUnits = []ByteUnit{UnitPetabytes, UnitTerabytes, UnitGigabytes, UnitMegabytes, UnitKilobytes}
+
+// FormatByteSizes renders minbytes/maxbytes parameters that are whole binary
+// units as sizes, e.g. "1 MB" for 1048576
+func FormatByteSizes(p MessageParam) string {
+	if p.Rule != "minbytes" && p.Rule != "maxbytes" {
+		return p.Param
+	}
+	n, err := strconv.ParseInt(p.Param, 10, 64)
+	if err != nil || n <= 0 {
+		return p.Param
+	}
+	for _, unit := range byteSizeUnits {
+		if multiplier := byteUnitMultipliers[unit]; n%multiplier == 0 {
+			return fmt.Sprintf("%d %s", n/multiplier, unit)
+		}
+	}
+	return p.Param
+}
+
+// FormatThousands groups the digits of numeric parameters using the
+// separators of the call's locale, e.g. "1,000,000" or "1.000.000" for "de"
+func FormatThousands(p MessageParam) string {
+	if _, err := strconv.ParseFloat(p.Param, 64); err != nil || strings.ContainsAny(p.Param, "eEnNxX_") {
+		return p.Param
+	}
+
+	group, decimal := numberSeparators(p.Locale)
+	sign, digits := "", p.Param
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(r)
+	}
+	if hasFraction {
+		b.WriteString(decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// numberSeparators returns the digit group and decimal separators for a
+// locale such as "de" or "fr-CA", defaulting to English
+func numberSeparators(locale string) (group, decimal string) {
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	language, _, _ = strings.Cut(language, "_")
+	switch language {
+	case "de", "es", "it", "nl", "pt", "id", "tr", "da":
+		return ".", ","
+	case "fr", "ru", "pl", "cs", "sv", "nb", "no", "fi", "uk":
+		return " ", ","
+	}
+	return ",", "."
+}
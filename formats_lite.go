@@ -0,0 +1,29 @@
+//go:build lite
+
+package validation
+
+import "context"
+
+// The lite build parses network addresses and UUIDs without the net package
+// and google/uuid, so it compiles under TinyGo and for WebAssembly
+
+func ipVersion(value string) int {
+	return portableIPVersion(value)
+}
+
+func validCIDR(value string) bool {
+	return portableCIDR(value)
+}
+
+func validMAC(value string) bool {
+	return portableMAC(value)
+}
+
+func uuidVersion(value string) (int, bool) {
+	return portableUUIDVersion(value)
+}
+
+// lookupMX fails without the net package; email_mx needs SetMXLookup
+func lookupMX(ctx context.Context, domain string) (bool, error) {
+	return false, errMXLookupUnavailable
+}
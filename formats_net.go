@@ -0,0 +1,46 @@
+//go:build !lite
+
+package validation
+
+import (
+	"net"
+
+	"github.com/google/uuid"
+)
+
+// ipVersion returns 4 or 6 for a valid IP address, IPv4-mapped IPv6
+// addresses counting as IPv4, and 0 otherwise
+func ipVersion(value string) int {
+	ip := net.ParseIP(value)
+	switch {
+	case ip == nil:
+		return 0
+	case ip.To4() != nil:
+		return 4
+	default:
+		return 6
+	}
+}
+
+// validCIDR reports whether value is an IP prefix in CIDR notation
+func validCIDR(value string) bool {
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+// validMAC reports whether value is an IEEE 802 MAC-48, EUI-48, EUI-64 or
+// 20-octet IP over InfiniBand link-layer address
+func validMAC(value string) bool {
+	_, err := net.ParseMAC(value)
+	return err == nil
+}
+
+// uuidVersion returns the version of a UUID in any of the forms uuid.Parse
+// accepts
+func uuidVersion(value string) (int, bool) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return 0, false
+	}
+	return int(id.Version()), true
+}
@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ValidateValues validates form or query values with the rules of each key,
+// written in the tag language of structs. A key is validated as its first
+// value, a string, or with dive as all of its values. Errors name the keys.
+func (v *Validator) ValidateValues(values url.Values, rules map[string]string, opts ...ValidateOption) error {
+	return v.validateForm(context.Background(), "url.Values", rules, opts, func(key, tag string) reflect.Value {
+		return formValue(values[key], tag)
+	})
+}
+
+// validateForm validates the value lookup returns for every key of rules,
+// in key order
+func (v *Validator) validateForm(ctx context.Context, typeName string, rules map[string]string, opts []ValidateOption, lookup func(key, tag string) reflect.Value) error {
+	v = v.current().withOptions(opts)
+	ctx, cancel := v.deadline(ctx)
+	defer cancel()
+	collector := NewErrorCollector()
+	collector.SetFailFast(v.config.FailFast)
+	collector.SetMaxErrors(v.config.MaxErrors)
+	collector.SetContext(ctx)
+
+	if v.hooks != nil {
+		var start time.Time
+		v, start = v.startHooks(ctx, ValidationInfo{Kind: KindStruct, TypeName: typeName, FieldCount: len(rules)})
+		defer v.endHooks(start, collector)
+	}
+
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tag := v.scenarioTag(rules[key])
+		if tag == "" || tag == "-" || v.isIgnoredField(key) {
+			continue
+		}
+		mark := collector.Count()
+		collector.sensitive = hasSensitiveTag(tag)
+		val := lookup(key, tag)
+		if strings.Contains(tag, "dive") {
+			collectionTag, elemTag := splitDiveTag(tag)
+			if collectionTag != "" {
+				v.validateField(val, reflect.Value{}, key, collectionTag, collector)
+			}
+			v.validateDive(val, key, elemTag, collector)
+		} else {
+			v.validateField(val, reflect.Value{}, key, tag, collector)
+		}
+		if collector.sensitive {
+			collector.redact(mark)
+		}
+		if collector.ShouldStop() {
+			break
+		}
+	}
+	collector.sensitive = false
+
+	v.resolveUnique(ctx, collector)
+	if collector.Canceled() {
+		return fmt.Errorf("%w: %w", ErrValidationAborted, context.Cause(ctx))
+	}
+	if collector.HasErrors() {
+		v.scrub(collector)
+		return collector.Errors()
+	}
+	return nil
+}
+
+// formValue returns the value validated for a key: all of its values with
+// dive, otherwise the first one or the empty string
+func formValue(values []string, tag string) reflect.Value {
+	if strings.Contains(tag, "dive") {
+		return reflect.ValueOf(values)
+	}
+	if len(values) == 0 {
+		return reflect.ValueOf("")
+	}
+	return reflect.ValueOf(values[0])
+}
+
+// ValidateValues validates form values using the default validator
+func ValidateValues(values url.Values, rules map[string]string, opts ...ValidateOption) error {
+	return defaultValidator.ValidateValues(values, rules, opts...)
+}
+
+// hasFileExt validates that a file name, or the name of an uploaded file,
+// ends in one of the space-separated extensions, ignoring case and the dot
+func hasFileExt(fl FieldLevel) bool {
+	name, ok := fileName(fl.Field())
+	if !ok {
+		return false
+	}
+	ext := strings.TrimPrefix(strings.ToLower(path.Ext(name)), ".")
+	if ext == "" {
+		return false
+	}
+	for _, allowed := range strings.Fields(fl.Param()) {
+		if strings.EqualFold(strings.TrimPrefix(allowed, "."), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMinFileSize validates that an uploaded file has at least the size of
+// the parameter, in bytes or with a unit such as 10KB
+func hasMinFileSize(fl FieldLevel) bool {
+	size, limit, ok := fileSize(fl)
+	return ok && size >= limit
+}
+
+// hasMaxFileSize validates that an uploaded file has at most the size of the
+// parameter, in bytes or with a unit such as 5MB
+func hasMaxFileSize(fl FieldLevel) bool {
+	size, limit, ok := fileSize(fl)
+	return ok && size <= limit
+}
@@ -0,0 +1,97 @@
+//go:build !lite
+
+package validation
+
+import (
+	"context"
+	"mime/multipart"
+	"reflect"
+	"strings"
+)
+
+// fileHeaderType is the type multipart file fields are validated as
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+// fileRules are the rules marking a multipart field as a file field
+var fileRules = map[string]bool{
+	"file_ext":      true,
+	"min_file_size": true,
+	"max_file_size": true,
+}
+
+// ValidateMultipart validates a parsed multipart form like ValidateValues.
+// Keys holding files, or whose rules include file_ext, min_file_size or
+// max_file_size, are validated as *multipart.FileHeader, or with dive as all
+// of the files uploaded under the key.
+func (v *Validator) ValidateMultipart(form *multipart.Form, rules map[string]string, opts ...ValidateOption) error {
+	if form == nil {
+		form = &multipart.Form{}
+	}
+	return v.validateForm(context.Background(), "multipart.Form", rules, opts, func(key, tag string) reflect.Value {
+		if files, ok := form.File[key]; ok || hasFileRule(tag) {
+			return formFile(files, tag)
+		}
+		return formValue(form.Value[key], tag)
+	})
+}
+
+// ValidateMultipart validates a multipart form using the default validator
+func ValidateMultipart(form *multipart.Form, rules map[string]string, opts ...ValidateOption) error {
+	return defaultValidator.ValidateMultipart(form, rules, opts...)
+}
+
+// formFile returns the file validated for a key: all of its files with dive,
+// otherwise the first one or a nil *multipart.FileHeader
+func formFile(files []*multipart.FileHeader, tag string) reflect.Value {
+	if strings.Contains(tag, "dive") {
+		return reflect.ValueOf(files)
+	}
+	if len(files) == 0 {
+		return reflect.Zero(fileHeaderType)
+	}
+	return reflect.ValueOf(files[0])
+}
+
+// hasFileRule reports whether a tag includes a rule for uploaded files
+func hasFileRule(tag string) bool {
+	for _, rule := range SplitRules(tag) {
+		name, _, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if fileRules[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// fileSize returns the size of an uploaded file and the rule's limit in bytes
+func fileSize(fl FieldLevel) (size, limit int64, ok bool) {
+	header, ok := fileHeader(fl.Field())
+	if !ok {
+		return 0, 0, false
+	}
+	spec, err := ParseSizeSpec(fl.Param())
+	if err != nil || (!spec.IsBytes && spec.Type != SizeDefault) {
+		return 0, 0, false
+	}
+	return header.Size, spec.Value, true
+}
+
+// fileName returns the name of an uploaded file, or a string naming one
+func fileName(field reflect.Value) (string, bool) {
+	if field.Kind() == reflect.String {
+		return field.String(), true
+	}
+	header, ok := fileHeader(field)
+	if !ok {
+		return "", false
+	}
+	return header.Filename, true
+}
+
+// fileHeader returns the uploaded file a field holds
+func fileHeader(field reflect.Value) (multipart.FileHeader, bool) {
+	if !field.IsValid() || field.Type() != fileHeaderType.Elem() || !field.CanInterface() {
+		return multipart.FileHeader{}, false
+	}
+	return field.Interface().(multipart.FileHeader), true
+}
@@ -0,0 +1,59 @@
+//go:build !lite
+
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"testing"
+)
+
+func TestValidateMultipartForm(t *testing.T) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	w.WriteField("title", "Holiday")
+	for name, size := range map[string]int{"photo.JPG": 2048, "notes.txt": 10} {
+		part, _ := w.CreateFormFile("attachments", name)
+		part.Write(bytes.Repeat([]byte("x"), size))
+	}
+	part, _ := w.CreateFormFile("avatar", "me.png")
+	part.Write(bytes.Repeat([]byte("x"), 4096))
+	w.Close()
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer form.RemoveAll()
+
+	rules := map[string]string{
+		"title":       "required,min=3",
+		"avatar":      "required,file_ext=png jpg,max_file_size=2KB",
+		"attachments": "maxitems=5,dive,file_ext=.jpg .txt,min_file_size=100",
+		"resume":      "required,file_ext=pdf",
+	}
+	err = ValidateMultipart(form, rules)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Field] = e.Tag
+	}
+	want := map[string]string{"avatar": "max_file_size", "resume": "required"}
+	for field, tag := range want {
+		if got[field] != tag {
+			t.Errorf("expected %s:%s, got %v", field, tag, errs)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("expected errors for avatar, resume and the small attachment, got %v", errs)
+	}
+	for _, e := range errs {
+		if e.Field == "avatar" && e.Error() != "field 'avatar' must be a file of at most 2KB" {
+			t.Errorf("unexpected message: %s", e.Error())
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fuzzTarget exercises every field shape the engine has to cope with
+type fuzzTarget struct {
+	Name     string            `validate:"required,min=2,max=50"`
+	Email    string            `validate:"omitempty,email"`
+	Confirm  string            `validate:"eqfield=Name"`
+	Age      int               `validate:"min=0,max=150,gtfield=Name"`
+	Score    float64           `validate:"min=1,max=10"`
+	Ptr      *string           `validate:"omitempty,alpha"`
+	Any      interface{}       `validate:"required,min=1"`
+	Tags     []string          `validate:"dive,required,min=1"`
+	Labels   map[string]string `validate:"dive,alphanum"`
+	Ch       chan int          `validate:"required,min=1,len=2"`
+	Fn       func()            `validate:"required,eqfield=Tags"`
+	Nested   *fuzzTarget       `validate:"omitempty"`
+	Required string            `validate:"required_if=Name admin,required_with=Ptr"`
+}
+
+func TestValidatorInvalidKindsDoNotPanic(t *testing.T) {
+	validator := New()
+
+	values := []interface{}{
+		nil,
+		make(chan int),
+		func() {},
+		(*string)(nil),
+		[]int(nil),
+		map[string]int(nil),
+		struct{}{},
+		&fuzzTarget{},
+		complex(1, 2),
+	}
+	tags := []string{"required", "min=1", "max=1", "len=1", "eq=1", "oneof=a b", "email", "eqfield=Name", "gtfield=Name", "required_if=Name x"}
+
+	for _, value := range values {
+		for _, tag := range tags {
+			_ = validator.Var(value, tag)
+		}
+	}
+
+	if err := validator.Var(nil, "required"); err == nil {
+		t.Error("expected required to fail for nil value")
+	}
+
+	err := validator.Struct(fuzzTarget{Name: "ab", Confirm: "ab", Any: "x"})
+	if err == nil {
+		t.Fatal("expected validation errors for fuzz target")
+	}
+}
+
+func TestValidatorRecoversFromPanickingRule(t *testing.T) {
+	validator := New()
+	_ = validator.RegisterValidation("boom", func(fl FieldLevel) bool {
+		panic("unexpected kind")
+	})
+
+	err := validator.Var("value", "boom")
+	if err == nil {
+		t.Fatal("expected error from panicking rule")
+	}
+
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok || len(validationErrors) != 1 {
+		t.Fatalf("expected a single ValidationError, got %#v", err)
+	}
+	if validationErrors[0].Code != ErrCodeRulePanic {
+		t.Errorf("expected code %q, got %q", ErrCodeRulePanic, validationErrors[0].Code)
+	}
+}
+
+func FuzzVar(f *testing.F) {
+	f.Add("hello", "required,min=2,max=10")
+	f.Add("", "omitempty,email")
+	f.Add("192.168.0.1", "ip")
+	f.Add("a b c", "oneof=a b c")
+	f.Add("2023-01-01", "date,eqfield=Other")
+	f.Add("x", "min=abc,max=,len=-1")
+
+	validator := New()
+	f.Fuzz(func(t *testing.T, value string, tag string) {
+		_ = validator.Var(value, tag)
+		_ = validator.Var(&value, tag)
+		_ = validator.Var(len(value), tag)
+		_ = validator.Var([]byte(value), tag)
+		_ = validator.Var(nil, tag)
+	})
+}
+
+func FuzzStruct(f *testing.F) {
+	f.Add("admin", "admin@example.com", 42, 5.5, "tag")
+	f.Add("", "", -1, -0.5, "")
+	f.Add("x", "not-an-email", 1<<30, 1e300, "a,b")
+
+	validator := New()
+	f.Fuzz(func(t *testing.T, name, email string, age int, score float64, tag string) {
+		target := fuzzTarget{
+			Name:    name,
+			Email:   email,
+			Confirm: name,
+			Age:     age,
+			Score:   score,
+			Ptr:     &tag,
+			Any:     tag,
+			Tags:    []string{tag, name},
+			Labels:  map[string]string{name: tag},
+			Ch:      make(chan int, 2),
+			Fn:      func() {},
+			Nested:  &fuzzTarget{Name: tag, Any: age},
+		}
+
+		_ = validator.Struct(target)
+		_ = validator.Struct(&target)
+	})
+}
+
+// malformedTags are seeds shared by the tag parsing fuzz targets: unbalanced
+// quotes, stray commas and separators, huge and negative params
+var malformedTags = []string{
+	"",
+	",",
+	",,,",
+	"required,",
+	",required",
+	"required,,min=1",
+	"=",
+	"min=",
+	"=1",
+	"min==1",
+	"min=1=2",
+	"oneof=",
+	"oneof='a b",
+	"oneof=\"a b",
+	"eq='",
+	"dive",
+	"dive,dive,dive",
+	"dive,keys,endkeys",
+	"keys=,values=",
+	"required_if=",
+	"required_if=Name",
+	"eqfield=",
+	"eqfield=.",
+	"eqfield=Nested..Name",
+	"min=99999999999999999999999",
+	"max=-99999999999999999999999",
+	"len=9223372036854775807",
+	"min=1e308,max=-1e308",
+	"min=NaN,max=Inf",
+	"size=1PB",
+	"size=999999999999PB",
+	"|||",
+	"required|omitempty",
+	"omitempty,omitempty,required",
+	"\x00",
+	"min=1\x00",
+	strings.Repeat("required,", 100),
+	"min=" + strings.Repeat("9", 400),
+	"oneof=" + strings.Repeat("a ", 200),
+}
+
+// maxTagDuration bounds how long one fuzzed tag may take, catching rules
+// whose cost grows with an attacker-controlled param
+const maxTagDuration = time.Second
+
+func FuzzVarTag(f *testing.F) {
+	for _, tag := range malformedTags {
+		f.Add(tag)
+	}
+
+	validator := New()
+	values := []interface{}{
+		"value",
+		42,
+		uint8(7),
+		-1.5,
+		true,
+		time.Second,
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		[]string{"a", "b"},
+		[]int{3, 1, 2},
+		map[string]int{"a": 1},
+		&fuzzTarget{Name: "ab"},
+	}
+	f.Fuzz(func(t *testing.T, tag string) {
+		start := time.Now()
+		for _, value := range values {
+			_ = validator.Var(value, tag)
+		}
+		if elapsed := time.Since(start); elapsed > maxTagDuration {
+			t.Errorf("tag %q took %v", tag, elapsed)
+		}
+	})
+}
+
+func FuzzStructTags(f *testing.F) {
+	for _, tag := range malformedTags {
+		f.Add(tag, tag)
+	}
+	f.Add(`validate:"required`, "required")
+	f.Add(`validate:required`, "min=1")
+	f.Add(`validate:"min=1" validate:"max=0"`, "eqfield=Other")
+	f.Add(`json:",omitempty" validate:",,"`, "required_with=Other")
+	f.Add(`validate:"\"`, "")
+
+	f.Fuzz(func(t *testing.T, raw, tag string) {
+		// A validator per input keeps the plan cache from growing with
+		// every fuzzed struct type
+		validator := New()
+		typ := reflect.StructOf([]reflect.StructField{
+			{Name: "Raw", Type: reflect.TypeOf(""), Tag: reflect.StructTag(raw)},
+			{Name: "Field", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`validate:` + strconv.Quote(tag))},
+			{Name: "Items", Type: reflect.TypeOf([]string{}), Tag: reflect.StructTag(`validate:` + strconv.Quote(tag))},
+			{Name: "Other", Type: reflect.TypeOf(0), Tag: reflect.StructTag(`validate:` + strconv.Quote(tag))},
+		})
+		value := reflect.New(typ)
+		value.Elem().Field(0).SetString(raw)
+		value.Elem().Field(1).SetString(tag)
+		value.Elem().Field(2).Set(reflect.ValueOf([]string{tag, ""}))
+
+		start := time.Now()
+		_ = validator.Struct(value.Interface())
+		_ = validator.Struct(value.Elem().Interface())
+		_ = validator.Explain(value.Interface())
+		if elapsed := time.Since(start); elapsed > maxTagDuration {
+			t.Errorf("tags %q and %q took %v", raw, tag, elapsed)
+		}
+	})
+}
+
+func FuzzParseSizeSpec(f *testing.F) {
+	for _, rule := range []string{
+		"", "0", "100", "-1", "900B", "10MB", "1.5GB", "1PB", "50:chars", "10:bytes", "5:runes",
+		":", "1:", ":chars", "1:2:3", "MB", "-MB", "NaNMB", "InfGB", "1e308PB", "  7KB  ",
+		"99999999999999999999", "9223372036854775807TB", strings.Repeat("9", 400) + "KB",
+	} {
+		f.Add(rule)
+	}
+
+	f.Fuzz(func(t *testing.T, rule string) {
+		spec, err := ParseSizeSpec(rule)
+		if err != nil {
+			return
+		}
+		if spec.IsBytes && spec.Type != SizeBytes {
+			t.Errorf("ParseSizeSpec(%q) = %+v: byte sizes must have type %q", rule, spec, SizeBytes)
+		}
+	})
+}
+
+// FuzzPortableFormats checks that the parsers of the lite build accept the
+// same addresses and UUIDs as net and google/uuid
+func FuzzPortableFormats(f *testing.F) {
+	for _, seed := range []string{
+		"192.168.0.1", "::1", "::ffff:10.0.0.1", "fe80::1%eth0", "010.0.0.1", "1.2.3", "",
+		"10.0.0.0/8", "2001:db8::/32", "10.0.0.0/33", "10.0.0.0/08", "::ffff:1.2.3.4/104", "10.0.0.0/",
+		"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E", "001a.2b3c.4d5e", "00:1a:2b:3c:4d:5e:6f:70", "00:1a-2b:3c:4d:5e", "0:1a:2b:3c:4d:5e",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "{6ba7b810-9dad-41d1-80b4-00c04fd430c8}",
+		"URN:UUID:6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b8109dad11d180b400c04fd430c8", "6ba7b810x9dad-11d1-80b4-00c04fd430c8",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		if got, want := portableIPVersion(value), ipVersion(value); got != want {
+			t.Errorf("IP version of %q: portable %d, net %d", value, got, want)
+		}
+		if got, want := portableCIDR(value), validCIDR(value); got != want {
+			t.Errorf("CIDR %q: portable %v, net %v", value, got, want)
+		}
+		if got, want := portableMAC(value), validMAC(value); got != want {
+			t.Errorf("MAC %q: portable %v, net %v", value, got, want)
+		}
+		gotVersion, gotOK := portableUUIDVersion(value)
+		wantVersion, wantOK := uuidVersion(value)
+		if gotOK != wantOK || gotVersion != wantVersion {
+			t.Errorf("UUID %q: portable %d %v, uuid %d %v", value, gotVersion, gotOK, wantVersion, wantOK)
+		}
+	})
+}
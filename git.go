@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The git rules check repository locations, commits and branches in CI/CD
+// configuration: git_url accepts what git clone does over the network,
+// ssh_url only ssh:// URLs, commit_sha full or abbreviated SHA-1 object
+// names, and branch_name the names git check-ref-format --branch allows.
+
+var (
+	// gitSchemes are the URL schemes git clones over the network
+	gitSchemes = map[string]bool{"https": true, "http": true, "ssh": true, "git": true, "git+ssh": true, "ssh+git": true}
+	sshSchemes = map[string]bool{"ssh": true}
+
+	// scpLikeGitURL matches the scp-like syntax [user@]host:path, capturing
+	// the host and path
+	scpLikeGitURL = regexp.MustCompile(`^(?:[A-Za-z0-9._~-]+@)?([A-Za-z0-9.-]+|\[[0-9A-Fa-f:.]+\]):(.+)$`)
+
+	// commitSHA matches an object name abbreviated to at least 7 hex digits
+	commitSHA = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+)
+
+// validGitHost reports whether a host is a hostname or an IP address
+func validGitHost(host string) bool {
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return validHostname(host) || ipVersion(host) != 0
+}
+
+// validRemoteURL reports whether value is a URL with one of the schemes,
+// a valid host and port, no query or fragment, and a path when required
+func validRemoteURL(value string, schemes map[string]bool, requirePath bool) bool {
+	u, err := url.Parse(value)
+	if err != nil || !schemes[u.Scheme] || u.Opaque != "" || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+	if !validGitHost(u.Hostname()) {
+		return false
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return false
+		}
+	}
+	return !requirePath || strings.Trim(u.Path, "/") != ""
+}
+
+// validGitURL reports whether value is a remote repository location: an
+// https, http, ssh or git URL, or the scp-like git@host:org/repo.git
+func validGitURL(value string) bool {
+	if strings.Contains(value, "://") {
+		return validRemoteURL(value, gitSchemes, true)
+	}
+	match := scpLikeGitURL.FindStringSubmatch(value)
+	return match != nil && validGitHost(match[1]) && !strings.ContainsAny(match[2], " \t\r\n")
+}
+
+// validBranchName reports whether name is a branch name git accepts, following
+// the rules of git check-ref-format --branch
+func validBranchName(name string) bool {
+	if name == "" || name == "@" || name == "HEAD" || name[0] == '-' {
+		return false
+	}
+	if strings.HasSuffix(name, ".") || strings.Contains(name, "..") || strings.Contains(name, "@{") {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c < 0x20 || c == 0x7f || strings.IndexByte(" ~^:?*[\\", c) >= 0 {
+			return false
+		}
+	}
+	for _, component := range strings.Split(name, "/") {
+		if component == "" || component[0] == '.' || strings.HasSuffix(component, ".lock") {
+			return false
+		}
+	}
+	return true
+}
+
+// isGitURL validates a remote repository location
+func isGitURL(fl FieldLevel) bool {
+	return validGitURL(getString(fl.Field()))
+}
+
+// isSSHURL validates an ssh:// URL
+func isSSHURL(fl FieldLevel) bool {
+	return validRemoteURL(getString(fl.Field()), sshSchemes, false)
+}
+
+// isCommitSHA validates a full or abbreviated commit SHA
+func isCommitSHA(fl FieldLevel) bool {
+	return commitSHA.MatchString(getString(fl.Field()))
+}
+
+// isBranchName validates a branch name
+func isBranchName(fl FieldLevel) bool {
+	return validBranchName(getString(fl.Field()))
+}
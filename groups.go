@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidFieldGroup is returned by Struct when a groups tag names an
+// unknown kind of group or a field the struct does not have
+var ErrInvalidFieldGroup = errors.New("invalid field group")
+
+// Field groups constrain fields that are only meaningful together. They are
+// declared with a groups tag, conventionally on a blank field, as groups of
+// Go field names separated by semicolons:
+//
+//	type Auth struct {
+//		_ struct{} `groups:"at_least_one_of=APIKey Token BasicAuth;mutually_exclusive=TLSCertFile TLSCertInline"`
+//		...
+//	}
+//
+// Declaring a group as both at_least_one_of and mutually_exclusive requires
+// exactly one of its fields.
+
+// groupsTagName is the struct tag declaring field groups
+const groupsTagName = "groups"
+
+// groupKinds builds the struct-level function checking each kind of group
+var groupKinds = map[string]func(fields ...string) StructLevelValidationFunc{
+	"at_least_one_of":    AtLeastOneOf,
+	"mutually_exclusive": MutuallyExclusive,
+}
+
+// MutuallyExclusive allows at most one of the fields to be set: non-zero, or
+// non-empty for strings, slices and maps. The error is reported on the first
+// field.
+func MutuallyExclusive(fields ...string) StructLevelValidationFunc {
+	param := strings.Join(fields, " ")
+	return func(sl StructLevel) {
+		set := 0
+		for _, name := range fields {
+			if isSetValue(sl.FieldByName(name)) {
+				set++
+			}
+		}
+		if set > 1 {
+			sl.ReportValidationError(ValidationError{StructField: fields[0], Tag: "mutually_exclusive", Param: param})
+		}
+	}
+}
+
+// fieldGroups returns the groups declared on typ in declaration order, as
+// kind=fields strings
+func fieldGroups(typ reflect.Type) []string {
+	var groups []string
+	for i := 0; i < typ.NumField(); i++ {
+		for _, group := range strings.Split(typ.Field(i).Tag.Get(groupsTagName), ";") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}
+
+// compileFieldGroups returns a struct-level function checking the groups
+// declared on typ, nil when there are none
+func compileFieldGroups(typ reflect.Type) (StructLevelValidationFunc, error) {
+	groups := fieldGroups(typ)
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	fns := make([]StructLevelValidationFunc, 0, len(groups))
+	for _, group := range groups {
+		kind, list, _ := strings.Cut(group, "=")
+		build, ok := groupKinds[strings.TrimSpace(kind)]
+		if !ok {
+			return nil, fmt.Errorf("%w in %s: %s: unknown group %q", ErrInvalidFieldGroup, typ, group, strings.TrimSpace(kind))
+		}
+		fields := strings.Fields(list)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%w in %s: %s: a group needs at least two fields", ErrInvalidFieldGroup, typ, group)
+		}
+		for _, name := range fields {
+			if field, found := typ.FieldByName(name); !found || !field.IsExported() {
+				return nil, fmt.Errorf("%w in %s: %s: no exported field %s", ErrInvalidFieldGroup, typ, group, name)
+			}
+		}
+		fns = append(fns, build(fields...))
+	}
+	return StructRules(fns...), nil
+}
+
+// checkFieldGroups checks the groups declared on typ and its nested struct
+// types
+func (v *Validator) checkFieldGroups(typ reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+
+	if _, err := compileFieldGroups(typ); err != nil {
+		return err
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || v.isIgnoredField(field.Name) {
+			continue
+		}
+		nested := indirectType(field.Type)
+		switch nested.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			nested = indirectType(nested.Elem())
+		}
+		if nested.Kind() == reflect.Struct {
+			if err := v.checkFieldGroups(nested, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Hooks receives notifications about validation calls so that metrics, traces
+// or logs can be recorded without wrapping every Struct and Var call site.
+// Implementations must be safe for concurrent use.
+type Hooks interface {
+	// OnValidateStart is called before a Struct or Var call runs its rules.
+	// The returned context is passed to every later notification of the call,
+	// which lets tracers carry their span; return ctx unchanged otherwise.
+	OnValidateStart(ctx context.Context, info ValidationInfo) context.Context
+	// OnValidateEnd is called once the call has finished with the errors it produced
+	OnValidateEnd(ctx context.Context, info ValidationInfo, duration time.Duration, errs ValidationErrors)
+	// OnRuleFail is called for every validation error reported by the call
+	OnRuleFail(ctx context.Context, info ValidationInfo, err ValidationError)
+}
+
+// RuleHooks is optionally implemented by Hooks that observe every individual
+// rule evaluation, e.g. to emit spans for expensive rules. Rules are only
+// timed when the installed hooks implement it.
+type RuleHooks interface {
+	Hooks
+	OnRuleEvaluated(ctx context.Context, info ValidationInfo, eval RuleEvaluation)
+}
+
+// ValidationInfo describes a single Struct or Var call
+type ValidationInfo struct {
+	Kind       string // "struct" or "var"
+	TypeName   string // validated type, e.g. "config.ServerConfig"
+	Tag        string // validation tag for Var calls
+	FieldCount int    // number of fields of the top-level struct
+}
+
+// RuleEvaluation describes a single rule run against a field
+type RuleEvaluation struct {
+	Field     string
+	Namespace string // path of the field from the validated struct, e.g. servers[1].port
+	Tag       string
+	Param     string
+	Start     time.Time
+	Duration  time.Duration
+	Passed    bool
+}
+
+// Validation call kinds reported in ValidationInfo.Kind
+const (
+	KindStruct = "struct"
+	KindVar    = "var"
+)
+
+// SetHooks installs hooks notified on every validation call. Pass nil to remove them.
+func (v *Validator) SetHooks(hooks Hooks) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.hooks = hooks
+	v.publish()
+}
+
+// SetHooks installs hooks on the default validator
+func SetHooks(hooks Hooks) {
+	defaultValidator.SetHooks(hooks)
+}
+
+// MultiHooks fans notifications out to several hooks in order. The result
+// implements RuleHooks when any of the given hooks does.
+func MultiHooks(hooks ...Hooks) Hooks {
+	filtered := make(multiHooks, 0, len(hooks))
+	observesRules := false
+	for _, h := range hooks {
+		if h != nil {
+			filtered = append(filtered, h)
+			if _, ok := h.(RuleHooks); ok {
+				observesRules = true
+			}
+		}
+	}
+	if observesRules {
+		return multiRuleHooks{filtered}
+	}
+	return filtered
+}
+
+type multiHooks []Hooks
+
+func (m multiHooks) OnValidateStart(ctx context.Context, info ValidationInfo) context.Context {
+	for _, h := range m {
+		if next := h.OnValidateStart(ctx, info); next != nil {
+			ctx = next
+		}
+	}
+	return ctx
+}
+
+func (m multiHooks) OnValidateEnd(ctx context.Context, info ValidationInfo, duration time.Duration, errs ValidationErrors) {
+	for _, h := range m {
+		h.OnValidateEnd(ctx, info, duration, errs)
+	}
+}
+
+func (m multiHooks) OnRuleFail(ctx context.Context, info ValidationInfo, err ValidationError) {
+	for _, h := range m {
+		h.OnRuleFail(ctx, info, err)
+	}
+}
+
+type multiRuleHooks struct {
+	multiHooks
+}
+
+func (m multiRuleHooks) OnRuleEvaluated(ctx context.Context, info ValidationInfo, eval RuleEvaluation) {
+	for _, h := range m.multiHooks {
+		if rh, ok := h.(RuleHooks); ok {
+			rh.OnRuleEvaluated(ctx, info, eval)
+		}
+	}
+}
+
+// startHooks notifies hooks that a call is starting. It returns a per-call
+// copy of the validator carrying the call context and the start time.
+func (v *Validator) startHooks(ctx context.Context, info ValidationInfo) (*Validator, time.Time) {
+	call := v.clone()
+	call.info = info
+	call.ctx = ctx
+	if next := v.hooks.OnValidateStart(ctx, info); next != nil {
+		call.ctx = next
+	}
+	call.ruleHooks, _ = v.hooks.(RuleHooks)
+	return call, time.Now()
+}
+
+// endHooks reports rule failures and the end of a call to the installed hooks
+func (v *Validator) endHooks(start time.Time, collector *ErrorCollector) {
+	duration := time.Since(start)
+	var errs ValidationErrors
+	if collector.HasErrors() {
+		errs = collector.Errors()
+		for _, err := range errs {
+			v.hooks.OnRuleFail(v.ctx, v.info, err)
+		}
+	}
+	v.hooks.OnValidateEnd(v.ctx, v.info, duration, errs)
+}
+
+// evaluateRule runs a rule, timing it for RuleHooks when they are installed.
+// namespace is the namespace of the collector the rule reports to.
+func (v *Validator) evaluateRule(fn ValidationFunc, fl *fieldLevel, namespace string) (bool, error) {
+	if v.ruleHooks == nil {
+		return callRule(fn, fl)
+	}
+
+	start := time.Now()
+	ok, err := callRule(fn, fl)
+	v.ruleHooks.OnRuleEvaluated(v.ctx, v.info, RuleEvaluation{
+		Field:     fl.fieldName,
+		Namespace: fieldPath(namespace, fl.fieldName),
+		Tag:       fl.tag,
+		Param:     fl.param,
+		Start:     start,
+		Duration:  time.Since(start),
+		Passed:    ok && err == nil,
+	})
+	return ok, err
+}
+
+// fieldPath joins a namespace and a field name. Dive elements are named by
+// their full path already.
+func fieldPath(namespace, field string) string {
+	if namespace == "" || strings.HasPrefix(field, namespace+".") || strings.HasPrefix(field, namespace+"[") {
+		return field
+	}
+	return namespace + "." + field
+}
+
+// typeName returns the name reported to hooks for a validated value
+func typeName(val reflect.Value) string {
+	if !val.IsValid() {
+		return "nil"
+	}
+	return val.Type().String()
+}
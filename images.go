@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The image rules follow the reference grammar of the OCI distribution
+// spec: an optional registry host, a lowercase repository path, and an
+// optional tag and digest, as in registry.example.com:5000/team/app:1.2@sha256:...
+
+var (
+	// imageReference matches a reference, capturing its name, tag and digest
+	imageReference = regexp.MustCompile(`^(` +
+		// Registry: a hostname or bracketed IPv6 address with an optional port
+		`(?:(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*|\[[a-fA-F0-9:]+\])(?::[0-9]+)?/)?` +
+		// Repository: path components separated by slashes
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		`)(?::([\w][\w.-]{0,127}))?(?:@([A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}))?$`)
+
+	// imageTag matches a tag such as latest or v1.2.3
+	imageTag = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+	// sha256Digest matches a digest in the canonical form of sha256
+	sha256Digest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// maxImageNameLength is the longest repository name, registry included
+const maxImageNameLength = 255
+
+// validImageRef reports whether value is an image reference. mode "tagged"
+// requires a tag or digest and "digest" a digest, so deployments can demand
+// references that cannot move.
+func validImageRef(value, mode string) bool {
+	match := imageReference.FindStringSubmatch(value)
+	if match == nil || len(match[1]) > maxImageNameLength {
+		return false
+	}
+	tag, digest := match[2], match[3]
+	if strings.HasPrefix(digest, "sha256:") && !sha256Digest.MatchString(digest) {
+		return false
+	}
+	switch mode {
+	case "tagged":
+		return tag != "" || digest != ""
+	case "digest":
+		return digest != ""
+	}
+	return true
+}
+
+// isImageRef validates an image reference in the mode given by the parameter
+func isImageRef(fl FieldLevel) bool {
+	return validImageRef(getString(fl.Field()), fl.Param())
+}
+
+// isImageTag validates an image tag
+func isImageTag(fl FieldLevel) bool {
+	return imageTag.MatchString(getString(fl.Field()))
+}
+
+// isSHA256Digest validates a digest such as sha256:e3b0c442...
+func isSHA256Digest(fl FieldLevel) bool {
+	return sha256Digest.MatchString(getString(fl.Field()))
+}
@@ -3,13 +3,19 @@
 package analyzer
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/mateothegreat/go-validation"
 )
 
 // ConfigAnalyzer analyzes Go configuration structs and extracts validation metadata
@@ -20,18 +26,36 @@ type ConfigAnalyzer struct {
 	structs      map[string]*StructInfo
 	dependencies map[string][]string // struct dependency graph
 	yamlPaths    map[string]string   // field to YAML path mapping
+	yamlSites    map[string]string   // field chain from a root struct to YAML path
+	options      AnalyzerOptions
+	root         string                  // analyzed directory
+	packages     map[string]*PackageInfo // packages by relative directory
 }
 
 // StructInfo represents analyzed struct information
 type StructInfo struct {
-	Name           string
-	Package        string
-	Fields         []FieldInfo
-	Position       token.Pos
-	IsConfig       bool
-	YAMLPath       string
-	Dependencies   []string // nested struct dependencies
-	ValidationTags map[string][]ValidationRule
+	Name            string
+	Package         string
+	Fields          []FieldInfo
+	Position        token.Pos
+	IsConfig        bool
+	YAMLPath        string
+	Dependencies    []string // nested struct dependencies
+	ValidationTags  map[string][]ValidationRule
+	EvaluationOrder []int // field indexes, referenced fields before the fields referencing them
+}
+
+// OrderedFields returns the fields in evaluation order: declaration order,
+// except that fields referenced by cross-field rules come first
+func (si *StructInfo) OrderedFields() []FieldInfo {
+	if len(si.EvaluationOrder) != len(si.Fields) {
+		return si.Fields
+	}
+	fields := make([]FieldInfo, len(si.Fields))
+	for i, index := range si.EvaluationOrder {
+		fields[i] = si.Fields[index]
+	}
+	return fields
 }
 
 // FieldInfo represents analyzed field information
@@ -41,6 +65,8 @@ type FieldInfo struct {
 	GoType          GoType
 	ValidationRules []ValidationRule
 	YAMLTag         string
+	TOMLTag         string // key name of the toml tag
+	JSONTag         string // key name of the json tag
 	EnvTag          string
 	DefaultValue    string
 	Position        token.Pos
@@ -49,6 +75,7 @@ type FieldInfo struct {
 	NestedType      string
 	IsSlice         bool
 	IsMap           bool
+	IsInline        bool // yaml:",inline" merges the field's keys into its parent
 	KeyType         string
 	ElementType     string
 }
@@ -64,6 +91,32 @@ type GoType struct {
 	IsInterface bool
 	KeyType     *GoType
 	ElemType    *GoType
+	IsNullable  bool   // sql.Null* or validation.Optional: ElemType is the contained value
+	ValueField  string // field holding the contained value of a nullable type
+}
+
+// sqlNullValueFields maps the database/sql null types to their value fields
+var sqlNullValueFields = map[string]string{
+	"NullString":  "String",
+	"NullInt64":   "Int64",
+	"NullInt32":   "Int32",
+	"NullInt16":   "Int16",
+	"NullByte":    "Byte",
+	"NullFloat64": "Float64",
+	"NullBool":    "Bool",
+	"NullTime":    "Time",
+}
+
+// sqlNullElemTypes are the contained types of the database/sql null types
+var sqlNullElemTypes = map[string]GoType{
+	"NullString":  {Kind: TypeString, Name: "string"},
+	"NullInt64":   {Kind: TypeInt64, Name: "int64"},
+	"NullInt32":   {Kind: TypeInt32, Name: "int32"},
+	"NullInt16":   {Kind: TypeInt16, Name: "int16"},
+	"NullByte":    {Kind: TypeUint8, Name: "byte"},
+	"NullFloat64": {Kind: TypeFloat64, Name: "float64"},
+	"NullBool":    {Kind: TypeBool, Name: "bool"},
+	"NullTime":    {Kind: TypeStruct, Name: "Time", Package: "time"},
 }
 
 // TypeKind represents the fundamental type categories
@@ -105,10 +158,12 @@ type ValidationRule struct {
 // AnalysisResult contains the complete analysis results
 type AnalysisResult struct {
 	Structs      map[string]*StructInfo
+	Packages     map[string]*PackageInfo // structs grouped by relative package directory
 	Dependencies map[string][]string
-	YAMLPaths    map[string]string
+	YAMLPaths    map[string]string // Struct.Field to the YAML path of its first usage site
+	YAMLSites    map[string]string // Root.Field.Field... chain to YAML path, one per usage site
 	Imports      []string
-	PackageName  string
+	PackageName  string // package of the analyzed directory
 }
 
 // NewConfigAnalyzer creates a new configuration analyzer
@@ -119,12 +174,19 @@ func NewConfigAnalyzer() *ConfigAnalyzer {
 		structs:      make(map[string]*StructInfo),
 		dependencies: make(map[string][]string),
 		yamlPaths:    make(map[string]string),
+		yamlSites:    make(map[string]string),
+		packages:     make(map[string]*PackageInfo),
 	}
 }
 
 // AnalyzeDirectory analyzes all Go files in a directory for config structs
 func (ca *ConfigAnalyzer) AnalyzeDirectory(dir string) (*AnalysisResult, error) {
+	if err := ca.checkPatterns(); err != nil {
+		return nil, err
+	}
+
 	// Parse all Go files in directory
+	ca.root = dir
 	if err := ca.parseDirectory(dir); err != nil {
 		return nil, fmt.Errorf("failed to parse directory: %w", err)
 	}
@@ -137,6 +199,16 @@ func (ca *ConfigAnalyzer) AnalyzeDirectory(dir string) (*AnalysisResult, error)
 	// Build dependency graph
 	ca.buildDependencyGraph()
 
+	// Reject cross-field rules that reference each other in a cycle
+	if err := ca.checkFieldDependencyCycles(); err != nil {
+		return nil, fmt.Errorf("invalid cross-field dependencies: %w", err)
+	}
+
+	// Reject rule parameters their schemas do not accept, e.g. min=abc
+	if err := ca.checkRuleParams(); err != nil {
+		return nil, fmt.Errorf("invalid rule parameters: %w", err)
+	}
+
 	// Generate YAML path mappings
 	ca.generateYAMLPaths()
 
@@ -145,8 +217,10 @@ func (ca *ConfigAnalyzer) AnalyzeDirectory(dir string) (*AnalysisResult, error)
 
 	return &AnalysisResult{
 		Structs:      ca.structs,
+		Packages:     ca.packages,
 		Dependencies: ca.dependencies,
 		YAMLPaths:    ca.yamlPaths,
+		YAMLSites:    ca.yamlSites,
 		Imports:      ca.extractRequiredImports(),
 		PackageName:  ca.packageName,
 	}, nil
@@ -162,15 +236,30 @@ func (ca *ConfigAnalyzer) AnalyzeFile(filename string) (*AnalysisResult, error)
 
 	ca.parsedFiles[filename] = file
 	ca.packageName = file.Name.Name
+	ca.root = filepath.Dir(filename)
 
 	// Extract struct information from the file
-	if err := ca.extractStructsFromFile(file); err != nil {
+	pkg, err := ca.packageFor(".", file.Name.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.extractStructsFromFile(file, pkg); err != nil {
 		return nil, fmt.Errorf("failed to extract structs from file: %w", err)
 	}
 
 	// Build dependency graph
 	ca.buildDependencyGraph()
 
+	// Reject cross-field rules that reference each other in a cycle
+	if err := ca.checkFieldDependencyCycles(); err != nil {
+		return nil, fmt.Errorf("invalid cross-field dependencies: %w", err)
+	}
+
+	// Reject rule parameters their schemas do not accept, e.g. min=abc
+	if err := ca.checkRuleParams(); err != nil {
+		return nil, fmt.Errorf("invalid rule parameters: %w", err)
+	}
+
 	// Generate YAML path mappings
 	ca.generateYAMLPaths()
 
@@ -179,8 +268,10 @@ func (ca *ConfigAnalyzer) AnalyzeFile(filename string) (*AnalysisResult, error)
 
 	return &AnalysisResult{
 		Structs:      ca.structs,
+		Packages:     ca.packages,
 		Dependencies: ca.dependencies,
 		YAMLPaths:    ca.yamlPaths,
+		YAMLSites:    ca.yamlSites,
 		Imports:      ca.extractRequiredImports(),
 		PackageName:  ca.packageName,
 	}, nil
@@ -193,7 +284,21 @@ func (ca *ConfigAnalyzer) parseDirectory(dir string) error {
 			return err
 		}
 
-		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		// Skip vendored, test data and excluded directories below the root
+		if info.IsDir() {
+			if rel != "." && ca.skipDir(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") || !ca.includeFile(rel) {
 			return nil
 		}
 
@@ -203,42 +308,74 @@ func (ca *ConfigAnalyzer) parseDirectory(dir string) error {
 		}
 
 		ca.parsedFiles[path] = file
-		if ca.packageName == "" {
-			ca.packageName = file.Name.Name
-		}
-
 		return nil
 	})
 }
 
 // extractStructs extracts struct information from all parsed files
 func (ca *ConfigAnalyzer) extractStructs() error {
-	for _, file := range ca.parsedFiles {
-		if err := ca.extractStructsFromFile(file); err != nil {
+	for _, filename := range ca.sortedFiles() {
+		file := ca.parsedFiles[filename]
+		pkg, err := ca.packageFor(ca.relativeDir(filename), file.Name.Name)
+		if err != nil {
+			return err
+		}
+		if err := ca.extractStructsFromFile(file, pkg); err != nil {
 			return err
 		}
 	}
+	ca.packageName = ca.rootPackageName()
 	return nil
 }
 
-// extractStructsFromFile extracts struct information from a single file
-func (ca *ConfigAnalyzer) extractStructsFromFile(file *ast.File) error {
+// extractStructsFromFile extracts struct information from a single file of
+// a package
+func (ca *ConfigAnalyzer) extractStructsFromFile(file *ast.File, pkg *PackageInfo) error {
+	var err error
 	ast.Inspect(file, func(node ast.Node) bool {
-		if typeSpec, ok := node.(*ast.TypeSpec); ok {
-			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-				structInfo := ca.analyzeStruct(typeSpec.Name.Name, structType)
-				if structInfo != nil {
-					ca.structs[structInfo.Name] = structInfo
-				}
+		decl, ok := node.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE || err != nil {
+			return err == nil
+		}
+		for _, spec := range decl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			var d directives
+			if d, err = ca.parseDirectives(typeDoc(decl, typeSpec)); err != nil {
+				return false
+			}
+			if d.skip {
+				continue
+			}
+
+			var structInfo *StructInfo
+			if structInfo, err = ca.analyzeStruct(typeSpec.Name.Name, structType); err != nil {
+				return false
 			}
+			if structInfo == nil {
+				continue
+			}
+			// Analysis links structs by name, so names must be unique across packages
+			if _, exists := ca.structs[structInfo.Name]; exists {
+				err = fmt.Errorf("struct %s is declared in both %s and %s, exclude one of them", structInfo.Name, ca.structDir(structInfo.Name), pkg.Dir)
+				return false
+			}
+			structInfo.Package = pkg.Name
+			structInfo.YAMLPath = d.rename
+			pkg.Structs[structInfo.Name] = structInfo
+			ca.structs[structInfo.Name] = structInfo
 		}
 		return true
 	})
-	return nil
+	return err
 }
 
 // analyzeStruct analyzes a single struct and extracts validation information
-func (ca *ConfigAnalyzer) analyzeStruct(name string, structType *ast.StructType) *StructInfo {
+func (ca *ConfigAnalyzer) analyzeStruct(name string, structType *ast.StructType) (*StructInfo, error) {
 	structInfo := &StructInfo{
 		Name:           name,
 		Package:        ca.packageName,
@@ -250,7 +387,10 @@ func (ca *ConfigAnalyzer) analyzeStruct(name string, structType *ast.StructType)
 	hasConfigTags := false
 
 	for _, field := range structType.Fields.List {
-		fieldInfo := ca.analyzeField(field)
+		fieldInfo, err := ca.analyzeField(field)
+		if err != nil {
+			return nil, err
+		}
 		if fieldInfo != nil {
 			structInfo.Fields = append(structInfo.Fields, *fieldInfo)
 			if len(fieldInfo.ValidationRules) > 0 || fieldInfo.YAMLTag != "" {
@@ -260,20 +400,33 @@ func (ca *ConfigAnalyzer) analyzeStruct(name string, structType *ast.StructType)
 	}
 
 	if !hasConfigTags {
-		return nil // Not a config struct
+		return nil, nil // Not a config struct
 	}
 
 	structInfo.IsConfig = true
-	return structInfo
+	return structInfo, nil
 }
 
 // analyzeField analyzes a single struct field
-func (ca *ConfigAnalyzer) analyzeField(field *ast.Field) *FieldInfo {
-	if len(field.Names) == 0 {
-		return nil // Anonymous field, skip for now
+func (ca *ConfigAnalyzer) analyzeField(field *ast.Field) (*FieldInfo, error) {
+	// Embedded fields are only analyzed when tagged, typically yaml:",inline"
+	if len(field.Names) == 0 && field.Tag == nil {
+		return nil, nil
+	}
+
+	// Directives may appear above the field or trailing it
+	d, err := ca.parseDirectives(field.Doc, field.Comment)
+	if err != nil || d.skip {
+		return nil, err
 	}
 
-	fieldName := field.Names[0].Name
+	fieldName := embeddedFieldName(field.Type)
+	if len(field.Names) > 0 {
+		fieldName = field.Names[0].Name
+	}
+	if fieldName == "" {
+		return nil, nil
+	}
 	fieldInfo := &FieldInfo{
 		Name:     fieldName,
 		Position: field.Pos(),
@@ -287,14 +440,19 @@ func (ca *ConfigAnalyzer) analyzeField(field *ast.Field) *FieldInfo {
 	if field.Tag != nil {
 		ca.extractFieldTags(field.Tag.Value, fieldInfo)
 	}
+	if d.rename != "" {
+		fieldInfo.YAMLTag = d.rename
+		fieldInfo.TOMLTag = d.rename
+		fieldInfo.JSONTag = d.rename
+	}
 
 	// Determine if field is nested config
-	if fieldInfo.GoType.Kind == TypeStruct && !ca.isBuiltinType(fieldInfo.GoType.Name) {
+	if fieldInfo.GoType.Kind == TypeStruct && !fieldInfo.GoType.IsNullable && !ca.isBuiltinType(fieldInfo.GoType.Name) {
 		fieldInfo.IsNested = true
 		fieldInfo.NestedType = fieldInfo.GoType.Name
 	}
 
-	return fieldInfo
+	return fieldInfo, nil
 }
 
 // analyzeGoType analyzes a Go type expression and returns detailed type information
@@ -341,6 +499,17 @@ func (ca *ConfigAnalyzer) analyzeGoType(expr ast.Expr) GoType {
 
 	case *ast.SelectorExpr:
 		if pkg, ok := t.X.(*ast.Ident); ok {
+			if value, ok := sqlNullValueFields[t.Sel.Name]; ok && pkg.Name == "sql" {
+				elemType := sqlNullElemTypes[t.Sel.Name]
+				return GoType{
+					Kind:       TypeStruct,
+					Name:       t.Sel.Name,
+					Package:    pkg.Name,
+					ElemType:   &elemType,
+					IsNullable: true,
+					ValueField: value,
+				}
+			}
 			return GoType{
 				Kind:    TypeStruct, // Assume external types are structs
 				Name:    t.Sel.Name,
@@ -348,6 +517,22 @@ func (ca *ConfigAnalyzer) analyzeGoType(expr ast.Expr) GoType {
 			}
 		}
 
+	case *ast.IndexExpr:
+		// validation.Optional[T] and sql.Null[T] hold their value in V
+		if sel, ok := t.X.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && (sel.Sel.Name == "Optional" || sel.Sel.Name == "Null" && pkg.Name == "sql") {
+				elemType := ca.analyzeGoType(t.Index)
+				return GoType{
+					Kind:       TypeStruct,
+					Name:       sel.Sel.Name + "[" + elemType.Name + "]",
+					Package:    pkg.Name,
+					ElemType:   &elemType,
+					IsNullable: true,
+					ValueField: "V",
+				}
+			}
+		}
+
 	case *ast.InterfaceType:
 		return GoType{
 			Kind:        TypeInterface,
@@ -417,8 +602,10 @@ func (ca *ConfigAnalyzer) goTypeToString(goType GoType) string {
 
 // extractFieldTags extracts validation and configuration tags from a field
 func (ca *ConfigAnalyzer) extractFieldTags(tagValue string, fieldInfo *FieldInfo) {
-	// Remove backticks
-	tagValue = strings.Trim(tagValue, "`")
+	// Unquote the raw or interpreted string literal
+	if unquoted, err := strconv.Unquote(tagValue); err == nil {
+		tagValue = unquoted
+	}
 
 	// Parse individual tags
 	tags := ca.parseStructTags(tagValue)
@@ -431,6 +618,15 @@ func (ca *ConfigAnalyzer) extractFieldTags(tagValue string, fieldInfo *FieldInfo
 	// Extract YAML tag
 	if yamlTag, exists := tags["yaml"]; exists {
 		fieldInfo.YAMLTag = ca.parseYAMLTag(yamlTag)
+		fieldInfo.IsInline = hasTagOption(yamlTag, "inline")
+	}
+
+	// Extract the key names of the other config formats
+	if tomlTag, exists := tags["toml"]; exists {
+		fieldInfo.TOMLTag = ca.parseYAMLTag(tomlTag)
+	}
+	if jsonTag, exists := tags["json"]; exists {
+		fieldInfo.JSONTag = ca.parseYAMLTag(jsonTag)
 	}
 
 	// Extract environment variable tag
@@ -447,16 +643,54 @@ func (ca *ConfigAnalyzer) extractFieldTags(tagValue string, fieldInfo *FieldInfo
 	fieldInfo.IsOptional = ca.isFieldOptional(fieldInfo.ValidationRules)
 }
 
-// parseStructTags parses struct tag string into key-value pairs
+// parseStructTags parses a struct tag into key-value pairs the way
+// reflect.StructTag.Lookup does: space-separated key:"value" pairs whose
+// quoted values may contain spaces and escapes. Parsing stops at the first
+// malformed pair, and the first occurrence of a key wins.
 func (ca *ConfigAnalyzer) parseStructTags(tagStr string) map[string]string {
 	tags := make(map[string]string)
 
-	// Simple tag parsing - this could be enhanced for more complex cases
-	parts := strings.Fields(tagStr)
-	for _, part := range parts {
-		if colonIdx := strings.Index(part, ":"); colonIdx != -1 {
-			key := part[:colonIdx]
-			value := strings.Trim(part[colonIdx+1:], `"`)
+	for tagStr != "" {
+		// Skip leading space
+		i := 0
+		for i < len(tagStr) && tagStr[i] == ' ' {
+			i++
+		}
+		tagStr = tagStr[i:]
+		if tagStr == "" {
+			break
+		}
+
+		// Scan to colon; a space, a quote or a control character is a syntax error
+		i = 0
+		for i < len(tagStr) && tagStr[i] > ' ' && tagStr[i] != ':' && tagStr[i] != '"' && tagStr[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tagStr) || tagStr[i] != ':' || tagStr[i+1] != '"' {
+			break
+		}
+		key := tagStr[:i]
+		tagStr = tagStr[i+1:]
+
+		// Scan quoted string to find value
+		i = 1
+		for i < len(tagStr) && tagStr[i] != '"' {
+			if tagStr[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tagStr) {
+			break
+		}
+		quoted := tagStr[:i+1]
+		tagStr = tagStr[i+1:]
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+		if _, exists := tags[key]; !exists {
 			tags[key] = value
 		}
 	}
@@ -469,13 +703,19 @@ func (ca *ConfigAnalyzer) parseValidationRules(validateTag string) []ValidationR
 	var rules []ValidationRule
 
 	// Split by comma and parse each rule
-	ruleParts := strings.Split(validateTag, ",")
+	ruleParts := validation.SplitRules(validateTag)
 	for i, rulePart := range ruleParts {
 		rulePart = strings.TrimSpace(rulePart)
 		if rulePart == "" || rulePart == "-" {
 			continue
 		}
 
+		// Generated validators apply the default scenario, which skips
+		// scenario rules such as required@create
+		if scenarioRule.MatchString(rulePart) {
+			continue
+		}
+
 		rule := ValidationRule{
 			Priority: i, // Maintain order for optimization
 		}
@@ -488,20 +728,28 @@ func (ca *ConfigAnalyzer) parseValidationRules(validateTag string) []ValidationR
 			rule.Name = rulePart
 		}
 
-		// Determine if rule is conditional
-		rule.IsConditional = ca.isConditionalRule(rule.Name)
+		// Conditional and cross-field rules depend on the value of another field
+		rule.IsConditional = ca.isConditionalRule(rule.Name) || ca.isCrossFieldRule(rule.Name)
 
 		// Extract dependencies for cross-field validation
 		if ca.isCrossFieldRule(rule.Name) {
 			rule.DependsOn = ca.extractCrossFieldDependencies(rule)
 		}
 
+		// A $Field parameter reads a sibling field at validation time
+		if name, env, ok := validation.ParamPlaceholder(rule.Parameter); ok && !env {
+			rule.DependsOn = []string{name}
+		}
+
 		rules = append(rules, rule)
 	}
 
 	return rules
 }
 
+// scenarioRule matches rules suffixed with @scenario or @scenario1|scenario2
+var scenarioRule = regexp.MustCompile(`.@[A-Za-z][A-Za-z0-9_-]*(\|[A-Za-z][A-Za-z0-9_-]*)*$`)
+
 // parseYAMLTag extracts the YAML field name from yaml tag
 func (ca *ConfigAnalyzer) parseYAMLTag(yamlTag string) string {
 	// Handle yaml:"field_name,omitempty" format
@@ -585,40 +833,379 @@ func (ca *ConfigAnalyzer) buildDependencyGraph() {
 	}
 }
 
-// generateYAMLPaths generates YAML path mappings for configuration fields
+// ErrDependencyCycle is returned when cross-field rules reference each other
+// in a cycle, or a field references itself
+var ErrDependencyCycle = errors.New("cross-field dependency cycle")
+
+// checkFieldDependencyCycles reports the first cross-field dependency cycle
+// found in any analyzed struct
+func (ca *ConfigAnalyzer) checkFieldDependencyCycles() error {
+	names := make([]string, 0, len(ca.structs))
+	for name := range ca.structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if cycle := findFieldCycle(ca.structs[name]); cycle != nil {
+			return fmt.Errorf("%w in %s: %s", ErrDependencyCycle, name, strings.Join(cycle, " -> "))
+		}
+		ca.structs[name].EvaluationOrder = evaluationOrder(ca.structs[name])
+	}
+	return nil
+}
+
+// checkRuleParams reports the first rule whose parameter does not match the
+// parameter schema the validation package declares for it
+func (ca *ConfigAnalyzer) checkRuleParams() error {
+	names := make([]string, 0, len(ca.structs))
+	for name := range ca.structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, field := range ca.structs[name].Fields {
+			for _, rule := range field.ValidationRules {
+				err := validation.CheckRuleParam(rule.Name, rule.Parameter)
+				if err == nil {
+					continue
+				}
+				tag := rule.Name
+				if rule.Parameter != "" {
+					tag += "=" + rule.Parameter
+				}
+				return fmt.Errorf("%w in %s.%s: %s: %v", validation.ErrInvalidRuleParam, name, field.Name, tag, err)
+			}
+		}
+	}
+	return nil
+}
+
+// evaluationOrder returns field indexes in declaration order, except that
+// fields referenced by cross-field rules come before the fields referencing
+// them. The struct must be acyclic.
+func evaluationOrder(structInfo *StructInfo) []int {
+	byName := make(map[string]int, len(structInfo.Fields))
+	for i, field := range structInfo.Fields {
+		byName[field.Name] = i
+	}
+
+	order := make([]int, 0, len(structInfo.Fields))
+	placed := make([]bool, len(structInfo.Fields))
+	var place func(i int)
+	place = func(i int) {
+		if placed[i] {
+			return
+		}
+		placed[i] = true
+		for _, rule := range structInfo.Fields[i].ValidationRules {
+			for _, dep := range orderingDependencies(rule) {
+				if j, ok := byName[dep]; ok {
+					place(j)
+				}
+			}
+		}
+		order = append(order, i)
+	}
+
+	for i := range structInfo.Fields {
+		place(i)
+	}
+	return order
+}
+
+// orderingDependencies returns the sibling fields a rule must be evaluated
+// after. required_with/required_without only test presence and are commonly
+// paired in both directions to express "one of", so they never form cycles.
+func orderingDependencies(rule ValidationRule) []string {
+	switch rule.Name {
+	case "required_with", "required_without":
+		return nil
+	}
+	return rule.DependsOn
+}
+
+// findFieldCycle returns the fields forming a dependency cycle, with the
+// first field repeated at the end, or nil when the struct is acyclic
+func findFieldCycle(structInfo *StructInfo) []string {
+	edges := make(map[string][]string, len(structInfo.Fields))
+	for _, field := range structInfo.Fields {
+		for _, rule := range field.ValidationRules {
+			edges[field.Name] = append(edges[field.Name], orderingDependencies(rule)...)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(edges))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range edges[name] {
+			switch state[dep] {
+			case visiting:
+				for i, field := range path {
+					if field == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, field := range structInfo.Fields {
+		if state[field.Name] == unvisited {
+			if cycle := visit(field.Name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// generateYAMLPaths generates YAML path mappings for configuration fields,
+// starting from the structs no other struct references
 func (ca *ConfigAnalyzer) generateYAMLPaths() {
+	referenced := make(map[string]bool)
 	for _, structInfo := range ca.structs {
-		ca.generateStructYAMLPaths(structInfo, "")
+		for _, field := range structInfo.Fields {
+			if nested, _ := ca.yamlElemStruct(field.GoType); nested != "" {
+				referenced[nested] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(ca.structs))
+	for name := range ca.structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Structs only reachable through a cycle are walked as roots last
+	visited := make(map[string]bool)
+	for _, roots := range [][]string{filterNames(names, func(name string) bool { return !referenced[name] }), names} {
+		for _, name := range roots {
+			if !visited[name] {
+				ca.generateStructYAMLPaths(ca.structs[name], ca.structs[name].YAMLPath, name, visited, map[string]bool{})
+			}
+		}
 	}
 }
 
-// generateStructYAMLPaths generates YAML paths for a struct recursively
-func (ca *ConfigAnalyzer) generateStructYAMLPaths(structInfo *StructInfo, prefix string) {
+// generateStructYAMLPaths generates YAML paths for a struct recursively,
+// recording one path per usage site since a struct shared by several parents
+// lives under several prefixes. Collection elements are addressed with
+// YAMLIndexPlaceholder, inline fields share the path of their parent, and
+// chain guards against recursive types.
+func (ca *ConfigAnalyzer) generateStructYAMLPaths(structInfo *StructInfo, prefix, site string, visited, chain map[string]bool) {
+	visited[structInfo.Name] = true
+	chain[structInfo.Name] = true
+	defer delete(chain, structInfo.Name)
+
 	for _, field := range structInfo.Fields {
 		yamlName := field.YAMLTag
+		if yamlName == "-" {
+			continue // Not loaded from YAML
+		}
 		if yamlName == "" {
 			yamlName = strings.ToLower(field.Name)
 		}
 
-		var fullPath string
-		if prefix == "" {
-			fullPath = yamlName
-		} else {
-			fullPath = prefix + "." + yamlName
+		fullPath := joinYAMLPath(prefix, yamlName)
+		if field.IsInline {
+			fullPath = prefix
 		}
 
+		fieldSite := site + "." + field.Name
+		ca.yamlSites[fieldSite] = fullPath
 		fieldKey := structInfo.Name + "." + field.Name
-		ca.yamlPaths[fieldKey] = fullPath
+		if _, exists := ca.yamlPaths[fieldKey]; !exists {
+			ca.yamlPaths[fieldKey] = fullPath
+		}
+
+		// Recurse into nested structs, through pointers and collections
+		nested, suffix := ca.yamlElemStruct(field.GoType)
+		if nestedStruct, exists := ca.structs[nested]; exists && !chain[nested] {
+			ca.generateStructYAMLPaths(nestedStruct, fullPath+suffix, fieldSite+suffix, visited, chain)
+		}
+	}
+}
+
+// YAMLIndexPlaceholder stands for the index or key of a slice, array or map
+// element in generated YAML paths, e.g. server.hosts[*].name
+const YAMLIndexPlaceholder = "[*]"
+
+// ResolveYAMLPath replaces the index placeholders of a generated YAML path
+// with concrete indexes or keys, in order, e.g. server.hosts[*].name with 0
+// becomes server.hosts[0].name. Placeholders without a key are kept.
+func ResolveYAMLPath(path string, keys ...string) string {
+	for _, key := range keys {
+		if !strings.Contains(path, YAMLIndexPlaceholder) {
+			break
+		}
+		path = strings.Replace(path, YAMLIndexPlaceholder, "["+key+"]", 1)
+	}
+	return path
+}
+
+// YAMLPathFor returns the YAML path of a field given the root struct and the
+// field's namespace below it, as reported in validation errors, e.g.
+// Servers[0].TLS.CertFile. Indexes and map keys in the namespace are carried
+// over into the path.
+func (ar *AnalysisResult) YAMLPathFor(root, namespace string) (string, bool) {
+	var site strings.Builder
+	var keys []string
+	site.WriteString(root)
+	site.WriteByte('.')
+	for namespace != "" {
+		open := strings.IndexByte(namespace, '[')
+		if open == -1 {
+			site.WriteString(namespace)
+			break
+		}
+		end := strings.IndexByte(namespace[open:], ']')
+		if end == -1 {
+			return "", false
+		}
+		site.WriteString(namespace[:open] + YAMLIndexPlaceholder)
+		keys = append(keys, namespace[open+1:open+end])
+		namespace = namespace[open+end+1:]
+	}
+
+	path, exists := ar.YAMLSites[site.String()]
+	if !exists {
+		return "", false
+	}
+	return ResolveYAMLPath(path, keys...), true
+}
+
+// FieldFor returns the analyzed field a validation error namespace below
+// the root struct names, e.g. Servers[0].TLS.CertFile
+func (ar *AnalysisResult) FieldFor(root, namespace string) (*FieldInfo, bool) {
+	chain, ok := ar.FieldChain(root, namespace)
+	if !ok {
+		return nil, false
+	}
+	return chain[len(chain)-1], true
+}
+
+// FieldChain returns the analyzed field of every segment of a validation
+// error namespace below the root struct, e.g. Servers, TLS and CertFile for
+// Servers[0].TLS.CertFile
+func (ar *AnalysisResult) FieldChain(root, namespace string) ([]*FieldInfo, bool) {
+	structInfo, exists := ar.Structs[root]
+	segments := strings.Split(namespace, ".")
+	chain := make([]*FieldInfo, 0, len(segments))
+	for i := 0; exists; i++ {
+		name, _, _ := strings.Cut(segments[i], "[")
+		field := findFieldByName(structInfo, name)
+		if field == nil {
+			return nil, false
+		}
+		chain = append(chain, field)
+		if i == len(segments)-1 {
+			return chain, true
+		}
+		nested, _ := elemStruct(ar.Structs, field.GoType)
+		structInfo, exists = ar.Structs[nested]
+	}
+	return nil, false
+}
+
+// findFieldByName returns the field of a struct with the given Go name
+func findFieldByName(structInfo *StructInfo, name string) *FieldInfo {
+	for i := range structInfo.Fields {
+		if structInfo.Fields[i].Name == name {
+			return &structInfo.Fields[i]
+		}
+	}
+	return nil
+}
+
+// yamlElemStruct returns the analyzed struct reached through pointers,
+// slices and maps of a type, with the index placeholders its elements need
+func (ca *ConfigAnalyzer) yamlElemStruct(goType GoType) (string, string) {
+	return elemStruct(ca.structs, goType)
+}
 
-		// Recurse into nested structs
-		if field.IsNested {
-			if nestedStruct, exists := ca.structs[field.NestedType]; exists {
-				ca.generateStructYAMLPaths(nestedStruct, fullPath)
+// elemStruct returns the struct of structs reached through pointers, slices
+// and maps of a type, with a YAMLIndexPlaceholder per collection level
+func elemStruct(structs map[string]*StructInfo, goType GoType) (string, string) {
+	var suffix string
+	for {
+		switch {
+		case goType.IsPointer && goType.ElemType != nil:
+			goType = *goType.ElemType
+		case (goType.IsSlice || goType.IsMap) && goType.ElemType != nil:
+			suffix += YAMLIndexPlaceholder
+			goType = *goType.ElemType
+		default:
+			if _, exists := structs[goType.Name]; exists && goType.Kind == TypeStruct {
+				return goType.Name, suffix
 			}
+			return "", ""
 		}
 	}
 }
 
+// joinYAMLPath appends a key to a YAML path
+func joinYAMLPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// filterNames returns the names keep reports true for
+func filterNames(names []string, keep func(string) bool) []string {
+	var kept []string
+	for _, name := range names {
+		if keep(name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// embeddedFieldName returns the implicit name of an embedded field
+func embeddedFieldName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// hasTagOption reports whether a comma-separated tag value lists an option
+// after its name
+func hasTagOption(tagValue, option string) bool {
+	options := strings.Split(tagValue, ",")
+	for _, opt := range options[1:] {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+	return false
+}
+
 // optimizeValidationRules optimizes validation rules for performance
 func (ca *ConfigAnalyzer) optimizeValidationRules() {
 	for _, structInfo := range ca.structs {
@@ -638,7 +1225,7 @@ func (ca *ConfigAnalyzer) optimizeFieldValidationRules(field *FieldInfo) {
 	ca.sortRulesByPriority(field.ValidationRules)
 
 	// Merge compatible rules where possible
-	field.ValidationRules = ca.mergeCompatibleRules(field.ValidationRules)
+	field.ValidationRules = ca.mergeCompatibleRules(field.GoType, field.ValidationRules)
 }
 
 // sortRulesByPriority sorts validation rules by execution priority
@@ -666,10 +1253,67 @@ func (ca *ConfigAnalyzer) sortRulesByPriority(rules []ValidationRule) {
 	}
 }
 
-// mergeCompatibleRules merges validation rules that can be combined
-func (ca *ConfigAnalyzer) mergeCompatibleRules(rules []ValidationRule) []ValidationRule {
-	// For now, return as-is. Could implement rule fusion optimizations here
-	return rules
+// mergeCompatibleRules folds the constant parameters of a field's rules:
+// duplicates and rules that can never fail for the field's type are dropped,
+// equal min and max lengths collapse into len, and min/max bounds implied by
+// len are removed. Adjacent min/max rules are fused into range checks by the
+// generator. Fields with dive keep their rules as written: all but the
+// collection rules apply to the elements, wherever they appear.
+func (ca *ConfigAnalyzer) mergeCompatibleRules(goType GoType, rules []ValidationRule) []ValidationRule {
+	for _, rule := range rules {
+		if rule.Name == "dive" {
+			return rules
+		}
+	}
+
+	lengthKind := goType.Kind == TypeString || goType.Kind == TypeSlice || goType.Kind == TypeMap
+	unsignedKind := goType.Kind >= TypeUint && goType.Kind <= TypeUint64
+
+	// Integer parameters of the length and range rules
+	bounds := make(map[string]int64)
+	for _, rule := range rules {
+		switch rule.Name {
+		case "min", "max", "len":
+			if n, err := strconv.ParseInt(rule.Parameter, 10, 64); err == nil {
+				if _, seen := bounds[rule.Name]; !seen {
+					bounds[rule.Name] = n
+				}
+			}
+		}
+	}
+	minVal, hasMin := bounds["min"]
+	maxVal, hasMax := bounds["max"]
+	lenVal, hasLen := bounds["len"]
+
+	merged := make([]ValidationRule, 0, len(rules))
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		key := rule.Name + "=" + rule.Parameter
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		n, err := strconv.ParseInt(rule.Parameter, 10, 64)
+		isBound := err == nil && (rule.Name == "min" || rule.Name == "max")
+		switch {
+		case isBound && rule.Name == "min" && n <= 0 && (lengthKind || unsignedKind):
+			// Lengths and unsigned values are never negative
+			continue
+		case isBound && lengthKind && hasLen:
+			if (rule.Name == "min" && n <= lenVal) || (rule.Name == "max" && n >= lenVal) {
+				continue
+			}
+		case isBound && lengthKind && hasMin && hasMax && minVal == maxVal:
+			if rule.Name == "max" {
+				continue
+			}
+			rule.Name = "len"
+		}
+		merged = append(merged, rule)
+	}
+
+	return merged
 }
 
 // extractRequiredImports determines what imports are needed for generated code
@@ -696,6 +1340,10 @@ func (ca *ConfigAnalyzer) extractRequiredImports() []string {
 					imports = ca.addImportIfMissing(imports, "net")
 				case "url", "uri":
 					imports = ca.addImportIfMissing(imports, "net/url")
+				case "min", "max", "len", "minrunes", "maxrunes":
+					if field.GoType.Kind == TypeString && countsRunes(rule) {
+						imports = ca.addImportIfMissing(imports, "unicode/utf8")
+					}
 				}
 			}
 		}
@@ -704,6 +1352,20 @@ func (ca *ConfigAnalyzer) extractRequiredImports() []string {
 	return imports
 }
 
+// countsRunes reports whether a length rule measures characters rather than bytes
+func countsRunes(rule ValidationRule) bool {
+	switch rule.Name {
+	case "minrunes", "maxrunes":
+		return true
+	}
+	for _, unit := range []string{":chars", ":runes", ":codepoints"} {
+		if strings.HasSuffix(strings.TrimSpace(rule.Parameter), unit) {
+			return true
+		}
+	}
+	return false
+}
+
 // addImportIfMissing adds an import if it's not already in the list
 func (ca *ConfigAnalyzer) addImportIfMissing(imports []string, newImport string) []string {
 	for _, imp := range imports {
@@ -736,6 +1398,16 @@ func (ca *ConfigAnalyzer) GetAllStructs() map[string]*StructInfo {
 	return ca.structs
 }
 
+// StructNames returns the names of the analyzed structs, sorted
+func (ar *AnalysisResult) StructNames() []string {
+	names := make([]string, 0, len(ar.Structs))
+	for name := range ar.Structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // String returns a string representation of the analysis result
 func (ar *AnalysisResult) String() string {
 	var sb strings.Builder
@@ -743,8 +1415,8 @@ func (ar *AnalysisResult) String() string {
 	sb.WriteString(fmt.Sprintf("Structs: %d\n", len(ar.Structs)))
 	sb.WriteString(fmt.Sprintf("Imports: %v\n", ar.Imports))
 
-	for name, structInfo := range ar.Structs {
-		sb.WriteString(fmt.Sprintf("  %s: %d fields\n", name, len(structInfo.Fields)))
+	for _, name := range ar.StructNames() {
+		sb.WriteString(fmt.Sprintf("  %s: %d fields\n", name, len(ar.Structs[name].Fields)))
 	}
 
 	return sb.String()
@@ -1,10 +1,14 @@
 package analyzer
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
+
+	"github.com/mateothegreat/go-validation"
 )
 
 // TestConfigAnalyzer_AnalyzeFile tests file analysis functionality
@@ -320,6 +324,48 @@ type Config struct {
 	}
 }
 
+// TestConfigAnalyzer_NullableTypes tests recognizing sql.Null* and Optional fields
+func TestConfigAnalyzer_NullableTypes(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type Config struct {
+	Name  sql.NullString              `+"`validate:\"required,min=3\"`"+`
+	Limit validation.Optional[int]     `+"`validate:\"max=5\"`"+`
+	Count sql.Null[int64]             `+"`validate:\"min=1\"`"+`
+	Other other.Type
+}
+`)
+	defer os.Remove(testFile)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to analyze file: %v", err)
+	}
+	fields := result.Structs["Config"].Fields
+
+	expected := map[string]struct {
+		value string
+		kind  TypeKind
+	}{
+		"Name":  {"String", TypeString},
+		"Limit": {"V", TypeInt},
+		"Count": {"V", TypeInt64},
+	}
+	for name, want := range expected {
+		field := findField(fields, name)
+		if !field.GoType.IsNullable || field.GoType.ValueField != want.value || field.GoType.ElemType.Kind != want.kind {
+			t.Errorf("Expected %s to be nullable with value field %s of kind %v, got %+v", name, want.value, want.kind, field.GoType)
+		}
+		if field.IsNested {
+			t.Errorf("Expected nullable field %s not to be nested", name)
+		}
+	}
+	if other := findField(fields, "Other"); other.GoType.IsNullable || !other.IsNested {
+		t.Errorf("Expected other external types to stay nested structs, got %+v", other)
+	}
+}
+
 // TestConfigAnalyzer_YAMLPaths tests YAML path generation
 func TestConfigAnalyzer_YAMLPaths(t *testing.T) {
 	testFile := createTestFile(t, `
@@ -680,4 +726,707 @@ type Config struct {
 	if _, exists := result.Structs["Config"]; exists {
 		t.Error("Should not include structs without validation tags")
 	}
-}
\ No newline at end of file
+}
+// TestConfigAnalyzer_DependencyCycles tests cross-field cycle detection
+func TestConfigAnalyzer_DependencyCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{
+			name: "mutual eqfield",
+			source: `
+type Pair struct {
+	A string ` + "`validate:\"eqfield=B\"`" + `
+	B string ` + "`validate:\"eqfield=A\"`" + `
+}`,
+			wantErr: "in Pair: A -> B -> A",
+		},
+		{
+			name: "self reference",
+			source: `
+type Single struct {
+	A string ` + "`validate:\"required,nefield=A\"`" + `
+}`,
+			wantErr: "in Single: A -> A",
+		},
+		{
+			name: "three field cycle",
+			source: `
+type Triangle struct {
+	A int ` + "`validate:\"gtfield=B\"`" + `
+	B int ` + "`validate:\"required_if=C 1\"`" + `
+	C int ` + "`validate:\"ltfield=A\"`" + `
+}`,
+			wantErr: "in Triangle: A -> B -> C -> A",
+		},
+		{
+			name: "either or presence",
+			source: `
+type Contact struct {
+	Email string ` + "`validate:\"required_without=Phone\"`" + `
+	Phone string ` + "`validate:\"required_without=Email\"`" + `
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := createTestFile(t, "package test\n"+tt.source)
+			defer os.Remove(testFile)
+
+			_, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrDependencyCycle) {
+				t.Fatalf("Expected ErrDependencyCycle, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+// TestConfigAnalyzer_RuleParams tests rule parameters are checked against their schemas
+func TestConfigAnalyzer_RuleParams(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type Limits struct {
+	Name    string `+"`validate:\"required,min=abc\"`"+`
+}
+
+type Server struct {
+	Port    int    `+"`validate:\"min=1,max=65535\"`"+`
+}`)
+	defer os.Remove(testFile)
+
+	_, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if !errors.Is(err, validation.ErrInvalidRuleParam) {
+		t.Fatalf("Expected ErrInvalidRuleParam, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "in Limits.Name: min=abc: must be a number") {
+		t.Errorf("Expected error naming Limits.Name min=abc, got %q", err.Error())
+	}
+}
+
+// TestConfigAnalyzer_ParamPlaceholders tests $Field parameters order the
+// referenced field first and placeholders pass the parameter checks
+func TestConfigAnalyzer_ParamPlaceholders(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type Profile struct {
+	Name       string `+"`validate:\"max=$MaxNameLen\"`"+`
+	Age        int    `+"`validate:\"min=${MIN_AGE}\"`"+`
+	MaxNameLen int    `+"`validate:\"min=1\"`"+`
+}`)
+	defer os.Remove(testFile)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	profile := result.Structs["Profile"]
+	if deps := profile.Fields[0].ValidationRules[0].DependsOn; len(deps) != 1 || deps[0] != "MaxNameLen" {
+		t.Errorf("Expected max=$MaxNameLen to depend on MaxNameLen, got %v", deps)
+	}
+	if len(profile.Fields[1].ValidationRules[0].DependsOn) != 0 {
+		t.Errorf("Expected environment placeholders to have no field dependencies")
+	}
+	if order := profile.EvaluationOrder; len(order) != 3 || order[0] != 2 {
+		t.Errorf("Expected MaxNameLen to be evaluated first, got %v", order)
+	}
+}
+
+// TestConfigAnalyzer_EvaluationOrder tests that referenced fields are ordered first
+func TestConfigAnalyzer_EvaluationOrder(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type Signup struct {
+	Confirm  string `+"`validate:\"eqfield=Password\"`"+`
+	Plan     string `+"`validate:\"required_if=Tier paid\"`"+`
+	Password string `+"`validate:\"required\"`"+`
+	Tier     string `+"`validate:\"required\"`"+`
+}
+`)
+	defer os.Remove(testFile)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to analyze file: %v", err)
+	}
+
+	var names []string
+	for _, field := range result.Structs["Signup"].OrderedFields() {
+		names = append(names, field.Name)
+	}
+
+	if got := strings.Join(names, ","); got != "Password,Confirm,Tier,Plan" {
+		t.Errorf("Expected evaluation order Password,Confirm,Tier,Plan, got %s", got)
+	}
+}
+
+func TestConfigAnalyzer_MergeCompatibleRules(t *testing.T) {
+	analyzer := NewConfigAnalyzer()
+	str := GoType{Kind: TypeString, Name: "string"}
+	uintType := GoType{Kind: TypeUint, Name: "uint"}
+	intType := GoType{Kind: TypeInt, Name: "int"}
+	sliceType := GoType{Kind: TypeSlice, Name: "[]string"}
+
+	tests := []struct {
+		name     string
+		goType   GoType
+		tag      string
+		expected string
+	}{
+		{"duplicates dropped", str, "required,min=3,required,min=3", "required,min=3"},
+		{"equal bounds collapse into len", str, "required,min=5,max=5", "required,len=5"},
+		{"bounds implied by len dropped", str, "min=2,len=4,max=10", "len=4"},
+		{"contradicting bounds kept", str, "min=6,len=4", "min=6,len=4"},
+		{"zero minimum length dropped", str, "min=0,max=10", "max=10"},
+		{"zero minimum of unsigned dropped", uintType, "min=0,max=10", "max=10"},
+		{"zero minimum of signed kept", intType, "min=0,max=10", "min=0,max=10"},
+		{"equal numeric bounds kept", intType, "min=5,max=5", "min=5,max=5"},
+		{"size specs kept", str, "min=5:chars,max=5:chars", "min=5:chars,max=5:chars"},
+		{"element rules after dive kept", sliceType, "len=3,dive,max=5", "len=3,dive,max=5"},
+		{"element duplicates of field rules kept", sliceType, "min=1,dive,min=1", "min=1,dive,min=1"},
+		{"rules before dive kept", sliceType, "min=2,max=2,dive,min=0,min=0", "min=2,max=2,dive,min=0,min=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := analyzer.mergeCompatibleRules(tt.goType, analyzer.parseValidationRules(tt.tag))
+			parts := make([]string, len(merged))
+			for i, rule := range merged {
+				parts[i] = rule.Name
+				if rule.Parameter != "" {
+					parts[i] += "=" + rule.Parameter
+				}
+			}
+			if got := strings.Join(parts, ","); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestConfigAnalyzer_Directives tests //validate: comment directives
+func TestConfigAnalyzer_Directives(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+//validate:rename=user_config
+type UserConfig struct {
+	//validate:rename=display_name
+	Name string `+"`json:\"name\" validate:\"required\"`"+`
+	// Secret is loaded from the environment
+	//validate:skip
+	Secret string `+"`validate:\"required\"`"+`
+	Email  string `+"`yaml:\"email\" validate:\"email\"`"+` //validate:skip
+}
+
+// Internal shares the tags but is not configuration
+//
+//validate:skip
+type Internal struct {
+	ID string `+"`validate:\"required\"`"+`
+}
+
+type (
+	//validate:skip
+	Grouped struct {
+		ID string `+"`validate:\"required\"`"+`
+	}
+)
+`)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to analyze file: %v", err)
+	}
+
+	for _, name := range []string{"Internal", "Grouped"} {
+		if _, exists := result.Structs[name]; exists {
+			t.Errorf("Expected %s to be skipped", name)
+		}
+	}
+
+	userConfig, exists := result.Structs["UserConfig"]
+	if !exists {
+		t.Fatal("UserConfig struct not found")
+	}
+	if len(userConfig.Fields) != 1 || userConfig.Fields[0].Name != "Name" {
+		t.Fatalf("Expected only the Name field, got %+v", userConfig.Fields)
+	}
+	if userConfig.Fields[0].YAMLTag != "display_name" {
+		t.Errorf("Expected renamed key display_name, got %q", userConfig.Fields[0].YAMLTag)
+	}
+	if path := result.YAMLPaths["UserConfig.Name"]; path != "user_config.display_name" {
+		t.Errorf("Expected YAML path user_config.display_name, got %q", path)
+	}
+}
+
+// TestConfigAnalyzer_InvalidDirective tests that unknown directives are rejected
+func TestConfigAnalyzer_InvalidDirective(t *testing.T) {
+	for _, directive := range []string{"//validate:rename", "//validate:skip=true", "//validate:ignore"} {
+		testFile := createTestFile(t, `
+package test
+
+type Config struct {
+	`+directive+`
+	Name string `+"`validate:\"required\"`"+`
+}
+`)
+
+		_, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+		if err == nil || !strings.Contains(err.Error(), "invalid directive "+directive) {
+			t.Errorf("Expected invalid directive error for %s, got %v", directive, err)
+		}
+	}
+}
+
+// TestConfigAnalyzer_MultiPackage tests package grouping and directory filtering
+func TestConfigAnalyzer_MultiPackage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"config.go":                 "package app\n\ntype AppConfig struct {\n\tName string `validate:\"required\"`\n}\n",
+		"db/db.go":                  "package db\n\ntype DBConfig struct {\n\tURL string `validate:\"required,url\"`\n}\n",
+		"db/internal/pool.go":       "package internal\n\ntype PoolConfig struct {\n\tSize int `validate:\"min=1\"`\n}\n",
+		"vendor/dep/dep.go":         "package dep\n\ntype DepConfig struct {\n\tKey string `validate:\"required\"`\n}\n",
+		"testdata/fixture.go":       "package fixture\n\ntype FixtureConfig struct {\n\tKey string `validate:\"required\"`\n}\n",
+		"db/testdata/broken.go":     "package broken\n\nthis does not parse\n",
+		"generated/validators.go":   "package generated\n\ntype GeneratedConfig struct {\n\tKey string `validate:\"required\"`\n}\n",
+		".cache/cached.go":          "package cached\n\ntype CachedConfig struct {\n\tKey string `validate:\"required\"`\n}\n",
+		"db/internal/pool_extra.go": "package internal\n\ntype ExtraConfig struct {\n\tKey string `validate:\"required\"`\n}\n",
+	}
+	writeTree(t, root, files)
+
+	tests := []struct {
+		name     string
+		options  AnalyzerOptions
+		packages map[string]string
+		structs  []string
+	}{
+		{
+			name:     "vendor, testdata and hidden directories skipped",
+			packages: map[string]string{".": "app", "db": "db", "db/internal": "internal", "generated": "generated"},
+			structs:  []string{"AppConfig", "DBConfig", "ExtraConfig", "GeneratedConfig", "PoolConfig"},
+		},
+		{
+			name:     "excluded directory and file",
+			options:  AnalyzerOptions{Exclude: []string{"generated", "db/internal/*_extra.go"}},
+			packages: map[string]string{".": "app", "db": "db", "db/internal": "internal"},
+			structs:  []string{"AppConfig", "DBConfig", "PoolConfig"},
+		},
+		{
+			name:     "included directory tree",
+			options:  AnalyzerOptions{Include: []string{"db"}},
+			packages: map[string]string{"db": "db", "db/internal": "internal"},
+			structs:  []string{"DBConfig", "ExtraConfig", "PoolConfig"},
+		},
+		{
+			name:     "vendor opted in",
+			options:  AnalyzerOptions{Include: []string{"vendor"}, IncludeVendor: true},
+			packages: map[string]string{"vendor/dep": "dep"},
+			structs:  []string{"DepConfig"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewConfigAnalyzerWithOptions(tt.options).AnalyzeDirectory(root)
+			if err != nil {
+				t.Fatalf("Failed to analyze directory: %v", err)
+			}
+
+			if len(result.Packages) != len(tt.packages) {
+				t.Errorf("Expected packages %v, got %d packages", tt.packages, len(result.Packages))
+			}
+			var structs []string
+			for dir, pkg := range result.Packages {
+				if tt.packages[dir] != pkg.Name || pkg.Dir != dir {
+					t.Errorf("Unexpected package %s in %s", pkg.Name, dir)
+				}
+				for name, structInfo := range pkg.Structs {
+					if structInfo.Package != pkg.Name {
+						t.Errorf("Expected %s in package %s, got %s", name, pkg.Name, structInfo.Package)
+					}
+					structs = append(structs, name)
+				}
+			}
+			sort.Strings(structs)
+			if strings.Join(structs, ",") != strings.Join(tt.structs, ",") {
+				t.Errorf("Expected structs %v, got %v", tt.structs, structs)
+			}
+			if len(result.Structs) != len(tt.structs) {
+				t.Errorf("Expected %d structs in the flat map, got %d", len(tt.structs), len(result.Structs))
+			}
+		})
+	}
+
+	result, err := NewConfigAnalyzer().AnalyzeDirectory(root)
+	if err != nil {
+		t.Fatalf("Failed to analyze directory: %v", err)
+	}
+	if result.PackageName != "app" {
+		t.Errorf("Expected the root package app, got %s", result.PackageName)
+	}
+}
+
+// TestConfigAnalyzer_PackageConflicts tests that ambiguous trees are rejected
+func TestConfigAnalyzer_PackageConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		options  AnalyzerOptions
+		expected string
+	}{
+		{
+			name: "struct in two packages",
+			files: map[string]string{
+				"a/a.go": "package a\n\ntype Config struct {\n\tName string `validate:\"required\"`\n}\n",
+				"b/b.go": "package b\n\ntype Config struct {\n\tName string `validate:\"required\"`\n}\n",
+			},
+			expected: "struct Config is declared in both a and b",
+		},
+		{
+			name: "directory mixing packages",
+			files: map[string]string{
+				"config.go": "package config\n",
+				"gen.go":    "package main\n",
+			},
+			expected: "directory . mixes packages config and main",
+		},
+		{
+			name:     "malformed glob",
+			options:  AnalyzerOptions{Exclude: []string{"["}},
+			expected: "invalid pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeTree(t, root, tt.files)
+
+			_, err := NewConfigAnalyzerWithOptions(tt.options).AnalyzeDirectory(root)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// writeTree writes files, keyed by slash-separated relative path, below root
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		filename := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestConfigAnalyzer_ParseStructTags tests reflect.StructTag compatible tag parsing
+func TestConfigAnalyzer_ParseStructTags(t *testing.T) {
+	analyzer := NewConfigAnalyzer()
+
+	tests := []struct {
+		name     string
+		tag      string
+		expected map[string]string
+	}{
+		{"spaces inside values", `validate:"oneof=a b" yaml:"role"`, map[string]string{"validate": "oneof=a b", "yaml": "role"}},
+		{"extra separating spaces", `  yaml:"name,omitempty"   env:"NAME"  `, map[string]string{"yaml": "name,omitempty", "env": "NAME"}},
+		{"escaped quote", `default:"say \"hi\"" yaml:"greeting"`, map[string]string{"default": `say "hi"`, "yaml": "greeting"}},
+		{"colon in value", `default:"localhost:8080"`, map[string]string{"default": "localhost:8080"}},
+		{"first key wins", `yaml:"first" yaml:"second"`, map[string]string{"yaml": "first"}},
+		{"stops at malformed pair", `yaml:"name" broken env:"NAME"`, map[string]string{"yaml": "name"}},
+		{"unterminated value", `yaml:"name`, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := analyzer.parseStructTags(tt.tag)
+			if len(tags) != len(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tags)
+			}
+			for key, value := range tt.expected {
+				if tags[key] != value {
+					t.Errorf("Expected %s=%q, got %q", key, value, tags[key])
+				}
+			}
+		})
+	}
+}
+
+// TestConfigAnalyzer_ScenarioRules tests that scenario rules are left out of
+// generated validators, which apply the default scenario
+func TestConfigAnalyzer_ScenarioRules(t *testing.T) {
+	analyzer := NewConfigAnalyzer()
+
+	rules := analyzer.parseValidationRules("required@create|update,omitempty@update,eq=ops@example.com,min=8")
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Name+"="+rule.Parameter)
+	}
+	if got := strings.Join(names, " "); got != "eq=ops@example.com min=8" {
+		t.Errorf("Expected only the unsuffixed rules, got %q", got)
+	}
+}
+
+// TestConfigAnalyzer_DecimalRules tests that the digit counts of decimal stay
+// one parameter
+func TestConfigAnalyzer_DecimalRules(t *testing.T) {
+	analyzer := NewConfigAnalyzer()
+
+	rules := analyzer.parseValidationRules("required,decimal=10,2,positive")
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Name+"="+rule.Parameter)
+	}
+	if got := strings.Join(names, " "); got != "required= decimal=10,2 positive=" {
+		t.Errorf("Expected decimal to keep its precision, got %q", got)
+	}
+}
+
+// TestConfigAnalyzer_MultiTagFields tests fields combining tags with spaces
+func TestConfigAnalyzer_MultiTagFields(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type Config struct {
+	Role  string `+"`validate:\"required,oneof=admin user\" yaml:\"role\" env:\"ROLE\"`"+`
+	Level string "validate:\"oneof=low high\" yaml:\"level\""
+}
+`)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to analyze file: %v", err)
+	}
+	config := result.Structs["Config"]
+	if config == nil {
+		t.Fatal("Config struct not found")
+	}
+
+	role := findField(config.Fields, "Role")
+	if role.YAMLTag != "role" || role.EnvTag != "ROLE" {
+		t.Errorf("Expected yaml role and env ROLE, got %q and %q", role.YAMLTag, role.EnvTag)
+	}
+	if rule := findValidationRule(role.ValidationRules, "oneof"); rule == nil || rule.Parameter != "admin user" {
+		t.Errorf("Expected oneof parameter 'admin user', got %+v", rule)
+	}
+
+	level := findField(config.Fields, "Level")
+	if level.YAMLTag != "level" {
+		t.Errorf("Expected yaml level from an interpreted string tag, got %q", level.YAMLTag)
+	}
+	if rule := findValidationRule(level.ValidationRules, "oneof"); rule == nil || rule.Parameter != "low high" {
+		t.Errorf("Expected oneof parameter 'low high', got %+v", rule)
+	}
+}
+
+// TestConfigAnalyzer_CollectionYAMLPaths tests YAML paths through pointers,
+// collections and inline fields
+func TestConfigAnalyzer_CollectionYAMLPaths(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type Config struct {
+	Server  *ServerConfig            `+"`yaml:\"server\"`"+`
+	Routes  map[string]RouteConfig   `+"`yaml:\"routes\"`"+`
+	Matrix  [][]CellConfig           `+"`yaml:\"matrix\"`"+`
+	Ignored string                   `+"`yaml:\"-\" validate:\"required\"`"+`
+	Common  CommonConfig             `+"`yaml:\",inline\"`"+`
+	Tree    NodeConfig               `+"`yaml:\"tree\"`"+`
+}
+
+type ServerConfig struct {
+	Hosts []*HostConfig `+"`yaml:\"hosts\"`"+`
+}
+
+type HostConfig struct {
+	Name string `+"`yaml:\"name\" validate:\"required\"`"+`
+}
+
+type RouteConfig struct {
+	Path string `+"`yaml:\"path\" validate:\"required\"`"+`
+}
+
+type CellConfig struct {
+	Value string `+"`yaml:\"value\" validate:\"required\"`"+`
+}
+
+type CommonConfig struct {
+	Region string `+"`yaml:\"region\" validate:\"required\"`"+`
+	BaseConfig `+"`yaml:\",inline\"`"+`
+}
+
+type BaseConfig struct {
+	Owner string `+"`yaml:\"owner\"`"+`
+}
+
+type NodeConfig struct {
+	Name     string       `+"`yaml:\"name\" validate:\"required\"`"+`
+	Children []NodeConfig `+"`yaml:\"children\"`"+`
+}
+`)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to analyze file: %v", err)
+	}
+
+	expectedPaths := map[string]string{
+		"Config.Server":           "server",
+		"ServerConfig.Hosts":      "server.hosts",
+		"HostConfig.Name":         "server.hosts[*].name",
+		"RouteConfig.Path":        "routes[*].path",
+		"CellConfig.Value":        "matrix[*][*].value",
+		"Config.Common":           "",
+		"CommonConfig.Region":     "region",
+		"CommonConfig.BaseConfig": "",
+		"BaseConfig.Owner":        "owner",
+		"NodeConfig.Name":         "tree.name",
+		"NodeConfig.Children":     "tree.children",
+	}
+	for fieldKey, expectedPath := range expectedPaths {
+		if actualPath, exists := result.YAMLPaths[fieldKey]; !exists {
+			t.Errorf("YAML path for %s not found", fieldKey)
+		} else if actualPath != expectedPath {
+			t.Errorf("Expected YAML path %q for %s, got %q", expectedPath, fieldKey, actualPath)
+		}
+	}
+	if path, exists := result.YAMLPaths["Config.Ignored"]; exists {
+		t.Errorf("Expected no YAML path for a yaml:\"-\" field, got %q", path)
+	}
+
+	common := findField(result.Structs["CommonConfig"].Fields, "BaseConfig")
+	if common == nil || !common.IsInline {
+		t.Errorf("Expected the embedded BaseConfig field to be analyzed as inline, got %+v", common)
+	}
+
+	if resolved := ResolveYAMLPath("matrix[*][*].name", "1", "2"); resolved != "matrix[1][2].name" {
+		t.Errorf("Expected resolved path matrix[1][2].name, got %s", resolved)
+	}
+	if resolved := ResolveYAMLPath("routes[*].path", "api", "extra"); resolved != "routes[api].path" {
+		t.Errorf("Expected resolved path routes[api].path, got %s", resolved)
+	}
+}
+
+// TestConfigAnalyzer_SharedStructYAMLPaths tests per usage site paths of a
+// struct reused under several parents
+func TestConfigAnalyzer_SharedStructYAMLPaths(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type AppConfig struct {
+	Server   ServerConfig     `+"`yaml:\"server\"`"+`
+	Database DatabaseConfig   `+"`yaml:\"database\"`"+`
+	Replicas []DatabaseConfig `+"`yaml:\"replicas\"`"+`
+}
+
+type ServerConfig struct {
+	TLS *TLSConfig `+"`yaml:\"tls\"`"+`
+}
+
+type DatabaseConfig struct {
+	TLS TLSConfig `+"`yaml:\"ssl\"`"+`
+}
+
+type TLSConfig struct {
+	CertFile string `+"`yaml:\"cert_file\" validate:\"required\"`"+`
+}
+`)
+
+	for i := 0; i < 5; i++ {
+		result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+		if err != nil {
+			t.Fatalf("Failed to analyze file: %v", err)
+		}
+
+		expectedSites := map[string]string{
+			"AppConfig.Server.TLS.CertFile":      "server.tls.cert_file",
+			"AppConfig.Database.TLS.CertFile":    "database.ssl.cert_file",
+			"AppConfig.Replicas[*].TLS.CertFile": "replicas[*].ssl.cert_file",
+			"AppConfig.Replicas":                 "replicas",
+		}
+		for site, expectedPath := range expectedSites {
+			if actualPath, exists := result.YAMLSites[site]; !exists || actualPath != expectedPath {
+				t.Errorf("Expected YAML path %q for site %s, got %q", expectedPath, site, actualPath)
+			}
+		}
+
+		// The per-struct map deterministically reports the first site in declaration order
+		if path := result.YAMLPaths["TLSConfig.CertFile"]; path != "server.tls.cert_file" {
+			t.Errorf("Expected the first site server.tls.cert_file, got %q", path)
+		}
+
+		namespaces := map[string]string{
+			"Server.TLS.CertFile":      "server.tls.cert_file",
+			"Database.TLS.CertFile":    "database.ssl.cert_file",
+			"Replicas[2].TLS.CertFile": "replicas[2].ssl.cert_file",
+		}
+		for namespace, expectedPath := range namespaces {
+			if actualPath, exists := result.YAMLPathFor("AppConfig", namespace); !exists || actualPath != expectedPath {
+				t.Errorf("Expected YAML path %q for %s, got %q", expectedPath, namespace, actualPath)
+			}
+		}
+		if _, exists := result.YAMLPathFor("AppConfig", "Missing.Field"); exists {
+			t.Error("Expected no YAML path for an unknown namespace")
+		}
+	}
+}
+
+// TestConfigAnalyzer_SourceFormatTags tests the key names of toml and json
+// tags and the fields of a namespace
+func TestConfigAnalyzer_SourceFormatTags(t *testing.T) {
+	testFile := createTestFile(t, `
+package test
+
+type AppConfig struct {
+	Servers []ServerConfig `+"`yaml:\"servers\" toml:\"servers\" json:\"servers,omitempty\"`"+`
+}
+
+type ServerConfig struct {
+	CertFile string `+"`yaml:\"cert_file\" toml:\"cert-file\" json:\"certFile\" validate:\"required\"`"+`
+	Port     int    `+"`validate:\"min=1\"`"+`
+}
+`)
+
+	result, err := NewConfigAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to analyze file: %v", err)
+	}
+
+	certFile := findField(result.Structs["ServerConfig"].Fields, "CertFile")
+	if certFile.TOMLTag != "cert-file" || certFile.JSONTag != "certFile" {
+		t.Errorf("Expected toml cert-file and json certFile, got %q and %q", certFile.TOMLTag, certFile.JSONTag)
+	}
+	port := findField(result.Structs["ServerConfig"].Fields, "Port")
+	if port.TOMLTag != "" || port.JSONTag != "" {
+		t.Errorf("Expected no toml or json keys without tags, got %q and %q", port.TOMLTag, port.JSONTag)
+	}
+
+	chain, exists := result.FieldChain("AppConfig", "Servers[1].CertFile")
+	if !exists || len(chain) != 2 || chain[0].Name != "Servers" || chain[1].Name != "CertFile" {
+		t.Errorf("Expected the fields Servers and CertFile, got %+v", chain)
+	}
+	if _, exists := result.FieldChain("AppConfig", "Servers[1].Missing"); exists {
+		t.Error("Expected no fields for an unknown namespace")
+	}
+}
@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// directivePrefix starts the comment directives that control analysis
+// without touching struct tags shared with other tools
+const directivePrefix = "//validate:"
+
+// directives holds the parsed //validate: comments of a declaration
+type directives struct {
+	skip   bool   // //validate:skip leaves the declaration out of the analysis
+	rename string // //validate:rename=name overrides the configuration key
+}
+
+// parseDirectives parses the //validate: directives in the comment groups of
+// a declaration, rejecting unknown or malformed ones
+func (ca *ConfigAnalyzer) parseDirectives(groups ...*ast.CommentGroup) (directives, error) {
+	var d directives
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			directive, ok := strings.CutPrefix(comment.Text, directivePrefix)
+			if !ok {
+				continue
+			}
+			name, value, hasValue := strings.Cut(strings.TrimSpace(directive), "=")
+			switch {
+			case name == "skip" && !hasValue:
+				d.skip = true
+			case name == "rename" && value != "":
+				d.rename = value
+			default:
+				return d, fmt.Errorf("%s: invalid directive %s", ca.fileSet.Position(comment.Pos()), comment.Text)
+			}
+		}
+	}
+	return d, nil
+}
+
+// typeDoc returns the doc comment of a type spec, which the parser attaches
+// to the declaration unless the spec is part of a grouped type block
+func typeDoc(decl *ast.GenDecl, spec *ast.TypeSpec) *ast.CommentGroup {
+	if spec.Doc == nil && !decl.Lparen.IsValid() {
+		return decl.Doc
+	}
+	return spec.Doc
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AnalyzerOptions controls which files AnalyzeDirectory reads
+type AnalyzerOptions struct {
+	// Include lists path.Match globs, relative to the analyzed directory and
+	// slash-separated. When set, only files matching a pattern, or inside a
+	// directory matching one, are analyzed.
+	Include []string
+	// Exclude lists globs, matched like Include, of files and directories to skip
+	Exclude []string
+	// IncludeVendor analyzes vendor directories, which are skipped by default
+	IncludeVendor bool
+	// IncludeTestdata analyzes testdata directories, which are skipped by default
+	IncludeTestdata bool
+}
+
+// PackageInfo groups the structs declared by one package
+type PackageInfo struct {
+	Name    string
+	Dir     string // slash-separated, relative to the analyzed directory
+	Structs map[string]*StructInfo
+}
+
+// NewConfigAnalyzerWithOptions creates a configuration analyzer that filters
+// the files it reads
+func NewConfigAnalyzerWithOptions(options AnalyzerOptions) *ConfigAnalyzer {
+	ca := NewConfigAnalyzer()
+	ca.options = options
+	return ca
+}
+
+// skipDir reports whether the walk leaves out a directory, given its path
+// relative to the analyzed directory
+func (ca *ConfigAnalyzer) skipDir(rel string) bool {
+	name := path.Base(rel)
+	switch {
+	case strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"):
+		// Ignored by the go tool as well
+		return true
+	case name == "vendor" && !ca.options.IncludeVendor:
+		return true
+	case name == "testdata" && !ca.options.IncludeTestdata:
+		return true
+	}
+	return matchesAny(ca.options.Exclude, rel)
+}
+
+// includeFile reports whether a file, given its path relative to the
+// analyzed directory, passes the include and exclude globs
+func (ca *ConfigAnalyzer) includeFile(rel string) bool {
+	if matchesAny(ca.options.Exclude, rel) {
+		return false
+	}
+	if len(ca.options.Include) == 0 {
+		return true
+	}
+	for current := rel; current != "."; current = path.Dir(current) {
+		if matchesAny(ca.options.Include, current) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether a slash-separated path matches one of the globs
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPatterns rejects malformed include and exclude globs
+func (ca *ConfigAnalyzer) checkPatterns() error {
+	for _, pattern := range append(append([]string(nil), ca.options.Include...), ca.options.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// packageFor returns the package declared by files in a directory, creating
+// it on first use and rejecting directories that mix packages
+func (ca *ConfigAnalyzer) packageFor(dir, name string) (*PackageInfo, error) {
+	pkg, exists := ca.packages[dir]
+	if !exists {
+		pkg = &PackageInfo{Name: name, Dir: dir, Structs: make(map[string]*StructInfo)}
+		ca.packages[dir] = pkg
+		return pkg, nil
+	}
+	if pkg.Name != name {
+		return nil, fmt.Errorf("directory %s mixes packages %s and %s", dir, pkg.Name, name)
+	}
+	return pkg, nil
+}
+
+// structDir returns the directory of the package declaring a struct
+func (ca *ConfigAnalyzer) structDir(name string) string {
+	for dir, pkg := range ca.packages {
+		if _, exists := pkg.Structs[name]; exists {
+			return dir
+		}
+	}
+	return ""
+}
+
+// sortedFiles returns the parsed file paths in lexical order
+func (ca *ConfigAnalyzer) sortedFiles() []string {
+	paths := make([]string, 0, len(ca.parsedFiles))
+	for filename := range ca.parsedFiles {
+		paths = append(paths, filename)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// relativeDir returns the slash-separated directory of a file relative to
+// the analyzed directory
+func (ca *ConfigAnalyzer) relativeDir(filename string) string {
+	rel, err := filepath.Rel(ca.root, filepath.Dir(filename))
+	if err != nil {
+		return filepath.ToSlash(filepath.Dir(filename))
+	}
+	return filepath.ToSlash(rel)
+}
+
+// rootPackageName returns the package of the analyzed directory itself,
+// falling back to the first package in directory order
+func (ca *ConfigAnalyzer) rootPackageName() string {
+	if pkg, exists := ca.packages["."]; exists {
+		return pkg.Name
+	}
+	dirs := make([]string, 0, len(ca.packages))
+	for dir := range ca.packages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	if len(dirs) == 0 {
+		return ""
+	}
+	return ca.packages[dirs[0]].Name
+}
@@ -0,0 +1,51 @@
+// Package disposable holds an embedded list of domains handing out
+// disposable email addresses, such as mailinator.com. The list covers widely
+// used providers rather than every one; edit domains.txt to extend it.
+package disposable
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed domains.txt
+var listData string
+
+var (
+	loadOnce sync.Once
+	domains  map[string]bool
+)
+
+// load parses the list on first use
+func load() map[string]bool {
+	loadOnce.Do(func() {
+		domains = make(map[string]bool)
+		scanner := bufio.NewScanner(strings.NewReader(listData))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				domains[strings.ToLower(line)] = true
+			}
+		}
+	})
+	return domains
+}
+
+// Contains reports whether domain, or a domain it is under, is listed
+func Contains(domain string) bool {
+	list := load()
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for domain != "" {
+		if list[domain] {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			break
+		}
+		domain = parent
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package disposable
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	for domain, expected := range map[string]bool{
+		"mailinator.com":      true,
+		"MAILINATOR.COM.":     true,
+		"inbox.yopmail.com":   true,
+		"gmail.com":           false,
+		"notmailinator.com":   false,
+		"mailinator.com.evil": false,
+		"":                    false,
+	} {
+		if Contains(domain) != expected {
+			t.Errorf("Contains(%q) = %v, want %v", domain, !expected, expected)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+// celType is the type of a translated CEL expression: goType is the Go type
+// of an operand, empty for untyped constants, whose CEL kind is celKind
+type celType struct {
+	goType  string
+	celKind string // "bool", "int", "uint", "double" or "string"
+}
+
+// celKinds maps the field types expressions may reference to their CEL kinds
+var celKinds = map[analyzer.TypeKind]string{
+	analyzer.TypeString:  "string",
+	analyzer.TypeBool:    "bool",
+	analyzer.TypeInt:     "int",
+	analyzer.TypeInt8:    "int",
+	analyzer.TypeInt16:   "int",
+	analyzer.TypeInt32:   "int",
+	analyzer.TypeInt64:   "int",
+	analyzer.TypeUint:    "uint",
+	analyzer.TypeUint8:   "uint",
+	analyzer.TypeUint16:  "uint",
+	analyzer.TypeUint32:  "uint",
+	analyzer.TypeUint64:  "uint",
+	analyzer.TypeFloat32: "double",
+	analyzer.TypeFloat64: "double",
+}
+
+// celTranslator compiles a CEL expression over self, the struct holding
+// the field, into a Go expression over cfg
+type celTranslator struct {
+	fields map[string]*analyzer.FieldInfo
+}
+
+// generateCELValidation generates a cel rule: natively when its expression
+// only compares and combines fields of basic types and constants, and
+// through validation.VarField, which needs contrib/cel registered on the
+// default validator, otherwise
+func (cg *CodeGenerator) generateCELValidation(structName string, field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if structInfo, ok := cg.analysisResult.Structs[structName]; ok && !isSecret(field) {
+		translator := &celTranslator{fields: make(map[string]*analyzer.FieldInfo, len(structInfo.Fields))}
+		for i := range structInfo.Fields {
+			translator.fields[structInfo.Fields[i].Name] = &structInfo.Fields[i]
+		}
+		if condition, ok := translator.translate(rule.Parameter); ok {
+			return []ast.Stmt{
+				&ast.IfStmt{
+					Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: condition}},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							cg.generateAddError(field.Name, rule.Name, rule.Parameter, fmt.Sprintf("field '%s' must satisfy %s", field.Name, rule.Parameter)),
+						},
+					},
+				},
+			}
+		}
+	}
+
+	if cg.options.Pure {
+		cg.reportImpure(field, rule.Name)
+		return nil
+	}
+	tag := rule.Name + "=" + rule.Parameter
+	if isSensitive(field) {
+		tag += ",sensitive"
+	}
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{X: ast.NewIdent("validation"), Sel: ast.NewIdent("VarField")},
+						Args: []ast.Expr{
+							ast.NewIdent("cfg"),
+							&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(field.Name)},
+							&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(tag)},
+						},
+					},
+				},
+			},
+			Cond: &ast.BinaryExpr{X: ast.NewIdent("err"), Op: token.NEQ, Y: ast.NewIdent("nil")},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun:  &ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("addValidationError")},
+							Args: []ast.Expr{ast.NewIdent("err")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// translate returns the Go equivalent of a boolean CEL expression, or false
+// when the expression uses anything beyond self fields, constants, operators
+// and size()
+func (t *celTranslator) translate(expression string) (ast.Expr, bool) {
+	source, ok := celToGoSource(expression)
+	if !ok {
+		return nil, false
+	}
+	parsed, err := parser.ParseExpr(source)
+	if err != nil {
+		return nil, false
+	}
+	expr, typ, ok := t.expr(parsed)
+	if !ok || typ.celKind != "bool" {
+		return nil, false
+	}
+	return expr, true
+}
+
+// expr translates a parsed expression, rebuilding it without positions
+func (t *celTranslator) expr(node ast.Expr) (ast.Expr, celType, bool) {
+	switch node := node.(type) {
+	case *ast.ParenExpr:
+		inner, typ, ok := t.expr(node.X)
+		return &ast.ParenExpr{X: inner}, typ, ok
+	case *ast.BasicLit:
+		return t.literal(node)
+	case *ast.Ident:
+		if node.Name == "true" || node.Name == "false" {
+			return ast.NewIdent(node.Name), celType{celKind: "bool"}, true
+		}
+	case *ast.SelectorExpr:
+		return t.field(node)
+	case *ast.CallExpr:
+		return t.size(node)
+	case *ast.UnaryExpr:
+		operand, typ, ok := t.expr(node.X)
+		if !ok {
+			break
+		}
+		switch {
+		case node.Op == token.NOT && typ.celKind == "bool",
+			node.Op == token.SUB && (typ.celKind == "int" || typ.celKind == "double"):
+			return &ast.UnaryExpr{Op: node.Op, X: operand}, typ, true
+		}
+	case *ast.BinaryExpr:
+		return t.binary(node)
+	}
+	return nil, celType{}, false
+}
+
+// literal translates a constant. CEL has no octal, binary or underscored
+// integers, so Go-only spellings are rejected.
+func (t *celTranslator) literal(lit *ast.BasicLit) (ast.Expr, celType, bool) {
+	switch lit.Kind {
+	case token.STRING:
+		return &ast.BasicLit{Kind: token.STRING, Value: lit.Value}, celType{celKind: "string"}, true
+	case token.INT:
+		value := strings.ToLower(lit.Value)
+		if strings.Contains(value, "_") || (len(value) > 1 && value[0] == '0' && value[1] != 'x') {
+			break
+		}
+		return &ast.BasicLit{Kind: token.INT, Value: lit.Value}, celType{celKind: "int"}, true
+	case token.FLOAT:
+		if strings.Contains(lit.Value, "_") || strings.ContainsAny(lit.Value, "xXpP") {
+			break
+		}
+		return &ast.BasicLit{Kind: token.FLOAT, Value: lit.Value}, celType{celKind: "double"}, true
+	}
+	return nil, celType{}, false
+}
+
+// field translates self.Field to cfg.Field for fields of basic types
+func (t *celTranslator) field(sel *ast.SelectorExpr) (ast.Expr, celType, bool) {
+	self, ok := sel.X.(*ast.Ident)
+	if !ok || self.Name != "self" {
+		return nil, celType{}, false
+	}
+	field, ok := t.fields[sel.Sel.Name]
+	if !ok || field.GoType.IsPointer || field.GoType.IsNullable {
+		return nil, celType{}, false
+	}
+	kind, ok := celKinds[field.GoType.Kind]
+	if !ok || field.Type != field.GoType.Name {
+		// Named types may carry methods CEL does not see
+		return nil, celType{}, false
+	}
+	access := &ast.SelectorExpr{X: ast.NewIdent("cfg"), Sel: ast.NewIdent(field.Name)}
+	return access, celType{goType: field.Type, celKind: kind}, true
+}
+
+// size translates size(self.Field): len for slices and maps, and the rune
+// count for strings, as CEL counts code points
+func (t *celTranslator) size(call *ast.CallExpr) (ast.Expr, celType, bool) {
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "size" || len(call.Args) != 1 {
+		return nil, celType{}, false
+	}
+	sel, ok := call.Args[0].(*ast.SelectorExpr)
+	if !ok {
+		return nil, celType{}, false
+	}
+	self, ok := sel.X.(*ast.Ident)
+	if !ok || self.Name != "self" {
+		return nil, celType{}, false
+	}
+	field, ok := t.fields[sel.Sel.Name]
+	if !ok || field.GoType.IsPointer || field.GoType.IsNullable {
+		return nil, celType{}, false
+	}
+
+	var arg ast.Expr = &ast.SelectorExpr{X: ast.NewIdent("cfg"), Sel: ast.NewIdent(field.Name)}
+	switch field.GoType.Kind {
+	case analyzer.TypeSlice, analyzer.TypeMap:
+	case analyzer.TypeString:
+		arg = &ast.CallExpr{Fun: &ast.ArrayType{Elt: ast.NewIdent("rune")}, Args: []ast.Expr{arg}}
+	default:
+		return nil, celType{}, false
+	}
+	return &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{arg}}, celType{goType: "int", celKind: "int"}, true
+}
+
+// binary translates an operator, requiring operands CEL and Go both accept
+func (t *celTranslator) binary(bin *ast.BinaryExpr) (ast.Expr, celType, bool) {
+	x, xType, ok := t.expr(bin.X)
+	if !ok {
+		return nil, celType{}, false
+	}
+	y, yType, ok := t.expr(bin.Y)
+	if !ok {
+		return nil, celType{}, false
+	}
+	typ, ok := unifyCELTypes(xType, yType)
+	if !ok {
+		return nil, celType{}, false
+	}
+	// A constant must be representable in the Go type of the other operand
+	if xType.goType == "" && yType.goType != "" && !constantFits(x, yType.goType) ||
+		yType.goType == "" && xType.goType != "" && !constantFits(y, xType.goType) {
+		return nil, celType{}, false
+	}
+	result := &ast.BinaryExpr{X: x, Op: bin.Op, Y: y}
+
+	switch bin.Op {
+	case token.LAND, token.LOR:
+		if typ.celKind == "bool" {
+			return result, typ, true
+		}
+	case token.EQL, token.NEQ:
+		return result, celType{celKind: "bool"}, true
+	case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		if typ.celKind != "bool" {
+			return result, celType{celKind: "bool"}, true
+		}
+	case token.ADD:
+		if typ.celKind == "string" || typ.celKind != "bool" && isWideType(typ.goType) {
+			return result, typ, true
+		}
+	case token.SUB, token.MUL:
+		if typ.celKind != "bool" && typ.celKind != "string" && isWideType(typ.goType) {
+			return result, typ, true
+		}
+	case token.QUO, token.REM:
+		// CEL reports division by zero where Go panics, so only constant
+		// divisors are translated
+		lit, isLit := y.(*ast.BasicLit)
+		if typ.celKind == "string" || typ.celKind == "bool" || !isWideType(typ.goType) || !isLit || isZeroLiteral(lit.Value) {
+			break
+		}
+		if bin.Op == token.REM && typ.celKind == "double" {
+			break
+		}
+		return result, typ, true
+	}
+	return nil, celType{}, false
+}
+
+// unifyCELTypes returns the type of an operation on x and y: both must have
+// the same CEL kind, and typed operands the same Go type
+func unifyCELTypes(x, y celType) (celType, bool) {
+	if x.celKind != y.celKind {
+		return celType{}, false
+	}
+	switch {
+	case x.goType == "":
+		return y, true
+	case y.goType == "" || x.goType == y.goType:
+		return x, true
+	}
+	return celType{}, false
+}
+
+// isWideType reports whether arithmetic in goType matches CEL's 64-bit
+// arithmetic, which the narrower types would wrap instead
+func isWideType(goType string) bool {
+	switch goType {
+	case "", "int", "int64", "uint", "uint64", "float64":
+		return true
+	}
+	return false
+}
+
+// constantFits reports whether expr, a literal or negated literal, is
+// representable in goType. Compound constants are left to CEL.
+func constantFits(expr ast.Expr, goType string) bool {
+	negative := false
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+		negative, expr = true, unary.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return false
+	}
+	if lit.Kind != token.INT {
+		return lit.Kind == token.STRING || lit.Kind == token.FLOAT && goType == "float64"
+	}
+
+	value, err := strconv.ParseUint(lit.Value, 0, 64)
+	if err != nil {
+		return false
+	}
+	bits := 64
+	switch goType {
+	case "int8", "uint8", "byte":
+		bits = 8
+	case "int16", "uint16":
+		bits = 16
+	case "int32", "uint32", "rune":
+		bits = 32
+	case "float32", "float64":
+		// Integer constants of float operands are exact up to the mantissa
+		return value <= 1<<24
+	}
+	if strings.HasPrefix(goType, "uint") || goType == "byte" {
+		return !negative && (bits == 64 || value < 1<<bits)
+	}
+	if negative {
+		return value <= 1<<(bits-1)
+	}
+	return value < 1<<(bits-1)
+}
+
+// isZeroLiteral reports whether a numeric literal is zero
+func isZeroLiteral(value string) bool {
+	f, err := strconv.ParseFloat(value, 64)
+	return err != nil || f == 0
+}
+
+// celToGoSource rewrites the single-quoted strings of a CEL expression as Go
+// strings. Expressions with escapes, raw or triple-quoted strings are left
+// to CEL.
+func celToGoSource(expression string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(expression); i++ {
+		c := expression[i]
+		if c != '\'' && c != '"' {
+			b.WriteByte(c)
+			continue
+		}
+		if i > 0 && (expression[i-1] == 'r' || expression[i-1] == 'R' || expression[i-1] == 'b' || expression[i-1] == 'B') {
+			return "", false
+		}
+		end := strings.IndexByte(expression[i+1:], c)
+		if end < 0 {
+			return "", false
+		}
+		content := expression[i+1 : i+1+end]
+		if strings.ContainsAny(content, "\\\n") || (end == 0 && i+2 < len(expression) && expression[i+2] == c) {
+			return "", false
+		}
+		b.WriteString(strconv.Quote(content))
+		i += end + 1
+	}
+	return b.String(), true
+}
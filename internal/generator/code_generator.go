@@ -3,15 +3,20 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/format"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
+	validation "github.com/mateothegreat/go-validation"
 	"github.com/mateothegreat/go-validation/internal/analyzer"
 )
 
@@ -20,6 +25,8 @@ type CodeGenerator struct {
 	fileSet        *token.FileSet
 	analysisResult *analyzer.AnalysisResult
 	options        GeneratorOptions
+	impure         []string // rules pure mode could not generate
+	serverOnly     []string // rules TypeScript validators leave to Go
 }
 
 // GeneratorOptions controls code generation behavior
@@ -30,7 +37,12 @@ type GeneratorOptions struct {
 	EnableOptimizations bool // Enable performance optimizations
 	IncludeDebugInfo    bool // Include debug information in generated code
 	FailFast            bool // Stop on first validation error
-	GenerateTests       bool // Generate test code
+	GenerateTests       bool // Generate tests checking each validator against the reflection engine
+	Pure                bool // Import only the standard library and never reflect
+	SourceImportPath    string // Import path of the analyzed package when generating into another package
+	FileSuffix          string // Suffix of per-struct file names, "_validator_gen" by default
+	BuildTags           string // Build constraint of generated files, e.g. "!novalidate"
+	TypeScriptFile      string // Also emit TypeScript validators to this file, relative to OutputDir
 }
 
 // ValidationMethod represents a generated validation method
@@ -52,14 +64,44 @@ func NewCodeGenerator(analysisResult *analyzer.AnalysisResult, options Generator
 
 // Generate generates all validation code files
 func (cg *CodeGenerator) Generate() error {
+	if err := cg.checkOutputOptions(); err != nil {
+		return err
+	}
 	if err := os.MkdirAll(cg.options.OutputDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Generate validation code for each struct
-	for structName, structInfo := range cg.analysisResult.Structs {
-		if err := cg.generateStructValidator(structName, structInfo); err != nil {
-			return fmt.Errorf("failed to generate validator for %s: %w", structName, err)
+	// Build every file before writing any, so pure mode writes nothing when
+	// a rule cannot be generated
+	cg.impure = nil
+	var files []generatedFile
+	for _, structName := range cg.sortedStructNames() {
+		structInfo := cg.analysisResult.Structs[structName]
+		files = append(files, cg.generateStructValidator(structName, structInfo))
+		if cg.options.GenerateTests {
+			file, err := cg.generateStructTest(structName)
+			if err != nil {
+				return fmt.Errorf("failed to generate test for %s: %w", structName, err)
+			}
+			files = append(files, file)
+		}
+	}
+
+	// Pure mode declares its own error types and format helpers
+	if cg.options.Pure {
+		if err := cg.impureError(); err != nil {
+			return err
+		}
+		file, err := cg.generatePureSupport()
+		if err != nil {
+			return fmt.Errorf("failed to generate pure mode support: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	for _, file := range files {
+		if err := cg.writeFormattedFile(file.path, file.file); err != nil {
+			return err
 		}
 	}
 
@@ -70,23 +112,39 @@ func (cg *CodeGenerator) Generate() error {
 		}
 	}
 
+	if cg.options.TypeScriptFile != "" {
+		filename := cg.options.TypeScriptFile
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(cg.options.OutputDir, filename)
+		}
+		if err := cg.GenerateTypeScript(filename); err != nil {
+			return fmt.Errorf("failed to generate TypeScript validators: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// generatedFile is a generated file waiting to be written
+type generatedFile struct {
+	path string
+	file *ast.File
+}
+
 // generateStructValidator generates a complete validator file for a struct
-func (cg *CodeGenerator) generateStructValidator(structName string, structInfo *analyzer.StructInfo) error {
-	filename := fmt.Sprintf("%s_validator_gen.go", strings.ToLower(structName))
+func (cg *CodeGenerator) generateStructValidator(structName string, structInfo *analyzer.StructInfo) generatedFile {
+	filename := cg.validatorFilename(structName)
 	outputPath := filepath.Join(cg.options.OutputDir, filename)
 
 	// Build AST for the generated file
 	file := &ast.File{
 		Name: ast.NewIdent(cg.options.PackageName),
 		Decls: []ast.Decl{
-			cg.generateFileHeader(),
-			cg.generateImports(),
 			cg.generateValidatorStruct(structName),
 			cg.generateConstructor(structName),
 			cg.generateValidateMethod(structName, structInfo),
+			cg.generateValidateFastMethod(structName, structInfo),
+			cg.generateSetFailFastMethod(structName),
 		},
 	}
 
@@ -99,43 +157,9 @@ func (cg *CodeGenerator) generateStructValidator(structName string, structInfo *
 
 	// Add helper methods
 	file.Decls = append(file.Decls, cg.generateHelperMethods(structName)...)
+	cg.addImports(file)
 
-	// Format and write the file
-	return cg.writeFormattedFile(outputPath, file)
-}
-
-// generateFileHeader generates the file header comment
-func (cg *CodeGenerator) generateFileHeader() *ast.GenDecl {
-	comment := &ast.CommentGroup{
-		List: []*ast.Comment{
-			{Text: "// Code generated by go-validation/cmd/configvalidator. DO NOT EDIT."},
-			{Text: "// This file contains zero-reflection validation code for maximum performance."},
-		},
-	}
-
-	return &ast.GenDecl{
-		Doc: comment,
-		Tok: token.IMPORT, // This will be replaced by actual imports
-	}
-}
-
-// generateImports creates the import declaration
-func (cg *CodeGenerator) generateImports() *ast.GenDecl {
-	var specs []ast.Spec
-
-	for _, imp := range cg.analysisResult.Imports {
-		specs = append(specs, &ast.ImportSpec{
-			Path: &ast.BasicLit{
-				Kind:  token.STRING,
-				Value: fmt.Sprintf(`"%s"`, imp),
-			},
-		})
-	}
-
-	return &ast.GenDecl{
-		Tok:   token.IMPORT,
-		Specs: specs,
-	}
+	return generatedFile{path: outputPath, file: file}
 }
 
 // generateValidatorStruct creates the validator struct declaration
@@ -146,21 +170,16 @@ func (cg *CodeGenerator) generateValidatorStruct(structName string) *ast.GenDecl
 		{
 			Names: []*ast.Ident{ast.NewIdent("errors")},
 			Type: &ast.ArrayType{
-				Elt: &ast.SelectorExpr{
-					X:   ast.NewIdent("validation"),
-					Sel: ast.NewIdent("ValidationError"),
-				},
+				Elt: cg.validationType("ValidationError"),
 			},
 		},
 	}
 
-	// Add configuration options if optimizations are enabled
-	if cg.options.EnableOptimizations {
-		fields = append(fields, &ast.Field{
-			Names: []*ast.Ident{ast.NewIdent("failFast")},
-			Type:  ast.NewIdent("bool"),
-		})
-	}
+	// Selects ValidateFast when Validate is called
+	fields = append(fields, &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent("failFast")},
+		Type:  ast.NewIdent("bool"),
+	})
 
 	return &ast.GenDecl{
 		Tok: token.TYPE,
@@ -189,10 +208,7 @@ func (cg *CodeGenerator) generateConstructor(structName string) *ast.FuncDecl {
 			Fun: ast.NewIdent("make"),
 			Args: []ast.Expr{
 				&ast.ArrayType{
-					Elt: &ast.SelectorExpr{
-						X:   ast.NewIdent("validation"),
-						Sel: ast.NewIdent("ValidationError"),
-					},
+					Elt: cg.validationType("ValidationError"),
 				},
 				&ast.BasicLit{Kind: token.INT, Value: "0"},
 				&ast.BasicLit{Kind: token.INT, Value: "10"}, // Initial capacity
@@ -200,13 +216,11 @@ func (cg *CodeGenerator) generateConstructor(structName string) *ast.FuncDecl {
 		},
 	})
 
-	// Add fail fast option if optimizations are enabled
-	if cg.options.EnableOptimizations {
-		initFields = append(initFields, &ast.KeyValueExpr{
-			Key:   ast.NewIdent("failFast"),
-			Value: ast.NewIdent("false"),
-		})
-	}
+	// Default to the configured fail-fast mode
+	initFields = append(initFields, &ast.KeyValueExpr{
+		Key:   ast.NewIdent("failFast"),
+		Value: ast.NewIdent(strconv.FormatBool(cg.options.FailFast)),
+	})
 
 	return &ast.FuncDecl{
 		Name: ast.NewIdent(constructorName),
@@ -239,11 +253,122 @@ func (cg *CodeGenerator) generateConstructor(structName string) *ast.FuncDecl {
 	}
 }
 
-// generateValidateMethod creates the main Validate method
+// generateSpansDecl declares spans, the range of errors each field added,
+// indexed by declaration order
+func (cg *CodeGenerator) generateSpansDecl(fields int) ast.Stmt {
+	return &ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names: []*ast.Ident{ast.NewIdent("spans")},
+			Type: &ast.ArrayType{
+				Len: &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(fields)},
+				Elt: &ast.ArrayType{Len: &ast.BasicLit{Kind: token.INT, Value: "2"}, Elt: ast.NewIdent("int")},
+			},
+		}},
+	}}
+}
+
+// generateSpanMark records the number of errors at the start (end 0) or
+// end (end 1) of a field's validation
+func (cg *CodeGenerator) generateSpanMark(index, end int) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{&ast.IndexExpr{
+			X:     &ast.IndexExpr{X: ast.NewIdent("spans"), Index: &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(index)}},
+			Index: &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(end)},
+		}},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{&ast.CallExpr{
+			Fun:  ast.NewIdent("len"),
+			Args: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")}},
+		}},
+	}
+}
+
+// generateSpansReorder moves the errors into the declaration order of their
+// fields:
+//
+//	ordered := v.errors[0:0:0]
+//	for _, span := range spans {
+//		ordered = append(ordered, v.errors[span[0]:span[1]]...)
+//	}
+//	v.errors = ordered
+func (cg *CodeGenerator) generateSpansReorder() []ast.Stmt {
+	errorsField := func() ast.Expr { return &ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")} }
+	zero := &ast.BasicLit{Kind: token.INT, Value: "0"}
+	span := func(end string) ast.Expr {
+		return &ast.IndexExpr{X: ast.NewIdent("span"), Index: &ast.BasicLit{Kind: token.INT, Value: end}}
+	}
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("ordered")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.SliceExpr{X: errorsField(), Low: zero, High: zero, Max: zero, Slice3: true}},
+		},
+		&ast.RangeStmt{
+			Key:   ast.NewIdent("_"),
+			Value: ast.NewIdent("span"),
+			Tok:   token.DEFINE,
+			X:     ast.NewIdent("spans"),
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("ordered")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:      ast.NewIdent("append"),
+					Args:     []ast.Expr{ast.NewIdent("ordered"), &ast.SliceExpr{X: errorsField(), Low: span("0"), High: span("1")}},
+					Ellipsis: 1,
+				}},
+			}}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{errorsField()},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{ast.NewIdent("ordered")},
+		},
+	}
+}
+
+// generateValidateMethod creates the main Validate method, which collects
+// every error or defers to ValidateFast when fail-fast is enabled
 func (cg *CodeGenerator) generateValidateMethod(structName string, structInfo *analyzer.StructInfo) *ast.FuncDecl {
+	return cg.generateValidateFunc(structName, structInfo, "Validate", false)
+}
+
+// generateValidateFastMethod creates the ValidateFast method, which returns
+// on the first error
+func (cg *CodeGenerator) generateValidateFastMethod(structName string, structInfo *analyzer.StructInfo) *ast.FuncDecl {
+	return cg.generateValidateFunc(structName, structInfo, "ValidateFast", true)
+}
+
+// generateValidateFunc creates a validate method in collect-all or fail-fast mode
+func (cg *CodeGenerator) generateValidateFunc(structName string, structInfo *analyzer.StructInfo, methodName string, failFast bool) *ast.FuncDecl {
 	validatorName := structName + "Validator"
 	var stmts []ast.Stmt
 
+	// Collect-all validation switches to ValidateFast at runtime
+	if !failFast {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.SelectorExpr{
+				X:   ast.NewIdent("v"),
+				Sel: ast.NewIdent("failFast"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("v"),
+									Sel: ast.NewIdent("ValidateFast"),
+								},
+								Args: []ast.Expr{ast.NewIdent("cfg")},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
 	// Reset errors at the beginning
 	stmts = append(stmts, &ast.AssignStmt{
 		Lhs: []ast.Expr{
@@ -254,10 +379,6 @@ func (cg *CodeGenerator) generateValidateMethod(structName string, structInfo *a
 		},
 		Tok: token.ASSIGN,
 		Rhs: []ast.Expr{
-			&ast.SelectorExpr{
-				X:   ast.NewIdent("v"),
-				Sel: ast.NewIdent("errors"),
-			},
 			&ast.SliceExpr{
 				X:    &ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")},
 				Low:  &ast.BasicLit{Kind: token.INT, Value: "0"},
@@ -266,11 +387,24 @@ func (cg *CodeGenerator) generateValidateMethod(structName string, structInfo *a
 		},
 	})
 
-	// Generate validation calls for each field
-	for _, field := range structInfo.Fields {
-		fieldStmts := cg.generateFieldValidation(structName, &field)
+	// Generate validation calls for each field, referenced fields first. When
+	// that changes the order, collect-all mode records the errors of each
+	// field to report them in declaration order.
+	order := structInfo.EvaluationOrder
+	reordered := !failFast && len(order) == len(structInfo.Fields) && !slices.IsSorted(order)
+	if reordered {
+		stmts = append(stmts, cg.generateSpansDecl(len(order)))
+	}
+	for k, field := range structInfo.OrderedFields() {
+		fieldStmts := cg.generateFieldValidation(structName, &field, failFast)
+		if reordered {
+			fieldStmts = append(append([]ast.Stmt{cg.generateSpanMark(order[k], 0)}, fieldStmts...), cg.generateSpanMark(order[k], 1))
+		}
 		stmts = append(stmts, fieldStmts...)
 	}
+	if reordered {
+		stmts = append(stmts, cg.generateSpansReorder()...)
+	}
 
 	// Return error if any validation failed
 	stmts = append(stmts, &ast.IfStmt{
@@ -292,10 +426,7 @@ func (cg *CodeGenerator) generateValidateMethod(structName string, structInfo *a
 				&ast.ReturnStmt{
 					Results: []ast.Expr{
 						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("validation"),
-								Sel: ast.NewIdent("ValidationErrors"),
-							},
+							Fun: cg.validationType("ValidationErrors"),
 							Args: []ast.Expr{
 								&ast.SelectorExpr{
 									X:   ast.NewIdent("v"),
@@ -324,14 +455,14 @@ func (cg *CodeGenerator) generateValidateMethod(structName string, structInfo *a
 				},
 			},
 		},
-		Name: ast.NewIdent("Validate"),
+		Name: ast.NewIdent(methodName),
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
 					{
 						Names: []*ast.Ident{ast.NewIdent("cfg")},
 						Type: &ast.StarExpr{
-							X: ast.NewIdent(structName),
+							X: cg.typeExpr(structName),
 						},
 					},
 				},
@@ -346,89 +477,323 @@ func (cg *CodeGenerator) generateValidateMethod(structName string, structInfo *a
 	}
 }
 
-// generateFieldValidation generates validation code for a single field
-func (cg *CodeGenerator) generateFieldValidation(structName string, field *analyzer.FieldInfo) []ast.Stmt {
-	var stmts []ast.Stmt
-
+// generateFieldValidation generates validation code for a single field,
+// returning after the first failed rule in fail-fast mode
+func (cg *CodeGenerator) generateFieldValidation(structName string, field *analyzer.FieldInfo, failFast bool) []ast.Stmt {
 	fieldAccess := &ast.SelectorExpr{
 		X:   ast.NewIdent("cfg"),
 		Sel: ast.NewIdent(field.Name),
 	}
+	if field.GoType.IsNullable {
+		return cg.generateNullableValidation(structName, field, fieldAccess, failFast)
+	}
+	return cg.generateValueValidation(structName, field, fieldAccess, failFast)
+}
+
+// generateNullableValidation validates the contained value of a set
+// sql.Null* or validation.Optional field. An unset field fails required and
+// defined and skips every other rule.
+func (cg *CodeGenerator) generateNullableValidation(structName string, field *analyzer.FieldInfo, fieldAccess ast.Expr, failFast bool) []ast.Stmt {
+	inner := *field
+	inner.GoType = *field.GoType.ElemType
+	inner.ValidationRules = nil
+	presence, message := "", ""
+	for _, rule := range field.ValidationRules {
+		switch rule.Name {
+		case "required":
+			presence, message = rule.Name, "field is required"
+		case "defined":
+			presence, message = rule.Name, "field must be set"
+		default:
+			inner.ValidationRules = append(inner.ValidationRules, rule)
+		}
+	}
+
+	valid := &ast.SelectorExpr{X: fieldAccess, Sel: ast.NewIdent("Valid")}
+	value := &ast.SelectorExpr{X: fieldAccess, Sel: ast.NewIdent(field.GoType.ValueField)}
+	set := cg.generateValueValidation(structName, &inner, value, failFast)
+	var unset []ast.Stmt
+	if presence != "" {
+		unset = append(unset, cg.generateAddError(field.Name, presence, "", message))
+		if failFast {
+			unset = append(unset, cg.generateFailFastCheck()...)
+		}
+	}
+
+	switch {
+	case len(set) == 0 && len(unset) == 0:
+		return nil
+	case len(set) == 0:
+		return []ast.Stmt{&ast.IfStmt{
+			Cond: &ast.UnaryExpr{Op: token.NOT, X: valid},
+			Body: &ast.BlockStmt{List: unset},
+		}}
+	}
+	check := &ast.IfStmt{Cond: valid, Body: &ast.BlockStmt{List: set}}
+	if len(unset) > 0 {
+		check.Else = &ast.BlockStmt{List: unset}
+	}
+	return []ast.Stmt{check}
+}
 
-	// Handle pointer fields with nil checking
+// generateValueValidation generates the rules of a field for the value at
+// fieldAccess
+func (cg *CodeGenerator) generateValueValidation(structName string, field *analyzer.FieldInfo, fieldAccess ast.Expr, failFast bool) []ast.Stmt {
+	// Rules of a pointer field apply to the value it points to
 	if field.GoType.IsPointer {
-		stmts = append(stmts, cg.generatePointerNilCheck(field, fieldAccess)...)
-		fieldAccess = &ast.SelectorExpr{X: fieldAccess, Sel: ast.NewIdent("*")}
+		return cg.generatePointerValidation(structName, field, fieldAccess, failFast)
+	}
+
+	// Dive applies every rule but the collection rules to the elements
+	if hasRule(field, "dive") {
+		return cg.generateDiveValidation(structName, field, fieldAccess, failFast)
+	}
+	// omitempty skips the rules of an empty value
+	if hasRule(field, "omitempty") {
+		return cg.generateOmitEmptyValidation(structName, field, fieldAccess, failFast)
+	}
+
+	var stmts []ast.Stmt
+
+	// Fuse min and max into a single range check when optimizing
+	minIndex, maxIndex := -1, -1
+	var rangeStmts []ast.Stmt
+	if cg.options.EnableOptimizations {
+		minIndex, maxIndex = rangeRuleIndexes(field.ValidationRules)
+		if minIndex >= 0 {
+			rangeStmts = cg.generateRangeValidation(field, field.ValidationRules[minIndex], field.ValidationRules[maxIndex], fieldAccess)
+		}
+		if rangeStmts == nil {
+			minIndex, maxIndex = -1, -1
+		}
 	}
 
 	// Generate validation for each rule
-	for _, rule := range field.ValidationRules {
-		ruleStmts := cg.generateRuleValidation(field, rule, fieldAccess)
+	for i, rule := range field.ValidationRules {
+		var ruleStmts []ast.Stmt
+		switch i {
+		case maxIndex:
+			continue
+		case minIndex:
+			ruleStmts = rangeStmts
+		default:
+			if rule.Name == "cel" {
+				ruleStmts = cg.generateCELValidation(structName, field, rule, fieldAccess)
+			} else {
+				ruleStmts = cg.generateRuleValidation(field, rule, fieldAccess)
+			}
+		}
 		stmts = append(stmts, ruleStmts...)
 
-		// Add fail-fast check if optimizations are enabled
-		if cg.options.EnableOptimizations && cg.options.FailFast {
+		// Return early in fail-fast mode
+		if failFast {
 			stmts = append(stmts, cg.generateFailFastCheck()...)
 		}
 	}
 
-	// Handle nested struct validation
-	if field.IsNested {
+	// Handle nested struct validation, for the structs a validator is
+	// generated for
+	if _, analyzed := cg.analysisResult.Structs[field.NestedType]; field.IsNested && analyzed {
 		stmts = append(stmts, cg.generateNestedValidation(field, fieldAccess)...)
 	}
 
 	return stmts
 }
 
-// generatePointerNilCheck generates nil checking code for pointer fields
-func (cg *CodeGenerator) generatePointerNilCheck(field *analyzer.FieldInfo, fieldAccess ast.Expr) []ast.Stmt {
-	var stmts []ast.Stmt
+// hasRule reports whether a field has a rule
+func hasRule(field *analyzer.FieldInfo, name string) bool {
+	return slices.ContainsFunc(field.ValidationRules, func(rule analyzer.ValidationRule) bool {
+		return rule.Name == name
+	})
+}
 
-	// Check if field is required
-	isRequired := false
+// generateDiveValidation validates a dive field like the validation
+// package: the collection rules check the field wherever they appear in the
+// tag, and the other rules are passed to validation.VarField, which applies
+// them to each element and reports them at its path, e.g. Tags[0]
+func (cg *CodeGenerator) generateDiveValidation(structName string, field *analyzer.FieldInfo, fieldAccess ast.Expr, failFast bool) []ast.Stmt {
+	collection := *field
+	collection.ValidationRules = nil
+	elemTag := []string{"dive"}
+	omitEmpty := false
 	for _, rule := range field.ValidationRules {
-		if rule.Name == "required" {
-			isRequired = true
-			break
+		switch {
+		case rule.Name == "dive":
+		case rule.Name == "omitempty":
+			omitEmpty = true
+			elemTag = append(elemTag, rule.Name)
+		case collectionRule(rule):
+			collection.ValidationRules = append(collection.ValidationRules, rule)
+		default:
+			elemTag = append(elemTag, ruleString(rule))
 		}
 	}
-
-	if isRequired {
-		// Add nil check for required fields
-		stmts = append(stmts, &ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X:  fieldAccess,
-				Op: token.EQL,
-				Y:  ast.NewIdent("nil"),
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					cg.generateAddError(field.Name, "required", "", "field is required but is nil"),
-				},
-			},
-		})
-	} else {
-		// Skip validation if field is nil and not required
-		stmts = append(stmts, &ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X:  fieldAccess,
-				Op: token.EQL,
-				Y:  ast.NewIdent("nil"),
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{}, // Early return for nil optional fields
-				},
-			},
-		})
+	if omitEmpty && len(collection.ValidationRules) > 0 {
+		collection.ValidationRules = append([]analyzer.ValidationRule{{Name: "omitempty"}}, collection.ValidationRules...)
 	}
 
+	stmts := cg.generateValueValidation(structName, &collection, fieldAccess, failFast)
+	if cg.options.Pure {
+		cg.reportImpure(field, "dive")
+		return stmts
+	}
+	tag := &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(strings.Join(elemTag, ",") + sensitivitySuffix(field))}
+	stmts = append(stmts, cg.generateVarCall(field, fieldAccess, tag))
+	if failFast {
+		stmts = append(stmts, cg.generateFailFastCheck()...)
+	}
 	return stmts
 }
 
+// generateOmitEmptyValidation validates an omitempty field like the
+// validation package: an empty value is only checked by the required rules
+//
+//	if cfg.Email != "" {
+//		... every rule
+//	} else {
+//		... required rules
+//	}
+func (cg *CodeGenerator) generateOmitEmptyValidation(structName string, field *analyzer.FieldInfo, fieldAccess ast.Expr, failFast bool) []ast.Stmt {
+	hasValue := cg.hasValueCondition(field, fieldAccess)
+	if hasValue == nil {
+		cg.reportImpure(field, "omitempty")
+		return nil
+	}
+
+	set, unset := *field, *field
+	set.ValidationRules, unset.ValidationRules = nil, nil
+	for _, rule := range field.ValidationRules {
+		if rule.Name == "omitempty" {
+			continue
+		}
+		set.ValidationRules = append(set.ValidationRules, rule)
+		if strings.HasPrefix(rule.Name, "required") {
+			unset.ValidationRules = append(unset.ValidationRules, rule)
+		}
+	}
+	unset.IsNested = false
+
+	setStmts := cg.generateValueValidation(structName, &set, fieldAccess, failFast)
+	unsetStmts := cg.generateValueValidation(structName, &unset, fieldAccess, failFast)
+	if len(setStmts) == 0 && len(unsetStmts) == 0 {
+		return nil
+	}
+	check := &ast.IfStmt{Cond: hasValue, Body: &ast.BlockStmt{List: setStmts}}
+	if len(unsetStmts) > 0 {
+		check.Else = &ast.BlockStmt{List: unsetStmts}
+	}
+	return []ast.Stmt{check}
+}
+
+// hasValueCondition returns the condition of a field's value not being
+// empty, as omitempty checks it, or nil when pure mode cannot express it
+func (cg *CodeGenerator) hasValueCondition(field *analyzer.FieldInfo, fieldAccess ast.Expr) ast.Expr {
+	switch kind := field.GoType.Kind; {
+	case kind == analyzer.TypeString:
+		return &ast.BinaryExpr{X: fieldAccess, Op: token.NEQ, Y: &ast.BasicLit{Kind: token.STRING, Value: `""`}}
+	case isNumericKind(kind):
+		return &ast.BinaryExpr{X: fieldAccess, Op: token.NEQ, Y: &ast.BasicLit{Kind: token.INT, Value: "0"}}
+	case kind == analyzer.TypeBool:
+		return fieldAccess
+	case kind == analyzer.TypeSlice, kind == analyzer.TypeMap:
+		return &ast.BinaryExpr{
+			X:  &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{fieldAccess}},
+			Op: token.GTR,
+			Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
+		}
+	case cg.options.Pure:
+		return nil
+	}
+	// !reflect.ValueOf(cfg.Timeout).IsZero()
+	return &ast.UnaryExpr{
+		Op: token.NOT,
+		X: &ast.CallExpr{Fun: &ast.SelectorExpr{
+			X:   &ast.CallExpr{Fun: &ast.SelectorExpr{X: ast.NewIdent("reflect"), Sel: ast.NewIdent("ValueOf")}, Args: []ast.Expr{fieldAccess}},
+			Sel: ast.NewIdent("IsZero"),
+		}},
+	}
+}
+
+// generatePointerValidation validates the value a pointer field points to
+// when it is set. A nil pointer fails required and defined and skips every
+// other rule, like an unset nullable field.
+func (cg *CodeGenerator) generatePointerValidation(structName string, field *analyzer.FieldInfo, fieldAccess ast.Expr, failFast bool) []ast.Stmt {
+	inner := *field
+	inner.GoType = analyzer.GoType{Kind: analyzer.TypeUnknown}
+	if field.GoType.ElemType != nil {
+		inner.GoType = *field.GoType.ElemType
+	}
+	if inner.GoType.Kind == analyzer.TypeStruct && !inner.GoType.IsNullable {
+		inner.IsNested, inner.NestedType = true, inner.GoType.Name
+	}
+	inner.ValidationRules = nil
+	presence, message := "", ""
+	for _, rule := range field.ValidationRules {
+		switch rule.Name {
+		case "required":
+			presence, message = rule.Name, "field is required but is nil"
+		case "defined":
+			presence, message = rule.Name, "field must be set but is nil"
+		case "omitempty":
+			// A set pointer has a value
+		default:
+			inner.ValidationRules = append(inner.ValidationRules, rule)
+		}
+	}
+
+	set := cg.generateValueValidation(structName, &inner, &ast.StarExpr{X: fieldAccess}, failFast)
+	var unset []ast.Stmt
+	if presence != "" {
+		unset = append(unset, cg.generateAddError(field.Name, presence, "", message))
+		if failFast {
+			unset = append(unset, cg.generateFailFastCheck()...)
+		}
+	}
+	if len(set) == 0 && len(unset) == 0 {
+		return nil
+	}
+
+	isNil := &ast.BinaryExpr{X: fieldAccess, Op: token.EQL, Y: ast.NewIdent("nil")}
+	if len(set) == 0 {
+		return []ast.Stmt{&ast.IfStmt{Cond: isNil, Body: &ast.BlockStmt{List: unset}}}
+	}
+	check := &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: fieldAccess, Op: token.NEQ, Y: ast.NewIdent("nil")},
+		Body: &ast.BlockStmt{List: set},
+	}
+	if len(unset) > 0 {
+		check.Else = &ast.BlockStmt{List: unset}
+	}
+	return []ast.Stmt{check}
+}
+
 // generateRuleValidation generates validation code for a specific rule
 func (cg *CodeGenerator) generateRuleValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	// Secret references are resolved by the validation package, so every
+	// rule of the field validates through it
+	if isSecret(field) {
+		if rule.Name == "secret" {
+			return nil
+		}
+		return cg.generateGenericValidation(field, rule, fieldAccess)
+	}
+	// Placeholder parameters are only known at validation time
+	if _, _, ok := validation.ParamPlaceholder(rule.Parameter); ok {
+		return cg.generateGenericValidation(field, rule, fieldAccess)
+	}
+	if cg.options.Pure {
+		if stmts, ok := cg.generatePureCheck(field, rule, fieldAccess); ok {
+			return stmts
+		}
+	}
+
 	switch rule.Name {
+	case "sensitive":
+		// Marker only: generic validations of the field redact error values
+		return nil
+	case "immutable":
+		// Compared by StructUpdate, which needs the previous value
+		return nil
 	case "required":
 		return cg.generateRequiredValidation(field, fieldAccess)
 	case "min":
@@ -437,6 +802,10 @@ func (cg *CodeGenerator) generateRuleValidation(field *analyzer.FieldInfo, rule
 		return cg.generateMaxValidation(field, rule, fieldAccess)
 	case "len":
 		return cg.generateLenValidation(field, rule, fieldAccess)
+	case "minbytes", "maxbytes", "minrunes", "maxrunes":
+		return cg.generateSizeValidation(field, rule, fieldAccess)
+	case "minitems", "maxitems", "notempty":
+		return cg.generateItemsValidation(field, rule, fieldAccess)
 	case "email":
 		return cg.generateEmailValidation(field, fieldAccess)
 	case "url", "uri":
@@ -466,13 +835,21 @@ func (cg *CodeGenerator) generateRequiredValidation(field *analyzer.FieldInfo, f
 			Op: token.EQL,
 			Y:  &ast.BasicLit{Kind: token.STRING, Value: `""`},
 		}
-	case analyzer.TypeInt, analyzer.TypeInt8, analyzer.TypeInt16, analyzer.TypeInt32, analyzer.TypeInt64:
+	case analyzer.TypeInt, analyzer.TypeInt8, analyzer.TypeInt16, analyzer.TypeInt32, analyzer.TypeInt64,
+		analyzer.TypeUint, analyzer.TypeUint8, analyzer.TypeUint16, analyzer.TypeUint32, analyzer.TypeUint64,
+		analyzer.TypeFloat32, analyzer.TypeFloat64:
 		condition = &ast.BinaryExpr{
 			X:  fieldAccess,
 			Op: token.EQL,
 			Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
 		}
-	case analyzer.TypeSlice:
+	case analyzer.TypeBool:
+		// Only false is the zero value, no reflection needed
+		condition = &ast.UnaryExpr{
+			Op: token.NOT,
+			X:  fieldAccess,
+		}
+	case analyzer.TypeSlice, analyzer.TypeMap:
 		condition = &ast.BinaryExpr{
 			X: &ast.CallExpr{
 				Fun:  ast.NewIdent("len"),
@@ -482,30 +859,21 @@ func (cg *CodeGenerator) generateRequiredValidation(field *analyzer.FieldInfo, f
 			Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
 		}
 	default:
-		// Use generic zero-value check
+		if cg.options.Pure {
+			cg.reportImpure(field, "required")
+			return nil
+		}
+		// Use generic zero-value check: reflect.ValueOf(cfg.Server).IsZero()
 		condition = &ast.CallExpr{
 			Fun: &ast.SelectorExpr{
-				X:   ast.NewIdent("reflect"),
-				Sel: ast.NewIdent("DeepEqual"),
-			},
-			Args: []ast.Expr{
-				fieldAccess,
-				&ast.CallExpr{
-					Fun: ast.NewIdent("new"),
-					Args: []ast.Expr{
-						&ast.ParenExpr{
-							X: &ast.StarExpr{
-								X: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("reflect"),
-										Sel: ast.NewIdent("TypeOf"),
-									},
-									Args: []ast.Expr{fieldAccess},
-								},
-							},
-						},
+				X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("reflect"),
+						Sel: ast.NewIdent("ValueOf"),
 					},
+					Args: []ast.Expr{fieldAccess},
 				},
+				Sel: ast.NewIdent("IsZero"),
 			},
 		}
 	}
@@ -524,6 +892,10 @@ func (cg *CodeGenerator) generateRequiredValidation(field *analyzer.FieldInfo, f
 
 // generateMinValidation generates optimized minimum value/length validation
 func (cg *CodeGenerator) generateMinValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if _, ok := parseSizeParam(rule.Parameter); ok {
+		return cg.generateSizeValidation(field, rule, fieldAccess)
+	}
+
 	// Try parsing as integer first
 	minVal, intErr := strconv.ParseInt(rule.Parameter, 10, 64)
 	// Try parsing as float if integer parsing fails
@@ -602,6 +974,10 @@ func (cg *CodeGenerator) generateMinValidation(field *analyzer.FieldInfo, rule a
 
 // generateMaxValidation generates optimized maximum value/length validation
 func (cg *CodeGenerator) generateMaxValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if _, ok := parseSizeParam(rule.Parameter); ok {
+		return cg.generateSizeValidation(field, rule, fieldAccess)
+	}
+
 	// Try parsing as integer first
 	maxVal, intErr := strconv.ParseInt(rule.Parameter, 10, 64)
 	// Try parsing as float if integer parsing fails
@@ -678,8 +1054,94 @@ func (cg *CodeGenerator) generateMaxValidation(field *analyzer.FieldInfo, rule a
 	}
 }
 
+// rangeRuleIndexes returns the indexes of the min and max rules of a field,
+// or -1 when either is missing or the field has dive, whose min and max
+// rules apply to the elements rather than the value a range check loads
+func rangeRuleIndexes(rules []analyzer.ValidationRule) (minIndex, maxIndex int) {
+	minIndex, maxIndex = -1, -1
+	for i, rule := range rules {
+		switch {
+		case rule.Name == "dive":
+			return -1, -1
+		case rule.Name == "min" && minIndex < 0:
+			minIndex = i
+		case rule.Name == "max" && maxIndex < 0:
+			maxIndex = i
+		}
+	}
+	if minIndex < 0 || maxIndex < 0 {
+		return -1, -1
+	}
+	return minIndex, maxIndex
+}
+
+// generateRangeValidation fuses min and max checks on the same value into a
+// single range check loading the value once:
+//
+//	if n := len(cfg.Name); n < 3 || n > 50 { ... }
+//
+// It returns nil when the rules cannot be fused, e.g. when either falls back
+// to generic validation or they measure different units.
+func (cg *CodeGenerator) generateRangeValidation(field *analyzer.FieldInfo, minRule, maxRule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	minCheck, ok := comparisonCheck(cg.generateMinValidation(field, minRule, fieldAccess), token.LSS)
+	if !ok {
+		return nil
+	}
+	maxCheck, ok := comparisonCheck(cg.generateMaxValidation(field, maxRule, fieldAccess), token.GTR)
+	if !ok {
+		return nil
+	}
+	minCond := minCheck.Cond.(*ast.BinaryExpr)
+	maxCond := maxCheck.Cond.(*ast.BinaryExpr)
+	if types.ExprString(minCond.X) != types.ExprString(maxCond.X) {
+		return nil
+	}
+
+	value := ast.NewIdent("n")
+	belowMin := &ast.BinaryExpr{X: value, Op: token.LSS, Y: minCond.Y}
+	aboveMax := &ast.BinaryExpr{X: value, Op: token.GTR, Y: maxCond.Y}
+
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{value},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{minCond.X},
+			},
+			Cond: &ast.BinaryExpr{X: belowMin, Op: token.LOR, Y: aboveMax},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.IfStmt{
+						Cond: belowMin,
+						Body: minCheck.Body,
+						Else: maxCheck.Body,
+					},
+				},
+			},
+		},
+	}
+}
+
+// comparisonCheck returns the single if statement of generated rule code
+// comparing a value with op
+func comparisonCheck(stmts []ast.Stmt, op token.Token) (*ast.IfStmt, bool) {
+	if len(stmts) != 1 {
+		return nil, false
+	}
+	check, ok := stmts[0].(*ast.IfStmt)
+	if !ok || check.Init != nil || check.Else != nil {
+		return nil, false
+	}
+	cond, ok := check.Cond.(*ast.BinaryExpr)
+	return check, ok && cond.Op == op
+}
+
 // generateLenValidation generates exact length validation
 func (cg *CodeGenerator) generateLenValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if _, ok := parseSizeParam(rule.Parameter); ok {
+		return cg.generateSizeValidation(field, rule, fieldAccess)
+	}
+
 	lenVal, err := strconv.ParseInt(rule.Parameter, 10, 64)
 	if err != nil {
 		return cg.generateGenericValidation(field, rule, fieldAccess)
@@ -714,13 +1176,156 @@ func (cg *CodeGenerator) generateLenValidation(field *analyzer.FieldInfo, rule a
 	}
 }
 
-// generateEmailValidation generates email validation using existing validator
-func (cg *CodeGenerator) generateEmailValidation(field *analyzer.FieldInfo, fieldAccess ast.Expr) []ast.Stmt {
-	return []ast.Stmt{
-		&ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{ast.NewIdent("err")},
-				Tok: token.DEFINE,
+// parseSizeParam parses min/max/len parameters carrying a unit, such as
+// "5:chars" or "1KB". Plain numbers are not size parameters.
+func parseSizeParam(param string) (validation.SizeSpec, bool) {
+	if _, err := strconv.ParseFloat(param, 64); err == nil {
+		return validation.SizeSpec{}, false
+	}
+	spec, err := validation.ParseSizeSpec(param)
+	return spec, err == nil
+}
+
+// generateSizeValidation generates unit-aware string length validation for
+// minbytes/maxbytes/minrunes/maxrunes and size parameters like "5:chars"
+func (cg *CodeGenerator) generateSizeValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if field.GoType.Kind != analyzer.TypeString {
+		return cg.generateGenericValidation(field, rule, fieldAccess)
+	}
+
+	var spec validation.SizeSpec
+	switch rule.Name {
+	case "minbytes", "maxbytes", "minrunes", "maxrunes":
+		value, err := strconv.ParseInt(rule.Parameter, 10, 64)
+		if err != nil {
+			return cg.generateGenericValidation(field, rule, fieldAccess)
+		}
+		spec = validation.SizeSpec{Value: value, Type: validation.SizeBytes}
+		if strings.HasSuffix(rule.Name, "runes") {
+			spec.Type = validation.SizeRunes
+		}
+	default:
+		var ok bool
+		if spec, ok = parseSizeParam(rule.Parameter); !ok {
+			return cg.generateGenericValidation(field, rule, fieldAccess)
+		}
+	}
+
+	var measure ast.Expr
+	var unit string
+	switch spec.Type {
+	case validation.SizeBytes, validation.SizeDefault:
+		measure = &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{fieldAccess}}
+		unit = "bytes"
+	case validation.SizeChars, validation.SizeRunes, validation.SizeCodePoints:
+		measure = &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: ast.NewIdent("utf8"), Sel: ast.NewIdent("RuneCountInString")},
+			Args: []ast.Expr{fieldAccess},
+		}
+		unit = "characters"
+	default:
+		return cg.generateGenericValidation(field, rule, fieldAccess)
+	}
+
+	var op token.Token
+	var errorMessage string
+	switch rule.Name {
+	case "min", "minbytes", "minrunes":
+		op = token.LSS
+		errorMessage = fmt.Sprintf("value must be at least %d %s", spec.Value, unit)
+	case "max", "maxbytes", "maxrunes":
+		op = token.GTR
+		errorMessage = fmt.Sprintf("value must be at most %d %s", spec.Value, unit)
+	default:
+		op = token.NEQ
+		errorMessage = fmt.Sprintf("value must be exactly %d %s", spec.Value, unit)
+	}
+
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  measure,
+				Op: op,
+				Y:  &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(spec.Value, 10)},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					cg.generateAddError(field.Name, rule.Name, rule.Parameter, errorMessage),
+				},
+			},
+		},
+	}
+}
+
+// generateItemsValidation generates collection length validation for
+// minitems/maxitems/notempty
+func (cg *CodeGenerator) generateItemsValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if field.GoType.Kind != analyzer.TypeSlice && field.GoType.Kind != analyzer.TypeMap {
+		return cg.generateGenericValidation(field, rule, fieldAccess)
+	}
+
+	limit := int64(1)
+	if rule.Name != "notempty" {
+		var err error
+		if limit, err = strconv.ParseInt(rule.Parameter, 10, 64); err != nil {
+			return cg.generateGenericValidation(field, rule, fieldAccess)
+		}
+	}
+
+	op := token.LSS
+	errorMessage := fmt.Sprintf("must contain at least %d items", limit)
+	switch rule.Name {
+	case "maxitems":
+		op = token.GTR
+		errorMessage = fmt.Sprintf("must contain at most %d items", limit)
+	case "notempty":
+		errorMessage = "must not be empty"
+	}
+
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{fieldAccess}},
+				Op: op,
+				Y:  &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(limit, 10)},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					cg.generateAddError(field.Name, rule.Name, rule.Parameter, errorMessage),
+				},
+			},
+		},
+	}
+}
+
+// isSensitive reports whether a field is tagged sensitive
+func isSensitive(field *analyzer.FieldInfo) bool {
+	for _, rule := range field.ValidationRules {
+		if rule.Name == "sensitive" {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecret reports whether a field is tagged secret, holding references
+// resolved before its rules run
+func isSecret(field *analyzer.FieldInfo) bool {
+	for _, rule := range field.ValidationRules {
+		if rule.Name == "secret" {
+			return true
+		}
+	}
+	return false
+}
+
+// generateEmailValidation generates email validation using existing validator
+func (cg *CodeGenerator) generateEmailValidation(field *analyzer.FieldInfo, fieldAccess ast.Expr) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
 				Rhs: []ast.Expr{
 					&ast.CallExpr{
 						Fun: &ast.SelectorExpr{
@@ -997,6 +1602,11 @@ func (cg *CodeGenerator) generateNumericValidation(field *analyzer.FieldInfo, fi
 
 // generateGenericValidation generates fallback validation using the validation library
 func (cg *CodeGenerator) generateGenericValidation(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) []ast.Stmt {
+	if cg.options.Pure {
+		cg.reportImpure(field, rule.Name)
+		return nil
+	}
+
 	// Construct validation tag
 	var tag string
 	if rule.Parameter != "" {
@@ -1004,40 +1614,85 @@ func (cg *CodeGenerator) generateGenericValidation(field *analyzer.FieldInfo, ru
 	} else {
 		tag = rule.Name
 	}
-
-	return []ast.Stmt{
-		&ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{ast.NewIdent("err")},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("validation"),
-							Sel: ast.NewIdent("Var"),
-						},
-						Args: []ast.Expr{
-							fieldAccess,
-							&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`"%s"`, tag)},
-						},
-					},
-				},
+	suffix := sensitivitySuffix(field)
+	tagExpr := ast.Expr(&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(tag + suffix)})
+
+	// A $Field parameter is substituted with the sibling's current value;
+	// the validation package resolves ${ENV} parameters itself
+	if name, env, ok := validation.ParamPlaceholder(rule.Parameter); ok && !env {
+		tagExpr = &ast.BinaryExpr{
+			X:  &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(rule.Name + "=")},
+			Op: token.ADD,
+			Y: &ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: ast.NewIdent("validation"), Sel: ast.NewIdent("FormatParam")},
+				Args: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("cfg"), Sel: ast.NewIdent(name)}},
 			},
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("err"),
-				Op: token.NEQ,
-				Y:  ast.NewIdent("nil"),
+		}
+		if suffix != "" {
+			tagExpr = &ast.BinaryExpr{X: tagExpr, Op: token.ADD, Y: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(suffix)}}
+		}
+	}
+
+	return []ast.Stmt{cg.generateVarCall(field, fieldAccess, tagExpr)}
+}
+
+// sensitivitySuffix returns the rule a generic validation of a field appends
+// to its tag so errors redact the value: secret or sensitive
+func sensitivitySuffix(field *analyzer.FieldInfo) string {
+	switch {
+	case isSecret(field):
+		return ",secret"
+	case isSensitive(field):
+		return ",sensitive"
+	}
+	return ""
+}
+
+// generateVarCall validates a value against tag through the validation
+// package. Struct fields are validated by validation.VarField, which reports
+// them by name and lets cross-field rules read their siblings:
+//
+//	if err := validation.VarField(cfg, "End", "gtfield=Start"); err != nil {
+//		v.addValidationError(err)
+//	}
+//
+// Other values, such as the parameter of a field method, use validation.Var.
+func (cg *CodeGenerator) generateVarCall(field *analyzer.FieldInfo, fieldAccess ast.Expr, tagExpr ast.Expr) ast.Stmt {
+	call := &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: ast.NewIdent("validation"), Sel: ast.NewIdent("Var")},
+		Args: []ast.Expr{fieldAccess, tagExpr},
+	}
+	if rootIdent(fieldAccess) == "cfg" {
+		call = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: ast.NewIdent("validation"), Sel: ast.NewIdent("VarField")},
+			Args: []ast.Expr{
+				ast.NewIdent("cfg"),
+				&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(field.Name)},
+				tagExpr,
 			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ExprStmt{
-						X: &ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("v"),
-								Sel: ast.NewIdent("addValidationError"),
-							},
-							Args: []ast.Expr{ast.NewIdent("err")},
+		}
+	}
+
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{call},
+		},
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("v"),
+							Sel: ast.NewIdent("addValidationError"),
 						},
+						Args: []ast.Expr{ast.NewIdent("err")},
 					},
 				},
 			},
@@ -1045,9 +1700,32 @@ func (cg *CodeGenerator) generateGenericValidation(field *analyzer.FieldInfo, ru
 	}
 }
 
-// generateNestedValidation generates validation for nested structs
+// rootIdent returns the variable a selector or dereference chain starts
+// from: cfg for cfg.Count.Int64 and *cfg.Port
+func rootIdent(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// generateNestedValidation generates validation for nested structs, whose
+// validators take a pointer: cfg.Server is passed as &cfg.Server and the
+// value of a checked pointer, *cfg.TLS, as cfg.TLS
 func (cg *CodeGenerator) generateNestedValidation(field *analyzer.FieldInfo, fieldAccess ast.Expr) []ast.Stmt {
 	validatorName := field.NestedType + "Validator"
+	pointer := ast.Expr(&ast.UnaryExpr{Op: token.AND, X: fieldAccess})
+	if star, ok := fieldAccess.(*ast.StarExpr); ok {
+		pointer = star.X
+	}
 
 	return []ast.Stmt{
 		&ast.IfStmt{
@@ -1060,6 +1738,11 @@ func (cg *CodeGenerator) generateNestedValidation(field *analyzer.FieldInfo, fie
 					},
 				},
 			},
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("nestedValidator"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
 					&ast.IfStmt{
@@ -1072,7 +1755,7 @@ func (cg *CodeGenerator) generateNestedValidation(field *analyzer.FieldInfo, fie
 										X:   ast.NewIdent("nestedValidator"),
 										Sel: ast.NewIdent("Validate"),
 									},
-									Args: []ast.Expr{fieldAccess},
+									Args: []ast.Expr{pointer},
 								},
 							},
 						},
@@ -1104,39 +1787,30 @@ func (cg *CodeGenerator) generateNestedValidation(field *analyzer.FieldInfo, fie
 	}
 }
 
-// generateFailFastCheck generates fail-fast optimization code
+// generateFailFastCheck generates code returning the collected errors as
+// soon as there are any
 func (cg *CodeGenerator) generateFailFastCheck() []ast.Stmt {
 	return []ast.Stmt{
 		&ast.IfStmt{
 			Cond: &ast.BinaryExpr{
-				X: &ast.SelectorExpr{
-					X:   ast.NewIdent("v"),
-					Sel: ast.NewIdent("failFast"),
-				},
-				Op: token.LAND,
-				Y: &ast.BinaryExpr{
-					X: &ast.CallExpr{
-						Fun: ast.NewIdent("len"),
-						Args: []ast.Expr{
-							&ast.SelectorExpr{
-								X:   ast.NewIdent("v"),
-								Sel: ast.NewIdent("errors"),
-							},
+				X: &ast.CallExpr{
+					Fun: ast.NewIdent("len"),
+					Args: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("v"),
+							Sel: ast.NewIdent("errors"),
 						},
 					},
-					Op: token.GTR,
-					Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
 				},
+				Op: token.GTR,
+				Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
 			},
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
 					&ast.ReturnStmt{
 						Results: []ast.Expr{
 							&ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("validation"),
-									Sel: ast.NewIdent("ValidationErrors"),
-								},
+								Fun: cg.validationType("ValidationErrors"),
 								Args: []ast.Expr{
 									&ast.SelectorExpr{
 										X:   ast.NewIdent("v"),
@@ -1152,6 +1826,47 @@ func (cg *CodeGenerator) generateFailFastCheck() []ast.Stmt {
 	}
 }
 
+// generateSetFailFastMethod generates SetFailFast, which selects between
+// Validate and ValidateFast at runtime
+func (cg *CodeGenerator) generateSetFailFastMethod(structName string) *ast.FuncDecl {
+	validatorName := structName + "Validator"
+
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("v")},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(validatorName),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("SetFailFast"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{Names: []*ast.Ident{ast.NewIdent("enabled")}, Type: ast.NewIdent("bool")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("v"),
+							Sel: ast.NewIdent("failFast"),
+						},
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("enabled")},
+				},
+			},
+		},
+	}
+}
+
 // generateAddError generates code to add a validation error
 func (cg *CodeGenerator) generateAddError(fieldName, tag, param, message string) ast.Stmt {
 	return &ast.ExprStmt{
@@ -1161,10 +1876,10 @@ func (cg *CodeGenerator) generateAddError(fieldName, tag, param, message string)
 				Sel: ast.NewIdent("addError"),
 			},
 			Args: []ast.Expr{
-				&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`"%s"`, fieldName)},
-				&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`"%s"`, tag)},
-				&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`"%s"`, param)},
-				&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`"%s"`, message)},
+				&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(fieldName)},
+				&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(tag)},
+				&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(param)},
+				&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(message)},
 			},
 		},
 	}
@@ -1176,19 +1891,17 @@ func (cg *CodeGenerator) generateFieldValidationMethod(structName string, field
 	if len(field.ValidationRules) < 3 && !field.IsNested {
 		return nil
 	}
+	// The methods only see the field, not the struct other rules read
+	for _, rule := range field.ValidationRules {
+		if _, env, ok := validation.ParamPlaceholder(rule.Parameter); rule.Name == "cel" || rule.Name == "dive" || rule.IsConditional || ok && !env {
+			return nil
+		}
+	}
 
 	validatorName := structName + "Validator"
 	methodName := fmt.Sprintf("validate%s", field.Name)
 
-	var stmts []ast.Stmt
-	fieldAccess := ast.NewIdent("value")
-
-	// Generate validation for each rule
-	for _, rule := range field.ValidationRules {
-		ruleStmts := cg.generateRuleValidation(field, rule, fieldAccess)
-		stmts = append(stmts, ruleStmts...)
-	}
-
+	stmts := cg.generateValueValidation(structName, field, ast.NewIdent("value"), false)
 	stmts = append(stmts, &ast.ReturnStmt{
 		Results: []ast.Expr{ast.NewIdent("nil")},
 	})
@@ -1210,7 +1923,7 @@ func (cg *CodeGenerator) generateFieldValidationMethod(structName string, field
 				List: []*ast.Field{
 					{
 						Names: []*ast.Ident{ast.NewIdent("value")},
-						Type:  ast.NewIdent(field.Type),
+						Type:  cg.typeExpr(field.Type),
 					},
 				},
 			},
@@ -1271,10 +1984,7 @@ func (cg *CodeGenerator) generateHelperMethods(structName string) []ast.Decl {
 									Sel: ast.NewIdent("errors"),
 								},
 								&ast.CompositeLit{
-									Type: &ast.SelectorExpr{
-										X:   ast.NewIdent("validation"),
-										Sel: ast.NewIdent("ValidationError"),
-									},
+									Type: cg.validationType("ValidationError"),
 									Elts: []ast.Expr{
 										&ast.KeyValueExpr{
 											Key:   ast.NewIdent("Field"),
@@ -1324,51 +2034,126 @@ func (cg *CodeGenerator) generateHelperMethods(structName string) []ast.Decl {
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
-				&ast.IfStmt{
-					Init: &ast.AssignStmt{
-						Lhs: []ast.Expr{ast.NewIdent("valErr"), ast.NewIdent("ok")},
+				// Var returns ValidationErrors, the format helpers a
+				// single ValidationError
+				&ast.TypeSwitchStmt{
+					Assign: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("err")},
 						Tok: token.DEFINE,
-						Rhs: []ast.Expr{
-							&ast.TypeAssertExpr{
-								X:    ast.NewIdent("err"),
-								Type: &ast.SelectorExpr{X: ast.NewIdent("validation"), Sel: ast.NewIdent("ValidationError")},
-							},
-						},
+						Rhs: []ast.Expr{&ast.TypeAssertExpr{X: ast.NewIdent("err")}},
 					},
-					Cond: ast.NewIdent("ok"),
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									&ast.SelectorExpr{
-										X:   ast.NewIdent("v"),
-										Sel: ast.NewIdent("errors"),
-									},
-								},
+					Body: &ast.BlockStmt{List: []ast.Stmt{
+						&ast.CaseClause{
+							List: []ast.Expr{cg.validationType("ValidationErrors")},
+							Body: []ast.Stmt{&ast.AssignStmt{
+								Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")}},
 								Tok: token.ASSIGN,
-								Rhs: []ast.Expr{
-									&ast.CallExpr{
-										Fun: ast.NewIdent("append"),
-										Args: []ast.Expr{
-											&ast.SelectorExpr{
-												X:   ast.NewIdent("v"),
-												Sel: ast.NewIdent("errors"),
-											},
-											ast.NewIdent("valErr"),
-										},
-									},
-								},
-							},
+								Rhs: []ast.Expr{&ast.CallExpr{
+									Fun:      ast.NewIdent("append"),
+									Args:     []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")}, ast.NewIdent("err")},
+									Ellipsis: 1,
+								}},
+							}},
 						},
-					},
+						&ast.CaseClause{
+							List: []ast.Expr{cg.validationType("ValidationError")},
+							Body: []ast.Stmt{&ast.AssignStmt{
+								Lhs: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")}},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{&ast.CallExpr{
+									Fun:  ast.NewIdent("append"),
+									Args: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")}, ast.NewIdent("err")},
+								}},
+							}},
+						},
+					}},
 				},
 			},
 		},
 	})
 
+	// addNestedErrors helper method, reporting the errors of a nested
+	// struct's validator under the field holding it
+	decls = append(decls, cg.generateAddNestedErrorsMethod(validatorName))
+
 	return decls
 }
 
+// generateAddNestedErrorsMethod generates addNestedErrors, which prefixes
+// the errors of a nested validator with the field holding the struct: the
+// namespace, as the reflection engine reports it, or the field in pure mode
+//
+//	func (v *ConfigValidator) addNestedErrors(field string, err error) {
+//		errs, _ := err.(validation.ValidationErrors)
+//		for _, e := range errs {
+//			if e.Namespace == "" {
+//				e.Namespace = e.Field
+//			}
+//			e.Namespace = field + "." + e.Namespace
+//			v.errors = append(v.errors, e)
+//		}
+//	}
+func (cg *CodeGenerator) generateAddNestedErrorsMethod(validatorName string) *ast.FuncDecl {
+	path := "Namespace"
+	if cg.options.Pure {
+		path = "Field"
+	}
+	elem := func(name string) ast.Expr { return &ast.SelectorExpr{X: ast.NewIdent("e"), Sel: ast.NewIdent(name)} }
+	errorsField := func() ast.Expr { return &ast.SelectorExpr{X: ast.NewIdent("v"), Sel: ast.NewIdent("errors")} }
+
+	var loop []ast.Stmt
+	if !cg.options.Pure {
+		loop = append(loop, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: elem("Namespace"), Op: token.EQL, Y: &ast.BasicLit{Kind: token.STRING, Value: `""`}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.AssignStmt{Lhs: []ast.Expr{elem("Namespace")}, Tok: token.ASSIGN, Rhs: []ast.Expr{elem("Field")}},
+			}},
+		})
+	}
+	loop = append(loop,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{elem(path)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.BinaryExpr{
+				X:  &ast.BinaryExpr{X: ast.NewIdent("field"), Op: token.ADD, Y: &ast.BasicLit{Kind: token.STRING, Value: `"."`}},
+				Op: token.ADD,
+				Y:  elem(path),
+			}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{errorsField()},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: ast.NewIdent("append"), Args: []ast.Expr{errorsField(), ast.NewIdent("e")}}},
+		},
+	)
+
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{List: []*ast.Field{{
+			Names: []*ast.Ident{ast.NewIdent("v")},
+			Type:  &ast.StarExpr{X: ast.NewIdent(validatorName)},
+		}}},
+		Name: ast.NewIdent("addNestedErrors"),
+		Type: &ast.FuncType{Params: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{ast.NewIdent("field")}, Type: ast.NewIdent("string")},
+			{Names: []*ast.Ident{ast.NewIdent("err")}, Type: ast.NewIdent("error")},
+		}}},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("errs"), ast.NewIdent("_")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.TypeAssertExpr{X: ast.NewIdent("err"), Type: cg.validationType("ValidationErrors")}},
+			},
+			&ast.RangeStmt{
+				Key:   ast.NewIdent("_"),
+				Value: ast.NewIdent("e"),
+				Tok:   token.DEFINE,
+				X:     ast.NewIdent("errs"),
+				Body:  &ast.BlockStmt{List: loop},
+			},
+		}},
+	}
+}
+
 // generateStrategyFactory generates go-config compatible strategy factory
 func (cg *CodeGenerator) generateStrategyFactory() error {
 	filename := "validation_strategy_gen.go"
@@ -1378,32 +2163,19 @@ func (cg *CodeGenerator) generateStrategyFactory() error {
 	file := &ast.File{
 		Name: ast.NewIdent(cg.options.PackageName),
 		Decls: []ast.Decl{
-			cg.generateFileHeader(),
-			cg.generateStrategyImports(),
+			cg.generateValidatorRegistry(),
+			cg.generateValidateAny(),
 			cg.generateStrategyInterface(),
 			cg.generateStrategyImpl(),
+			cg.generateStrategyValidateMethod(),
 			cg.generateStrategyConstructor(),
 		},
 	}
+	cg.addImports(file)
 
 	return cg.writeFormattedFile(outputPath, file)
 }
 
-// generateStrategyImports generates imports for strategy file
-func (cg *CodeGenerator) generateStrategyImports() *ast.GenDecl {
-	return &ast.GenDecl{
-		Tok: token.IMPORT,
-		Specs: []ast.Spec{
-			&ast.ImportSpec{
-				Path: &ast.BasicLit{Kind: token.STRING, Value: `"context"`},
-			},
-			&ast.ImportSpec{
-				Path: &ast.BasicLit{Kind: token.STRING, Value: `"fmt"`},
-			},
-		},
-	}
-}
-
 // generateStrategyInterface generates the validation strategy interface
 func (cg *CodeGenerator) generateStrategyInterface() *ast.GenDecl {
 	return &ast.GenDecl{
@@ -1438,45 +2210,75 @@ func (cg *CodeGenerator) generateStrategyInterface() *ast.GenDecl {
 	}
 }
 
-// generateStrategyImpl generates the strategy implementation
+// generateStrategyImpl generates the strategy implementation, which
+// dispatches through ValidateAny
 func (cg *CodeGenerator) generateStrategyImpl() *ast.GenDecl {
 	return &ast.GenDecl{
 		Tok: token.TYPE,
 		Specs: []ast.Spec{
 			&ast.TypeSpec{
 				Name: ast.NewIdent("GeneratedValidationStrategy"),
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: []*ast.Field{
-							{
-								Names: []*ast.Ident{ast.NewIdent("validators")},
-								Type: &ast.MapType{
-									Key: ast.NewIdent("string"),
-									Value: &ast.InterfaceType{
-										Methods: &ast.FieldList{
-											List: []*ast.Field{
-												{
-													Names: []*ast.Ident{ast.NewIdent("Validate")},
-													Type: &ast.FuncType{
-														Params: &ast.FieldList{
-															List: []*ast.Field{
-																{Type: ast.NewIdent("interface{}")},
-															},
-														},
-														Results: &ast.FieldList{
-															List: []*ast.Field{
-																{Type: ast.NewIdent("error")},
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
+				Type: &ast.StructType{Fields: &ast.FieldList{}},
+			},
+		},
+	}
+}
+
+// generateStrategyValidateMethod generates the strategy's Validate method
+func (cg *CodeGenerator) generateStrategyValidateMethod() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("s")},
+					Type:  &ast.StarExpr{X: ast.NewIdent("GeneratedValidationStrategy")},
+				},
+			},
+		},
+		Name: ast.NewIdent("Validate"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{Names: []*ast.Ident{ast.NewIdent("ctx")}, Type: &ast.SelectorExpr{X: ast.NewIdent("context"), Sel: ast.NewIdent("Context")}},
+					{Names: []*ast.Ident{ast.NewIdent("config")}, Type: ast.NewIdent("interface{}")},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{X: ast.NewIdent("ctx"), Sel: ast.NewIdent("Err")},
 							},
 						},
 					},
+					Cond: &ast.BinaryExpr{
+						X:  ast.NewIdent("err"),
+						Op: token.NEQ,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("err")}},
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun:  ast.NewIdent("ValidateAny"),
+							Args: []ast.Expr{ast.NewIdent("config")},
+						},
+					},
 				},
 			},
 		},
@@ -1485,18 +2287,6 @@ func (cg *CodeGenerator) generateStrategyImpl() *ast.GenDecl {
 
 // generateStrategyConstructor generates the strategy constructor
 func (cg *CodeGenerator) generateStrategyConstructor() *ast.FuncDecl {
-	var initElements []ast.Expr
-
-	// Add validator for each struct
-	for structName := range cg.analysisResult.Structs {
-		initElements = append(initElements, &ast.KeyValueExpr{
-			Key: &ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf(`"%s"`, structName)},
-			Value: &ast.CallExpr{
-				Fun: ast.NewIdent("New" + structName + "Validator"),
-			},
-		})
-	}
-
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("NewGeneratedValidationStrategy"),
 		Type: &ast.FuncType{
@@ -1512,46 +2302,184 @@ func (cg *CodeGenerator) generateStrategyConstructor() *ast.FuncDecl {
 					Results: []ast.Expr{
 						&ast.UnaryExpr{
 							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: ast.NewIdent("GeneratedValidationStrategy"),
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key: ast.NewIdent("validators"),
-										Value: &ast.CompositeLit{
-											Type: &ast.MapType{
-												Key: ast.NewIdent("string"),
-												Value: &ast.InterfaceType{
-													Methods: &ast.FieldList{},
-												},
-											},
-											Elts: initElements,
-										},
-									},
-								},
+							X:  &ast.CompositeLit{Type: ast.NewIdent("GeneratedValidationStrategy")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// sortedStructNames returns the names of the analyzed structs in a stable
+// order, so generated files do not change between runs
+func (cg *CodeGenerator) sortedStructNames() []string {
+	names := make([]string, 0, len(cg.analysisResult.Structs))
+	for structName := range cg.analysisResult.Structs {
+		names = append(names, structName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// generateValidatorRegistry generates the registry mapping config type names
+// to the constructors of their generated validators
+func (cg *CodeGenerator) generateValidatorRegistry() *ast.GenDecl {
+	constructorType := &ast.FuncType{
+		Params: &ast.FieldList{},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{Type: ast.NewIdent("interface{}")},
+			},
+		},
+	}
+
+	var entries []ast.Expr
+	for _, structName := range cg.sortedStructNames() {
+		entries = append(entries, &ast.KeyValueExpr{
+			Key: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(structName)},
+			Value: &ast.FuncLit{
+				Type: constructorType,
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								&ast.CallExpr{Fun: ast.NewIdent("New" + structName + "Validator")},
 							},
 						},
 					},
 				},
 			},
+		})
+	}
+
+	return &ast.GenDecl{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{Text: "// ValidatorConstructors maps config type names to constructors of their generated validators"},
+			},
+		},
+		Tok: token.VAR,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{ast.NewIdent("ValidatorConstructors")},
+				Values: []ast.Expr{
+					&ast.CompositeLit{
+						Type: &ast.MapType{
+							Key:   ast.NewIdent("string"),
+							Value: constructorType,
+						},
+						Elts: entries,
+					},
+				},
+			},
 		},
 	}
 }
 
-// writeFormattedFile writes an AST file with proper formatting
-func (cg *CodeGenerator) writeFormattedFile(filename string, file *ast.File) error {
-	f, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file %s: %w", filename, err)
+// generateValidateAny generates ValidateAny, which dispatches a config value
+// or pointer to its generated validator with a type switch
+func (cg *CodeGenerator) generateValidateAny() *ast.FuncDecl {
+	validateWith := func(structName string, arg ast.Expr) *ast.BlockStmt {
+		return &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   &ast.CallExpr{Fun: ast.NewIdent("New" + structName + "Validator")},
+								Sel: ast.NewIdent("Validate"),
+							},
+							Args: []ast.Expr{arg},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	var clauses []ast.Stmt
+	for _, structName := range cg.sortedStructNames() {
+		clauses = append(clauses,
+			&ast.CaseClause{
+				List: []ast.Expr{&ast.StarExpr{X: cg.typeExpr(structName)}},
+				Body: validateWith(structName, ast.NewIdent("c")).List,
+			},
+			&ast.CaseClause{
+				List: []ast.Expr{cg.typeExpr(structName)},
+				Body: validateWith(structName, &ast.UnaryExpr{Op: token.AND, X: ast.NewIdent("c")}).List,
+			},
+		)
+	}
+	clauses = append(clauses, &ast.CaseClause{
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{X: ast.NewIdent("fmt"), Sel: ast.NewIdent("Errorf")},
+						Args: []ast.Expr{
+							&ast.BasicLit{Kind: token.STRING, Value: `"no generated validator for %T"`},
+							ast.NewIdent("cfg"),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	return &ast.FuncDecl{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{Text: "// ValidateAny validates a config struct or pointer with its generated validator"},
+			},
+		},
+		Name: ast.NewIdent("ValidateAny"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{Names: []*ast.Ident{ast.NewIdent("cfg")}, Type: ast.NewIdent("interface{}")},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.TypeSwitchStmt{
+					Assign: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("c")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{&ast.TypeAssertExpr{X: ast.NewIdent("cfg")}},
+					},
+					Body: &ast.BlockStmt{List: clauses},
+				},
+			},
+		},
 	}
-	defer f.Close()
+}
+
+// writeFormattedFile writes an AST file with proper formatting. The file is
+// formatted before it is created, so a formatting error leaves no partial
+// file behind.
+func (cg *CodeGenerator) writeFormattedFile(filename string, file *ast.File) error {
+	var buf bytes.Buffer
 
-	// Write generation comment
-	f.WriteString("// Code generated by go-validation/cmd/configvalidator. DO NOT EDIT.\n\n")
+	// Write build constraint and generation comment
+	if cg.options.BuildTags != "" {
+		fmt.Fprintf(&buf, "//go:build %s\n\n", cg.options.BuildTags)
+	}
+	buf.WriteString("// Code generated by go-validation/cmd/configvalidator. DO NOT EDIT.\n\n")
 
-	// Format and write the AST
-	if err := format.Node(f, cg.fileSet, file); err != nil {
+	// Format the AST
+	if err := format.Node(&buf, cg.fileSet, file); err != nil {
 		return fmt.Errorf("failed to format file %s: %w", filename, err)
 	}
 
+	if err := os.WriteFile(filename, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filename, err)
+	}
 	return nil
 }
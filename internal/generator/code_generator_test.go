@@ -1,16 +1,22 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/format"
+	"go/importer"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
+	validation "github.com/mateothegreat/go-validation"
 	"github.com/mateothegreat/go-validation/internal/analyzer"
 )
 
@@ -174,7 +180,7 @@ func TestCodeGenerator_FieldValidation(t *testing.T) {
 	}
 
 	// Generate field validation
-	stmts := generator.generateFieldValidation("TestConfig", emailField)
+	stmts := generator.generateFieldValidation("TestConfig", emailField, false)
 
 	if len(stmts) == 0 {
 		t.Error("Expected validation statements to be generated")
@@ -320,13 +326,13 @@ func TestCodeGenerator_PointerHandling(t *testing.T) {
 		},
 	}
 
-	generator := &CodeGenerator{}
+	generator := &CodeGenerator{analysisResult: &analyzer.AnalysisResult{}}
 	fieldAccess := &ast.SelectorExpr{
 		X:   ast.NewIdent("cfg"),
 		Sel: ast.NewIdent("OptionalValue"),
 	}
 
-	stmts := generator.generatePointerNilCheck(&field, fieldAccess)
+	stmts := generator.generatePointerValidation("Config", &field, fieldAccess, false)
 
 	if len(stmts) == 0 {
 		t.Error("Expected pointer nil check statements")
@@ -587,7 +593,7 @@ func BenchmarkCodeGenerator_FieldValidation(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_ = generator.generateFieldValidation("TestConfig", field)
+		_ = generator.generateFieldValidation("TestConfig", field, false)
 	}
 }
 
@@ -800,3 +806,781 @@ func TestCodeGenerator_GeneratedCodeFormat(t *testing.T) {
 	fmt.Println("--------------------------------")
 	fmt.Println(string(content))
 }
+
+// TestCodeGenerator_SizeValidation tests unit-aware length validation generation
+func TestCodeGenerator_SizeValidation(t *testing.T) {
+	generator := NewCodeGenerator(&analyzer.AnalysisResult{PackageName: "test"}, GeneratorOptions{PackageName: "test"})
+	field := analyzer.FieldInfo{
+		Name:   "Name",
+		GoType: analyzer.GoType{Kind: analyzer.TypeString, Name: "string"},
+	}
+	fieldAccess := &ast.SelectorExpr{X: ast.NewIdent("cfg"), Sel: ast.NewIdent("Name")}
+
+	tests := []struct {
+		rule     analyzer.ValidationRule
+		expected string
+	}{
+		{analyzer.ValidationRule{Name: "max", Parameter: "5:chars"}, "utf8.RuneCountInString(cfg.Name) > 5"},
+		{analyzer.ValidationRule{Name: "min", Parameter: "2:runes"}, "utf8.RuneCountInString(cfg.Name) < 2"},
+		{analyzer.ValidationRule{Name: "len", Parameter: "3:codepoints"}, "utf8.RuneCountInString(cfg.Name) != 3"},
+		{analyzer.ValidationRule{Name: "max", Parameter: "1KB"}, "len(cfg.Name) > 1024"},
+		{analyzer.ValidationRule{Name: "maxbytes", Parameter: "64"}, "len(cfg.Name) > 64"},
+		{analyzer.ValidationRule{Name: "minrunes", Parameter: "3"}, "utf8.RuneCountInString(cfg.Name) < 3"},
+	}
+
+	for _, tt := range tests {
+		stmts := generator.generateRuleValidation(&field, tt.rule, fieldAccess)
+		if len(stmts) != 1 {
+			t.Fatalf("Expected one statement for %s=%s, got %d", tt.rule.Name, tt.rule.Parameter, len(stmts))
+		}
+		ifStmt, ok := stmts[0].(*ast.IfStmt)
+		if !ok {
+			t.Fatalf("Expected if statement for %s=%s", tt.rule.Name, tt.rule.Parameter)
+		}
+
+		var buf bytes.Buffer
+		if err := format.Node(&buf, token.NewFileSet(), ifStmt.Cond); err != nil {
+			t.Fatalf("Failed to format condition: %v", err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("Expected condition %q for %s=%s, got %q", tt.expected, tt.rule.Name, tt.rule.Parameter, buf.String())
+		}
+	}
+}
+
+// TestCodeGenerator_FailFastModes tests generation of collect-all and fail-fast methods
+func TestCodeGenerator_FailFastModes(t *testing.T) {
+	analysisResult := createTestAnalysisResult()
+	generator := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "test", FailFast: true})
+	structInfo := analysisResult.Structs["TestConfig"]
+
+	render := func(node ast.Node) string {
+		var buf bytes.Buffer
+		if err := format.Node(&buf, token.NewFileSet(), node); err != nil {
+			t.Fatalf("Failed to format node: %v", err)
+		}
+		return buf.String()
+	}
+
+	validate := render(generator.generateValidateMethod("TestConfig", structInfo))
+	if !strings.Contains(validate, "if v.failFast {\n\t\treturn v.ValidateFast(cfg)\n\t}") {
+		t.Errorf("Expected Validate to defer to ValidateFast, got:\n%s", validate)
+	}
+	if !strings.Contains(validate, "v.errors = v.errors[0:0]") {
+		t.Errorf("Expected Validate to reset errors, got:\n%s", validate)
+	}
+
+	method := generator.generateValidateFastMethod("TestConfig", structInfo)
+	if method.Name.Name != "ValidateFast" {
+		t.Errorf("Expected method name ValidateFast, got %s", method.Name.Name)
+	}
+	validateFast := render(method)
+	if strings.Contains(validateFast, "v.ValidateFast(cfg)") {
+		t.Error("Expected ValidateFast not to call itself")
+	}
+	if strings.Count(validateFast, "if len(v.errors) > 0 {") < 2 {
+		t.Errorf("Expected ValidateFast to return after failed rules, got:\n%s", validateFast)
+	}
+
+	setFailFast := render(generator.generateSetFailFastMethod("TestConfig"))
+	if !strings.Contains(setFailFast, "func (v *TestConfigValidator) SetFailFast(enabled bool)") ||
+		!strings.Contains(setFailFast, "v.failFast = enabled") {
+		t.Errorf("Expected SetFailFast method, got:\n%s", setFailFast)
+	}
+
+	constructor := render(generator.generateConstructor("TestConfig"))
+	if !strings.Contains(constructor, "failFast: true") {
+		t.Errorf("Expected constructor to default to the configured mode, got:\n%s", constructor)
+	}
+}
+
+// TestCodeGenerator_RangeFusion tests fusing min and max into a single range check
+func TestCodeGenerator_RangeFusion(t *testing.T) {
+	field := analyzer.FieldInfo{
+		Name:   "Username",
+		Type:   "string",
+		GoType: analyzer.GoType{Kind: analyzer.TypeString, Name: "string"},
+		ValidationRules: []analyzer.ValidationRule{
+			{Name: "required"},
+			{Name: "min", Parameter: "3"},
+			{Name: "max", Parameter: "50"},
+		},
+	}
+	render := func(stmts []ast.Stmt) string {
+		var buf bytes.Buffer
+		for _, stmt := range stmts {
+			if err := format.Node(&buf, token.NewFileSet(), stmt); err != nil {
+				t.Fatalf("Failed to format statement: %v", err)
+			}
+			buf.WriteString("\n")
+		}
+		return buf.String()
+	}
+
+	optimized := NewCodeGenerator(&analyzer.AnalysisResult{}, GeneratorOptions{EnableOptimizations: true})
+	code := render(optimized.generateFieldValidation("Test", &field, false))
+	if !strings.Contains(code, "if n := len(cfg.Username); n < 3 || n > 50 {") {
+		t.Errorf("Expected fused range check, got:\n%s", code)
+	}
+	if strings.Count(code, "len(cfg.Username)") != 1 {
+		t.Errorf("Expected the length to be loaded once, got:\n%s", code)
+	}
+	if !strings.Contains(code, `v.addError("Username", "min", "3"`) || !strings.Contains(code, `v.addError("Username", "max", "50"`) {
+		t.Errorf("Expected fused check to keep per-rule errors, got:\n%s", code)
+	}
+
+	naive := NewCodeGenerator(&analyzer.AnalysisResult{}, GeneratorOptions{})
+	if code := render(naive.generateFieldValidation("Test", &field, false)); strings.Contains(code, "n :=") {
+		t.Errorf("Expected separate checks without optimizations, got:\n%s", code)
+	}
+
+	// Different units cannot share a load
+	field.ValidationRules = []analyzer.ValidationRule{{Name: "min", Parameter: "3:chars"}, {Name: "max", Parameter: "64"}}
+	if code := render(optimized.generateFieldValidation("Test", &field, false)); strings.Contains(code, "n :=") {
+		t.Errorf("Expected mixed units not to be fused, got:\n%s", code)
+	}
+
+	// The min and max of dive fields check the elements, not the collection
+	tags := analyzer.FieldInfo{
+		Name:   "Tags",
+		Type:   "[]string",
+		GoType: analyzer.GoType{Kind: analyzer.TypeSlice, Name: "[]string", IsSlice: true},
+		ValidationRules: []analyzer.ValidationRule{
+			{Name: "min", Parameter: "1"},
+			{Name: "dive"},
+			{Name: "max", Parameter: "5"},
+		},
+	}
+	if minIndex, maxIndex := rangeRuleIndexes(tags.ValidationRules); minIndex >= 0 || maxIndex >= 0 {
+		t.Errorf("Expected no range across dive, got min %d and max %d", minIndex, maxIndex)
+	}
+	tags.ValidationRules = []analyzer.ValidationRule{{Name: "min", Parameter: "2"}, {Name: "max", Parameter: "5"}, {Name: "dive"}}
+	if minIndex, _ := rangeRuleIndexes(tags.ValidationRules); minIndex >= 0 {
+		t.Errorf("Expected no range for a dive field, got min %d", minIndex)
+	}
+	if code := render(optimized.generateFieldValidation("Test", &tags, false)); strings.Contains(code, "n := len(cfg.Tags)") {
+		t.Errorf("Expected element rules not to be fused into a collection check, got:\n%s", code)
+	}
+
+	flag := analyzer.FieldInfo{Name: "Enabled", GoType: analyzer.GoType{Kind: analyzer.TypeBool, Name: "bool"}}
+	code = render(optimized.generateRequiredValidation(&flag, &ast.SelectorExpr{X: ast.NewIdent("cfg"), Sel: ast.NewIdent("Enabled")}))
+	if !strings.Contains(code, "if !cfg.Enabled {") || strings.Contains(code, "reflect") {
+		t.Errorf("Expected required bool to compile to a plain check, got:\n%s", code)
+	}
+}
+
+// benchConfig mirrors a config struct validated by emitted code
+type benchConfig struct {
+	Username string
+	Enabled  bool
+}
+
+var benchErrors int
+
+// BenchmarkEmitted_Naive measures the code emitted without optimizations:
+// separate min/max length checks and reflection for required bools
+func BenchmarkEmitted_Naive(b *testing.B) {
+	cfg := &benchConfig{Username: "gopher", Enabled: true}
+	for i := 0; i < b.N; i++ {
+		if len(cfg.Username) < 3 {
+			benchErrors++
+		}
+		if len(cfg.Username) > 50 {
+			benchErrors++
+		}
+		if reflect.DeepEqual(cfg.Enabled, reflect.Zero(reflect.TypeOf(cfg.Enabled)).Interface()) {
+			benchErrors++
+		}
+	}
+}
+
+// BenchmarkEmitted_Optimized measures the same checks with range fusion and
+// a plain required bool check
+func BenchmarkEmitted_Optimized(b *testing.B) {
+	cfg := &benchConfig{Username: "gopher", Enabled: true}
+	for i := 0; i < b.N; i++ {
+		if n := len(cfg.Username); n < 3 || n > 50 {
+			if n < 3 {
+				benchErrors++
+			} else {
+				benchErrors++
+			}
+		}
+		if !cfg.Enabled {
+			benchErrors++
+		}
+	}
+}
+
+// TestCodeGenerator_StrategyDispatch tests the generated registry and ValidateAny dispatcher
+func TestCodeGenerator_StrategyDispatch(t *testing.T) {
+	analysisResult := &analyzer.AnalysisResult{
+		Structs: map[string]*analyzer.StructInfo{
+			"ServerConfig": {Name: "ServerConfig"},
+			"AppConfig":    {Name: "AppConfig"},
+		},
+		PackageName: "config",
+	}
+	outputDir := t.TempDir()
+	generator := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: outputDir})
+	if err := generator.generateStrategyFactory(); err != nil {
+		t.Fatalf("Strategy generation failed: %v", err)
+	}
+
+	path := filepath.Join(outputDir, "validation_strategy_gen.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read strategy file: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, content, parser.AllErrors); err != nil {
+		t.Fatalf("Generated strategy does not parse: %v", err)
+	}
+
+	code := string(content)
+	for _, expected := range []string{
+		`"AppConfig": func() interface{} {`,
+		"return NewAppConfigValidator()",
+		"func ValidateAny(cfg interface{}) error {",
+		"switch c := cfg.(type) {",
+		"case *AppConfig:\n\t\treturn NewAppConfigValidator().Validate(c)",
+		"case ServerConfig:\n\t\treturn NewServerConfigValidator().Validate(&c)",
+		`return fmt.Errorf("no generated validator for %T", cfg)`,
+		"return ValidateAny(config)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Expected generated strategy to contain %q, got:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(code, `"reflect"`) {
+		t.Error("Expected dispatch without reflection")
+	}
+	if strings.Index(code, "case *AppConfig") > strings.Index(code, "case *ServerConfig") {
+		t.Error("Expected dispatch cases in stable order")
+	}
+}
+
+// TestCodeGenerator_PureMode tests that pure mode output imports only the standard library
+func TestCodeGenerator_PureMode(t *testing.T) {
+	fields := []analyzer.FieldInfo{
+		{Name: "Name", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString}, ValidationRules: []analyzer.ValidationRule{{Name: "required"}, {Name: "min", Parameter: "3"}, {Name: "max", Parameter: "5:chars"}}},
+		{Name: "Email", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString}, ValidationRules: []analyzer.ValidationRule{{Name: "email"}}},
+		{Name: "Host", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString}, ValidationRules: []analyzer.ValidationRule{{Name: "hostname"}}},
+		{Name: "ID", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString}, ValidationRules: []analyzer.ValidationRule{{Name: "uuid4"}}},
+		{Name: "Endpoint", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString}, ValidationRules: []analyzer.ValidationRule{{Name: "url"}}},
+		{Name: "Enabled", Type: "bool", GoType: analyzer.GoType{Kind: analyzer.TypeBool}, ValidationRules: []analyzer.ValidationRule{{Name: "required"}}},
+	}
+	analysisResult := &analyzer.AnalysisResult{
+		Structs:     map[string]*analyzer.StructInfo{"PureConfig": {Name: "PureConfig", Fields: fields}},
+		Imports:     []string{"fmt", "github.com/mateothegreat/go-validation"},
+		PackageName: "config",
+	}
+	outputDir := t.TempDir()
+	source := "package config\n\ntype PureConfig struct {\n\tName, Email, Host, ID, Endpoint string\n\tEnabled bool\n}\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "config.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	generator := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: outputDir, Pure: true, EnableOptimizations: true})
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("Pure generation failed: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	paths, _ := filepath.Glob(filepath.Join(outputDir, "*.go"))
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("Generated file does not parse: %v", err)
+		}
+		for _, imp := range file.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			if strings.Contains(strings.Split(importPath, "/")[0], ".") || importPath == "reflect" {
+				t.Errorf("Expected only reflection-free stdlib imports in %s, got %s", filepath.Base(path), importPath)
+			}
+		}
+		files = append(files, file)
+	}
+
+	// The output must type-check against the standard library alone
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("config", fset, files, nil); err != nil {
+		t.Errorf("Pure output does not type-check: %v", err)
+	}
+
+	// Rules needing reflection or the validation package are rejected
+	fields[0].ValidationRules = append(fields[0].ValidationRules, analyzer.ValidationRule{Name: "iso4217"})
+	rejectedDir := t.TempDir()
+	err := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: rejectedDir, Pure: true}).Generate()
+	if err == nil || !strings.Contains(err.Error(), "Name (iso4217)") {
+		t.Errorf("Expected pure mode to reject generic rules, got %v", err)
+	}
+	if strings.Count(err.Error(), "iso4217") != 1 {
+		t.Errorf("Expected each rejected rule to be listed once, got %v", err)
+	}
+	if written, _ := os.ReadDir(rejectedDir); len(written) > 0 {
+		t.Errorf("Expected a rejected generation to write no files, got %d", len(written))
+	}
+}
+
+func TestCodeGenerator_NestedStructs(t *testing.T) {
+	source := `package config
+
+type Server struct {
+	Name string ` + "`validate:\"required,min=3\"`" + `
+	Port int    ` + "`validate:\"min=1,max=65535\"`" + `
+}
+
+type TLS struct {
+	Cert string ` + "`validate:\"required\"`" + `
+}
+
+type Config struct {
+	Server   Server
+	TLS      *TLS   ` + "`validate:\"required\"`" + `
+	Backup   *Server
+	Replicas *int   ` + "`validate:\"omitempty,min=1\"`" + `
+}
+`
+	for _, pure := range []bool{false, true} {
+		outputDir := t.TempDir()
+		path := filepath.Join(outputDir, "config.go")
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		analysisResult, err := analyzer.NewConfigAnalyzer().AnalyzeFile(path)
+		if err != nil {
+			t.Fatalf("Analysis failed: %v", err)
+		}
+		options := GeneratorOptions{PackageName: "config", OutputDir: outputDir, EnableOptimizations: true, Pure: pure}
+		if err := NewCodeGenerator(analysisResult, options).Generate(); err != nil {
+			t.Fatalf("Generation failed (pure %v): %v", pure, err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(outputDir, "config_validator_gen.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"Validate(&cfg.Server)", "Validate(cfg.TLS)", "if cfg.Backup != nil {", "*cfg.Replicas < 1"} {
+			if !strings.Contains(string(content), expected) {
+				t.Errorf("Expected %q in the generated code (pure %v), got:\n%s", expected, pure, content)
+			}
+		}
+
+		fset := token.NewFileSet()
+		var files []*ast.File
+		paths, _ := filepath.Glob(filepath.Join(outputDir, "*.go"))
+		for _, path := range paths {
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				t.Fatalf("Generated file does not parse: %v", err)
+			}
+			files = append(files, file)
+		}
+		conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+		if _, err := conf.Check("config", fset, files, nil); err != nil {
+			t.Errorf("Output does not type-check (pure %v): %v", pure, err)
+		}
+	}
+}
+
+// TestCodeGenerator_NullableFields tests validating the contents of set
+// sql.Null* and Optional fields
+func TestCodeGenerator_NullableFields(t *testing.T) {
+	render := func(stmts []ast.Stmt) string {
+		var buf bytes.Buffer
+		for _, stmt := range stmts {
+			if err := format.Node(&buf, token.NewFileSet(), stmt); err != nil {
+				t.Fatalf("Failed to format statement: %v", err)
+			}
+			buf.WriteString("\n")
+		}
+		return buf.String()
+	}
+	generator := NewCodeGenerator(&analyzer.AnalysisResult{}, GeneratorOptions{})
+
+	name := analyzer.FieldInfo{
+		Name: "Name",
+		GoType: analyzer.GoType{
+			Kind: analyzer.TypeStruct, Name: "NullString", Package: "sql", IsNullable: true, ValueField: "String",
+			ElemType: &analyzer.GoType{Kind: analyzer.TypeString, Name: "string"},
+		},
+		ValidationRules: []analyzer.ValidationRule{{Name: "required"}, {Name: "min", Parameter: "3"}},
+	}
+	code := render(generator.generateFieldValidation("Test", &name, false))
+	for _, want := range []string{"if cfg.Name.Valid {", "len(cfg.Name.String) < 3", "} else {", `v.addError("Name", "required", "", "field is required")`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Expected %q in:\n%s", want, code)
+		}
+	}
+
+	limit := analyzer.FieldInfo{
+		Name: "Limit",
+		GoType: analyzer.GoType{
+			Kind: analyzer.TypeStruct, Name: "Optional[int]", Package: "validation", IsNullable: true, ValueField: "V",
+			ElemType: &analyzer.GoType{Kind: analyzer.TypeInt, Name: "int"},
+		},
+		ValidationRules: []analyzer.ValidationRule{{Name: "defined"}},
+	}
+	if code := render(generator.generateFieldValidation("Test", &limit, false)); !strings.Contains(code, "if !cfg.Limit.Valid {") || strings.Contains(code, "else") {
+		t.Errorf("Expected a plain unset check, got:\n%s", code)
+	}
+
+	limit.ValidationRules = nil
+	if stmts := generator.generateFieldValidation("Test", &limit, false); len(stmts) != 0 {
+		t.Errorf("Expected no code for a nullable field without rules, got:\n%s", render(stmts))
+	}
+}
+
+// TestCodeGenerator_TypeScript tests emitting TypeScript validators that
+// enforce the analyzed rules with the messages of the validation package
+func TestCodeGenerator_TypeScript(t *testing.T) {
+	stringType := analyzer.GoType{Kind: analyzer.TypeString}
+	serverType := analyzer.GoType{Kind: analyzer.TypeStruct, Name: "Server"}
+	analysisResult := &analyzer.AnalysisResult{
+		Structs: map[string]*analyzer.StructInfo{
+			"AppConfig": {Name: "AppConfig", Fields: []analyzer.FieldInfo{
+				{Name: "Name", JSONTag: "name", GoType: stringType, ValidationRules: []analyzer.ValidationRule{{Name: "required"}, {Name: "min", Parameter: "3"}}},
+				{Name: "Port", JSONTag: "port", GoType: analyzer.GoType{Kind: analyzer.TypeInt}, ValidationRules: []analyzer.ValidationRule{{Name: "max", Parameter: "65535"}}},
+				{Name: "Admin", JSONTag: "admin", GoType: analyzer.GoType{Kind: analyzer.TypePointer, IsPointer: true, ElemType: &stringType}, ValidationRules: []analyzer.ValidationRule{{Name: "omitempty"}, {Name: "email"}}},
+				{Name: "Tags", JSONTag: "tags", GoType: analyzer.GoType{Kind: analyzer.TypeSlice, IsSlice: true, ElemType: &stringType}, ValidationRules: []analyzer.ValidationRule{{Name: "maxitems", Parameter: "3"}, {Name: "dive"}, {Name: "alpha"}}},
+				{Name: "Servers", JSONTag: "servers", GoType: analyzer.GoType{Kind: analyzer.TypeSlice, IsSlice: true, ElemType: &serverType}, ValidationRules: []analyzer.ValidationRule{{Name: "dive"}}},
+				{Name: "Owner", JSONTag: "owner", GoType: stringType, ValidationRules: []analyzer.ValidationRule{{Name: "unique_db", Parameter: "users"}}},
+				{Name: "Token", JSONTag: "-", GoType: stringType, ValidationRules: []analyzer.ValidationRule{{Name: "required"}}},
+			}},
+			"Server": {Name: "Server", Fields: []analyzer.FieldInfo{
+				{Name: "Addr", GoType: stringType, ValidationRules: []analyzer.ValidationRule{{Name: "ip"}}},
+			}},
+		},
+		PackageName: "config",
+	}
+
+	filename := filepath.Join(t.TempDir(), "web", "validators.gen.ts")
+	generator := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config"})
+	if err := generator.GenerateTypeScript(filename); err != nil {
+		t.Fatalf("TypeScript generation failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("TypeScript file not written: %v", err)
+	}
+	output := string(data)
+
+	for _, want := range []string{
+		"export interface AppConfig {",
+		"  admin?: string | null;",
+		"  servers: Server[];",
+		"export function validateAppConfig(value: AppConfig, namespace = \"\"): ValidationError[] {",
+		`const v = value["name"] ?? "";`,
+		"if (byteLength(v) < 3) errors.push({ field: field, namespace: namespace && path, tag: \"min\", param: \"3\", message: `field '${field}' must be at least 3` });",
+		"if (v > 65535)",
+		"if (v != null && hasValue(v)) {",
+		"if (v.length > 3)",
+		"if (!isAlpha(v)) errors.push({ field: itemField,",
+		"errors.push(...validateServer(item as Server, itemPath));",
+		"// unique_db=users is checked by the Go validator only",
+		"if (!validIP(v))",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected TypeScript output to contain %q", want)
+		}
+	}
+	if strings.Contains(output, "Token") || strings.Contains(output, `"-"`) {
+		t.Error("Expected fields JSON skips to be left out")
+	}
+	if got := generator.ServerOnlyRules(); !reflect.DeepEqual(got, []string{"Owner (unique_db)"}) {
+		t.Errorf("Expected unique_db to be left to Go, got %v", got)
+	}
+
+	// Generate writes the TypeScript file next to the Go validators
+	outputDir := t.TempDir()
+	delete(analysisResult.Structs, "AppConfig")
+	if err := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: outputDir, TypeScriptFile: "validators.gen.ts"}).Generate(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "validators.gen.ts")); err != nil {
+		t.Errorf("Expected Generate to write the TypeScript file: %v", err)
+	}
+
+	// Messages are rendered from the validation package's formats
+	if got := tsMessage(analyzer.ValidationRule{Name: "oneof", Parameter: "a `b`"}, "field"); got != "`field '${field}' must be one of [a \\`b\\`]`" {
+		t.Errorf("Unexpected oneof message %s", got)
+	}
+}
+
+func TestCodeGenerator_MutantTests(t *testing.T) {
+	analysisResult := &analyzer.AnalysisResult{
+		PackageName: "config",
+		Structs: map[string]*analyzer.StructInfo{
+			"AppConfig": {
+				Name: "AppConfig",
+				Fields: []analyzer.FieldInfo{
+					{
+						Name:            "Name",
+						Type:            "string",
+						GoType:          analyzer.GoType{Kind: analyzer.TypeString, Name: "string"},
+						ValidationRules: []analyzer.ValidationRule{{Name: "required"}},
+					},
+				},
+			},
+		},
+	}
+
+	outputDir := t.TempDir()
+	options := GeneratorOptions{PackageName: "validators", OutputDir: outputDir, GenerateTests: true, SourceImportPath: "example.com/app/config"}
+	if err := NewCodeGenerator(analysisResult, options).Generate(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "appconfig_validator_gen_test.go"))
+	if err != nil {
+		t.Fatalf("Expected a test next to the validator: %v", err)
+	}
+	output := string(content)
+	for _, want := range []string{
+		"package validators",
+		`"example.com/app/config"`,
+		`"github.com/mateothegreat/go-validation/validationtest"`,
+		"func TestAppConfigValidator_RejectsMutants(t *testing.T) {",
+		"validationtest.AssertRejectsMutants(t, validationtest.Fixture[config.AppConfig](t), func(cfg *config.AppConfig) error {",
+		"func TestAppConfigValidator_MatchesReflection(t *testing.T) {",
+		"validationtest.AssertEquivalent(t, 500, func(cfg *config.AppConfig) error {",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected generated test to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestCodeGenerator_DiveAndOmitEmpty(t *testing.T) {
+	source := `package config
+
+type Config struct {
+	Tags  []string ` + "`validate:\"maxitems=5,dive,min=2\"`" + `
+	Email string   ` + "`validate:\"omitempty,email\"`" + `
+	Min   int
+	Max   int      ` + "`validate:\"gtfield=Min\"`" + `
+}
+`
+	outputDir := t.TempDir()
+	path := filepath.Join(outputDir, "config.go")
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	analysisResult, err := analyzer.NewConfigAnalyzer().AnalyzeFile(path)
+	if err != nil {
+		t.Fatalf("Analysis failed: %v", err)
+	}
+	options := GeneratorOptions{PackageName: "config", OutputDir: outputDir, EnableOptimizations: true}
+	if err := NewCodeGenerator(analysisResult, options).Generate(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "config_validator_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"if len(cfg.Tags) > 5 {",
+		`validation.VarField(cfg, "Tags", "dive,min=2")`,
+		`if cfg.Email != "" {`,
+		`validation.VarField(cfg, "Max", "gtfield=Min")`,
+		"case validation.ValidationErrors:",
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(content), e) {
+			t.Errorf("Expected %q in the generated code, got:\n%s", e, content)
+		}
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	paths, _ := filepath.Glob(filepath.Join(outputDir, "*.go"))
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("Generated file does not parse: %v", err)
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("config", fset, files, nil); err != nil {
+		t.Errorf("Output does not type-check: %v", err)
+	}
+}
+
+// TestCodeGenerator_ParamPlaceholders tests $Field and ${ENV} parameters are
+// resolved by the validation package when the validator runs
+func TestCodeGenerator_ParamPlaceholders(t *testing.T) {
+	fields := []analyzer.FieldInfo{
+		{Name: "Name", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString}, ValidationRules: []analyzer.ValidationRule{{Name: "min", Parameter: "2"}, {Name: "max", Parameter: "$MaxNameLen", DependsOn: []string{"MaxNameLen"}}}},
+		{Name: "Age", Type: "int", GoType: analyzer.GoType{Kind: analyzer.TypeInt}, ValidationRules: []analyzer.ValidationRule{{Name: "min", Parameter: "${MIN_AGE}"}}},
+		{Name: "MaxNameLen", Type: "int", GoType: analyzer.GoType{Kind: analyzer.TypeInt}},
+	}
+	analysisResult := &analyzer.AnalysisResult{
+		Structs:     map[string]*analyzer.StructInfo{"Profile": {Name: "Profile", Fields: fields}},
+		PackageName: "config",
+	}
+
+	outputDir := t.TempDir()
+	if err := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: outputDir, EnableOptimizations: true}).Generate(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "profile_validator_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(content)
+	for _, want := range []string{
+		`validation.VarField(cfg, "Name", "max="+validation.FormatParam(cfg.MaxNameLen))`,
+		`validation.VarField(cfg, "Age", "min=${MIN_AGE}")`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected generated code to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	err = NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: t.TempDir(), Pure: true}).Generate()
+	if err == nil || !strings.Contains(err.Error(), "Name (max)") {
+		t.Errorf("Expected pure mode to reject placeholder parameters, got %v", err)
+	}
+}
+
+func TestCodeGenerator_CELRules(t *testing.T) {
+	fields := []analyzer.FieldInfo{
+		{Name: "User", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString, Name: "string"}},
+		{Name: "Port", Type: "int", GoType: analyzer.GoType{Kind: analyzer.TypeInt, Name: "int"}, ValidationRules: []analyzer.ValidationRule{{Name: "cel", Parameter: "self.Port > 1024 || self.User == 'root'"}}},
+		{Name: "Retries", Type: "int8", GoType: analyzer.GoType{Kind: analyzer.TypeInt8, Name: "int8"}, ValidationRules: []analyzer.ValidationRule{{Name: "cel", Parameter: "self.Retries < 1000"}}},
+		{Name: "Tags", Type: "[]string", GoType: analyzer.GoType{Kind: analyzer.TypeSlice, Name: "[]string"}, ValidationRules: []analyzer.ValidationRule{{Name: "cel", Parameter: "size(self.Tags) <= size(self.User)"}}},
+		{Name: "Ratio", Type: "float64", GoType: analyzer.GoType{Kind: analyzer.TypeFloat64, Name: "float64"}, ValidationRules: []analyzer.ValidationRule{{Name: "cel", Parameter: "self.Tags.all(t, t != '')"}}},
+	}
+	analysisResult := &analyzer.AnalysisResult{
+		Structs:     map[string]*analyzer.StructInfo{"Listener": {Name: "Listener", Fields: fields}},
+		PackageName: "config",
+	}
+
+	outputDir := t.TempDir()
+	if err := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: outputDir}).Generate(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "listener_validator_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(content)
+	for _, want := range []string{
+		`if !(cfg.Port > 1024 || cfg.User == "root") {`,
+		`v.addError("Port", "cel", "self.Port > 1024 || self.User == 'root'", "field 'Port' must satisfy self.Port > 1024 || self.User == 'root'")`,
+		`if !(len(cfg.Tags) <= len([]rune(cfg.User))) {`,
+		// 1000 overflows int8, and comprehensions are not translated
+		`validation.VarField(cfg, "Retries", "cel=self.Retries < 1000")`,
+		`validation.VarField(cfg, "Ratio", "cel=self.Tags.all(t, t != '')")`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected generated code to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	err = NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: t.TempDir(), Pure: true}).Generate()
+	if err == nil || !strings.Contains(err.Error(), "Ratio (cel)") || strings.Contains(err.Error(), "Port (cel)") {
+		t.Errorf("Expected pure mode to reject only interpreted cel rules, got %v", err)
+	}
+}
+
+// TestCodeGenerator_GeneratedRules checks that validation.Rules reports as
+// generated exactly the rules emitted without calling validation.Var
+func TestCodeGenerator_GeneratedRules(t *testing.T) {
+	params := map[string]string{"oneof": "a b"}
+	for _, rule := range validation.Rules() {
+		field := analyzer.FieldInfo{Name: "Value", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString, Name: "string"}}
+		switch rule.Name {
+		case "minitems", "maxitems", "notempty":
+			field.Type, field.GoType = "[]string", analyzer.GoType{Kind: analyzer.TypeSlice, Name: "[]string"}
+		}
+		param, ok := params[rule.Name]
+		if !ok && rule.Param != nil && rule.Param.Type != validation.ParamNone {
+			param = "3"
+		}
+
+		generator := NewCodeGenerator(&analyzer.AnalysisResult{}, GeneratorOptions{})
+		stmts := generator.generateRuleValidation(&field, analyzer.ValidationRule{Name: rule.Name, Parameter: param}, &ast.SelectorExpr{X: ast.NewIdent("cfg"), Sel: ast.NewIdent("Value")})
+		var buf bytes.Buffer
+		for _, stmt := range stmts {
+			if err := format.Node(&buf, token.NewFileSet(), stmt); err != nil {
+				t.Fatalf("%s: %v", rule.Name, err)
+			}
+		}
+		generic := strings.Contains(buf.String(), "validation.Var")
+		if rule.Generated && (len(stmts) == 0 || generic) {
+			t.Errorf("Rules reports %s as generated, but the generator emits:\n%s", rule.Name, buf.String())
+		}
+		if !rule.Generated && len(stmts) > 0 && !generic {
+			t.Errorf("The generator emits reflection-free code for %s, but Rules does not report it as generated", rule.Name)
+		}
+	}
+}
+
+// TestCodeGenerator_DeclarationOrderErrors tests that Validate reports errors
+// in declaration order when referenced fields are validated first
+func TestCodeGenerator_DeclarationOrderErrors(t *testing.T) {
+	structInfo := &analyzer.StructInfo{
+		Name: "Window",
+		Fields: []analyzer.FieldInfo{
+			{Name: "Start", Type: "int", GoType: analyzer.GoType{Kind: analyzer.TypeInt, Name: "int"}, ValidationRules: []analyzer.ValidationRule{{Name: "min", Parameter: "1"}}},
+			{Name: "Name", Type: "string", GoType: analyzer.GoType{Kind: analyzer.TypeString, Name: "string"}, ValidationRules: []analyzer.ValidationRule{{Name: "required"}}},
+			{Name: "End", Type: "int", GoType: analyzer.GoType{Kind: analyzer.TypeInt, Name: "int"}, ValidationRules: []analyzer.ValidationRule{{Name: "max", Parameter: "10"}}},
+		},
+		EvaluationOrder: []int{2, 0, 1},
+	}
+	analysisResult := &analyzer.AnalysisResult{
+		Structs:     map[string]*analyzer.StructInfo{"Window": structInfo},
+		PackageName: "config",
+	}
+	outputDir := t.TempDir()
+	source := "package config\n\ntype Window struct {\n\tStart int\n\tName string\n\tEnd int\n}\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "config.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewCodeGenerator(analysisResult, GeneratorOptions{PackageName: "config", OutputDir: outputDir, Pure: true}).Generate(); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "window_validator_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(content)
+	validate := code[strings.Index(code, ") Validate("):strings.Index(code, ") ValidateFast(")]
+	for _, expected := range []string{
+		"var spans [3][2]int",
+		"spans[2][0] = len(v.errors)",
+		"spans[0][1] = len(v.errors)",
+		"ordered := v.errors[0:0:0]",
+		"ordered = append(ordered, v.errors[span[0]:span[1]]...)",
+	} {
+		if !strings.Contains(validate, expected) {
+			t.Errorf("Expected %q in Validate, got:\n%s", expected, validate)
+		}
+	}
+	if strings.Index(validate, "spans[2][0]") > strings.Index(validate, "spans[0][0]") {
+		t.Errorf("Expected End to be validated before Start, got:\n%s", validate)
+	}
+	if strings.Count(code, "spans") != strings.Count(validate, "spans") {
+		t.Error("Expected only collect-all validation to reorder errors")
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	paths, _ := filepath.Glob(filepath.Join(outputDir, "*.go"))
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("Generated file does not parse: %v", err)
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("config", fset, files, nil); err != nil {
+		t.Errorf("Output does not type-check: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"strings"
+)
+
+// defaultFileSuffix is appended to the lowercased struct name of per-struct files
+const defaultFileSuffix = "_validator_gen"
+
+// validatorFilename returns the name of the file holding a struct's validator
+func (cg *CodeGenerator) validatorFilename(structName string) string {
+	suffix := cg.options.FileSuffix
+	if suffix == "" {
+		suffix = defaultFileSuffix
+	}
+	return strings.ToLower(structName) + strings.TrimSuffix(suffix, ".go") + ".go"
+}
+
+// sourceQualifier returns the name generated code uses to refer to the
+// analyzed package, or "" when generating into the same package
+func (cg *CodeGenerator) sourceQualifier() string {
+	if cg.options.SourceImportPath == "" {
+		return ""
+	}
+	if name := cg.analysisResult.PackageName; name != "" && name != cg.options.PackageName {
+		return name
+	}
+	return "source" + path.Base(cg.options.SourceImportPath)
+}
+
+// typeExpr references a type of the analyzed package, qualified with the
+// source package when generating into a different one
+func (cg *CodeGenerator) typeExpr(typeName string) ast.Expr {
+	qualifier := cg.sourceQualifier()
+	if _, analyzed := cg.analysisResult.Structs[typeName]; !analyzed || qualifier == "" {
+		return ast.NewIdent(typeName)
+	}
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(qualifier),
+		Sel: ast.NewIdent(typeName),
+	}
+}
+
+// importPath returns the import path of a package name referenced by
+// generated code and the name to import it under, if it differs from the
+// path's last element
+func (cg *CodeGenerator) importPath(name string) (importPath, alias string, ok bool) {
+	if qualifier := cg.sourceQualifier(); qualifier != "" && name == qualifier {
+		if path.Base(cg.options.SourceImportPath) != name {
+			alias = name
+		}
+		return cg.options.SourceImportPath, alias, true
+	}
+	importPath, ok = packageImports[name]
+	if ok && path.Base(importPath) != name {
+		alias = name
+	}
+	return importPath, alias, ok
+}
+
+// checkOutputOptions validates the options controlling where and how files
+// are written
+func (cg *CodeGenerator) checkOutputOptions() error {
+	if cg.options.SourceImportPath != "" && cg.analysisResult.PackageName == "" {
+		return fmt.Errorf("source import path %q set but the analyzed package name is unknown", cg.options.SourceImportPath)
+	}
+	if strings.ContainsAny(cg.options.BuildTags, "\n\r") {
+		return fmt.Errorf("invalid build constraint %q", cg.options.BuildTags)
+	}
+	if strings.ContainsAny(cg.options.FileSuffix, `/\`) {
+		return fmt.Errorf("invalid file suffix %q", cg.options.FileSuffix)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+// validationImportPath is the import path of the validation package
+const validationImportPath = "github.com/mateothegreat/go-validation"
+
+// packageImports maps the package names referenced by generated code to
+// their import paths
+var packageImports = map[string]string{
+	"context":        "context",
+	"fmt":            "fmt",
+	"reflect":        "reflect",
+	"strings":        "strings",
+	"testing":        "testing",
+	"utf8":           "unicode/utf8",
+	"validation":     validationImportPath,
+	"validationtest": validationImportPath + "/validationtest",
+}
+
+// pureCheck describes a format rule inlined as a stdlib-only helper in pure mode
+type pureCheck struct {
+	helper  string
+	message string
+}
+
+// pureChecks lists the format rules pure mode inlines, keyed by rule name
+var pureChecks = map[string]pureCheck{
+	"email":    {"validEmail", "must be a valid email address"},
+	"hostname": {"validHostname", "must be a valid hostname"},
+	"uuid":     {"validUUID", "must be a valid UUID"},
+	"uuid4":    {"validUUIDv4", "must be a valid UUID v4"},
+	"url":      {"validURL", "must be a valid URL"},
+	"uri":      {"validURL", "must be a valid URL"},
+	"ip":       {"validIP", "must be a valid IP address"},
+	"ipv4":     {"validIPv4", "must be a valid IPv4 address"},
+	"ipv6":     {"validIPv6", "must be a valid IPv6 address"},
+}
+
+// pureSupportFile is the file holding the error types and helpers of pure mode
+const pureSupportFile = "validation_support_gen.go"
+
+// pureSupportSource declares the error types and format helpers generated
+// validators use in pure mode instead of the validation package
+const pureSupportSource = `package %s
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ValidationError describes a failed validation rule
+type ValidationError struct {
+	Field   string
+	Tag     string
+	Param   string
+	Message string
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors lists the errors of a validation run
+type ValidationErrors []ValidationError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// validEmail reports whether s is an RFC 5322 address with a hostname domain
+func validEmail(s string) bool {
+	at := strings.IndexByte(s, '@')
+	if len(s) > 254 || at <= 0 {
+		return false
+	}
+	for i := 0; i < at; i++ {
+		c := s[i]
+		if !isAlphaNum(c) && !strings.ContainsRune(".!#$%%&'*+/=?^_{|}~-` + "`" + `", rune(c)) {
+			return false
+		}
+	}
+	return validHostname(s[at+1:])
+}
+
+// validHostname reports whether s is an RFC 1123 hostname
+func validHostname(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || !isAlphaNum(label[0]) || !isAlphaNum(label[len(label)-1]) {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if !isAlphaNum(label[i]) && label[i] != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validUUID reports whether s is a UUID in canonical, braced, URN or
+// hyphen-less form
+func validUUID(s string) bool {
+	_, ok := uuidVersion(s)
+	return ok
+}
+
+// validUUIDv4 reports whether s is a version 4 UUID
+func validUUIDv4(s string) bool {
+	version, ok := uuidVersion(s)
+	return ok && version == 4
+}
+
+// uuidVersion returns the version nibble of a UUID
+func uuidVersion(s string) (byte, bool) {
+	switch {
+	case len(s) == 45 && strings.EqualFold(s[:9], "urn:uuid:"):
+		s = s[9:]
+	case len(s) == 38 && s[0] == '{' && s[37] == '}':
+		s = s[1:37]
+	}
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return 0, false
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return 0, false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHex(s[i]) {
+			return 0, false
+		}
+	}
+	return hexValue(s[12]), true
+}
+
+// validURL reports whether s is a URL with a scheme and a host
+func validURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// validIP reports whether s is an IPv4 or IPv6 address
+func validIP(s string) bool {
+	return net.ParseIP(s) != nil
+}
+
+// validIPv4 reports whether s is an IPv4 address
+func validIPv4(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil
+}
+
+// validIPv6 reports whether s is an IPv6 address
+func validIPv6(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil
+}
+
+func isAlphaNum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func hexValue(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	}
+	return c - '0'
+}
+`
+
+// validationType references a type of the validation package, or the
+// locally generated equivalent in pure mode
+func (cg *CodeGenerator) validationType(name string) ast.Expr {
+	if cg.options.Pure {
+		return ast.NewIdent(name)
+	}
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent("validation"),
+		Sel: ast.NewIdent(name),
+	}
+}
+
+// generatePureCheck generates a call to an inlined format helper, reporting
+// false for rules pure mode does not inline
+func (cg *CodeGenerator) generatePureCheck(field *analyzer.FieldInfo, rule analyzer.ValidationRule, fieldAccess ast.Expr) ([]ast.Stmt, bool) {
+	check, ok := pureChecks[rule.Name]
+	if !ok {
+		return nil, false
+	}
+
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: &ast.UnaryExpr{
+				Op: token.NOT,
+				X: &ast.CallExpr{
+					Fun:  ast.NewIdent(check.helper),
+					Args: []ast.Expr{fieldAccess},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					cg.generateAddError(field.Name, rule.Name, rule.Parameter, fmt.Sprintf("field '%s' %s", field.Name, check.message)),
+				},
+			},
+		},
+	}, true
+}
+
+// reportImpure records a rule that cannot be generated without reflection or
+// the validation package
+func (cg *CodeGenerator) reportImpure(field *analyzer.FieldInfo, rule string) {
+	impure := fmt.Sprintf("%s (%s)", field.Name, rule)
+	if !slices.Contains(cg.impure, impure) {
+		cg.impure = append(cg.impure, impure)
+	}
+}
+
+// impureError returns an error listing the rules pure mode could not generate
+func (cg *CodeGenerator) impureError() error {
+	if len(cg.impure) == 0 {
+		return nil
+	}
+	return fmt.Errorf("pure mode cannot generate reflection-free code for: %s", strings.Join(cg.impure, ", "))
+}
+
+// generatePureSupport generates the error types and helpers used in pure mode
+func (cg *CodeGenerator) generatePureSupport() (generatedFile, error) {
+	outputPath := filepath.Join(cg.options.OutputDir, pureSupportFile)
+	source := fmt.Sprintf(pureSupportSource, cg.options.PackageName)
+
+	file, err := parser.ParseFile(cg.fileSet, pureSupportFile, source, parser.ParseComments)
+	if err != nil {
+		return generatedFile{}, fmt.Errorf("failed to parse pure mode support code: %w", err)
+	}
+
+	return generatedFile{path: outputPath, file: file}, nil
+}
+
+// addImports prepends the import declaration for the packages the file
+// references, so no unused or missing imports are emitted
+func (cg *CodeGenerator) addImports(file *ast.File) {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				if _, _, known := cg.importPath(ident.Name); known {
+					used[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	if len(used) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pathI, _, _ := cg.importPath(names[i])
+		pathJ, _, _ := cg.importPath(names[j])
+		return pathI < pathJ
+	})
+
+	var specs []ast.Spec
+	for _, name := range names {
+		importPath, alias, _ := cg.importPath(name)
+		spec := &ast.ImportSpec{
+			Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(importPath)},
+		}
+		if alias != "" {
+			spec.Name = ast.NewIdent(alias)
+		}
+		specs = append(specs, spec)
+	}
+
+	imports := &ast.GenDecl{Tok: token.IMPORT, Lparen: 1, Specs: specs}
+	file.Decls = append([]ast.Decl{imports}, file.Decls...)
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"fmt"
+	"go/parser"
+	"path/filepath"
+	"strings"
+)
+
+// equivalenceSamples is the number of random values generated tests compare
+// the generated and reflection validators on
+const equivalenceSamples = 500
+
+// generatedTestSource holds the tests of a generated validator: it must
+// reject every mutant of a valid fixture, so rules the generator dropped
+// fail, and agree with the reflection engine on random values
+const generatedTestSource = `package %[1]s
+
+func Test%[2]sValidator_RejectsMutants(t *testing.T) {
+	validator := New%[2]sValidator()
+	validationtest.AssertRejectsMutants(t, validationtest.Fixture[%[3]s](t), func(cfg *%[3]s) error {
+		return validator.Validate(cfg)
+	})
+}
+
+func Test%[2]sValidator_MatchesReflection(t *testing.T) {
+	validator := New%[2]sValidator()
+	validationtest.AssertEquivalent(t, %[4]d, func(cfg *%[3]s) error {
+		return validator.Validate(cfg)
+	})
+}
+`
+
+// testFilename returns the name of the file holding a struct's generated test
+func (cg *CodeGenerator) testFilename(structName string) string {
+	return strings.TrimSuffix(cg.validatorFilename(structName), ".go") + "_test.go"
+}
+
+// generateStructTest generates the tests of a struct's validator
+func (cg *CodeGenerator) generateStructTest(structName string) (generatedFile, error) {
+	typeName := structName
+	if qualifier := cg.sourceQualifier(); qualifier != "" {
+		typeName = qualifier + "." + structName
+	}
+
+	filename := cg.testFilename(structName)
+	source := fmt.Sprintf(generatedTestSource, cg.options.PackageName, structName, typeName, equivalenceSamples)
+	file, err := parser.ParseFile(cg.fileSet, filename, source, parser.ParseComments)
+	if err != nil {
+		return generatedFile{}, fmt.Errorf("failed to parse test code: %w", err)
+	}
+	cg.addImports(file)
+
+	return generatedFile{path: filepath.Join(cg.options.OutputDir, filename), file: file}, nil
+}
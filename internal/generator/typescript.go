@@ -0,0 +1,735 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	validation "github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+// tsMessageFormats are the messages the validation package reports per
+// rule, shared so frontend and backend errors read the same
+var tsMessageFormats = map[string]string{
+	"required": validation.ErrorMsgRequired,
+	"defined":  validation.ErrorMsgDefined,
+	"min":      validation.ErrorMsgMin,
+	"max":      validation.ErrorMsgMax,
+	"len":      validation.ErrorMsgLength,
+	"minbytes": validation.ErrorMsgMinBytes,
+	"maxbytes": validation.ErrorMsgMaxBytes,
+	"minrunes": validation.ErrorMsgMinLength,
+	"maxrunes": validation.ErrorMsgMaxLength,
+	"minitems": validation.ErrorMsgMinItems,
+	"maxitems": validation.ErrorMsgMaxItems,
+	"notempty": validation.ErrorMsgNotEmpty,
+	"email":    validation.ErrorMsgEmail,
+	"url":      validation.ErrorMsgURL,
+	"oneof":    validation.ErrorMsgOneOf,
+}
+
+// tsFormatChecks maps the format rules TypeScript validators check to the
+// helper of the support code implementing them
+var tsFormatChecks = map[string]string{
+	"email":    "validEmail",
+	"url":      "validURL",
+	"uri":      "validURL",
+	"hostname": "validHostname",
+	"ip":       "validIP",
+	"ipv4":     "validIPv4",
+	"ipv6":     "validIPv6",
+	"uuid":     "validUUID",
+	"uuid4":    "validUUIDv4",
+	"alpha":    "isAlpha",
+	"alphanum": "isAlphaNum",
+	"numeric":  "isNumeric",
+}
+
+// tsSupportSource declares the error type and helpers of TypeScript
+// validators. The format checks mirror the validation package: lengths are
+// UTF-8 byte counts and letters and digits are ASCII only.
+const tsSupportSource = `export interface ValidationError {
+  field: string;
+  namespace: string;
+  tag: string;
+  param?: string;
+  message: string;
+}
+
+const encoder = new TextEncoder();
+
+function byteLength(s: string): number {
+  return encoder.encode(s).length;
+}
+
+function runeCount(s: string): number {
+  return Array.from(s).length;
+}
+
+function join(namespace: string, key: string): string {
+  return namespace === "" ? key : namespace + "." + key;
+}
+
+function hasValue(v: unknown): boolean {
+  if (v === undefined || v === null) return false;
+  if (typeof v === "string") return v !== "";
+  if (typeof v === "number") return v !== 0;
+  if (typeof v === "boolean") return v;
+  if (Array.isArray(v)) return v.length > 0;
+  if (typeof v === "object") return Object.keys(v as object).length > 0;
+  return true;
+}
+
+function entries(v: unknown): [string, unknown][] {
+  if (Array.isArray(v)) return v.map((item, i) => [String(i), item]);
+  if (v === null || typeof v !== "object") return [];
+  return Object.entries(v as object).sort(([a], [b]) => (a < b ? -1 : a > b ? 1 : 0));
+}
+
+function isAlpha(s: string): boolean {
+  return /^[A-Za-z]+$/.test(s);
+}
+
+function isAlphaNum(s: string): boolean {
+  return /^[A-Za-z0-9]+$/.test(s);
+}
+
+function isNumeric(s: string): boolean {
+  return /^[0-9]+$/.test(s);
+}
+
+function validHostname(s: string): boolean {
+  if (s === "" || byteLength(s) > 253) return false;
+  return s.split(".").every((label) => /^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$/.test(label));
+}
+
+function validEmail(s: string): boolean {
+  const at = s.indexOf("@");
+  if (byteLength(s) > 254 || at < 0) return false;
+  const local = s.slice(0, at);
+  return /^[A-Za-z0-9.!#$%&'*+/=?^_\x60{|}~-]+$/.test(local) && byteLength(local) <= 64 && validHostname(s.slice(at + 1));
+}
+
+function validURL(s: string): boolean {
+  if (byteLength(s) > 8192) return false;
+  try {
+    const u = new URL(s);
+    return u.protocol !== "" && u.host !== "";
+  } catch {
+    return false;
+  }
+}
+
+const ipv4Pattern = /^(25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])(\.(25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}$/;
+
+function ipv6Bytes(s: string): number[] | null {
+  const halves = s.split("::");
+  if (halves.length > 2) return null;
+  const parse = (part: string, last: boolean): number[] | null => {
+    if (part === "") return [];
+    const bytes: number[] = [];
+    const groups = part.split(":");
+    for (let i = 0; i < groups.length; i++) {
+      const group = groups[i];
+      if (last && i === groups.length - 1 && ipv4Pattern.test(group)) {
+        bytes.push(...group.split(".").map(Number));
+      } else if (/^[0-9A-Fa-f]{1,4}$/.test(group)) {
+        const n = parseInt(group, 16);
+        bytes.push(n >> 8, n & 0xff);
+      } else {
+        return null;
+      }
+    }
+    return bytes;
+  };
+  const head = parse(halves[0], halves.length === 1);
+  const tail = halves.length === 2 ? parse(halves[1], true) : [];
+  if (head === null || tail === null) return null;
+  if (halves.length === 1) return head.length === 16 ? head : null;
+  if (head.length + tail.length > 14) return null;
+  return [...head, ...new Array(16 - head.length - tail.length).fill(0), ...tail];
+}
+
+function ipVersion(s: string): number {
+  if (ipv4Pattern.test(s)) return 4;
+  const bytes = ipv6Bytes(s);
+  if (bytes === null) return 0;
+  // IPv4-mapped addresses are IPv4, as in Go
+  const mapped = bytes.slice(0, 10).every((b) => b === 0) && bytes[10] === 0xff && bytes[11] === 0xff;
+  return mapped ? 4 : 6;
+}
+
+function validIP(s: string): boolean {
+  return ipVersion(s) !== 0;
+}
+
+function validIPv4(s: string): boolean {
+  return ipVersion(s) === 4;
+}
+
+function validIPv6(s: string): boolean {
+  return ipVersion(s) === 6;
+}
+
+function uuidVersion(s: string): number {
+  switch (s.length) {
+    case 36:
+      break;
+    case 38:
+      s = s.slice(1, 37);
+      break;
+    case 45:
+      if (s.slice(0, 9).toLowerCase() !== "urn:uuid:") return -1;
+      s = s.slice(9);
+      break;
+    case 32:
+      return /^[0-9A-Fa-f]{32}$/.test(s) ? parseInt(s[12], 16) : -1;
+    default:
+      return -1;
+  }
+  return /^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$/.test(s) ? parseInt(s[14], 16) : -1;
+}
+
+function validUUID(s: string): boolean {
+  return uuidVersion(s) >= 0;
+}
+
+function validUUIDv4(s: string): boolean {
+  return uuidVersion(s) === 4;
+}
+`
+
+// tsWriter accumulates indented TypeScript source
+type tsWriter struct {
+	b      strings.Builder
+	indent int
+}
+
+// line writes a formatted line at the current indentation
+func (w *tsWriter) line(format string, args ...interface{}) {
+	if format != "" {
+		w.b.WriteString(strings.Repeat("  ", w.indent))
+		fmt.Fprintf(&w.b, format, args...)
+	}
+	w.b.WriteByte('\n')
+}
+
+// open writes a line opening a block and indents the lines after it
+func (w *tsWriter) open(format string, args ...interface{}) {
+	w.line(format, args...)
+	w.indent++
+}
+
+// close dedents and writes the line closing a block
+func (w *tsWriter) close(line string) {
+	w.indent--
+	w.line("%s", line)
+}
+
+// tsValue is a value a TypeScript validator checks: the expressions holding
+// it, its field name for messages and its path from the root, and its Go type
+type tsValue struct {
+	expr   string
+	field  string
+	path   string
+	goType analyzer.GoType
+}
+
+// GenerateTypeScript writes a TypeScript module to filename declaring an
+// interface and a validate function per analyzed struct, so frontend forms
+// enforce the rules of the Go validators. Rules that only the Go validator
+// can check are left out with a comment and returned by ServerOnlyRules.
+func (cg *CodeGenerator) GenerateTypeScript(filename string) error {
+	cg.serverOnly = nil
+
+	w := &tsWriter{}
+	w.line("// Code generated by go-validation/cmd/configvalidator. DO NOT EDIT.")
+	w.line("")
+	w.b.WriteString(tsSupportSource)
+	for _, structName := range cg.sortedStructNames() {
+		structInfo := cg.analysisResult.Structs[structName]
+		w.line("")
+		cg.writeTypeScriptInterface(w, structName, structInfo)
+		w.line("")
+		cg.writeTypeScriptValidator(w, structName, structInfo)
+	}
+
+	if dir := filepath.Dir(filename); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(filename, []byte(w.b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return nil
+}
+
+// ServerOnlyRules returns the rules, as "Field (rule)", the last
+// GenerateTypeScript left to the Go validators
+func (cg *CodeGenerator) ServerOnlyRules() []string {
+	return cg.serverOnly
+}
+
+// writeTypeScriptInterface declares the shape of a struct's JSON encoding
+func (cg *CodeGenerator) writeTypeScriptInterface(w *tsWriter, structName string, structInfo *analyzer.StructInfo) {
+	var extends []string
+	for _, field := range structInfo.Fields {
+		if field.IsInline && field.IsNested {
+			extends = append(extends, field.NestedType)
+		}
+	}
+	if len(extends) > 0 {
+		w.open("export interface %s extends %s {", structName, strings.Join(extends, ", "))
+	} else {
+		w.open("export interface %s {", structName)
+	}
+	for _, field := range structInfo.Fields {
+		key, ok := tsKey(&field)
+		if !ok {
+			continue
+		}
+		optional := ""
+		if field.GoType.IsPointer || field.GoType.IsNullable {
+			optional = "?"
+		}
+		w.line("%s%s: %s;", tsPropertyName(key), optional, cg.tsType(field.GoType))
+	}
+	w.close("}")
+}
+
+// tsType returns the TypeScript type of a Go type's JSON encoding
+func (cg *CodeGenerator) tsType(goType analyzer.GoType) string {
+	switch {
+	case goType.IsNullable, goType.IsPointer:
+		if goType.ElemType == nil {
+			return "unknown"
+		}
+		return cg.tsType(*goType.ElemType) + " | null"
+	case goType.IsSlice:
+		if goType.ElemType == nil {
+			return "unknown[]"
+		}
+		elem := cg.tsType(*goType.ElemType)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case goType.IsMap:
+		if goType.ElemType == nil {
+			return "Record<string, unknown>"
+		}
+		return "Record<string, " + cg.tsType(*goType.ElemType) + ">"
+	}
+
+	switch {
+	case goType.Kind == analyzer.TypeString:
+		return "string"
+	case goType.Kind == analyzer.TypeBool:
+		return "boolean"
+	case isNumericKind(goType.Kind):
+		return "number"
+	case goType.Kind == analyzer.TypeStruct:
+		if _, analyzed := cg.analysisResult.Structs[goType.Name]; analyzed {
+			return goType.Name
+		}
+	}
+	return "unknown"
+}
+
+// writeTypeScriptValidator writes the validate function of a struct
+func (cg *CodeGenerator) writeTypeScriptValidator(w *tsWriter, structName string, structInfo *analyzer.StructInfo) {
+	w.open(`export function validate%s(value: %s, namespace = ""): ValidationError[] {`, structName, structName)
+	w.line("const errors: ValidationError[] = [];")
+	for _, field := range structInfo.Fields {
+		field := field
+		if field.IsInline && field.IsNested {
+			// Inline fields share the keys of their parent
+			w.line("errors.push(...validate%s(value, namespace));", field.NestedType)
+			continue
+		}
+		key, ok := tsKey(&field)
+		if !ok {
+			continue
+		}
+		if len(field.ValidationRules) == 0 && cg.tsStruct(field.GoType) == "" {
+			continue
+		}
+
+		w.open("{")
+		w.line("const field = %s;", strconv.Quote(key))
+		w.line("const path = join(namespace, field);")
+		w.line("const v = value[%s]%s;", strconv.Quote(key), cg.tsZero(field.GoType))
+		cg.writeTypeScriptChecks(w, &field, field.ValidationRules, tsValue{
+			expr:   "v",
+			field:  "field",
+			path:   "path",
+			goType: field.GoType,
+		})
+		w.close("}")
+	}
+	w.line("return errors;")
+	w.close("}")
+}
+
+// writeTypeScriptChecks writes the checks of rules for a value, mirroring
+// the validation package: omitempty skips every rule but required for empty
+// values, nil pointers only fail presence rules, and dive applies the rules
+// after it to each element
+func (cg *CodeGenerator) writeTypeScriptChecks(w *tsWriter, field *analyzer.FieldInfo, rules []analyzer.ValidationRule, value tsValue) {
+	dive := false
+	for _, rule := range rules {
+		dive = dive || rule.Name == "dive"
+	}
+	// Like the validation package, a dive tag applies every rule but the
+	// collection rules to the elements, wherever they appear
+	collectionRules, elemRules := rules, []analyzer.ValidationRule(nil)
+	omitEmpty := false
+	if dive {
+		collectionRules = nil
+	}
+	for _, rule := range rules {
+		switch {
+		case rule.Name == "dive":
+		case rule.Name == "omitempty":
+			omitEmpty = true
+			if dive {
+				elemRules = append(elemRules, rule)
+			}
+		case dive && !collectionRule(rule):
+			elemRules = append(elemRules, rule)
+		case dive:
+			collectionRules = append(collectionRules, rule)
+		}
+	}
+
+	nullable := value.goType.IsPointer || value.goType.IsNullable
+	inner := value
+	if nullable && value.goType.ElemType != nil {
+		inner.goType = *value.goType.ElemType
+	}
+
+	// Presence rules see the value itself
+	var rest []analyzer.ValidationRule
+	for _, rule := range collectionRules {
+		switch rule.Name {
+		case "required":
+			cond := fmt.Sprintf("!hasValue(%s)", value.expr)
+			if nullable || inner.goType.Kind == analyzer.TypeStruct && !inner.goType.IsSlice && !inner.goType.IsMap {
+				cond = fmt.Sprintf("%s == null", value.expr)
+			}
+			cg.writeTypeScriptError(w, cond, rule, value)
+		case "defined":
+			cg.writeTypeScriptError(w, fmt.Sprintf("%s === undefined", value.expr), rule, value)
+		case "omitempty":
+		default:
+			rest = append(rest, rule)
+		}
+	}
+
+	guard := ""
+	switch {
+	case omitEmpty && nullable:
+		// The null check narrows the type of the value
+		guard = fmt.Sprintf("%s != null && hasValue(%s)", value.expr, value.expr)
+	case omitEmpty:
+		guard = fmt.Sprintf("hasValue(%s)", value.expr)
+	case nullable:
+		guard = fmt.Sprintf("%s != null", value.expr)
+	}
+	nested := cg.tsStruct(inner.goType)
+	if len(rest) == 0 && !dive && nested == "" {
+		return
+	}
+	if guard != "" {
+		w.open("if (%s) {", guard)
+		defer w.close("}")
+	}
+
+	for _, rule := range rest {
+		cg.writeTypeScriptRule(w, field, rule, inner)
+	}
+
+	switch {
+	case dive:
+		elemType := analyzer.GoType{Kind: analyzer.TypeUnknown}
+		if inner.goType.ElemType != nil {
+			elemType = *inner.goType.ElemType
+		}
+		elemStruct := cg.tsStruct(elemType)
+		if len(elemRules) == 0 && elemStruct == "" {
+			return
+		}
+		w.open("for (const [key, item] of entries(%s)) {", inner.expr)
+		w.line("const itemPath = `${%s}[${key}]`;", inner.path)
+		if len(elemRules) > 0 {
+			w.line("const itemField = `${%s}[${key}]`;", inner.field)
+			elem := tsValue{expr: "item", field: "itemField", path: "itemPath", goType: elemType}
+			cg.writeTypeScriptElementChecks(w, field, elemRules, elem)
+		} else {
+			w.line("errors.push(...validate%s(item as %s, itemPath));", elemStruct, elemStruct)
+		}
+		w.close("}")
+	case nested != "":
+		w.line("errors.push(...validate%s(%s, %s));", nested, inner.expr, inner.path)
+	}
+}
+
+// writeTypeScriptElementChecks writes the checks of the rules after dive
+// for one element, typed by the element's Go type
+func (cg *CodeGenerator) writeTypeScriptElementChecks(w *tsWriter, field *analyzer.FieldInfo, rules []analyzer.ValidationRule, elem tsValue) {
+	w.open("{")
+	w.line("const v = item as %s;", cg.tsType(elem.goType))
+	elem.expr = "v"
+	cg.writeTypeScriptChecks(w, field, rules, elem)
+	w.close("}")
+}
+
+// writeTypeScriptRule writes the check of a non-presence rule, or a comment
+// for rules left to the Go validators
+func (cg *CodeGenerator) writeTypeScriptRule(w *tsWriter, field *analyzer.FieldInfo, rule analyzer.ValidationRule, value tsValue) {
+	switch rule.Name {
+	case "sensitive", "secret", "immutable":
+		// Markers only; secret references resolve and updates are compared on the server
+		return
+	}
+	if cond, ok := cg.tsCondition(rule, value); ok {
+		cg.writeTypeScriptError(w, cond, rule, value)
+		return
+	}
+	cg.serverOnly = append(cg.serverOnly, fmt.Sprintf("%s (%s)", field.Name, rule.Name))
+	w.line("// %s is checked by the Go validator only", ruleString(rule))
+}
+
+// tsCondition returns the TypeScript condition under which a value fails a
+// rule, and false for rules TypeScript validators cannot check
+func (cg *CodeGenerator) tsCondition(rule analyzer.ValidationRule, value tsValue) (string, bool) {
+	kind := value.goType.Kind
+	if value.goType.IsSlice {
+		kind = analyzer.TypeSlice
+	} else if value.goType.IsMap {
+		kind = analyzer.TypeMap
+	}
+
+	if helper, ok := tsFormatChecks[rule.Name]; ok {
+		if kind != analyzer.TypeString {
+			return "", false
+		}
+		return fmt.Sprintf("!%s(%s)", helper, value.expr), true
+	}
+
+	switch rule.Name {
+	case "min", "max", "len":
+		op := map[string]string{"min": "<", "max": ">", "len": "!=="}[rule.Name]
+		if kind == analyzer.TypeString || kind == analyzer.TypeSlice || kind == analyzer.TypeMap {
+			limit, err := strconv.ParseInt(rule.Parameter, 10, 64)
+			if err != nil {
+				return "", false
+			}
+			return fmt.Sprintf("%s %s %d", tsLength(value.expr, kind), op, limit), true
+		}
+		if isNumericKind(kind) {
+			if _, err := strconv.ParseFloat(rule.Parameter, 64); err != nil {
+				return "", false
+			}
+			return fmt.Sprintf("%s %s %s", value.expr, op, rule.Parameter), true
+		}
+	case "minbytes", "maxbytes", "minrunes", "maxrunes":
+		limit, err := strconv.ParseInt(rule.Parameter, 10, 64)
+		if err != nil || kind != analyzer.TypeString {
+			return "", false
+		}
+		measure := "byteLength"
+		if strings.HasSuffix(rule.Name, "runes") {
+			measure = "runeCount"
+		}
+		op := "<"
+		if strings.HasPrefix(rule.Name, "max") {
+			op = ">"
+		}
+		return fmt.Sprintf("%s(%s) %s %d", measure, value.expr, op, limit), true
+	case "minitems", "maxitems", "notempty":
+		if kind != analyzer.TypeSlice && kind != analyzer.TypeMap {
+			return "", false
+		}
+		if rule.Name == "notempty" {
+			return fmt.Sprintf("%s === 0", tsLength(value.expr, kind)), true
+		}
+		limit, err := strconv.ParseInt(rule.Parameter, 10, 64)
+		if err != nil {
+			return "", false
+		}
+		op := "<"
+		if rule.Name == "maxitems" {
+			op = ">"
+		}
+		return fmt.Sprintf("%s %s %d", tsLength(value.expr, kind), op, limit), true
+	case "eq", "ne":
+		literal, ok := tsLiteral(rule.Parameter, kind)
+		if !ok {
+			// A parameter the field's type cannot hold fails eq and passes ne
+			if rule.Name == "ne" || !(kind == analyzer.TypeBool || isNumericKind(kind)) {
+				return "", false
+			}
+			return "true", true
+		}
+		op := "!=="
+		if rule.Name == "ne" {
+			op = "==="
+		}
+		return fmt.Sprintf("%s %s %s", value.expr, op, literal), true
+	case "oneof":
+		if kind != analyzer.TypeString && !isNumericKind(kind) && kind != analyzer.TypeBool {
+			return "", false
+		}
+		values, _ := json.Marshal(strings.Split(rule.Parameter, " "))
+		return fmt.Sprintf("!%s.includes(String(%s))", values, value.expr), true
+	}
+	return "", false
+}
+
+// writeTypeScriptError writes a check pushing the error of a rule. As in
+// the validation package, only errors of nested fields have a namespace.
+func (cg *CodeGenerator) writeTypeScriptError(w *tsWriter, cond string, rule analyzer.ValidationRule, value tsValue) {
+	param := ""
+	if rule.Parameter != "" {
+		param = fmt.Sprintf(", param: %s", strconv.Quote(rule.Parameter))
+	}
+	w.line("if (%s) errors.push({ field: %s, namespace: namespace && %s, tag: %s%s, message: %s });",
+		cond, value.field, value.path, strconv.Quote(rule.Name), param, tsMessage(rule, value.field))
+}
+
+// tsMessage returns a template literal rendering the message the validation
+// package reports for a rule, with the field name read from fieldVar
+func tsMessage(rule analyzer.ValidationRule, fieldVar string) string {
+	const placeholder = "\x00"
+	var message string
+	switch format, ok := tsMessageFormats[rule.Name]; {
+	case !ok:
+		message = fmt.Sprintf("field '%s' failed validation '%s'", placeholder, rule.Name)
+	case strings.Count(format, "%") == 2:
+		message = fmt.Sprintf(format, placeholder, rule.Parameter)
+	default:
+		message = fmt.Sprintf(format, placeholder)
+	}
+
+	escaped := strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${").Replace(message)
+	return "`" + strings.Replace(escaped, placeholder, "${"+fieldVar+"}", 1) + "`"
+}
+
+// tsStruct returns the analyzed struct a value of a Go type holds directly
+// or through a pointer, or ""
+func (cg *CodeGenerator) tsStruct(goType analyzer.GoType) string {
+	if goType.IsPointer && goType.ElemType != nil {
+		goType = *goType.ElemType
+	}
+	if goType.Kind != analyzer.TypeStruct || goType.IsNullable {
+		return ""
+	}
+	if _, analyzed := cg.analysisResult.Structs[goType.Name]; !analyzed {
+		return ""
+	}
+	return goType.Name
+}
+
+// tsKey returns the JSON key of a field, and false for fields JSON skips
+func tsKey(field *analyzer.FieldInfo) (string, bool) {
+	if field.IsInline {
+		return "", false
+	}
+	switch field.JSONTag {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	}
+	return field.JSONTag, true
+}
+
+// tsPropertyName quotes a property name that is not an identifier
+func tsPropertyName(key string) string {
+	for i, c := range key {
+		if !(c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
+
+// tsZero returns the expression defaulting a missing value to the Go zero
+// value its rules see, or "" for pointers, which stay null
+func (cg *CodeGenerator) tsZero(goType analyzer.GoType) string {
+	switch {
+	case goType.IsPointer, goType.IsNullable:
+		return ""
+	case goType.IsSlice:
+		return " ?? []"
+	case goType.IsMap:
+		return " ?? {}"
+	case goType.Kind == analyzer.TypeString:
+		return ` ?? ""`
+	case goType.Kind == analyzer.TypeBool:
+		return " ?? false"
+	case isNumericKind(goType.Kind):
+		return " ?? 0"
+	case cg.tsStruct(goType) != "":
+		return " ?? ({} as " + goType.Name + ")"
+	}
+	return ""
+}
+
+// tsLength returns the length min, max and len measure: the UTF-8 byte
+// length of strings and the element count of collections
+func tsLength(expr string, kind analyzer.TypeKind) string {
+	switch kind {
+	case analyzer.TypeString:
+		return "byteLength(" + expr + ")"
+	case analyzer.TypeMap:
+		return "Object.keys(" + expr + ").length"
+	}
+	return expr + ".length"
+}
+
+// tsLiteral renders a rule parameter as a literal of a value's type
+func tsLiteral(param string, kind analyzer.TypeKind) (string, bool) {
+	switch {
+	case kind == analyzer.TypeString:
+		return strconv.Quote(param), true
+	case kind == analyzer.TypeBool:
+		b, err := strconv.ParseBool(param)
+		return strconv.FormatBool(b), err == nil
+	case isNumericKind(kind):
+		_, err := strconv.ParseFloat(param, 64)
+		return param, err == nil
+	}
+	return "", false
+}
+
+// collectionRule reports whether a rule of a dive tag applies to the
+// collection rather than its elements
+func collectionRule(rule analyzer.ValidationRule) bool {
+	switch rule.Name {
+	case "minitems", "maxitems", "notempty", "sorted", "sorted_desc", "haskeys", "allowedkeys", "sum_to":
+		return true
+	case "keys", "values":
+		return rule.Parameter != ""
+	}
+	return false
+}
+
+// ruleString renders a rule as written in its tag
+func ruleString(rule analyzer.ValidationRule) string {
+	if rule.Parameter == "" {
+		return rule.Name
+	}
+	return rule.Name + "=" + rule.Parameter
+}
+
+// isNumericKind reports whether a kind is an integer or float
+func isNumericKind(kind analyzer.TypeKind) bool {
+	return kind >= analyzer.TypeInt && kind <= analyzer.TypeFloat64
+}
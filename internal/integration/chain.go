@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// FallthroughPolicy decides when a chain moves on to its next strategy
+type FallthroughPolicy int
+
+const (
+	// FallthroughUnsupported moves on when a strategy reports
+	// ErrUnsupportedType or times out; validation errors are final
+	FallthroughUnsupported FallthroughPolicy = iota
+	// FallthroughOnError moves on after any error, including validation
+	// errors, so the last strategy has the final say
+	FallthroughOnError
+)
+
+// ErrStrategyTimeout is returned, wrapped with the strategy name, when a
+// chain step does not finish within its timeout
+var ErrStrategyTimeout = errors.New("validation strategy timed out")
+
+// ChainStep is a named strategy of a chain with an optional timeout
+type ChainStep struct {
+	name     string
+	strategy ConfigValidationStrategy
+	timeout  time.Duration
+	running  atomic.Bool // a timed-out run has not returned yet
+}
+
+// Step creates a chain step running strategy under name
+func Step(name string, strategy ConfigValidationStrategy) *ChainStep {
+	return &ChainStep{name: name, strategy: strategy}
+}
+
+// WithTimeout bounds how long the step may run before the chain moves on.
+// Zero disables the timeout.
+func (cs *ChainStep) WithTimeout(timeout time.Duration) *ChainStep {
+	cs.timeout = timeout
+	return cs
+}
+
+// ChainEvent records one attempt of a chain step
+type ChainEvent struct {
+	TypeName string
+	Strategy string
+	Err      error
+	Elapsed  time.Duration
+	Handled  bool // the step's result is the chain's result
+}
+
+// ChainStrategy tries its steps in order until one handles the config, so
+// generated validators can be rolled out type by type with analysis and
+// reflection behind them
+type ChainStrategy struct {
+	steps   []*ChainStep
+	policy  FallthroughPolicy
+	onEvent func(ChainEvent)
+	errors  []EnhancedValidationError
+}
+
+// Chain composes steps into a strategy, e.g.
+//
+//	Chain(Step("generated", generated), Step("analysis", analysis), Step("reflection", reflection))
+func Chain(steps ...*ChainStep) *ChainStrategy {
+	return &ChainStrategy{
+		steps:  steps,
+		errors: make([]EnhancedValidationError, 0),
+	}
+}
+
+// SetPolicy sets when the chain falls through to the next step
+func (cs *ChainStrategy) SetPolicy(policy FallthroughPolicy) {
+	cs.policy = policy
+}
+
+// OnEvent registers a hook called after every step attempt, e.g. to record
+// which strategy handled each type
+func (cs *ChainStrategy) OnEvent(hook func(ChainEvent)) {
+	cs.onEvent = hook
+}
+
+// Validate validates a configuration struct with the first step handling it
+func (cs *ChainStrategy) Validate(ctx context.Context, config interface{}) error {
+	return cs.ValidateWithPath(ctx, config, "")
+}
+
+// ValidateWithPath validates a configuration struct with YAML path context,
+// returning the result of the last step tried
+func (cs *ChainStrategy) ValidateWithPath(ctx context.Context, config interface{}, yamlPath string) error {
+	cs.errors = cs.errors[:0]
+	typeName := fmt.Sprintf("%T", config)
+
+	err := fmt.Errorf("%w: %s", ErrUnsupportedType, typeName)
+	for i, step := range cs.steps {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		start := time.Now()
+		err = cs.run(ctx, step, config, yamlPath)
+		last := i == len(cs.steps)-1
+		handled := last || !cs.fallsThrough(err)
+		if cs.onEvent != nil {
+			cs.onEvent(ChainEvent{
+				TypeName: typeName,
+				Strategy: step.name,
+				Err:      err,
+				Elapsed:  time.Since(start),
+				Handled:  handled,
+			})
+		}
+		if handled {
+			if !errors.Is(err, ErrStrategyTimeout) {
+				cs.errors = append(cs.errors, step.strategy.GetValidationErrors()...)
+			}
+			return err
+		}
+	}
+	return err
+}
+
+// run validates with one step, abandoning it when its timeout expires
+func (cs *ChainStrategy) run(ctx context.Context, step *ChainStep, config interface{}, yamlPath string) error {
+	if step.timeout <= 0 {
+		return step.strategy.ValidateWithPath(ctx, config, yamlPath)
+	}
+
+	// Strategies keep per-run state, so one still running after a timeout
+	// is skipped until it returns
+	if !step.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("%w: %s is still running a previous validation", ErrStrategyTimeout, step.name)
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, step.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer step.running.Store(false)
+		done <- step.strategy.ValidateWithPath(stepCtx, config, yamlPath)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-stepCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s after %s", ErrStrategyTimeout, step.name, step.timeout)
+	}
+}
+
+// fallsThrough reports whether the policy moves past a step's result
+func (cs *ChainStrategy) fallsThrough(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, ErrUnsupportedType), errors.Is(err, ErrStrategyTimeout):
+		return true
+	}
+	return cs.policy == FallthroughOnError
+}
+
+// GetValidationErrors returns the detailed errors of the step that handled
+// the last validation
+func (cs *ChainStrategy) GetValidationErrors() []EnhancedValidationError {
+	return cs.errors
+}
+
+// SetFailFast configures fail-fast behavior of every step
+func (cs *ChainStrategy) SetFailFast(enabled bool) {
+	for _, step := range cs.steps {
+		step.strategy.SetFailFast(enabled)
+	}
+}
@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// slowStrategy blocks until released, standing in for a hung strategy
+type slowStrategy struct {
+	release chan struct{}
+}
+
+func (s *slowStrategy) Validate(ctx context.Context, config interface{}) error {
+	return s.ValidateWithPath(ctx, config, "")
+}
+
+func (s *slowStrategy) ValidateWithPath(ctx context.Context, config interface{}, yamlPath string) error {
+	<-s.release
+	return nil
+}
+
+func (s *slowStrategy) GetValidationErrors() []EnhancedValidationError { return nil }
+
+func (s *slowStrategy) SetFailFast(enabled bool) {}
+
+// failingStrategy reports a validation error for every config
+type failingStrategy struct{}
+
+func (failingStrategy) Validate(ctx context.Context, config interface{}) error {
+	return failingStrategy{}.ValidateWithPath(ctx, config, "")
+}
+
+func (failingStrategy) ValidateWithPath(ctx context.Context, config interface{}, yamlPath string) error {
+	return validation.ValidationErrors{{Field: "Name", Tag: "required"}}
+}
+
+func (failingStrategy) GetValidationErrors() []EnhancedValidationError { return nil }
+
+func (failingStrategy) SetFailFast(enabled bool) {}
+
+func TestChain_GradualRollout(t *testing.T) {
+	generated := NewGeneratedStrategy(testAnalysis())
+	generated.SetFallbacks(false, false)
+	generated.RegisterValidator("testDatabaseConfig", &testDatabaseValidator{})
+
+	var events []ChainEvent
+	chain := Chain(
+		Step("generated", generated),
+		Step("analysis", NewAnalysisStrategy(testAnalysis())),
+		Step("reflection", NewReflectionStrategy(testAnalysis())),
+	)
+	chain.OnEvent(func(event ChainEvent) { events = append(events, event) })
+
+	if err := chain.Validate(context.Background(), &testDatabaseConfig{}); err == nil {
+		t.Error("expected the generated validator to report the missing host")
+	}
+	if len(events) != 1 || events[0].Strategy != "generated" || !events[0].Handled {
+		t.Errorf("expected the generated step to handle testDatabaseConfig, got %+v", events)
+	}
+	if errs := chain.GetValidationErrors(); len(errs) != 1 || errs[0].ConfigSource != "generated" {
+		t.Errorf("expected the generated step's errors, got %+v", errs)
+	}
+
+	events = nil
+	config := &testAppConfig{Name: "app", Database: testDatabaseConfig{Host: "db"}, Cache: &testCacheConfig{Size: 1}}
+	if err := chain.Validate(context.Background(), config); err != nil {
+		t.Errorf("expected no errors, got %v", err)
+	}
+	if len(events) != 2 || events[0].Handled || !errors.Is(events[0].Err, ErrUnsupportedType) {
+		t.Fatalf("expected the generated step to report an unsupported type, got %+v", events)
+	}
+	if events[1].Strategy != "analysis" || !events[1].Handled || events[1].TypeName != "*integration.testAppConfig" {
+		t.Errorf("expected the analysis step to handle *integration.testAppConfig, got %+v", events[1])
+	}
+}
+
+func TestChain_Timeout(t *testing.T) {
+	slow := &slowStrategy{release: make(chan struct{})}
+	defer close(slow.release)
+
+	var handledBy []string
+	chain := Chain(
+		Step("slow", slow).WithTimeout(10*time.Millisecond),
+		Step("reflection", NewReflectionStrategy(nil)),
+	)
+	chain.OnEvent(func(event ChainEvent) {
+		if event.Handled {
+			handledBy = append(handledBy, event.Strategy)
+		} else if !errors.Is(event.Err, ErrStrategyTimeout) {
+			t.Errorf("expected a timeout from %s, got %v", event.Strategy, event.Err)
+		}
+	})
+
+	// The second run skips the slow step while its first run is still going
+	for i := 0; i < 2; i++ {
+		if err := chain.Validate(context.Background(), &testAppConfig{}); err != nil {
+			t.Errorf("expected reflection to accept the untagged config, got %v", err)
+		}
+	}
+	if len(handledBy) != 2 || handledBy[0] != "reflection" || handledBy[1] != "reflection" {
+		t.Errorf("expected reflection to handle both runs, got %v", handledBy)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := chain.Validate(ctx, &testAppConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled context's error, got %v", err)
+	}
+}
+
+func TestChain_Policy(t *testing.T) {
+	chain := Chain(Step("failing", failingStrategy{}), Step("reflection", NewReflectionStrategy(nil)))
+
+	if err := chain.Validate(context.Background(), &testAppConfig{}); err == nil {
+		t.Error("expected validation errors to be final by default")
+	}
+
+	chain.SetPolicy(FallthroughOnError)
+	if err := chain.Validate(context.Background(), &testAppConfig{}); err != nil {
+		t.Errorf("expected the reflection step to have the final say, got %v", err)
+	}
+
+	if err := Chain().Validate(context.Background(), &testAppConfig{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected an empty chain to report an unsupported type, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package integration
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// FieldChange describes how the value of a failing field differs from the
+// last config of the same type that passed validation
+type FieldChange struct {
+	Changed  bool        `json:"changed"`
+	Added    bool        `json:"added,omitempty"` // absent from the last valid config
+	Previous interface{} `json:"previous,omitempty"`
+}
+
+// SetTrackChanges enables remembering the last valid config of each type,
+// so errors of a reloaded config report in Change whether the failing field
+// was edited and its previous value. Sensitive fields are redacted.
+func (gs *GeneratedStrategy) SetTrackChanges(enabled bool) {
+	gs.trackChanges = enabled
+	if !enabled {
+		gs.lastValid = nil
+	}
+}
+
+// recordChanges remembers a valid config, or annotates the collected
+// errors of an invalid one with the changes since the last valid config
+func (gs *GeneratedStrategy) recordChanges(config interface{}, yamlPath string, valid bool) {
+	if !gs.trackChanges || config == nil {
+		return
+	}
+
+	current := make(map[string]interface{})
+	flattenConfig(reflect.ValueOf(config), yamlPath, false, current)
+
+	key := gs.getConfigTypeName(config) + "@" + yamlPath
+	if valid {
+		if gs.lastValid == nil {
+			gs.lastValid = make(map[string]map[string]interface{})
+		}
+		gs.lastValid[key] = current
+		return
+	}
+
+	previous, exists := gs.lastValid[key]
+	if !exists {
+		return
+	}
+	for i := range gs.errors {
+		path := gs.errors[i].YAMLPath
+		before, existed := previous[path]
+		gs.errors[i].Change = &FieldChange{
+			Changed:  !existed || !reflect.DeepEqual(before, current[path]),
+			Added:    !existed,
+			Previous: before,
+		}
+	}
+}
+
+// flattenConfig records the leaf values of a config by YAML path, using the
+// same keys as the analyzer: yaml tags or lowercased field names, [i] and
+// [key] for collection elements, and inline fields merged into their parent
+func flattenConfig(value reflect.Value, path string, sensitive bool, out map[string]interface{}) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			out[path] = nil
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if !hasExportedFields(value.Type()) {
+			break
+		}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, inline, skip := yamlKey(field)
+			if skip {
+				continue
+			}
+			fieldPath := path
+			if !inline {
+				fieldPath = joinPath(path, name)
+			}
+			flattenConfig(value.Field(i), fieldPath, sensitive || isSensitive(field), out)
+		}
+		return
+
+	case reflect.Slice, reflect.Array:
+		out[path] = value.Len()
+		for i := 0; i < value.Len(); i++ {
+			flattenConfig(value.Index(i), fmt.Sprintf("%s[%d]", path, i), sensitive, out)
+		}
+		return
+
+	case reflect.Map:
+		out[path] = value.Len()
+		iter := value.MapRange()
+		for iter.Next() {
+			flattenConfig(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key().Interface()), sensitive, out)
+		}
+		return
+	}
+
+	if !value.IsValid() || !value.CanInterface() {
+		return
+	}
+	if sensitive {
+		out[path] = validation.RedactedValue
+		return
+	}
+	out[path] = value.Interface()
+}
+
+// yamlKey returns the YAML key of a struct field, whether it is inlined
+// into its parent, and whether YAML ignores it
+func yamlKey(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get("yaml")
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", false, true
+	}
+	inline := false
+	for _, option := range strings.Split(options, ",") {
+		if option == "inline" {
+			inline = true
+		}
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, inline, false
+}
+
+// isSensitive reports whether a struct field's validate tag marks it sensitive
+func isSensitive(field reflect.StructField) bool {
+	for _, rule := range validation.SplitRules(field.Tag.Get("validate")) {
+		if rule = strings.TrimSpace(rule); rule == "sensitive" || rule == "secret" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExportedFields reports whether a struct type has fields to descend
+// into, as opposed to values such as time.Time
+func hasExportedFields(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// joinPath appends a key to a YAML path
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGeneratedStrategy_TrackChanges(t *testing.T) {
+	strategy := NewGeneratedStrategy(testAnalysis())
+	strategy.RegisterValidator("testDatabaseConfig", &testDatabaseValidator{})
+	strategy.SetTrackChanges(true)
+	ctx := context.Background()
+
+	if err := strategy.Validate(ctx, &testAppConfig{Name: "api", Database: testDatabaseConfig{Host: ""}, Cache: &testCacheConfig{}}); err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, valErr := range strategy.GetValidationErrors() {
+		if valErr.Change != nil {
+			t.Errorf("expected no change for %s before a valid config, got %+v", valErr.YAMLPath, valErr.Change)
+		}
+	}
+
+	valid := &testAppConfig{Name: "api", Database: testDatabaseConfig{Host: "db"}, Cache: &testCacheConfig{Size: 1}}
+	if err := strategy.Validate(ctx, valid); err != nil {
+		t.Fatalf("expected a valid config, got %v", err)
+	}
+
+	reloaded := &testAppConfig{Name: "", Database: testDatabaseConfig{Host: "db"}, Cache: &testCacheConfig{Size: 1}}
+	if err := strategy.Validate(ctx, reloaded); err == nil {
+		t.Fatal("expected validation errors")
+	}
+	errs := strategy.GetValidationErrors()
+	if len(errs) != 1 || errs[0].YAMLPath != "name" {
+		t.Fatalf("expected one error at name, got %+v", errs)
+	}
+	change := errs[0].Change
+	if change == nil || !change.Changed || change.Added || change.Previous != "api" {
+		t.Errorf("expected name to have changed from \"api\", got %+v", change)
+	}
+}
+
+func TestFlattenConfig(t *testing.T) {
+	type credentials struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password" validate:"sensitive"`
+	}
+	type Base struct {
+		Region string `yaml:"region"`
+	}
+	type config struct {
+		Base     `yaml:",inline"`
+		Hosts    []string          `yaml:"hosts"`
+		Labels   map[string]string `yaml:"labels"`
+		Auth     *credentials      `yaml:"auth"`
+		Internal string            `yaml:"-"`
+	}
+
+	values := make(map[string]interface{})
+	flattenConfig(reflect.ValueOf(&config{
+		Base:   Base{Region: "eu"},
+		Hosts:  []string{"a", "b"},
+		Labels: map[string]string{"team": "core"},
+		Auth:   &credentials{User: "admin", Password: "secret"},
+	}), "", false, values)
+
+	expected := map[string]interface{}{
+		"region":        "eu",
+		"hosts":         2,
+		"hosts[0]":      "a",
+		"hosts[1]":      "b",
+		"labels":        1,
+		"labels[team]":  "core",
+		"auth.user":     "admin",
+		"auth.password": "[REDACTED]",
+	}
+	for path, value := range expected {
+		if values[path] != value {
+			t.Errorf("expected %s to be %v, got %v", path, value, values[path])
+		}
+	}
+	if _, exists := values["internal"]; exists {
+		t.Error("expected fields ignored by YAML to be left out")
+	}
+}
@@ -3,6 +3,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -30,19 +31,32 @@ type ConfigValidationStrategy interface {
 type EnhancedValidationError struct {
 	validation.ValidationError
 	YAMLPath     string            `json:"yaml_path"`
+	Path         string            `json:"path"` // the path in the strategy's source format
+	SourcePath   SourcePath        `json:"-"`
 	ConfigSource string            `json:"config_source"`
 	Suggestions  []string          `json:"suggestions,omitempty"`
 	Context      map[string]string `json:"context,omitempty"`
+	Change       *FieldChange      `json:"change,omitempty"` // nil unless changes are tracked and a valid config was seen
 }
 
 // GeneratedStrategy implements ConfigValidationStrategy using generated validators
 type GeneratedStrategy struct {
-	validators     map[string]ValidatorInterface
-	analysisResult *analyzer.AnalysisResult
-	errors         []EnhancedValidationError
-	failFast       bool
-	debugMode      bool
-}
+	validators         map[string]ValidatorInterface
+	analysisResult     *analyzer.AnalysisResult
+	errors             []EnhancedValidationError
+	failFast           bool
+	debugMode          bool
+	analysisFallback   bool
+	reflectionFallback bool
+	trackChanges       bool
+	format             SourceFormat
+	sourcePaths        map[string]SourcePath             // YAML path to source path, per run
+	lastValid          map[string]map[string]interface{} // type@path to flattened last valid config
+}
+
+// ErrUnsupportedType is returned, wrapped with the type name, by strategies
+// that cannot validate a config type
+var ErrUnsupportedType = errors.New("unsupported config type")
 
 // ValidatorInterface defines the interface that generated validators must implement
 type ValidatorInterface interface {
@@ -59,9 +73,37 @@ func NewGeneratedStrategy(analysisResult *analyzer.AnalysisResult) *GeneratedStr
 		errors:         make([]EnhancedValidationError, 0),
 		failFast:       false,
 		debugMode:      false,
+		format:         SourceYAML,
+		sourcePaths:    make(map[string]SourcePath),
+
+		analysisFallback:   true,
+		reflectionFallback: true,
 	}
 }
 
+// NewAnalysisStrategy creates a strategy validating from the analysis alone,
+// for types without generated validators. Other types are unsupported.
+func NewAnalysisStrategy(analysisResult *analyzer.AnalysisResult) *GeneratedStrategy {
+	strategy := NewGeneratedStrategy(analysisResult)
+	strategy.SetFallbacks(true, false)
+	return strategy
+}
+
+// SetFallbacks selects how types without a registered validator are
+// validated: from the analysis, with reflection, or, with both disabled,
+// not at all, reporting ErrUnsupportedType
+func (gs *GeneratedStrategy) SetFallbacks(analysis, reflection bool) {
+	gs.analysisFallback = analysis
+	gs.reflectionFallback = reflection
+}
+
+// SetSourceFormat selects the config format errors report paths in, using
+// the toml or json tag key names of fields for TOML and JSON. YAMLPath is
+// reported in every format.
+func (gs *GeneratedStrategy) SetSourceFormat(format SourceFormat) {
+	gs.format = format
+}
+
 // RegisterValidator registers a generated validator for a specific config type
 func (gs *GeneratedStrategy) RegisterValidator(typeName string, validator ValidatorInterface) {
 	gs.validators[typeName] = validator
@@ -74,9 +116,29 @@ func (gs *GeneratedStrategy) Validate(ctx context.Context, config interface{}) e
 
 // ValidateWithPath validates a configuration struct with YAML path context
 func (gs *GeneratedStrategy) ValidateWithPath(ctx context.Context, config interface{}, yamlPath string) error {
-	// Clear previous errors
+	// Clear the errors of the previous run; nested structs add to this one
 	gs.errors = gs.errors[:0]
+	clear(gs.sourcePaths)
+
+	err := gs.validateConfig(config, yamlPath)
+	gs.recordChanges(config, yamlPath, err == nil)
+	if err != nil {
+		return err
+	}
+
+	// Check for context cancellation
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
 
+// validateConfig validates a config, or a nested struct of one, with its
+// registered generated validator, falling back to analysis and reflection.
+// It accumulates into gs.errors and returns all errors collected so far.
+func (gs *GeneratedStrategy) validateConfig(config interface{}, yamlPath string) error {
 	// Get the type name of the config
 	configType := gs.getConfigTypeName(config)
 
@@ -91,16 +153,9 @@ func (gs *GeneratedStrategy) ValidateWithPath(ctx context.Context, config interf
 
 	// Perform validation
 	if err := validator.Validate(config); err != nil {
-		return gs.enhanceValidationErrors(err, yamlPath, "generated")
-	}
-
-	// Check for context cancellation
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-		return nil
+		return gs.enhanceValidationErrors(err, configType, yamlPath, "generated")
 	}
+	return gs.buildError()
 }
 
 // GetValidationErrors returns detailed validation errors with context
@@ -138,12 +193,17 @@ func (gs *GeneratedStrategy) getConfigTypeName(config interface{}) string {
 // handleUnregisteredType handles validation for types without generated validators
 func (gs *GeneratedStrategy) handleUnregisteredType(typeName string, config interface{}, yamlPath string) error {
 	// Check if we have analysis information for this type
-	if structInfo, exists := gs.analysisResult.Structs[typeName]; exists {
-		return gs.validateUsingAnalysis(structInfo, config, yamlPath)
+	if gs.analysisFallback && gs.analysisResult != nil {
+		if structInfo, exists := gs.analysisResult.Structs[typeName]; exists {
+			return gs.validateUsingAnalysis(structInfo, config, yamlPath)
+		}
 	}
 
 	// Fallback to reflection-based validation
-	return gs.validateUsingReflection(config, yamlPath)
+	if gs.reflectionFallback {
+		return gs.validateUsingReflection(typeName, config, yamlPath)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedType, typeName)
 }
 
 // validateUsingAnalysis validates using analysis information without generated code
@@ -157,8 +217,8 @@ func (gs *GeneratedStrategy) validateUsingAnalysis(structInfo *analyzer.StructIn
 		configValue = configValue.Elem()
 	}
 
-	// Validate each field according to analysis
-	for _, fieldInfo := range structInfo.Fields {
+	// Validate each field according to analysis, referenced fields first
+	for _, fieldInfo := range structInfo.OrderedFields() {
 		fieldValue := configValue.FieldByName(fieldInfo.Name)
 		if !fieldValue.IsValid() {
 			continue
@@ -181,7 +241,11 @@ func (gs *GeneratedStrategy) validateFieldUsingAnalysis(fieldInfo *analyzer.Fiel
 	// Skip validation if field is a pointer and nil (and not required)
 	if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
 		if gs.isFieldRequired(fieldInfo) {
-			gs.addError(fieldInfo.Name, "required", "", "field is required but is nil", yamlPath, "analysis")
+			gs.addFieldError(validation.ValidationError{
+				Field:   fieldInfo.Name,
+				Tag:     "required",
+				Message: "field is required but is nil",
+			}, fieldInfo, yamlPath, "analysis")
 			return gs.buildError()
 		}
 		return nil
@@ -201,10 +265,15 @@ func (gs *GeneratedStrategy) validateFieldUsingAnalysis(fieldInfo *analyzer.Fiel
 		}
 	}
 
-	// Handle nested struct validation
+	// Dispatch nested structs to their own validator, keeping collected errors
 	if fieldInfo.IsNested && fieldValue.Kind() == reflect.Struct {
 		nestedConfig := fieldValue.Interface()
-		if err := gs.ValidateWithPath(context.Background(), nestedConfig, yamlPath); err != nil {
+		if fieldValue.CanAddr() {
+			// Generated validators take pointers
+			nestedConfig = fieldValue.Addr().Interface()
+		}
+		// Nested structs nothing can validate carry no rules of their own
+		if err := gs.validateConfig(nestedConfig, yamlPath); err != nil && !errors.Is(err, ErrUnsupportedType) {
 			if gs.failFast {
 				return err
 			}
@@ -224,34 +293,47 @@ func (gs *GeneratedStrategy) validateRule(fieldInfo *analyzer.FieldInfo, rule an
 		tag = rule.Name
 	}
 
+	if !fieldValue.IsValid() || !fieldValue.CanInterface() {
+		return nil
+	}
+
 	// Use the validation library for the actual validation
 	err := validation.Var(fieldValue.Interface(), tag)
-	if err != nil {
-		gs.addValidationError(err.(validation.ValidationError), yamlPath, "analysis")
-		return gs.buildError()
+	if err == nil {
+		return nil
 	}
 
-	return nil
+	valErrs, ok := err.(validation.ValidationErrors)
+	if !ok {
+		return gs.enhanceValidationErrors(err, "", yamlPath, "analysis")
+	}
+
+	for _, valErr := range valErrs {
+		valErr.Field = fieldInfo.Name
+		gs.addFieldError(valErr, fieldInfo, yamlPath, "analysis")
+	}
+	return gs.buildError()
 }
 
 // validateUsingReflection provides fallback validation using reflection
-func (gs *GeneratedStrategy) validateUsingReflection(config interface{}, yamlPath string) error {
+func (gs *GeneratedStrategy) validateUsingReflection(typeName string, config interface{}, yamlPath string) error {
 	// Use the validation library's reflection-based validation as fallback
 	err := validation.Struct(config)
 	if err != nil {
-		return gs.enhanceValidationErrors(err, yamlPath, "reflection")
+		return gs.enhanceValidationErrors(err, typeName, yamlPath, "reflection")
 	}
 	return nil
 }
 
-// enhanceValidationErrors converts validation errors to enhanced errors with context
-func (gs *GeneratedStrategy) enhanceValidationErrors(err error, yamlPath, source string) error {
+// enhanceValidationErrors converts validation errors of the root type, when
+// known, to enhanced errors with context
+func (gs *GeneratedStrategy) enhanceValidationErrors(err error, rootType, yamlPath, source string) error {
 	if validationErrors, ok := err.(validation.ValidationErrors); ok {
 		for _, valErr := range validationErrors {
-			gs.addValidationError(valErr, yamlPath, source)
+			gs.addValidationError(valErr, rootType, yamlPath, source)
 		}
 	} else if valErr, ok := err.(validation.ValidationError); ok {
-		gs.addValidationError(valErr, yamlPath, source)
+		gs.addValidationError(valErr, rootType, yamlPath, source)
 	} else {
 		// Handle generic errors
 		gs.addError("", "validation", "", err.Error(), yamlPath, source)
@@ -261,17 +343,29 @@ func (gs *GeneratedStrategy) enhanceValidationErrors(err error, yamlPath, source
 }
 
 // addValidationError adds a validation error to the enhanced error list
-func (gs *GeneratedStrategy) addValidationError(valErr validation.ValidationError, yamlPath, source string) {
-	fieldYAMLPath := gs.buildFieldYAMLPath(yamlPath, &analyzer.FieldInfo{
-		Name:    valErr.Field,
-		YAMLTag: strings.ToLower(valErr.Field), // Default YAML name
-	})
+func (gs *GeneratedStrategy) addValidationError(valErr validation.ValidationError, rootType, yamlPath, source string) {
+	fieldYAMLPath := gs.resolveYAMLPath(valErr, rootType, yamlPath)
+	sourcePath := gs.sourcePath(fieldYAMLPath)
+
+	var fieldInfo *analyzer.FieldInfo
+	if gs.analysisResult != nil && rootType != "" {
+		namespace := valErr.Namespace
+		if namespace == "" {
+			namespace = valErr.Field
+		}
+		if chain, exists := gs.analysisResult.FieldChain(rootType, namespace); exists {
+			fieldInfo = chain[len(chain)-1]
+			sourcePath = gs.namespaceSourcePath(gs.sourcePath(yamlPath), chain, namespace)
+		}
+	}
 
 	enhancedErr := EnhancedValidationError{
 		ValidationError: valErr,
 		YAMLPath:        fieldYAMLPath,
+		Path:            sourcePath.Render(gs.format),
+		SourcePath:      sourcePath,
 		ConfigSource:    source,
-		Suggestions:     gs.generateSuggestions(valErr),
+		Suggestions:     gs.generateSuggestions(valErr, fieldInfo, fieldYAMLPath),
 		Context:         gs.generateContext(valErr, yamlPath),
 	}
 
@@ -280,35 +374,102 @@ func (gs *GeneratedStrategy) addValidationError(valErr validation.ValidationErro
 
 // addError adds a custom validation error
 func (gs *GeneratedStrategy) addError(field, tag, param, message, yamlPath, source string) {
-	valErr := validation.ValidationError{
+	gs.addFieldError(validation.ValidationError{
 		Field:   field,
 		Tag:     tag,
 		Param:   param,
 		Message: message,
-	}
+	}, nil, yamlPath, source)
+}
 
+// addFieldError adds an error of a field, with its analysis when known, to
+// the enhanced error list
+func (gs *GeneratedStrategy) addFieldError(valErr validation.ValidationError, fieldInfo *analyzer.FieldInfo, yamlPath, source string) {
+	sourcePath := gs.sourcePath(yamlPath)
 	enhancedErr := EnhancedValidationError{
 		ValidationError: valErr,
 		YAMLPath:        yamlPath,
+		Path:            sourcePath.Render(gs.format),
+		SourcePath:      sourcePath,
 		ConfigSource:    source,
-		Suggestions:     gs.generateSuggestions(valErr),
+		Suggestions:     gs.generateSuggestions(valErr, fieldInfo, yamlPath),
 		Context:         gs.generateContext(valErr, yamlPath),
 	}
 
 	gs.errors = append(gs.errors, enhancedErr)
 }
 
-// buildFieldYAMLPath constructs the full YAML path for a field
+// resolveYAMLPath returns the YAML path of an error, using the analyzed path
+// of the error's namespace below the root type so fields of structs shared
+// by several parents resolve to the instance that failed
+func (gs *GeneratedStrategy) resolveYAMLPath(valErr validation.ValidationError, rootType, yamlPath string) string {
+	namespace := valErr.Namespace
+	if namespace == "" {
+		namespace = valErr.Field
+	}
+	if gs.analysisResult != nil && rootType != "" {
+		if path, exists := gs.analysisResult.YAMLPathFor(rootType, namespace); exists {
+			if yamlPath == "" || path == "" {
+				return yamlPath + path
+			}
+			return yamlPath + "." + path
+		}
+	}
+
+	return gs.buildFieldYAMLPath(yamlPath, &analyzer.FieldInfo{
+		Name:    valErr.Field,
+		YAMLTag: strings.ToLower(valErr.Field), // Default YAML name
+	})
+}
+
+// buildFieldYAMLPath constructs the full YAML path for a field, recording
+// its path in the source format
 func (gs *GeneratedStrategy) buildFieldYAMLPath(basePath string, fieldInfo *analyzer.FieldInfo) string {
+	// Inline fields share the keys of their parent
+	if fieldInfo.IsInline {
+		return basePath
+	}
+
 	fieldName := fieldInfo.YAMLTag
 	if fieldName == "" {
 		fieldName = strings.ToLower(fieldInfo.Name)
 	}
 
-	if basePath == "" {
-		return fieldName
+	path := fieldName
+	if basePath != "" {
+		path = basePath + "." + fieldName
+	}
+	if key, ok := fieldKey(fieldInfo, gs.format); ok {
+		gs.sourcePaths[path] = gs.sourcePath(basePath).Key(key)
+	}
+	return path
+}
+
+// sourcePath returns the source path recorded for a YAML path, or the YAML
+// path itself for paths given by callers
+func (gs *GeneratedStrategy) sourcePath(yamlPath string) SourcePath {
+	if path, exists := gs.sourcePaths[yamlPath]; exists {
+		return path
+	}
+	return ParseSourcePath(yamlPath)
+}
+
+// namespaceSourcePath extends base by the keys of the analyzed fields of a
+// validation error namespace and its element indexes and map keys
+func (gs *GeneratedStrategy) namespaceSourcePath(base SourcePath, chain []*analyzer.FieldInfo, namespace string) SourcePath {
+	path := base
+	for i, segment := range strings.Split(namespace, ".") {
+		_, elements, _ := strings.Cut(segment, "[")
+		if key, ok := fieldKey(chain[i], gs.format); ok {
+			path = path.Key(key)
+		}
+		for elements != "" {
+			element, more, _ := strings.Cut(elements, "]")
+			path = path.Element(element)
+			elements = strings.TrimPrefix(more, "[")
+		}
 	}
-	return basePath + "." + fieldName
+	return path
 }
 
 // isFieldRequired checks if a field is required based on validation rules
@@ -321,46 +482,6 @@ func (gs *GeneratedStrategy) isFieldRequired(fieldInfo *analyzer.FieldInfo) bool
 	return false
 }
 
-// generateSuggestions generates helpful suggestions for validation errors
-func (gs *GeneratedStrategy) generateSuggestions(valErr validation.ValidationError) []string {
-	var suggestions []string
-
-	switch valErr.Tag {
-	case "required":
-		suggestions = append(suggestions, fmt.Sprintf("Ensure the '%s' field is provided in your configuration", valErr.Field))
-		suggestions = append(suggestions, "Check that the field name in your config file matches the expected name")
-
-	case "email":
-		suggestions = append(suggestions, "Ensure the email address follows the format: user@domain.com")
-		suggestions = append(suggestions, "Check for typos in the email address")
-
-	case "url":
-		suggestions = append(suggestions, "Ensure the URL includes a scheme (http:// or https://)")
-		suggestions = append(suggestions, "Check that the URL is properly formatted")
-
-	case "min":
-		suggestions = append(suggestions, fmt.Sprintf("Ensure the value is at least %s", valErr.Param))
-		if strings.Contains(valErr.Field, "port") {
-			suggestions = append(suggestions, "Port numbers must be between 1 and 65535")
-		}
-
-	case "max":
-		suggestions = append(suggestions, fmt.Sprintf("Ensure the value is at most %s", valErr.Param))
-		if strings.Contains(valErr.Field, "port") {
-			suggestions = append(suggestions, "Port numbers must be between 1 and 65535")
-		}
-
-	case "oneof":
-		suggestions = append(suggestions, fmt.Sprintf("Valid values are: %s", valErr.Param))
-		suggestions = append(suggestions, "Check for typos in the configuration value")
-
-	default:
-		suggestions = append(suggestions, fmt.Sprintf("Check the documentation for the '%s' validation rule", valErr.Tag))
-	}
-
-	return suggestions
-}
-
 // generateContext generates contextual information for validation errors
 func (gs *GeneratedStrategy) generateContext(valErr validation.ValidationError, yamlPath string) map[string]string {
 	context := make(map[string]string)
@@ -436,10 +557,7 @@ func (csf *ConfigStrategyFactory) CreateGeneratedStrategy() ConfigValidationStra
 
 // CreateReflectionStrategy creates a reflection-based validation strategy (fallback)
 func (csf *ConfigStrategyFactory) CreateReflectionStrategy() ConfigValidationStrategy {
-	strategy := &ReflectionStrategy{
-		analysisResult: csf.analysisResult,
-		errors:         make([]EnhancedValidationError, 0),
-	}
+	strategy := NewReflectionStrategy(csf.analysisResult)
 	csf.strategies["reflection"] = strategy
 	return strategy
 }
@@ -457,6 +575,14 @@ type ReflectionStrategy struct {
 	failFast       bool
 }
 
+// NewReflectionStrategy creates a reflection-based validation strategy
+func NewReflectionStrategy(analysisResult *analyzer.AnalysisResult) *ReflectionStrategy {
+	return &ReflectionStrategy{
+		analysisResult: analysisResult,
+		errors:         make([]EnhancedValidationError, 0),
+	}
+}
+
 // Validate validates using reflection-based validation
 func (rs *ReflectionStrategy) Validate(ctx context.Context, config interface{}) error {
 	return rs.ValidateWithPath(ctx, config, "")
@@ -470,9 +596,12 @@ func (rs *ReflectionStrategy) ValidateWithPath(ctx context.Context, config inter
 	if err != nil {
 		if validationErrors, ok := err.(validation.ValidationErrors); ok {
 			for _, valErr := range validationErrors {
+				fieldYAMLPath := yamlPath + "." + strings.ToLower(valErr.Field)
 				enhancedErr := EnhancedValidationError{
 					ValidationError: valErr,
-					YAMLPath:        yamlPath + "." + strings.ToLower(valErr.Field),
+					YAMLPath:        fieldYAMLPath,
+					Path:            fieldYAMLPath,
+					SourcePath:      ParseSourcePath(fieldYAMLPath),
 					ConfigSource:    "reflection",
 					Suggestions:     []string{"Consider using generated validation for better performance"},
 				}
@@ -0,0 +1,117 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+type testAppConfig struct {
+	Name     string
+	Database testDatabaseConfig
+	Cache    *testCacheConfig
+}
+
+type testDatabaseConfig struct {
+	Host string
+}
+
+type testCacheConfig struct {
+	Size int
+}
+
+// testDatabaseValidator stands in for a generated validator
+type testDatabaseValidator struct {
+	calls int
+}
+
+func (v *testDatabaseValidator) Validate(config interface{}) error {
+	v.calls++
+	if config.(*testDatabaseConfig).Host == "" {
+		return validation.ValidationErrors{{Field: "Host", Tag: "required", Message: "field 'Host' is required"}}
+	}
+	return nil
+}
+
+func (v *testDatabaseValidator) SetFailFast(enabled bool) {}
+
+func (v *testDatabaseValidator) GetFieldPath(fieldName string) string { return fieldName }
+
+func testAnalysis() *analyzer.AnalysisResult {
+	rules := func(tag string) []analyzer.ValidationRule {
+		return []analyzer.ValidationRule{{Name: tag}}
+	}
+	return &analyzer.AnalysisResult{
+		Structs: map[string]*analyzer.StructInfo{
+			"testAppConfig": {
+				Name: "testAppConfig",
+				Fields: []analyzer.FieldInfo{
+					{Name: "Name", YAMLTag: "name", ValidationRules: rules("required")},
+					{Name: "Database", YAMLTag: "database", IsNested: true, NestedType: "testDatabaseConfig"},
+					{Name: "Cache", YAMLTag: "cache", IsNested: true, NestedType: "testCacheConfig"},
+				},
+			},
+			"testCacheConfig": {
+				Name:   "testCacheConfig",
+				Fields: []analyzer.FieldInfo{{Name: "Size", YAMLTag: "size", ValidationRules: rules("required")}},
+			},
+		},
+		YAMLSites: map[string]string{"testDatabaseConfig.Host": "host"},
+	}
+}
+
+func TestGeneratedStrategy_NestedErrorsAccumulate(t *testing.T) {
+	strategy := NewGeneratedStrategy(testAnalysis())
+	databaseValidator := &testDatabaseValidator{}
+	strategy.RegisterValidator("testDatabaseConfig", databaseValidator)
+
+	err := strategy.Validate(context.Background(), &testAppConfig{Cache: &testCacheConfig{}})
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	if databaseValidator.calls != 1 {
+		t.Errorf("expected the nested struct to use its registered validator once, got %d calls", databaseValidator.calls)
+	}
+
+	expected := map[string]string{
+		"name":          "analysis",
+		"database.host": "generated",
+		"cache.size":    "analysis",
+	}
+	errs := strategy.GetValidationErrors()
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %+v", len(expected), errs)
+	}
+	for _, enhancedErr := range errs {
+		if source, ok := expected[enhancedErr.YAMLPath]; !ok || source != enhancedErr.ConfigSource {
+			t.Errorf("unexpected error at %s from %s", enhancedErr.YAMLPath, enhancedErr.ConfigSource)
+		}
+	}
+	if valErrs, ok := err.(validation.ValidationErrors); !ok || len(valErrs) != len(expected) {
+		t.Errorf("expected the returned error to hold all %d errors, got %v", len(expected), err)
+	}
+
+	// A new run starts from a clean slate
+	if err := strategy.Validate(context.Background(), &testAppConfig{Name: "app", Database: testDatabaseConfig{Host: "db"}, Cache: &testCacheConfig{Size: 1}}); err != nil {
+		t.Errorf("expected no errors, got %v", err)
+	}
+	if errs := strategy.GetValidationErrors(); len(errs) != 0 {
+		t.Errorf("expected errors of the previous run to be cleared, got %+v", errs)
+	}
+}
+
+func TestGeneratedStrategy_NestedFailFast(t *testing.T) {
+	strategy := NewGeneratedStrategy(testAnalysis())
+	strategy.RegisterValidator("testDatabaseConfig", &testDatabaseValidator{})
+	strategy.SetFailFast(true)
+
+	if err := strategy.Validate(context.Background(), &testAppConfig{Name: "app", Cache: &testCacheConfig{}}); err == nil {
+		t.Fatal("expected validation errors")
+	}
+	errs := strategy.GetValidationErrors()
+	if len(errs) != 1 || errs[0].YAMLPath != "database.host" {
+		t.Errorf("expected to stop at database.host, got %+v", errs)
+	}
+}
@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+// SourceFormat is the format of a config file, which decides the key names
+// and the syntax of the paths reported in errors
+type SourceFormat string
+
+const (
+	SourceYAML SourceFormat = "yaml" // server.hosts[0].name
+	SourceTOML SourceFormat = "toml" // server.hosts[0].name, quoting keys that are not bare
+	SourceJSON SourceFormat = "json" // JSONPath: $.server.hosts[0].name
+)
+
+// PathSegment is one step of a SourcePath: a key, or the index or map key
+// of a collection element
+type PathSegment struct {
+	Key     string
+	Element bool
+}
+
+// SourcePath locates a value in a config file independently of its format
+type SourcePath []PathSegment
+
+// ParseSourcePath parses a dotted path with bracketed elements, such as
+// server.hosts[0].name or a YAMLPath
+func ParseSourcePath(path string) SourcePath {
+	var p SourcePath
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			// Element keys may hold dots, e.g. map keys
+			element, rest, _ := strings.Cut(path[1:], "]")
+			p = append(p, PathSegment{Key: element, Element: true})
+			path = rest
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			p = append(p, PathSegment{Key: path[:end]})
+			path = path[end:]
+		}
+	}
+	return p
+}
+
+// Key returns the path extended by a key
+func (p SourcePath) Key(key string) SourcePath {
+	return append(p[:len(p):len(p)], PathSegment{Key: key})
+}
+
+// Element returns the path extended by an element index or map key
+func (p SourcePath) Element(key string) SourcePath {
+	return append(p[:len(p):len(p)], PathSegment{Key: key, Element: true})
+}
+
+// String renders the path in YAML syntax
+func (p SourcePath) String() string {
+	return p.Render(SourceYAML)
+}
+
+// Render renders the path in the syntax of a config format. Elements with
+// numeric keys, or the [*] placeholder, are array indexes; other elements
+// are map keys, which TOML and JSON address like any other key.
+func (p SourcePath) Render(format SourceFormat) string {
+	var b strings.Builder
+	if format == SourceJSON {
+		b.WriteByte('$')
+	}
+	for _, segment := range p {
+		if segment.Element && (format == SourceYAML || isIndex(segment.Key)) {
+			b.WriteString("[" + segment.Key + "]")
+			continue
+		}
+		switch format {
+		case SourceJSON:
+			if isBareKey(segment.Key) && !strings.Contains(segment.Key, "-") {
+				b.WriteString("." + segment.Key)
+			} else {
+				b.WriteString("['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(segment.Key) + "']")
+			}
+		case SourceTOML:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			if isBareKey(segment.Key) {
+				b.WriteString(segment.Key)
+			} else {
+				b.WriteString(strconv.Quote(segment.Key))
+			}
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(segment.Key)
+		}
+	}
+	return b.String()
+}
+
+// fieldKey returns the key of a field in a config format, and false for
+// inline fields, which share the keys of their parent, and fields the
+// format does not load
+func fieldKey(field *analyzer.FieldInfo, format SourceFormat) (string, bool) {
+	if field.IsInline {
+		return "", false
+	}
+	var key string
+	switch format {
+	case SourceTOML:
+		key = field.TOMLTag
+	case SourceJSON:
+		key = field.JSONTag
+	default:
+		key = field.YAMLTag
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+	}
+	if key == "-" {
+		return "", false
+	}
+	if key == "" {
+		key = field.Name
+	}
+	return key, true
+}
+
+// isIndex reports whether an element key is an array index
+func isIndex(key string) bool {
+	if key == analyzer.YAMLIndexPlaceholder[1:2] {
+		return true
+	}
+	_, err := strconv.Atoi(key)
+	return err == nil
+}
+
+// isBareKey reports whether a key needs no quoting: letters, digits,
+// underscores and dashes
+func isBareKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+func TestSourcePath_Render(t *testing.T) {
+	tests := []struct {
+		path string
+		yaml string
+		toml string
+		json string
+	}{
+		{"name", "name", "name", "$.name"},
+		{"server.hosts[0].name", "server.hosts[0].name", "server.hosts[0].name", "$.server.hosts[0].name"},
+		{"servers[*].port", "servers[*].port", "servers[*].port", "$.servers[*].port"},
+		{"limits[eu-west].max", "limits[eu-west].max", "limits.eu-west.max", "$.limits['eu-west'].max"},
+		{"labels[a.b c]", "labels[a.b c]", `labels."a.b c"`, "$.labels['a.b c']"},
+		{"matrix[1][2]", "matrix[1][2]", "matrix[1][2]", "$.matrix[1][2]"},
+	}
+
+	for _, tt := range tests {
+		path := ParseSourcePath(tt.path)
+		if got := path.Render(SourceYAML); got != tt.yaml {
+			t.Errorf("YAML path of %s: expected %s, got %s", tt.path, tt.yaml, got)
+		}
+		if got := path.Render(SourceTOML); got != tt.toml {
+			t.Errorf("TOML path of %s: expected %s, got %s", tt.path, tt.toml, got)
+		}
+		if got := path.Render(SourceJSON); got != tt.json {
+			t.Errorf("JSON path of %s: expected %s, got %s", tt.path, tt.json, got)
+		}
+	}
+
+	if got := (SourcePath{{Key: "it's"}}).Render(SourceJSON); got != `$['it\'s']` {
+		t.Errorf("expected the quote to be escaped, got %s", got)
+	}
+	if got := ParseSourcePath(""); len(got) != 0 {
+		t.Errorf("expected an empty path, got %+v", got)
+	}
+}
+
+type testServiceConfig struct {
+	Name    string
+	Servers []testServerConfig `validate:"dive"`
+}
+
+type testServerConfig struct {
+	Host string `validate:"required"`
+}
+
+func testSourceAnalysis() *analyzer.AnalysisResult {
+	return &analyzer.AnalysisResult{
+		Structs: map[string]*analyzer.StructInfo{
+			"testServiceConfig": {
+				Name: "testServiceConfig",
+				Fields: []analyzer.FieldInfo{
+					{Name: "Name", YAMLTag: "name", TOMLTag: "service-name", JSONTag: "serviceName",
+						ValidationRules: []analyzer.ValidationRule{{Name: "required"}}},
+					{Name: "Servers", YAMLTag: "servers", TOMLTag: "servers", JSONTag: "servers",
+						GoType: analyzer.GoType{IsSlice: true, ElemType: &analyzer.GoType{Kind: analyzer.TypeStruct, Name: "testServerConfig"}}},
+				},
+			},
+			"testServerConfig": {
+				Name: "testServerConfig",
+				Fields: []analyzer.FieldInfo{
+					{Name: "Host", YAMLTag: "host", TOMLTag: "host", JSONTag: "host-name"},
+				},
+			},
+		},
+		YAMLSites: map[string]string{"testServiceConfig.Servers[*].Host": "servers[*].host"},
+	}
+}
+
+func TestGeneratedStrategy_SourceFormats(t *testing.T) {
+	config := &testServiceConfig{Servers: []testServerConfig{{Host: "a"}, {}}}
+
+	tests := []struct {
+		format  SourceFormat
+		name    string
+		host    string
+		nameKey string
+	}{
+		{SourceYAML, "name", "servers[1].host", "name"},
+		{SourceTOML, "service-name", "servers[1].host", "service-name"},
+		{SourceJSON, "$.serviceName", "$.servers[1]['host-name']", "serviceName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			// Analysis validates Name; reflection reports the server hosts
+			strategy := NewGeneratedStrategy(testSourceAnalysis())
+			strategy.SetSourceFormat(tt.format)
+			if err := strategy.Validate(context.Background(), config); err == nil {
+				t.Fatal("expected validation errors")
+			}
+
+			paths := map[string]string{}
+			for _, enhancedErr := range strategy.GetValidationErrors() {
+				paths[enhancedErr.YAMLPath] = enhancedErr.Path
+				if got := enhancedErr.SourcePath.Render(tt.format); got != enhancedErr.Path {
+					t.Errorf("expected the source path to render as %s, got %s", enhancedErr.Path, got)
+				}
+			}
+			if paths["name"] != tt.name {
+				t.Errorf("expected the name error at %s, got %s", tt.name, paths["name"])
+			}
+
+			reflection := NewGeneratedStrategy(testSourceAnalysis())
+			reflection.SetFallbacks(false, true)
+			reflection.SetSourceFormat(tt.format)
+			if err := reflection.Validate(context.Background(), config); err == nil {
+				t.Fatal("expected validation errors")
+			}
+			errs := reflection.GetValidationErrors()
+			if len(errs) != 1 || errs[0].YAMLPath != "servers[1].host" || errs[0].Path != tt.host {
+				t.Errorf("expected the host error at %s, got %+v", tt.host, errs)
+			}
+		})
+	}
+}
+
+func TestGeneratedStrategy_SourceFormatBasePath(t *testing.T) {
+	strategy := NewGeneratedStrategy(testSourceAnalysis())
+	strategy.SetSourceFormat(SourceJSON)
+	if err := strategy.ValidateWithPath(context.Background(), &testServiceConfig{}, "services[0]"); err == nil {
+		t.Fatal("expected validation errors")
+	}
+
+	errs := strategy.GetValidationErrors()
+	if len(errs) != 1 || errs[0].YAMLPath != "services[0].name" || errs[0].Path != "$.services[0].serviceName" {
+		t.Errorf("expected the name error below the base path, got %+v", errs)
+	}
+}
@@ -0,0 +1,233 @@
+package integration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mateothegreat/go-validation"
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+// generateSuggestions generates helpful suggestions for validation errors,
+// drawing on the analyzed field, when known, for allowed values, defaults,
+// environment variables and the expected YAML
+func (gs *GeneratedStrategy) generateSuggestions(valErr validation.ValidationError, fieldInfo *analyzer.FieldInfo, yamlPath string) []string {
+	var suggestions []string
+	value, hasValue := errorValue(valErr)
+
+	switch valErr.Tag {
+	case "required":
+		suggestions = append(suggestions, fmt.Sprintf("Ensure the '%s' field is provided in your configuration", valErr.Field))
+		suggestions = append(suggestions, "Check that the field name in your config file matches the expected name")
+
+	case "email":
+		suggestions = append(suggestions, "Ensure the email address follows the format: user@domain.com")
+		suggestions = append(suggestions, "Check for typos in the email address")
+
+	case "url":
+		suggestions = append(suggestions, "Ensure the URL includes a scheme (http:// or https://)")
+		suggestions = append(suggestions, "Check that the URL is properly formatted")
+
+	case "min":
+		suggestions = append(suggestions, fmt.Sprintf("Ensure the value is at least %s", valErr.Param))
+		if strings.Contains(strings.ToLower(valErr.Field), "port") {
+			suggestions = append(suggestions, "Port numbers must be between 1 and 65535")
+		}
+
+	case "max":
+		suggestions = append(suggestions, fmt.Sprintf("Ensure the value is at most %s", valErr.Param))
+		if strings.Contains(strings.ToLower(valErr.Field), "port") {
+			suggestions = append(suggestions, "Port numbers must be between 1 and 65535")
+		}
+
+	case "oneof":
+		choices := oneofChoices(valErr.Param)
+		if nearest, ok := nearestChoice(value, choices); hasValue && ok {
+			suggestions = append(suggestions, fmt.Sprintf("Did you mean %q?", nearest))
+		}
+		suggestions = append(suggestions, fmt.Sprintf("Valid values are: %s", strings.Join(choices, ", ")))
+
+	default:
+		suggestions = append(suggestions, fmt.Sprintf("Check the documentation for the '%s' validation rule", valErr.Tag))
+	}
+
+	if fieldInfo != nil {
+		if fieldInfo.DefaultValue != "" {
+			suggestions = append(suggestions, fmt.Sprintf("The field defaults to %q when not set", fieldInfo.DefaultValue))
+		}
+		if fieldInfo.EnvTag != "" {
+			suggestions = append(suggestions, fmt.Sprintf("It can also be set with the %s environment variable", fieldInfo.EnvTag))
+		}
+	}
+
+	if yamlPath != "" {
+		example := exampleValue(valErr, fieldInfo, value)
+		suggestions = append(suggestions, "Expected YAML:\n"+yamlSnippet(yamlPath, example))
+	}
+
+	return suggestions
+}
+
+// errorValue renders the value of a validation error
+func errorValue(valErr validation.ValidationError) (string, bool) {
+	if valErr.Value == nil {
+		return "", false
+	}
+	return fmt.Sprint(valErr.Value), true
+}
+
+// oneofChoices splits the parameter of a oneof rule into its choices
+func oneofChoices(param string) []string {
+	return strings.FieldsFunc(param, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// nearestChoice returns the choice closest to a value by edit distance,
+// ignoring case, when it is close enough to be a likely typo
+func nearestChoice(value string, choices []string) (string, bool) {
+	value = strings.ToLower(value)
+	if value == "" {
+		return "", false
+	}
+
+	best, bestDistance := "", -1
+	for _, choice := range choices {
+		lower := strings.ToLower(choice)
+		distance := editDistance(value, lower)
+		limit := len([]rune(lower)) / 3
+		if limit < 2 {
+			limit = 2
+		}
+		// Truncated values such as "prod" count as close
+		if strings.HasPrefix(lower, value) && len(value) >= 2 {
+			distance = 0
+		} else if distance > limit {
+			continue
+		}
+		if bestDistance == -1 || distance < bestDistance {
+			best, bestDistance = choice, distance
+		}
+	}
+	return best, bestDistance != -1
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// exampleValue picks a value to show in the expected YAML: the nearest or
+// first allowed value, the default, a bound, or a placeholder of the type
+func exampleValue(valErr validation.ValidationError, fieldInfo *analyzer.FieldInfo, value string) string {
+	if valErr.Tag == "oneof" {
+		choices := oneofChoices(valErr.Param)
+		if nearest, ok := nearestChoice(value, choices); ok {
+			return yamlScalar(nearest)
+		}
+		if len(choices) > 0 {
+			return yamlScalar(choices[0])
+		}
+	}
+	if fieldInfo == nil {
+		return "<value>"
+	}
+	if fieldInfo.DefaultValue != "" {
+		return yamlScalar(fieldInfo.DefaultValue)
+	}
+	if (valErr.Tag == "min" || valErr.Tag == "max") && isNumericKind(fieldInfo.GoType.Kind) {
+		return valErr.Param
+	}
+	return "<" + fieldInfo.Type + ">"
+}
+
+// isNumericKind reports whether a type kind holds numbers
+func isNumericKind(kind analyzer.TypeKind) bool {
+	return kind >= analyzer.TypeInt && kind <= analyzer.TypeFloat64
+}
+
+// yamlScalar quotes a string when YAML would not read it back verbatim
+func yamlScalar(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// yamlSnippet renders the YAML document setting the value at a path such as
+// server.hosts[0].name, with numeric or placeholder indexes as list items
+// and other bracketed keys as map keys
+func yamlSnippet(path, example string) string {
+	var lines []string
+	indent, items := "", ""
+
+	// emit writes a line, opening any pending list items before it
+	emit := func(text string) {
+		lines = append(lines, indent+items+text)
+		indent += strings.Repeat(" ", len(items))
+		items = ""
+	}
+
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		name, keys := splitIndexes(segment)
+		lastSegment := i == len(segments)-1
+		if lastSegment && len(keys) == 0 {
+			emit(name + ": " + example)
+			break
+		}
+		emit(name + ":")
+		indent += "  "
+
+		for j, key := range keys {
+			last := lastSegment && j == len(keys)-1
+			_, err := strconv.Atoi(key)
+			isItem := err == nil || key == "*"
+			switch {
+			case isItem && last:
+				emit("- " + example)
+			case isItem:
+				items += "- "
+			case last:
+				emit(key + ": " + example)
+			default:
+				emit(key + ":")
+				indent += "  "
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitIndexes splits a path segment such as hosts[0][1] into its name and
+// bracketed indexes or keys
+func splitIndexes(segment string) (string, []string) {
+	name, rest, found := strings.Cut(segment, "[")
+	if !found {
+		return segment, nil
+	}
+	var keys []string
+	for _, part := range strings.Split(rest, "[") {
+		keys = append(keys, strings.TrimSuffix(part, "]"))
+	}
+	return name, keys
+}
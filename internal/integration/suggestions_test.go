@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mateothegreat/go-validation/internal/analyzer"
+)
+
+type testLogConfig struct {
+	Level string
+}
+
+func TestGeneratedStrategy_DataDrivenSuggestions(t *testing.T) {
+	result := &analyzer.AnalysisResult{
+		Structs: map[string]*analyzer.StructInfo{
+			"testLogConfig": {
+				Name: "testLogConfig",
+				Fields: []analyzer.FieldInfo{{
+					Name:            "Level",
+					Type:            "string",
+					GoType:          analyzer.GoType{Kind: analyzer.TypeString, Name: "string"},
+					YAMLTag:         "level",
+					EnvTag:          "LOG_LEVEL",
+					DefaultValue:    "info",
+					ValidationRules: []analyzer.ValidationRule{{Name: "oneof", Parameter: "debug info warning error"}},
+				}},
+			},
+		},
+	}
+	strategy := NewGeneratedStrategy(result)
+
+	if err := strategy.ValidateWithPath(context.Background(), &testLogConfig{Level: "warn"}, "logging"); err == nil {
+		t.Fatal("expected a oneof error")
+	}
+	errs := strategy.GetValidationErrors()
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %+v", errs)
+	}
+
+	expected := []string{
+		`Did you mean "warning"?`,
+		"Valid values are: debug, info, warning, error",
+		`The field defaults to "info" when not set`,
+		"It can also be set with the LOG_LEVEL environment variable",
+		"Expected YAML:\nlogging:\n  level: warning",
+	}
+	if strings.Join(errs[0].Suggestions, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected suggestions %q, got %q", expected, errs[0].Suggestions)
+	}
+}
+
+func TestNearestChoice(t *testing.T) {
+	choices := []string{"development", "staging", "production"}
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"prod", "production"},
+		{"stagign", "staging"},
+		{"Developmnet", "development"},
+		{"qa", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		nearest, ok := nearestChoice(tt.value, choices)
+		if nearest != tt.expected || ok != (tt.expected != "") {
+			t.Errorf("expected %q for %q, got %q", tt.expected, tt.value, nearest)
+		}
+	}
+}
+
+func TestYAMLSnippet(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"port", "port: 8080"},
+		{"server.port", "server:\n  port: 8080"},
+		{"server.hosts[0].port", "server:\n  hosts:\n    - port: 8080"},
+		{"routes[api].port", "routes:\n  api:\n    port: 8080"},
+		{"matrix[1][2].port", "matrix:\n  - - port: 8080"},
+		{"ports[3]", "ports:\n  - 8080"},
+	}
+	for _, tt := range tests {
+		if snippet := yamlSnippet(tt.path, "8080"); snippet != tt.expected {
+			t.Errorf("expected for %s:\n%s\ngot:\n%s", tt.path, tt.expected, snippet)
+		}
+	}
+}
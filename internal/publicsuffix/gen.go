@@ -0,0 +1,219 @@
+//go:build ignore
+
+// gen writes list.dat, the public suffix list snapshot embedded by the
+// package: the rules of https://publicsuffix.org/list/public_suffix_list.dat
+// without their comments, internationalized rules in their ASCII (punycode)
+// form.
+//
+//	go generate ./internal/publicsuffix
+//	go run gen.go -input /usr/share/publicsuffix/public_suffix_list.dat
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const listURL = "https://publicsuffix.org/list/public_suffix_list.dat"
+
+func main() {
+	input := flag.String("input", "", "Read the list from this file instead of "+listURL)
+	output := flag.String("output", "list.dat", "File to write the snapshot to")
+	flag.Parse()
+
+	data, source, err := read(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	snapshot, err := generate(data, source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, snapshot, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// read returns the list from a file, or downloaded when input is empty
+func read(input string) ([]byte, string, error) {
+	if input != "" {
+		data, err := os.ReadFile(input)
+		return data, input, err
+	}
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Get(listURL)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching %s: %s", listURL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	return data, listURL, err
+}
+
+// generate keeps the license notice, the section markers and the rules
+func generate(data []byte, source string) ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by gen.go from %s; DO NOT EDIT.\n", listURL)
+
+	// The license notice is the first paragraph of the list
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	license, rules := true, 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			license = false
+		case strings.HasPrefix(line, "// ===BEGIN") || strings.HasPrefix(line, "// ===END"):
+			out.WriteString(line + "\n")
+		case strings.HasPrefix(line, "//"):
+			if license {
+				out.WriteString(line + "\n")
+			}
+		default:
+			rule, _, _ := strings.Cut(line, " ")
+			ascii, err := toASCII(rule)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule, err)
+			}
+			out.WriteString(ascii + "\n")
+			rules++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if rules == 0 {
+		return nil, fmt.Errorf("no rules in %s", source)
+	}
+	return out.Bytes(), nil
+}
+
+// toASCII converts the labels of a rule holding non-ASCII characters to
+// punycode, keeping its ! or *. prefix
+func toASCII(rule string) (string, error) {
+	labels := strings.Split(strings.ToLower(rule), ".")
+	for i, label := range labels {
+		ascii := true
+		for j := 0; j < len(label); j++ {
+			ascii = ascii && label[j] < 0x80
+		}
+		if ascii {
+			continue
+		}
+		prefix := ""
+		if i == 0 && strings.HasPrefix(label, "!") {
+			prefix, label = "!", label[1:]
+		}
+		encoded, err := punycode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = prefix + "xn--" + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// Punycode parameters of RFC 3492
+const (
+	base        = 36
+	tmin        = 1
+	tmax        = 26
+	skew        = 38
+	damp        = 700
+	initialBias = 72
+	initialN    = 128
+)
+
+// punycode encodes a label as RFC 3492 describes
+func punycode(label string) (string, error) {
+	runes := []rune(label)
+	var out []byte
+	for _, r := range runes {
+		if r < 0x80 {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out)
+	if basic > 0 {
+		out = append(out, '-')
+	}
+
+	n, delta, bias := rune(initialN), 0, initialBias
+	for handled := basic; handled < len(runes); {
+		m := rune(0x10FFFF)
+		for _, r := range runes {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+		delta += int(m-n) * (handled + 1)
+		n = m
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+			q := delta
+			for k := base; ; k += base {
+				t := k - bias
+				if t < tmin {
+					t = tmin
+				} else if t > tmax {
+					t = tmax
+				}
+				if q < t {
+					break
+				}
+				out = append(out, punycodeDigit(t+(q-t)%(base-t)))
+				q = (q - t) / (base - t)
+			}
+			out = append(out, punycodeDigit(q))
+			bias = adapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	if len(out) > 59 {
+		return "", fmt.Errorf("label %q is too long once encoded", label)
+	}
+	return string(out), nil
+}
+
+// punycodeDigit returns the character of a base-36 digit
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// adapt returns the bias following a delta
+func adapt(delta, points int, first bool) int {
+	if first {
+		delta /= damp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+	k := 0
+	for delta > ((base-tmin)*tmax)/2 {
+		delta /= base - tmin
+		k += base
+	}
+	return k + (base-tmin+1)*delta/(delta+skew)
+}
@@ -0,0 +1,123 @@
+// Package publicsuffix looks up domains in an embedded snapshot of the
+// public suffix list (https://publicsuffix.org), the suffixes under which
+// anyone can register names, such as com, co.uk or github.io.
+//
+// The snapshot holds internationalized rules in their ASCII (punycode) form,
+// so domains are matched as they appear in DNS. Refresh it with go generate.
+package publicsuffix
+
+//go:generate go run gen.go
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"strings"
+	"sync"
+)
+
+//go:embed list.dat
+var listData string
+
+// list holds the rules of the snapshot, each with whether it belongs to the
+// ICANN section rather than the private one
+type list struct {
+	rules      map[string]bool // com, co.uk
+	wildcards  map[string]bool // ck for *.ck
+	exceptions map[string]bool // www.ck for !www.ck
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *list
+)
+
+// load parses the snapshot on first use
+func load() *list {
+	loadOnce.Do(func() {
+		l := &list{rules: map[string]bool{}, wildcards: map[string]bool{}, exceptions: map[string]bool{}}
+		icann := false
+		scanner := bufio.NewScanner(strings.NewReader(listData))
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "// ===BEGIN ICANN DOMAINS===":
+				icann = true
+			case line == "// ===END ICANN DOMAINS===":
+				icann = false
+			case line == "" || strings.HasPrefix(line, "//"):
+			case strings.HasPrefix(line, "!"):
+				l.exceptions[line[1:]] = icann
+			case strings.HasPrefix(line, "*."):
+				l.wildcards[line[2:]] = icann
+			default:
+				l.rules[line] = icann
+			}
+		}
+		loaded = l
+	})
+	return loaded
+}
+
+// normalize lowercases a domain and drops the trailing dot of an FQDN
+func normalize(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
+
+// PublicSuffix returns the public suffix of domain, e.g. "co.uk" for
+// "www.example.co.uk". listed is false when no rule matched and the suffix is
+// the last label, as the list's default rule gives; icann reports a suffix
+// from the ICANN section rather than a private one such as github.io.
+func PublicSuffix(domain string) (suffix string, icann, listed bool) {
+	l := load()
+	domain = normalize(domain)
+	for candidate := domain; ; {
+		if icann, ok := l.exceptions[candidate]; ok {
+			_, parent, _ := strings.Cut(candidate, ".")
+			return parent, icann, true
+		}
+		if icann, ok := l.rules[candidate]; ok {
+			return candidate, icann, true
+		}
+		_, parent, more := strings.Cut(candidate, ".")
+		if !more {
+			break
+		}
+		if icann, ok := l.wildcards[parent]; ok {
+			return candidate, icann, true
+		}
+		candidate = parent
+	}
+	return domain[strings.LastIndexByte(domain, '.')+1:], false, false
+}
+
+// ErrNotRegistrable is returned by Registrable for domains that are public
+// suffixes themselves or end in no listed suffix
+var ErrNotRegistrable = errors.New("not under a listed public suffix")
+
+// Registrable returns the registrable domain of domain, its public suffix
+// and one more label, e.g. "example.co.uk" for "www.example.co.uk"
+func Registrable(domain string) (string, error) {
+	domain = normalize(domain)
+	suffix, _, listed := PublicSuffix(domain)
+	if !listed || len(domain) <= len(suffix) || domain[len(domain)-len(suffix)-1] != '.' {
+		return "", ErrNotRegistrable
+	}
+	rest := domain[:len(domain)-len(suffix)-1]
+	return rest[strings.LastIndexByte(rest, '.')+1:] + "." + suffix, nil
+}
+
+// IsTLD reports whether label is a top-level domain of the ICANN section,
+// such as com or uk
+func IsTLD(label string) bool {
+	l := load()
+	label = strings.ToLower(label)
+	if label == "" || strings.Contains(label, ".") {
+		return false
+	}
+	if icann, ok := l.rules[label]; ok && icann {
+		return true
+	}
+	icann, ok := l.wildcards[label]
+	return ok && icann
+}
@@ -0,0 +1,55 @@
+package publicsuffix
+
+import "testing"
+
+func TestPublicSuffix(t *testing.T) {
+	tests := []struct {
+		domain, suffix string
+		icann, listed  bool
+	}{
+		{"example.com", "com", true, true},
+		{"www.example.co.uk", "co.uk", true, true},
+		{"WWW.Example.CO.UK.", "co.uk", true, true},
+		{"foo.github.io", "github.io", false, true},
+		{"a.b.ck", "b.ck", true, true},
+		{"www.ck", "ck", true, true},
+		{"a.www.ck", "ck", true, true},
+		{"xn--fiqs8s.xn--55qx5d.cn", "xn--55qx5d.cn", true, true},
+		{"example.notatld", "notatld", false, false},
+	}
+	for _, tt := range tests {
+		suffix, icann, listed := PublicSuffix(tt.domain)
+		if suffix != tt.suffix || icann != tt.icann || listed != tt.listed {
+			t.Errorf("PublicSuffix(%q) = %q, %v, %v, want %q, %v, %v", tt.domain, suffix, icann, listed, tt.suffix, tt.icann, tt.listed)
+		}
+	}
+}
+
+func TestRegistrable(t *testing.T) {
+	tests := map[string]string{
+		"example.com":           "example.com",
+		"www.example.co.uk":     "example.co.uk",
+		"a.b.example.github.io": "example.github.io",
+		"a.www.ck":              "www.ck",
+		"co.uk":                 "",
+		"com":                   "",
+		"example.notatld":       "",
+	}
+	for domain, expected := range tests {
+		registrable, err := Registrable(domain)
+		if registrable != expected || (err == nil) != (expected != "") {
+			t.Errorf("Registrable(%q) = %q, %v, want %q", domain, registrable, err, expected)
+		}
+	}
+}
+
+func TestIsTLD(t *testing.T) {
+	for label, expected := range map[string]bool{
+		"com": true, "UK": true, "bd": true, "xn--p1ai": true,
+		"co.uk": false, "notatld": false, "": false,
+	} {
+		if IsTLD(label) != expected {
+			t.Errorf("IsTLD(%q) = %v, want %v", label, !expected, expected)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+//go:build ignore
+
+// gen writes zones.txt, the index of IANA time zone names embedded by the
+// package, from the zoneinfo.zip shipped with the Go toolchain.
+//
+//	go generate ./internal/timezones
+//	go run gen.go -input /path/to/zoneinfo.zip
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+func main() {
+	input := flag.String("input", filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"), "zoneinfo.zip to index")
+	output := flag.String("output", "zones.txt", "File to write the index to")
+	flag.Parse()
+
+	archive, err := zip.OpenReader(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer archive.Close()
+
+	var names []string
+	for _, file := range archive.File {
+		if !file.FileInfo().IsDir() {
+			names = append(names, file.Name)
+		}
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# IANA time zone names indexed from the zoneinfo.zip of %s.\n", runtime.Version())
+	fmt.Fprintf(&b, "# Regenerate with go generate.\n")
+	for _, name := range names {
+		fmt.Fprintln(&b, name)
+	}
+	if err := os.WriteFile(*output, b.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,41 @@
+// Package timezones holds an embedded index of IANA time zone names, such as
+// Europe/Berlin, so names can be validated on systems without tzdata.
+// Refresh it with go generate.
+package timezones
+
+//go:generate go run gen.go
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed zones.txt
+var indexData string
+
+var (
+	loadOnce sync.Once
+	zones    map[string]bool
+)
+
+// load parses the index on first use
+func load() map[string]bool {
+	loadOnce.Do(func() {
+		zones = make(map[string]bool)
+		scanner := bufio.NewScanner(strings.NewReader(indexData))
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" && !strings.HasPrefix(line, "#") {
+				zones[line] = true
+			}
+		}
+	})
+	return zones
+}
+
+// Contains reports whether name is an indexed time zone. Names are case
+// sensitive, as they are for time.LoadLocation.
+func Contains(name string) bool {
+	return load()[name]
+}
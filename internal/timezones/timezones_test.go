@@ -0,0 +1,19 @@
+package timezones
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"Europe/Berlin":     true,
+		"America/New_York":  true,
+		"UTC":               true,
+		"europe/berlin":     false,
+		"Local":             false,
+		"Mars/Olympus_Mons": false,
+		"":                  false,
+	} {
+		if Contains(name) != expected {
+			t.Errorf("Contains(%q) = %v, want %v", name, !expected, expected)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnknownJSONSchema is returned when validating against a schema name that
+// was never registered
+var ErrUnknownJSONSchema = errors.New("unknown JSON schema")
+
+// jsonSchema is a compiled JSON Schema document. Only the keywords needed to
+// constrain passthrough config blobs are supported: type, enum, const,
+// properties, required, additionalProperties, items, minItems, maxItems,
+// minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum and
+// exclusiveMaximum.
+type jsonSchema struct {
+	types            []string
+	enum             []interface{}
+	constant         interface{}
+	hasConst         bool
+	properties       map[string]*jsonSchema
+	required         []string
+	additional       *jsonSchema
+	noAdditional     bool
+	items            *jsonSchema
+	minItems         *int
+	maxItems         *int
+	minLength        *int
+	maxLength        *int
+	pattern          *regexp.Regexp
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+}
+
+// unsupportedSchemaKeywords are rejected at registration rather than
+// silently ignored, so a schema never validates less than it appears to
+var unsupportedSchemaKeywords = []string{
+	"$ref", "allOf", "anyOf", "oneOf", "not", "if", "then", "else",
+	"patternProperties", "dependentRequired", "dependentSchemas",
+	"prefixItems", "contains", "uniqueItems",
+}
+
+// RegisterJSONSchema compiles a JSON Schema document and registers it under
+// name for use by the jsonschema rule (e.g. `validate:"jsonschema=plugin"`)
+func (v *Validator) RegisterJSONSchema(name string, schema []byte) error {
+	if name == "" {
+		return fmt.Errorf("JSON schema name cannot be empty")
+	}
+
+	compiled, err := compileJSONSchema(schema, "#")
+	if err != nil {
+		return fmt.Errorf("invalid JSON schema %q: %w", name, err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	schemas := make(map[string]*jsonSchema, len(v.schemas)+1)
+	for schemaName, s := range v.schemas {
+		schemas[schemaName] = s
+	}
+	schemas[name] = compiled
+
+	v.schemas = schemas
+	v.publish()
+	return nil
+}
+
+// RegisterJSONSchema registers a JSON Schema document on the default validator
+func RegisterJSONSchema(name string, schema []byte) error {
+	return defaultValidator.RegisterJSONSchema(name, schema)
+}
+
+// ValidateJSONSchema validates a JSON document against a registered schema and
+// describes the first violation found
+func (v *Validator) ValidateJSONSchema(name string, data []byte) error {
+	schema, ok := v.current().schemas[name]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownJSONSchema, name)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return schema.validate(doc, "$")
+}
+
+// isJSONSchema validates a string, []byte or json.RawMessage against the
+// schema registered under the rule's param
+func isJSONSchema(fl FieldLevel) bool {
+	impl, ok := fl.(*fieldLevel)
+	if !ok || impl.validator == nil {
+		return false
+	}
+
+	var data []byte
+	field := fl.Field()
+	switch {
+	case field.Kind() == reflect.String:
+		data = []byte(field.String())
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		data = field.Bytes()
+	default:
+		return false
+	}
+
+	return impl.validator.ValidateJSONSchema(fl.Param(), data) == nil
+}
+
+// compileJSONSchema parses a schema object, compiling nested schemas
+func compileJSONSchema(raw []byte, path string) (*jsonSchema, error) {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keywords); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, keyword := range unsupportedSchemaKeywords {
+		if _, ok := keywords[keyword]; ok {
+			return nil, fmt.Errorf("%s: unsupported keyword %q", path, keyword)
+		}
+	}
+
+	s := &jsonSchema{}
+	var err error
+	decode := func(keyword string, target interface{}) {
+		if value, ok := keywords[keyword]; ok && err == nil {
+			if decodeErr := json.Unmarshal(value, target); decodeErr != nil {
+				err = fmt.Errorf("%s/%s: %w", path, keyword, decodeErr)
+			}
+		}
+	}
+
+	if value, ok := keywords["type"]; ok {
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+			decode("type", &s.types)
+		} else {
+			var single string
+			decode("type", &single)
+			s.types = []string{single}
+		}
+	}
+	decode("enum", &s.enum)
+	decode("required", &s.required)
+	decode("minItems", &s.minItems)
+	decode("maxItems", &s.maxItems)
+	decode("minLength", &s.minLength)
+	decode("maxLength", &s.maxLength)
+	decode("minimum", &s.minimum)
+	decode("maximum", &s.maximum)
+	decode("exclusiveMinimum", &s.exclusiveMinimum)
+	decode("exclusiveMaximum", &s.exclusiveMaximum)
+	if _, ok := keywords["const"]; ok {
+		s.hasConst = true
+		decode("const", &s.constant)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := keywords["pattern"]; ok {
+		var pattern string
+		if err := json.Unmarshal(value, &pattern); err != nil {
+			return nil, fmt.Errorf("%s/pattern: %w", path, err)
+		}
+		if s.pattern, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%s/pattern: %w", path, err)
+		}
+	}
+
+	if value, ok := keywords["properties"]; ok {
+		var properties map[string]json.RawMessage
+		if err := json.Unmarshal(value, &properties); err != nil {
+			return nil, fmt.Errorf("%s/properties: %w", path, err)
+		}
+		s.properties = make(map[string]*jsonSchema, len(properties))
+		for name, property := range properties {
+			if s.properties[name], err = compileJSONSchema(property, path+"/properties/"+name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if value, ok := keywords["additionalProperties"]; ok {
+		var allowed bool
+		if json.Unmarshal(value, &allowed) == nil {
+			s.noAdditional = !allowed
+		} else if s.additional, err = compileJSONSchema(value, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, ok := keywords["items"]; ok {
+		if s.items, err = compileJSONSchema(value, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// validate checks a decoded JSON value against the schema, returning the
+// first violation with its JSON path
+func (s *jsonSchema) validate(value interface{}, path string) error {
+	if len(s.types) > 0 && !s.matchesType(value) {
+		return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(s.types, " or "), jsonTypeOf(value))
+	}
+	if s.hasConst && !reflect.DeepEqual(value, s.constant) {
+		return fmt.Errorf("%s: must equal %v", path, s.constant)
+	}
+	if len(s.enum) > 0 && !containsJSONValue(s.enum, value) {
+		return fmt.Errorf("%s: must be one of %v", path, s.enum)
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			return fmt.Errorf("%s: must be at least %d characters", path, *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			return fmt.Errorf("%s: must be at most %d characters", path, *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			return fmt.Errorf("%s: must match pattern %s", path, s.pattern)
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			return fmt.Errorf("%s: must be at least %v", path, *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			return fmt.Errorf("%s: must be at most %v", path, *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			return fmt.Errorf("%s: must be greater than %v", path, *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			return fmt.Errorf("%s: must be less than %v", path, *s.exclusiveMaximum)
+		}
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			return fmt.Errorf("%s: must contain at least %d items", path, *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			return fmt.Errorf("%s: must contain at most %d items", path, *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				if err := s.items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			property, ok := s.properties[name]
+			switch {
+			case ok:
+			case s.noAdditional:
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			case s.additional != nil:
+				property = s.additional
+			default:
+				continue
+			}
+			if err := property.validate(v[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchesType reports whether value has one of the schema's types
+func (s *jsonSchema) matchesType(value interface{}) bool {
+	actual := jsonTypeOf(value)
+	for _, typ := range s.types {
+		if typ == actual {
+			return true
+		}
+		if typ == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeOf returns the JSON Schema type name of a decoded value
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func containsJSONValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+package validation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The Kubernetes rules follow the apimachinery validation of object names,
+// label keys and values, resource quantities and label selectors, without
+// depending on it, for configs that are turned into manifests later.
+
+var (
+	// dns1123Label matches a lowercase RFC 1123 label such as my-app
+	dns1123Label = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+	// labelName matches the name part of a label key and a label value
+	labelName = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+	// k8sQuantity matches a resource.Quantity: a signed decimal followed by a
+	// binary suffix (Ki), a decimal suffix (m, k) or a decimal exponent (e3)
+	k8sQuantity = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E|[eE][+-]?[0-9]+)?$`)
+
+	// setRequirement matches "key in (a, b)" and "key notin (a, b)"
+	setRequirement = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// validK8sSubdomain reports whether name is a DNS-1123 subdomain: lowercase
+// labels joined by dots, at most 253 characters
+func validK8sSubdomain(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !dns1123Label.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// validK8sLabel reports whether name is a DNS-1123 label of at most 63
+// characters
+func validK8sLabel(name string) bool {
+	return len(name) <= 63 && dns1123Label.MatchString(name)
+}
+
+// validK8sLabelKey reports whether key is a label or annotation key: a name
+// of at most 63 characters, optionally prefixed by a DNS subdomain and a
+// slash, such as app.kubernetes.io/name
+func validK8sLabelKey(key string) bool {
+	prefix, name, found := strings.Cut(key, "/")
+	if !found {
+		name = prefix
+	} else if !validK8sSubdomain(prefix) {
+		return false
+	}
+	return len(name) <= 63 && labelName.MatchString(name)
+}
+
+// validK8sLabelValue reports whether value is a label value: empty, or at
+// most 63 alphanumerics, '-', '_' and '.' starting and ending alphanumeric
+func validK8sLabelValue(value string) bool {
+	return value == "" || len(value) <= 63 && labelName.MatchString(value)
+}
+
+// validK8sQuantity reports whether value is a resource quantity such as
+// 500m, 1.5Gi or 2e3
+func validK8sQuantity(value string) bool {
+	return k8sQuantity.MatchString(value)
+}
+
+// validK8sSelector reports whether value is a label selector such as
+// "app=web,tier!=cache,env in (prod, staging),!canary". The empty selector
+// matches everything and is valid.
+func validK8sSelector(value string) bool {
+	if strings.TrimSpace(value) == "" {
+		return true
+	}
+	for _, requirement := range splitSelector(value) {
+		if !validSelectorRequirement(strings.TrimSpace(requirement)) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitSelector splits a selector at the commas outside set parentheses
+func splitSelector(value string) []string {
+	var requirements []string
+	depth, start := 0, 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(requirements, value[start:])
+}
+
+// validSelectorRequirement validates one requirement of a selector
+func validSelectorRequirement(requirement string) bool {
+	if match := setRequirement.FindStringSubmatch(requirement); match != nil {
+		if !validK8sLabelKey(match[1]) || strings.TrimSpace(match[3]) == "" {
+			return false
+		}
+		for _, value := range strings.Split(match[3], ",") {
+			if !validK8sLabelValue(strings.TrimSpace(value)) {
+				return false
+			}
+		}
+		return true
+	}
+	if key, found := strings.CutPrefix(requirement, "!"); found {
+		return validK8sLabelKey(strings.TrimSpace(key))
+	}
+	for _, op := range []string{"!=", "==", "=", ">", "<"} {
+		key, value, found := strings.Cut(requirement, op)
+		if !found {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if op == ">" || op == "<" {
+			_, err := strconv.ParseInt(value, 10, 64)
+			return validK8sLabelKey(key) && err == nil
+		}
+		return validK8sLabelKey(key) && validK8sLabelValue(value)
+	}
+	return validK8sLabelKey(requirement)
+}
+
+// isK8sName validates an object name: a DNS-1123 subdomain, or a DNS-1123
+// label when the parameter is "label", as for Services and Namespaces
+func isK8sName(fl FieldLevel) bool {
+	name := getString(fl.Field())
+	if fl.Param() == "label" {
+		return validK8sLabel(name)
+	}
+	return validK8sSubdomain(name)
+}
+
+// isK8sLabelKey validates a label or annotation key
+func isK8sLabelKey(fl FieldLevel) bool {
+	return validK8sLabelKey(getString(fl.Field()))
+}
+
+// isK8sLabelValue validates a label value
+func isK8sLabelValue(fl FieldLevel) bool {
+	return validK8sLabelValue(getString(fl.Field()))
+}
+
+// isK8sQuantity validates a resource quantity
+func isK8sQuantity(fl FieldLevel) bool {
+	return validK8sQuantity(getString(fl.Field()))
+}
+
+// isK8sSelector validates a label selector
+func isK8sSelector(fl FieldLevel) bool {
+	return validK8sSelector(getString(fl.Field()))
+}
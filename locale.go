@@ -0,0 +1,201 @@
+package validation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mateothegreat/go-validation/internal/timezones"
+)
+
+// The i18n rules check settings such as a user's time zone, locale and
+// currency: timezone accepts IANA names, locale BCP 47 language tags, and
+// currency_matches_locale a currency used in the country of a locale.
+
+// regionCurrencies lists the ISO 4217 currencies in use in each ISO 3166
+// country, the first being the country's own
+var regionCurrencies = map[string][]string{
+	"AD": {"EUR"}, "AE": {"AED"}, "AF": {"AFN"}, "AG": {"XCD"}, "AI": {"XCD"},
+	"AL": {"ALL"}, "AM": {"AMD"}, "AO": {"AOA"}, "AR": {"ARS"}, "AS": {"USD"},
+	"AT": {"EUR"}, "AU": {"AUD"}, "AW": {"AWG"}, "AX": {"EUR"}, "AZ": {"AZN"},
+	"BA": {"BAM"}, "BB": {"BBD"}, "BD": {"BDT"}, "BE": {"EUR"}, "BF": {"XOF"},
+	"BG": {"EUR"}, "BH": {"BHD"}, "BI": {"BIF"}, "BJ": {"XOF"}, "BL": {"EUR"},
+	"BM": {"BMD"}, "BN": {"BND"}, "BO": {"BOB"}, "BQ": {"USD"}, "BR": {"BRL"},
+	"BS": {"BSD"}, "BT": {"BTN", "INR"}, "BV": {"NOK"}, "BW": {"BWP"}, "BY": {"BYN"},
+	"BZ": {"BZD"}, "CA": {"CAD"}, "CC": {"AUD"}, "CD": {"CDF"}, "CF": {"XAF"},
+	"CG": {"XAF"}, "CH": {"CHF"}, "CI": {"XOF"}, "CK": {"NZD"}, "CL": {"CLP"},
+	"CM": {"XAF"}, "CN": {"CNY"}, "CO": {"COP"}, "CR": {"CRC"}, "CU": {"CUP"},
+	"CV": {"CVE"}, "CW": {"XCG"}, "CX": {"AUD"}, "CY": {"EUR"}, "CZ": {"CZK"},
+	"DE": {"EUR"}, "DJ": {"DJF"}, "DK": {"DKK"}, "DM": {"XCD"}, "DO": {"DOP"},
+	"DZ": {"DZD"}, "EC": {"USD"}, "EE": {"EUR"}, "EG": {"EGP"}, "EH": {"MAD"},
+	"ER": {"ERN"}, "ES": {"EUR"}, "ET": {"ETB"}, "FI": {"EUR"}, "FJ": {"FJD"},
+	"FK": {"FKP"}, "FM": {"USD"}, "FO": {"DKK"}, "FR": {"EUR"}, "GA": {"XAF"},
+	"GB": {"GBP"}, "GD": {"XCD"}, "GE": {"GEL"}, "GF": {"EUR"}, "GG": {"GBP"},
+	"GH": {"GHS"}, "GI": {"GIP"}, "GL": {"DKK"}, "GM": {"GMD"}, "GN": {"GNF"},
+	"GP": {"EUR"}, "GQ": {"XAF"}, "GR": {"EUR"}, "GS": {"GBP"}, "GT": {"GTQ"},
+	"GU": {"USD"}, "GW": {"XOF"}, "GY": {"GYD"}, "HK": {"HKD"}, "HM": {"AUD"},
+	"HN": {"HNL"}, "HR": {"EUR"}, "HT": {"HTG"}, "HU": {"HUF"}, "ID": {"IDR"},
+	"IE": {"EUR"}, "IL": {"ILS"}, "IM": {"GBP"}, "IN": {"INR"}, "IO": {"USD"},
+	"IQ": {"IQD"}, "IR": {"IRR"}, "IS": {"ISK"}, "IT": {"EUR"}, "JE": {"GBP"},
+	"JM": {"JMD"}, "JO": {"JOD"}, "JP": {"JPY"}, "KE": {"KES"}, "KG": {"KGS"},
+	"KH": {"KHR", "USD"}, "KI": {"AUD"}, "KM": {"KMF"}, "KN": {"XCD"}, "KP": {"KPW"},
+	"KR": {"KRW"}, "KW": {"KWD"}, "KY": {"KYD"}, "KZ": {"KZT"}, "LA": {"LAK"},
+	"LB": {"LBP"}, "LC": {"XCD"}, "LI": {"CHF"}, "LK": {"LKR"}, "LR": {"LRD", "USD"},
+	"LS": {"LSL", "ZAR"}, "LT": {"EUR"}, "LU": {"EUR"}, "LV": {"EUR"}, "LY": {"LYD"},
+	"MA": {"MAD"}, "MC": {"EUR"}, "MD": {"MDL"}, "ME": {"EUR"}, "MF": {"EUR"},
+	"MG": {"MGA"}, "MH": {"USD"}, "MK": {"MKD"}, "ML": {"XOF"}, "MM": {"MMK"},
+	"MN": {"MNT"}, "MO": {"MOP"}, "MP": {"USD"}, "MQ": {"EUR"}, "MR": {"MRU"},
+	"MS": {"XCD"}, "MT": {"EUR"}, "MU": {"MUR"}, "MV": {"MVR"}, "MW": {"MWK"},
+	"MX": {"MXN"}, "MY": {"MYR"}, "MZ": {"MZN"}, "NA": {"NAD", "ZAR"}, "NC": {"XPF"},
+	"NE": {"XOF"}, "NF": {"AUD"}, "NG": {"NGN"}, "NI": {"NIO"}, "NL": {"EUR"},
+	"NO": {"NOK"}, "NP": {"NPR"}, "NR": {"AUD"}, "NU": {"NZD"}, "NZ": {"NZD"},
+	"OM": {"OMR"}, "PA": {"PAB", "USD"}, "PE": {"PEN"}, "PF": {"XPF"}, "PG": {"PGK"},
+	"PH": {"PHP"}, "PK": {"PKR"}, "PL": {"PLN"}, "PM": {"EUR"}, "PN": {"NZD"},
+	"PR": {"USD"}, "PS": {"ILS", "JOD"}, "PT": {"EUR"}, "PW": {"USD"}, "PY": {"PYG"},
+	"QA": {"QAR"}, "RE": {"EUR"}, "RO": {"RON"}, "RS": {"RSD"}, "RU": {"RUB"},
+	"RW": {"RWF"}, "SA": {"SAR"}, "SB": {"SBD"}, "SC": {"SCR"}, "SD": {"SDG"},
+	"SE": {"SEK"}, "SG": {"SGD"}, "SH": {"SHP"}, "SI": {"EUR"}, "SJ": {"NOK"},
+	"SK": {"EUR"}, "SL": {"SLE"}, "SM": {"EUR"}, "SN": {"XOF"}, "SO": {"SOS"},
+	"SR": {"SRD"}, "SS": {"SSP"}, "ST": {"STN"}, "SV": {"USD"}, "SX": {"XCG"},
+	"SY": {"SYP"}, "SZ": {"SZL", "ZAR"}, "TC": {"USD"}, "TD": {"XAF"}, "TF": {"EUR"},
+	"TG": {"XOF"}, "TH": {"THB"}, "TJ": {"TJS"}, "TK": {"NZD"}, "TL": {"USD"},
+	"TM": {"TMT"}, "TN": {"TND"}, "TO": {"TOP"}, "TR": {"TRY"}, "TT": {"TTD"},
+	"TV": {"AUD"}, "TW": {"TWD"}, "TZ": {"TZS"}, "UA": {"UAH"}, "UG": {"UGX"},
+	"UM": {"USD"}, "US": {"USD"}, "UY": {"UYU"}, "UZ": {"UZS"}, "VA": {"EUR"},
+	"VC": {"XCD"}, "VE": {"VES"}, "VG": {"USD"}, "VI": {"USD"}, "VN": {"VND"},
+	"VU": {"VUV"}, "WF": {"XPF"}, "WS": {"WST"}, "YE": {"YER"}, "YT": {"EUR"},
+	"ZA": {"ZAR"}, "ZM": {"ZMW"}, "ZW": {"ZWG", "USD"},
+}
+
+// RegionCurrencies returns the ISO 4217 currencies in use in an ISO 3166
+// country, such as EUR for DE, the country's own first
+func RegionCurrencies(region string) []string {
+	return append([]string(nil), regionCurrencies[strings.ToUpper(region)]...)
+}
+
+// validTimezone reports whether name is an IANA time zone such as
+// Europe/Berlin or UTC. Names are looked up in an embedded index, then with
+// time.LoadLocation for zones newer than it; "Local" is not a zone name.
+func validTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	if timezones.Contains(name) {
+		return true
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// isASCIISubtag reports whether s holds only ASCII letters and digits, with
+// letters only when alpha is set
+func isASCIISubtag(s string, alpha bool) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if !(c >= 'a' && c <= 'z' || !alpha && s[i] >= '0' && s[i] <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseLocale checks a BCP 47 language tag such as en, en-US, zh-Hant-TW or
+// es-419 and returns its language and region subtags, canonically cased.
+// Underscores, as in the POSIX en_US, are not accepted.
+func ParseLocale(tag string) (language, region string, ok bool) {
+	subtags := strings.Split(tag, "-")
+	if len(subtags[0]) < 2 || len(subtags[0]) > 8 || len(subtags[0]) == 4 || !isASCIISubtag(subtags[0], true) {
+		// Private use tags such as x-custom carry no language
+		return "", "", false
+	}
+	language = strings.ToLower(subtags[0])
+	rest := subtags[1:]
+
+	// Up to three extended language subtags follow a short language
+	for n := 0; n < 3 && len(language) <= 3 && len(rest) > 0 && len(rest[0]) == 3 && isASCIISubtag(rest[0], true); n++ {
+		rest = rest[1:]
+	}
+	if len(rest) > 0 && len(rest[0]) == 4 && isASCIISubtag(rest[0], true) {
+		rest = rest[1:]
+	}
+	if len(rest) > 0 && (len(rest[0]) == 2 && isASCIISubtag(rest[0], true) || len(rest[0]) == 3 && isASCIISubtag(rest[0], false) && rest[0][0] >= '0' && rest[0][0] <= '9') {
+		region = strings.ToUpper(rest[0])
+		rest = rest[1:]
+	}
+	for len(rest) > 0 && isLocaleVariant(rest[0]) {
+		rest = rest[1:]
+	}
+	if !validLocaleExtensions(rest) {
+		return "", "", false
+	}
+	return language, region, true
+}
+
+// isLocaleVariant reports whether a subtag is a variant: 5 to 8 letters or
+// digits, or 4 starting with a digit
+func isLocaleVariant(s string) bool {
+	if !isASCIISubtag(s, false) {
+		return false
+	}
+	return len(s) >= 5 && len(s) <= 8 || len(s) == 4 && s[0] >= '0' && s[0] <= '9'
+}
+
+// validLocaleExtensions checks the extensions and private use subtags ending
+// a tag: a single-character singleton followed by at least one subtag
+func validLocaleExtensions(rest []string) bool {
+	for i := 0; i < len(rest); {
+		singleton := rest[i]
+		if len(singleton) != 1 || !isASCIISubtag(singleton, false) {
+			return false
+		}
+		private := singleton == "x" || singleton == "X"
+		i++
+		start := i
+		for i < len(rest) && isASCIISubtag(rest[i], false) && len(rest[i]) <= 8 &&
+			(private && len(rest[i]) >= 1 || len(rest[i]) >= 2) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+		if private && i != len(rest) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTimezone validates an IANA time zone name
+func isTimezone(fl FieldLevel) bool {
+	return validTimezone(getString(fl.Field()))
+}
+
+// isLocale validates a BCP 47 language tag
+func isLocale(fl FieldLevel) bool {
+	_, _, ok := ParseLocale(getString(fl.Field()))
+	return ok
+}
+
+// currencyMatchesLocale validates a currency in use in the country of the
+// locale in the sibling field, e.g. EUR for de-DE. Locales without a country,
+// such as en or es-419, and an empty locale or currency are left to the
+// fields' own rules.
+func currencyMatchesLocale(fl FieldLevel) bool {
+	locale, _, found := fl.GetStructFieldOK()
+	if !found {
+		return false
+	}
+	currency := strings.ToUpper(strings.TrimSpace(getString(fl.Field())))
+	tag := strings.TrimSpace(getString(locale))
+	if currency == "" || tag == "" {
+		return true
+	}
+	_, region, ok := ParseLocale(tag)
+	if !ok {
+		return false
+	}
+	currencies, known := regionCurrencies[region]
+	if !known {
+		return region == "" || region[0] >= '0' && region[0] <= '9'
+	}
+	return containsString(currencies, currency)
+}
@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The markup rules are a defense-in-depth layer for user content that is
+// escaped on output anyway: no_html rejects anything that parses as a tag,
+// no_script only the vectors that run code, and safe_text also rejects
+// control and bidirectional formatting characters. Entity-encoded markup
+// such as &lt;script&gt; is rejected too, as it turns into markup once
+// decoded twice.
+
+var (
+	// markupTag matches the start of a tag, comment or declaration
+	markupTag = regexp.MustCompile(`<[a-zA-Z!/?]`)
+
+	// scriptTag matches tags and attributes that load or run code
+	scriptTag = regexp.MustCompile(`<\s*/?\s*(script|iframe|frame|object|embed|applet|meta|base|link|style|form)\b|<[^>]*[\s/]on[a-z]+\s*=|expression\s*\(`)
+
+	// scriptScheme matches URL schemes running code, once whitespace and
+	// control characters browsers ignore in URLs were removed
+	scriptScheme = regexp.MustCompile(`(javascript|vbscript|livescript):|data:text/html`)
+)
+
+// decodedForms returns a value as written and with its HTML entities decoded
+func decodedForms(value string) []string {
+	if decoded := html.UnescapeString(value); decoded != value {
+		return []string{value, decoded}
+	}
+	return []string{value}
+}
+
+// containsMarkup reports whether a value holds an HTML tag, comment or
+// declaration
+func containsMarkup(value string) bool {
+	for _, form := range decodedForms(value) {
+		if markupTag.MatchString(form) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsScript reports whether a value holds a script tag, an event
+// handler attribute or a javascript: URL, however cased or obfuscated with
+// whitespace and control characters
+func containsScript(value string) bool {
+	for _, form := range decodedForms(value) {
+		form = strings.ToLower(form)
+		compact := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) || unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, form)
+		if scriptTag.MatchString(form) || scriptScheme.MatchString(compact) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnsafeTextRune reports control characters other than tab and line
+// breaks, and the bidirectional formatting characters used to make text
+// display differently from how it reads
+func isUnsafeTextRune(r rune) bool {
+	switch {
+	case r == '\t' || r == '\n' || r == '\r':
+		return false
+	case unicode.IsControl(r):
+		return true
+	case r >= '\u202A' && r <= '\u202E', r >= '\u2066' && r <= '\u2069':
+		return true
+	case r == '\u200E' || r == '\u200F' || r == '\u061C':
+		return true
+	}
+	return false
+}
+
+// isNoHTML validates a value without markup
+func isNoHTML(fl FieldLevel) bool {
+	return !containsMarkup(getString(fl.Field()))
+}
+
+// isNoScript validates a value without script vectors; other markup passes
+func isNoScript(fl FieldLevel) bool {
+	return !containsScript(getString(fl.Field()))
+}
+
+// isSafeText validates valid UTF-8 text without markup or unsafe characters
+func isSafeText(fl FieldLevel) bool {
+	value := getString(fl.Field())
+	if !utf8.ValidString(value) || containsMarkup(value) {
+		return false
+	}
+	return strings.IndexFunc(value, isUnsafeTextRune) < 0
+}
+
+// MarkupFinding is a string field MarkupReport found holding markup
+type MarkupFinding struct {
+	Field   string // path of the field, e.g. "comments[0].body"
+	Script  bool   // the markup includes script vectors no_script rejects
+	Guarded bool   // the field is tagged no_html or safe_text, so Struct rejects the value
+}
+
+// MarkupReport lists the string fields of a struct holding unescaped markup,
+// descending into nested structs, slices, arrays and maps. Run it on values
+// that passed Struct to find the fields whose markup must be escaped on
+// output, or to audit which fields lack a markup rule.
+func (v *Validator) MarkupReport(s interface{}) ([]MarkupFinding, error) {
+	val := indirectValue(reflect.ValueOf(s))
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("validation: MarkupReport expects a struct, got %T", s)
+	}
+	v = v.current()
+	var findings []MarkupFinding
+	v.reportMarkup(val, "", false, 0, &findings)
+	return findings, nil
+}
+
+// MarkupReport lists the fields holding markup with the default validator
+func MarkupReport(s interface{}) ([]MarkupFinding, error) {
+	return defaultValidator.MarkupReport(s)
+}
+
+// maxMarkupDepth bounds the descent of MarkupReport through self-referencing
+// values
+const maxMarkupDepth = 32
+
+// reportMarkup appends the findings of a value at path
+func (v *Validator) reportMarkup(val reflect.Value, path string, guarded bool, depth int, findings *[]MarkupFinding) {
+	val = indirectValue(val)
+	if !val.IsValid() || depth > maxMarkupDepth {
+		return
+	}
+	switch val.Kind() {
+	case reflect.String:
+		if value := val.String(); containsMarkup(value) {
+			*findings = append(*findings, MarkupFinding{Field: path, Script: containsScript(value), Guarded: guarded})
+		}
+	case reflect.Struct:
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := v.fieldNameFunc(field)
+			if path != "" {
+				name = path + "." + name
+			}
+			tag := v.fieldTag(typ, field)
+			fieldGuarded := hasRule(tag, "no_html") || hasRule(tag, "safe_text")
+			v.reportMarkup(val.Field(i), name, fieldGuarded, depth+1, findings)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			v.reportMarkup(val.Index(i), fmt.Sprintf("%s[%d]", path, i), guarded, depth+1, findings)
+		}
+	case reflect.Map:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys {
+			v.reportMarkup(val.MapIndex(key), fmt.Sprintf("%s[%v]", path, key), guarded, depth+1, findings)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// MessageData is the context passed to message templates
+type MessageData struct {
+	Field     string      // field name, e.g. "port"
+	Namespace string      // full path, e.g. "server.port"
+	Tag       string      // failed rule, e.g. "max"
+	Param     string      // rule parameter as rendered by the ParamFormatter
+	RawParam  string      // rule parameter as written in the tag
+	Value     interface{} // failing value, RedactedValue for sensitive fields
+}
+
+// RegisterMessageTemplates registers text/template messages for a locale,
+// keyed by rule tag, e.g. "{{.Namespace}} is {{.Value}}, at most {{.Param}}
+// allowed". The empty locale overrides the built-in English messages and is
+// the fallback for locales without a template for a tag. Templates take
+// precedence over messages registered with RegisterMessages.
+func (v *Validator) RegisterMessageTemplates(locale string, templates map[string]string) error {
+	parsed := make(map[string]*template.Template, len(templates))
+	for tag, text := range templates {
+		tmpl, err := template.New(tag).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid message template for %q: %w", tag, err)
+		}
+		parsed[tag] = tmpl
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	catalogs := make(map[string]map[string]*template.Template, len(v.templates)+1)
+	for loc, catalog := range v.templates {
+		catalogs[loc] = catalog
+	}
+
+	catalog := make(map[string]*template.Template, len(catalogs[locale])+len(parsed))
+	for tag, tmpl := range catalogs[locale] {
+		catalog[tag] = tmpl
+	}
+	for tag, tmpl := range parsed {
+		catalog[tag] = tmpl
+	}
+	catalogs[locale] = catalog
+
+	v.templates = catalogs
+	v.publish()
+	return nil
+}
+
+// RegisterMessageTemplates registers message templates on the default validator
+func RegisterMessageTemplates(locale string, templates map[string]string) error {
+	return defaultValidator.RegisterMessageTemplates(locale, templates)
+}
+
+// errorMessage renders the message of a failed rule, preferring a registered
+// template and falling back to the format-based messages
+func (v *Validator) errorMessage(fl *fieldLevel, collector *ErrorCollector) string {
+	tmpl := v.messageTemplate(fl.tag)
+	if tmpl == nil {
+		return v.getErrorMessage(fl.tag, fl.fieldName, fl.param, fl.field)
+	}
+
+	data := MessageData{
+		Field:     fl.fieldName,
+		Namespace: fl.fieldName,
+		Tag:       fl.tag,
+		Param:     v.formatParam(fl.tag, fl.param, fl.field),
+		RawParam:  fl.param,
+		Value:     valueInterface(fl.field),
+	}
+	if collector.namespace != "" {
+		data.Namespace = collector.namespace + "." + fl.fieldName
+	}
+	if collector.sensitive {
+		data.Value = RedactedValue
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return v.getErrorMessage(fl.tag, fl.fieldName, fl.param, fl.field)
+	}
+	return b.String()
+}
+
+// messageTemplate returns the template registered for a rule in the call's
+// locale, then in the default locale. Locale messages registered with
+// RegisterMessages win over default-locale templates.
+func (v *Validator) messageTemplate(rule string) *template.Template {
+	if len(v.templates) == 0 {
+		return nil
+	}
+	if tmpl, ok := v.templates[v.config.Locale][rule]; ok {
+		return tmpl
+	}
+	if v.config.Locale != "" {
+		if _, ok := v.messages[v.config.Locale][rule]; ok {
+			return nil
+		}
+	}
+	return v.templates[""][rule]
+}
@@ -0,0 +1,159 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The monetary rules validate amounts held as numbers, decimal strings such
+// as "1234.50" or json.Number, or types printing as decimals through
+// fmt.Stringer, like the decimal types of arbitrary-precision libraries:
+//
+//	Amount   string `validate:"required,decimal=10,2,positive,minor_units=Currency"`
+//	Currency string `validate:"required,len=3"`
+
+// currencyMinorUnits lists the ISO 4217 currencies whose minor unit is not
+// 2 decimal places
+var currencyMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyMinorUnits returns the decimal places of the minor unit of an ISO
+// 4217 currency code, e.g. 2 for USD and 0 for JPY. Codes of three letters
+// absent from the exceptions table have 2.
+func CurrencyMinorUnits(code string) (int, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || !isLetter(code[0]) || !isLetter(code[1]) || !isLetter(code[2]) {
+		return 0, false
+	}
+	if units, ok := currencyMinorUnits[code]; ok {
+		return units, true
+	}
+	return 2, true
+}
+
+// parsePrecision parses the parameter of decimal: the most integer digits,
+// optionally followed by the most fractional digits, e.g. "10,2"
+func parsePrecision(param string) (integer, fraction int, err error) {
+	digits, places, hasPlaces := strings.Cut(strings.TrimSpace(param), ",")
+	integer, err = strconv.Atoi(strings.TrimSpace(digits))
+	if err == nil && hasPlaces {
+		fraction, err = strconv.Atoi(strings.TrimSpace(places))
+	}
+	if err != nil || integer < 0 || fraction < 0 {
+		return 0, 0, fmt.Errorf("invalid decimal precision %q", param)
+	}
+	return integer, fraction, nil
+}
+
+// decimalValue returns the digits of an amount: its sign, its integer digits
+// without leading zeros and its fractional digits without trailing zeros
+func decimalValue(field reflect.Value) (negative bool, integer, fraction string, ok bool) {
+	field = indirectValue(field)
+	if !field.IsValid() {
+		return false, "", "", false
+	}
+
+	var text string
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		text = strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		text = strconv.FormatUint(field.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := field.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return false, "", "", false
+		}
+		bits := 64
+		if field.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		text = strconv.FormatFloat(f, 'f', -1, bits)
+	case reflect.String:
+		text = field.String()
+	default:
+		if !field.CanInterface() {
+			return false, "", "", false
+		}
+		stringer, isStringer := field.Interface().(fmt.Stringer)
+		if !isStringer {
+			return false, "", "", false
+		}
+		text = stringer.String()
+	}
+	return parseDecimal(text)
+}
+
+// parseDecimal splits a plain decimal such as "-1234.50"; exponents are not accepted
+func parseDecimal(text string) (negative bool, integer, fraction string, ok bool) {
+	text = strings.TrimSpace(text)
+	if text != "" && (text[0] == '-' || text[0] == '+') {
+		negative = text[0] == '-'
+		text = text[1:]
+	}
+	integer, fraction, _ = strings.Cut(text, ".")
+	if integer == "" && fraction == "" {
+		return false, "", "", false
+	}
+	for _, part := range []string{integer, fraction} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false, "", "", false
+			}
+		}
+	}
+	integer = strings.TrimLeft(integer, "0")
+	fraction = strings.TrimRight(fraction, "0")
+	return negative && (integer != "" || fraction != ""), integer, fraction, true
+}
+
+// isDecimal validates that an amount has at most the integer and fractional
+// digits of decimal=10,2
+func isDecimal(fl FieldLevel) bool {
+	maxInteger, maxFraction, err := parsePrecision(fl.Param())
+	if err != nil {
+		return false
+	}
+	_, integer, fraction, ok := decimalValue(fl.Field())
+	return ok && len(integer) <= maxInteger && len(fraction) <= maxFraction
+}
+
+// isPositive validates that a number or amount is greater than zero
+func isPositive(fl FieldLevel) bool {
+	negative, integer, fraction, ok := decimalValue(fl.Field())
+	return ok && !negative && (integer != "" || fraction != "")
+}
+
+// isNonNegative validates that a number or amount is zero or more
+func isNonNegative(fl FieldLevel) bool {
+	negative, _, _, ok := decimalValue(fl.Field())
+	return ok && !negative
+}
+
+// hasMinorUnits validates that an amount has no more decimal places than the
+// minor unit of the currency in the sibling field, e.g. none for JPY. An
+// empty currency is left to the currency field's own rules.
+func hasMinorUnits(fl FieldLevel) bool {
+	currency, _, found := fl.GetStructFieldOK()
+	if !found {
+		return false
+	}
+	code := getString(currency)
+	if strings.TrimSpace(code) == "" {
+		return true
+	}
+	units, ok := CurrencyMinorUnits(code)
+	if !ok {
+		return false
+	}
+	_, _, fraction, ok := decimalValue(fl.Field())
+	return ok && len(fraction) <= units
+}
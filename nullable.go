@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Nullable is implemented by option types holding a value that may be unset.
+// Validation sees the contained value when ok is true and no value otherwise,
+// so required checks that the value is set, omitempty skips unset values and
+// the other rules apply to the contained value.
+type Nullable interface {
+	NullableValue() (value interface{}, ok bool)
+}
+
+// Optional holds a value that may be unset. Its layout mirrors sql.Null[T],
+// so generated validators handle both alike. It marshals to and from JSON as
+// the value or null.
+type Optional[T any] struct {
+	V     T
+	Valid bool
+}
+
+// Some returns a set Optional holding value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{V: value, Valid: true}
+}
+
+// None returns an unset Optional
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Get returns the value and whether it is set
+func (o Optional[T]) Get() (T, bool) {
+	return o.V, o.Valid
+}
+
+// NullableValue implements Nullable
+func (o Optional[T]) NullableValue() (interface{}, bool) {
+	return o.V, o.Valid
+}
+
+// MarshalJSON encodes the value, or null when unset
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.V)
+}
+
+// UnmarshalJSON decodes null as unset and anything else as the value
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.V); err != nil {
+		return err
+	}
+	o.Valid = true
+	return nil
+}
+
+// nullableField locates the validity flag and the value of a database/sql
+// null type
+type nullableField struct {
+	valid, value int // field indexes; valid < 0 marks a Nullable implementation
+}
+
+var (
+	// sqlNullTypes are the database/sql null types with their value fields
+	sqlNullTypes = map[reflect.Type]string{
+		reflect.TypeOf(sql.NullString{}):  "String",
+		reflect.TypeOf(sql.NullInt64{}):   "Int64",
+		reflect.TypeOf(sql.NullInt32{}):   "Int32",
+		reflect.TypeOf(sql.NullInt16{}):   "Int16",
+		reflect.TypeOf(sql.NullByte{}):    "Byte",
+		reflect.TypeOf(sql.NullFloat64{}): "Float64",
+		reflect.TypeOf(sql.NullBool{}):    "Bool",
+		reflect.TypeOf(sql.NullTime{}):    "Time",
+	}
+
+	nullableType = reflect.TypeOf((*Nullable)(nil)).Elem()
+
+	// nullableFields caches the layout of every struct type seen, nil for
+	// types that are not nullable
+	nullableFields sync.Map // reflect.Type -> *nullableField
+)
+
+// nullableLayout returns how to unwrap a nullable struct type, or nil
+func nullableLayout(typ reflect.Type) *nullableField {
+	if cached, ok := nullableFields.Load(typ); ok {
+		return cached.(*nullableField)
+	}
+
+	var layout *nullableField
+	switch {
+	case typ.Implements(nullableType):
+		layout = &nullableField{valid: -1}
+	case typ.PkgPath() == "database/sql":
+		value := sqlNullTypes[typ]
+		if value == "" && strings.HasPrefix(typ.Name(), "Null[") {
+			value = "V" // the generic sql.Null[T]
+		}
+		if value != "" {
+			validField, _ := typ.FieldByName("Valid")
+			valueField, _ := typ.FieldByName(value)
+			layout = &nullableField{valid: validField.Index[0], value: valueField.Index[0]}
+		}
+	}
+	nullableFields.Store(typ, layout)
+	return layout
+}
+
+// unwrapNullable replaces a set nullable value with a pointer to a copy of
+// its contents, so required passes even for zero contents while other rules
+// see through the pointer, and an unset one with the invalid Value, which
+// required rejects and omitempty skips. Other values are returned unchanged.
+func unwrapNullable(val reflect.Value) (reflect.Value, bool) {
+	if val.Kind() != reflect.Struct {
+		return val, false
+	}
+	layout := nullableLayout(val.Type())
+	if layout == nil {
+		return val, false
+	}
+
+	var contents reflect.Value
+	if layout.valid < 0 {
+		if !val.CanInterface() {
+			return val, false
+		}
+		value, ok := val.Interface().(Nullable).NullableValue()
+		if !ok || value == nil {
+			return reflect.Value{}, true
+		}
+		contents = reflect.ValueOf(value)
+	} else {
+		if !val.Field(layout.valid).Bool() {
+			return reflect.Value{}, true
+		}
+		contents = val.Field(layout.value)
+	}
+
+	ptr := reflect.New(contents.Type())
+	ptr.Elem().Set(contents)
+	return ptr, true
+}
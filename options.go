@@ -0,0 +1,93 @@
+package validation
+
+import "time"
+
+// ValidateOption customizes the behavior of a single Struct or Var call
+// without mutating the shared validator configuration
+type ValidateOption func(*ValidatorConfig)
+
+// WithFailFast stops validation at the first error for this call
+func WithFailFast() ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.FailFast = true
+	}
+}
+
+// WithTagName reads validation rules from the given struct tag for this call
+func WithTagName(name string) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.TagName = name
+	}
+}
+
+// WithLocale renders error messages using the messages registered for locale,
+// falling back to the built-in English messages for unregistered tags
+func WithLocale(locale string) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.Locale = locale
+	}
+}
+
+// WithMaxErrors caps the errors reported by this call, summarizing the rest
+func WithMaxErrors(maxErrors int) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.MaxErrors = maxErrors
+	}
+}
+
+// WithMaxInputLength rejects strings and byte slices longer than maxLength
+// bytes for this call, before any rule runs
+func WithMaxInputLength(maxLength int) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.MaxInputLength = maxLength
+	}
+}
+
+// WithTimeout aborts this call once it has run for longer than timeout
+func WithTimeout(timeout time.Duration) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.Timeout = timeout
+	}
+}
+
+// WithRequiredSemantics selects what the required rule accepts for this call
+func WithRequiredSemantics(semantics RequiredSemantics) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.RequiredSemantics = semantics
+	}
+}
+
+// WithScenario also applies the rules suffixed @name for this call, such as
+// required@create, so one struct can be validated differently per flow
+func WithScenario(name string) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.Scenario = name
+	}
+}
+
+// WithUniqueConcurrency runs at most n unique_db resolver calls at once for
+// this call
+func WithUniqueConcurrency(n int) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.UniqueConcurrency = n
+	}
+}
+
+// withOptions returns a copy of the validator with per-call options applied.
+// The receiver is returned unchanged when no options are given.
+func (v *Validator) withOptions(opts []ValidateOption) *Validator {
+	if len(opts) == 0 {
+		return v
+	}
+
+	call := v.clone()
+	call.config.TagName = v.tagName
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&call.config)
+		}
+	}
+	call.tagName = call.config.TagName
+
+	return call
+}
@@ -0,0 +1,392 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRuleParam is returned by Struct when a tag gives a rule a
+// parameter its ParamSchema rejects, e.g. min=abc
+var ErrInvalidRuleParam = errors.New("invalid rule parameter")
+
+// ParamType is the kind of value a rule parameter holds
+type ParamType string
+
+const (
+	ParamNone       ParamType = "none"        // the rule takes no parameter
+	ParamString     ParamType = "string"      // any text
+	ParamInt        ParamType = "int"         // a whole number
+	ParamNumber     ParamType = "number"      // a number, a size such as 5:chars or 10KB, or a duration such as 1s
+	ParamDuration   ParamType = "duration"    // a time span such as 90s, 24h or 30d
+	ParamPrecision  ParamType = "precision"   // most integer digits, optionally followed by most fractional digits, e.g. 10,2
+	ParamList       ParamType = "list"        // space-separated values
+	ParamField      ParamType = "field"       // the Go name of a sibling field
+	ParamFieldValue ParamType = "field_value" // a sibling field name followed by a value
+)
+
+// ParamSchema describes the parameter a rule takes, so malformed tags are
+// rejected when a struct's plan is compiled and Explain can document it
+type ParamSchema struct {
+	Type        ParamType `json:"type"`
+	Required    bool      `json:"required,omitempty"`
+	Allowed     []string  `json:"allowed,omitempty"` // values, or list items, the parameter may take
+	Description string    `json:"description,omitempty"`
+}
+
+// Check returns why param does not match the schema, or nil
+func (s ParamSchema) Check(param string) error {
+	param = strings.TrimSpace(param)
+	if _, _, ok := ParamPlaceholder(param); ok {
+		// Checked once resolved at validation time
+		return nil
+	}
+	if param == "" {
+		if s.Required {
+			return errors.New("requires a parameter")
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case ParamNone:
+		return errors.New("takes no parameter")
+	case ParamInt:
+		if _, err := strconv.ParseInt(param, 10, 64); err != nil {
+			return errors.New("must be a whole number")
+		}
+	case ParamNumber:
+		if _, err := strconv.ParseFloat(param, 64); err != nil {
+			_, sizeErr := ParseSizeSpec(param)
+			_, durationErr := time.ParseDuration(param)
+			if sizeErr != nil && durationErr != nil {
+				return errors.New("must be a number, a size such as 5:chars or 10KB, or a duration such as 1s")
+			}
+		}
+	case ParamDuration:
+		if _, err := ParseTimeSpan(param); err != nil {
+			return errors.New("must be a time span such as 90s, 24h or 30d")
+		}
+	case ParamPrecision:
+		if _, _, err := parsePrecision(param); err != nil {
+			return errors.New("must be a digit count such as 10, or digit counts such as 10,2")
+		}
+	case ParamField:
+		if !isFieldPath(param) {
+			return errors.New("must be a field name")
+		}
+	case ParamFieldValue:
+		field, _, ok := strings.Cut(param, " ")
+		if !ok || !isFieldPath(field) {
+			return errors.New("must be a field name followed by a value")
+		}
+	}
+
+	if len(s.Allowed) > 0 {
+		values := []string{param}
+		if s.Type == ParamList {
+			values = strings.Fields(param)
+		}
+		for _, value := range values {
+			if !containsString(s.Allowed, value) {
+				return fmt.Errorf("%q is not one of %s", value, strings.Join(s.Allowed, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// String renders the schema for documentation, e.g. "number (required)"
+func (s ParamSchema) String() string {
+	text := string(s.Type)
+	if s.Type == "" {
+		text = string(ParamString)
+	}
+	if s.Required {
+		text += " (required)"
+	}
+	if len(s.Allowed) > 0 {
+		text += ": one of " + strings.Join(s.Allowed, ", ")
+	}
+	if s.Description != "" {
+		text += " - " + s.Description
+	}
+	return text
+}
+
+// isFieldPath reports whether s is a dotted path of identifiers
+func isFieldPath(s string) bool {
+	for _, name := range strings.Split(s, ".") {
+		if name == "" {
+			return false
+		}
+		for i, r := range name {
+			if r != '_' && !isLetter(byte(r)) && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SplitRules splits a validate tag into its rules at the commas, except the
+// comma between the digit counts of decimal=10,2
+func SplitRules(tag string) []string {
+	parts := strings.Split(tag, ",")
+	rules := parts[:0]
+	for _, part := range parts {
+		if n := len(rules); n > 0 && isPrecisionContinuation(rules[n-1], part) {
+			rules[n-1] += "," + part
+			continue
+		}
+		rules = append(rules, part)
+	}
+	return rules
+}
+
+// isPrecisionContinuation reports whether part holds the fractional digits
+// of a decimal rule cut at its comma
+func isPrecisionContinuation(rule, part string) bool {
+	name, param, ok := strings.Cut(strings.TrimSpace(rule), "=")
+	part = strings.TrimSpace(part)
+	return ok && name == "decimal" && param != "" && !strings.Contains(param, ",") &&
+		part != "" && part[0] >= '0' && part[0] <= '9'
+}
+
+// builtinParamSchemas describe the parameters of the built-in rules
+var builtinParamSchemas = map[string]ParamSchema{
+	"required":         {Type: ParamNone},
+	"defined":          {Type: ParamNone},
+	"boolean":          {Type: ParamNone},
+	"min":              {Type: ParamNumber, Description: "smallest value, length or size"},
+	"max":              {Type: ParamNumber, Description: "largest value, length or size"},
+	"len":              {Type: ParamNumber, Description: "exact length or size"},
+	"minbytes":         {Type: ParamInt, Required: true, Description: "fewest bytes"},
+	"maxbytes":         {Type: ParamInt, Required: true, Description: "most bytes"},
+	"minrunes":         {Type: ParamInt, Required: true, Description: "fewest characters"},
+	"maxrunes":         {Type: ParamInt, Required: true, Description: "most characters"},
+	"eq":               {Type: ParamString, Description: "value the field must equal"},
+	"ne":               {Type: ParamString, Description: "value the field must differ from"},
+	"oneof":            {Type: ParamList, Required: true, Description: "allowed values"},
+	"typeof":           {Type: ParamString, Required: true, Description: "|-separated type or kind names"},
+	"minitems":         {Type: ParamInt, Required: true, Description: "fewest items"},
+	"maxitems":         {Type: ParamInt, Required: true, Description: "most items"},
+	"notempty":         {Type: ParamNone},
+	"sorted":           {Type: ParamNone},
+	"sorted_desc":      {Type: ParamNone},
+	"haskeys":          {Type: ParamList, Required: true, Description: "keys the map must contain"},
+	"allowedkeys":      {Type: ParamList, Required: true, Description: "keys the map may contain"},
+	"alpha":            {Type: ParamNone},
+	"alphanum":         {Type: ParamNone},
+	"numeric":          {Type: ParamNone},
+	"email":            {Type: ParamNone},
+	"url":              {Type: ParamNone},
+	"uri":              {Type: ParamNone},
+	"ip":               {Type: ParamNone},
+	"ipv4":             {Type: ParamNone},
+	"ipv6":             {Type: ParamNone},
+	"cidr":             {Type: ParamNone},
+	"mac":              {Type: ParamNone},
+	"hostname":         {Type: ParamNone},
+	"uuid":             {Type: ParamNone},
+	"uuid4":            {Type: ParamNone},
+	"datetime":         {Type: ParamNone},
+	"date":             {Type: ParamNone},
+	"time":             {Type: ParamNone},
+	"expires_after":    {Type: ParamDuration, Required: true, Description: "shortest time left before the value"},
+	"not_before_now":   {Type: ParamNone},
+	"within":           {Type: ParamDuration, Required: true, Description: "furthest the value may be from now"},
+	"json":             {Type: ParamNone},
+	"jsonschema":       {Type: ParamString, Required: true, Description: "name of a registered JSON schema"},
+	"base64":           {Type: ParamNone},
+	"creditcard":       {Type: ParamNone},
+	"phone":            {Type: ParamNone},
+	"luhn":             {Type: ParamNone},
+	"imei":             {Type: ParamNone},
+	"ean13":            {Type: ParamNone},
+	"gtin":             {Type: ParamNone},
+	"isbn13":           {Type: ParamNone},
+	"isbn10":           {Type: ParamNone},
+	"vin":              {Type: ParamNone},
+	"percent":          {Type: ParamNone},
+	"ratio":            {Type: ParamNone},
+	"sum_to":           {Type: ParamList, Required: true, Description: "total, optionally followed by the Go names of the fields summed"},
+	"decimal":          {Type: ParamPrecision, Required: true, Description: "most integer and fractional digits"},
+	"positive":         {Type: ParamNone},
+	"nonnegative":      {Type: ParamNone},
+	"minor_units":      {Type: ParamField, Required: true, Description: "field holding the ISO 4217 currency code"},
+	"file_ext":         {Type: ParamList, Required: true, Description: "allowed file extensions"},
+	"min_file_size":    {Type: ParamNumber, Required: true, Description: "smallest file size, in bytes or with a unit such as 10KB"},
+	"max_file_size":    {Type: ParamNumber, Required: true, Description: "largest file size, in bytes or with a unit such as 5MB"},
+	"eqfield":          {Type: ParamField, Required: true, Description: "field the value must equal"},
+	"nefield":          {Type: ParamField, Required: true, Description: "field the value must differ from"},
+	"gtfield":          {Type: ParamField, Required: true, Description: "field the value must be greater than"},
+	"gtefiled":         {Type: ParamField, Required: true, Description: "field the value must be at least"},
+	"ltfield":          {Type: ParamField, Required: true, Description: "field the value must be less than"},
+	"ltefield":         {Type: ParamField, Required: true, Description: "field the value must be at most"},
+	"required_if":      {Type: ParamFieldValue, Required: true, Description: "required when the field has the value"},
+	"required_unless":  {Type: ParamFieldValue, Required: true, Description: "required unless the field has the value"},
+	"required_with":    {Type: ParamField, Required: true, Description: "required when the field is set"},
+	"required_without": {Type: ParamField, Required: true, Description: "required when the field is empty"},
+	"unique_db":        {Type: ParamString, Required: true, Description: "name of a registered unique resolver"},
+	"immutable":        {Type: ParamNone},
+
+	// Names looked up in the embedded public suffix list
+	"domain":             {Type: ParamNone},
+	"tld":                {Type: ParamNone},
+	"fqdn_public_suffix": {Type: ParamNone},
+
+	// Email deliverability and identity checks
+	"email_mx":             {Type: ParamNone},
+	"email_not_disposable": {Type: ParamNone},
+	"username":             {Type: ParamNone},
+
+	"notcontains_blocklist": {Type: ParamString, Required: true, Description: "name of a registered blocklist"},
+	"no_html":               {Type: ParamNone},
+	"no_script":             {Type: ParamNone},
+	"safe_text":             {Type: ParamNone},
+	"safe_path":             {Type: ParamString, Allowed: []string{"relative", "absolute"}, Description: "relative or absolute to require either"},
+	"no_shell_meta":         {Type: ParamNone},
+	"sql_identifier":        {Type: ParamString, Allowed: []string{"qualified"}, Description: "qualified to accept dotted names"},
+
+	"sql_like_escape_required": {Type: ParamString, Description: "escape character, a backslash by default"},
+
+	// Kubernetes object rules
+	"k8s_name":        {Type: ParamString, Allowed: []string{"label"}, Description: "label for a DNS-1123 label rather than subdomain"},
+	"k8s_label_key":   {Type: ParamNone},
+	"k8s_label_value": {Type: ParamNone},
+	"k8s_qty":         {Type: ParamNone},
+	"k8s_selector":    {Type: ParamNone},
+
+	// Container image rules
+	"image_ref":     {Type: ParamString, Allowed: []string{"tagged", "digest"}, Description: "tagged or digest to require a tag or digest"},
+	"image_tag":     {Type: ParamNone},
+	"sha256_digest": {Type: ParamNone},
+
+	// Git repository rules
+	"git_url":     {Type: ParamNone},
+	"ssh_url":     {Type: ParamNone},
+	"commit_sha":  {Type: ParamNone},
+	"branch_name": {Type: ParamNone},
+
+	// URL component rules
+	"url_scheme":      {Type: ParamList, Required: true, Description: "allowed schemes"},
+	"url_host_in":     {Type: ParamList, Required: true, Description: "allowed hosts, *.example.com for any subdomain"},
+	"url_no_userinfo": {Type: ParamNone},
+	"url_no_fragment": {Type: ParamNone},
+
+	// Locale rules
+	"timezone":                {Type: ParamNone},
+	"locale":                  {Type: ParamNone},
+	"currency_matches_locale": {Type: ParamField, Required: true, Description: "field holding the BCP 47 locale"},
+}
+
+// RegisterParamSchema declares the parameter a rule takes. Struct then
+// rejects tags giving the rule a malformed parameter with an error wrapping
+// ErrInvalidRuleParam, and Explain marks them invalid.
+func (v *Validator) RegisterParamSchema(tag string, schema ParamSchema) error {
+	if tag == "" {
+		return fmt.Errorf("validation tag cannot be empty")
+	}
+	switch schema.Type {
+	case ParamNone, ParamString, ParamInt, ParamNumber, ParamDuration, ParamPrecision, ParamList, ParamField, ParamFieldValue:
+	case "":
+		schema.Type = ParamString
+	default:
+		return fmt.Errorf("unknown parameter type %q for rule %q", schema.Type, tag)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	paramSchemas := make(map[string]ParamSchema, len(v.paramSchemas)+1)
+	for name, existing := range v.paramSchemas {
+		paramSchemas[name] = existing
+	}
+	paramSchemas[tag] = schema
+
+	v.paramSchemas = paramSchemas
+	v.publish()
+	return nil
+}
+
+// RegisterParamSchema declares a rule's parameter on the default validator
+func RegisterParamSchema(tag string, schema ParamSchema) error {
+	return defaultValidator.RegisterParamSchema(tag, schema)
+}
+
+// ParamSchemaFor returns the parameter schema of a rule, if one is declared
+func (v *Validator) ParamSchemaFor(tag string) (ParamSchema, bool) {
+	schema, ok := v.current().paramSchemas[tag]
+	return schema, ok
+}
+
+// ParamSchemaFor returns a rule's parameter schema from the default validator
+func ParamSchemaFor(tag string) (ParamSchema, bool) {
+	return defaultValidator.ParamSchemaFor(tag)
+}
+
+// ParamSchemas returns the declared parameter schemas by rule name, e.g. to
+// generate documentation of the available rules
+func (v *Validator) ParamSchemas() map[string]ParamSchema {
+	current := v.current().paramSchemas
+	schemas := make(map[string]ParamSchema, len(current))
+	for name, schema := range current {
+		schemas[name] = schema
+	}
+	return schemas
+}
+
+// CheckRuleParam returns why a rule's parameter does not match its schema,
+// or nil when it does or the rule declares no schema
+func (v *Validator) CheckRuleParam(rule, param string) error {
+	schema, ok := v.paramSchemas[rule]
+	if !ok {
+		return nil
+	}
+	return schema.Check(param)
+}
+
+// CheckRuleParam checks a rule's parameter against the default validator's schemas
+func CheckRuleParam(rule, param string) error {
+	return defaultValidator.current().CheckRuleParam(rule, param)
+}
+
+// checkRuleParams checks the rule parameters of typ and its nested struct
+// types against their schemas
+func (v *Validator) checkRuleParams(typ reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || v.isIgnoredField(field.Name) {
+			continue
+		}
+
+		for _, rule := range strings.Split(v.fieldTag(typ, field), ",") {
+			rule, _, _ = cutScenarios(strings.TrimSpace(rule))
+			name, param, _ := strings.Cut(rule, "=")
+			if err := v.CheckRuleParam(name, param); err != nil {
+				return fmt.Errorf("%w in %s.%s: %s: %v", ErrInvalidRuleParam, typ, field.Name, rule, err)
+			}
+		}
+
+		nested := indirectType(field.Type)
+		switch nested.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			nested = indirectType(nested.Elem())
+		}
+		if nested.Kind() == reflect.Struct {
+			if err := v.checkRuleParams(nested, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// The path and shell rules guard values that end up in file operations or
+// exec calls: safe_path rejects traversal out of the directory a path is
+// joined to, and no_shell_meta rejects characters a shell would interpret.
+// Both check the value alone; resolving symlinks is left to the caller.
+
+// shellMetaChars are the characters POSIX shells give a meaning to, besides
+// the whitespace separating arguments
+const shellMetaChars = ";&|$`<>(){}[]*?!~#'\"\\\n\r\x00"
+
+// isAbsolutePath reports whether a path is absolute on Unix or Windows: it
+// starts with a separator, such as /etc or \\server\share, or a drive letter
+func isAbsolutePath(path string) bool {
+	if strings.HasPrefix(path, "/") || strings.HasPrefix(path, `\`) {
+		return true
+	}
+	return len(path) >= 2 && path[1] == ':' && (path[0] >= 'a' && path[0] <= 'z' || path[0] >= 'A' && path[0] <= 'Z')
+}
+
+// ValidateSafePath checks that a path has no ".." segments, with either
+// separator, and no control characters. mode "relative" also rejects
+// absolute paths and home directory references such as ~/.ssh, and
+// "absolute" requires an absolute path; any other mode allows both.
+func ValidateSafePath(field, value, mode string) error {
+	if !safePath(value, mode) {
+		return ValidationError{
+			Field:   field,
+			Tag:     "safe_path",
+			Param:   mode,
+			Value:   value,
+			Message: safePathMessage(field, mode),
+		}
+	}
+	return nil
+}
+
+// safePath reports whether a path is safe in the mode
+func safePath(path, mode string) bool {
+	if path == "" || strings.IndexFunc(path, unicode.IsControl) >= 0 {
+		return false
+	}
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return false
+		}
+	}
+	switch mode {
+	case "relative":
+		return !isAbsolutePath(path) && !strings.HasPrefix(path, "~")
+	case "absolute":
+		return isAbsolutePath(path)
+	}
+	return true
+}
+
+// safePathMessage returns the error message of safe_path in a mode
+func safePathMessage(field, mode string) string {
+	if mode == "relative" || mode == "absolute" {
+		return fmt.Sprintf(ErrorMsgSafePathMode, field, mode)
+	}
+	return fmt.Sprintf(ErrorMsgSafePath, field)
+}
+
+// ValidateNoShellMeta checks that a value has no shell metacharacters, so it
+// cannot chain commands, expand variables or redirect output when it reaches
+// a shell
+func ValidateNoShellMeta(field, value string) error {
+	if strings.ContainsAny(value, shellMetaChars) {
+		return ValidationError{
+			Field:   field,
+			Tag:     "no_shell_meta",
+			Value:   value,
+			Message: fmt.Sprintf(ErrorMsgNoShellMeta, field),
+		}
+	}
+	return nil
+}
+
+// isSafePath validates a path without traversal, in the mode given by the
+// parameter
+func isSafePath(fl FieldLevel) bool {
+	return safePath(getString(fl.Field()), fl.Param())
+}
+
+// isNoShellMeta validates a value without shell metacharacters
+func isNoShellMeta(fl FieldLevel) bool {
+	return ValidateNoShellMeta(fl.FieldName(), getString(fl.Field())) == nil
+}
@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// ParamPlaceholder parses a rule parameter taken from elsewhere at validation
+// time: $Field names a sibling field and ${NAME} an environment variable, as
+// in max=$MaxNameLen or min=${MIN_AGE}. ok is false for literal parameters.
+func ParamPlaceholder(param string) (name string, env bool, ok bool) {
+	param = strings.TrimSpace(param)
+	if strings.HasPrefix(param, "${") && strings.HasSuffix(param, "}") {
+		name = param[2 : len(param)-1]
+		return name, true, name != ""
+	}
+	if strings.HasPrefix(param, "$") && !strings.Contains(param, ".") && isFieldPath(param[1:]) {
+		return param[1:], false, true
+	}
+	return "", false, false
+}
+
+// FormatParam renders a value as a rule parameter, following pointers, e.g.
+// to substitute a $Field placeholder in generated code
+func FormatParam(value interface{}) string {
+	val := indirectValue(reflect.ValueOf(value))
+	if isNilValue(val) {
+		return ""
+	}
+	return fmt.Sprint(valueInterface(val))
+}
+
+// placeholderField returns the sibling field a $Field parameter reads
+func placeholderField(param string) (string, bool) {
+	name, env, ok := ParamPlaceholder(param)
+	return name, ok && !env
+}
+
+// resolveParam replaces a placeholder parameter of fl with the value it
+// refers to, checked against the rule's ParamSchema
+func (v *Validator) resolveParam(fl *fieldLevel) error {
+	name, env, ok := ParamPlaceholder(fl.param)
+	if !ok {
+		return nil
+	}
+
+	var resolved string
+	if env {
+		value, found := os.LookupEnv(name)
+		if !found {
+			return fmt.Errorf("environment variable %s is not set", name)
+		}
+		resolved = strings.TrimSpace(value)
+	} else {
+		field, _, found := fl.getStructFieldOK(fl.parent, name)
+		if !found {
+			if field.IsValid() {
+				return fmt.Errorf("field %s is nil", name)
+			}
+			return fmt.Errorf("field %s does not exist", name)
+		}
+		resolved = FormatParam(valueInterface(field))
+	}
+
+	if err := v.CheckRuleParam(fl.tag, resolved); err != nil {
+		return fmt.Errorf("%s is %q, which %v", fl.param, resolved, err)
+	}
+	fl.param = resolved
+	return nil
+}
+
+// resolveRuleParam resolves a placeholder parameter of fl, reporting the
+// field when it cannot be resolved. The rule must then be skipped.
+func (v *Validator) resolveRuleParam(fl *fieldLevel, collector *ErrorCollector) bool {
+	param := fl.param
+	err := v.resolveParam(fl)
+	if err == nil {
+		return true
+	}
+	collector.Add(ValidationError{
+		Field:   fl.fieldName,
+		Tag:     fl.tag,
+		Param:   param,
+		Value:   valueInterface(fl.field),
+		Message: fmt.Sprintf(ErrorMsgParamUnresolved, fl.fieldName, fl.tag, err),
+		Code:    ErrCodeParamUnresolved,
+	})
+	return false
+}
@@ -0,0 +1,286 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrDependencyCycle is returned by Struct when cross-field rules reference
+// each other in a cycle (A eqfield=B, B eqfield=A) or a field references itself
+var ErrDependencyCycle = errors.New("cross-field dependency cycle")
+
+// structPlan is the compiled, cached evaluation plan for a struct type
+type structPlan struct {
+	order          []int                     // field indexes in evaluation order for calls without a scenario
+	reordered      bool                      // order differs from declaration order, so errors are moved back
+	scenarioOrders map[string][]int          // scenario -> evaluation order, for scenarios whose rules add dependencies
+	groups         StructLevelValidationFunc // checks the field groups declared with the groups tag, if any
+	err            error                     // configuration error found while compiling, e.g. a dependency cycle or min=abc
+}
+
+// orderFor returns the field indexes in evaluation order for scenario, and
+// whether they differ from declaration order
+func (p *structPlan) orderFor(scenario string) ([]int, bool) {
+	if order, ok := p.scenarioOrders[scenario]; ok {
+		return order, !slices.IsSorted(order)
+	}
+	return p.order, p.reordered
+}
+
+// planKey identifies a plan; per-call options may change the tag name
+type planKey struct {
+	typ     reflect.Type
+	tagName string
+}
+
+// planCache holds the plans compiled against one published snapshot
+type planCache struct {
+	plans sync.Map // planKey -> *structPlan
+}
+
+// planFor returns the compiled plan for typ and every struct type reachable
+// from it, compiling and caching it on first use
+func (v *Validator) planFor(typ reflect.Type) *structPlan {
+	key := planKey{typ: typ, tagName: v.tagName}
+	if v.plans != nil {
+		if cached, ok := v.plans.plans.Load(key); ok {
+			return cached.(*structPlan)
+		}
+	}
+
+	fields := v.fieldDependencies(typ)
+	plan := &structPlan{
+		order: evaluationOrder(scenarioDependencies(fields, "")),
+		err:   v.checkDependencyCycles(typ, map[reflect.Type]bool{}),
+	}
+	plan.reordered = !slices.IsSorted(plan.order)
+	for _, scenario := range dependencyScenarios(fields) {
+		if plan.scenarioOrders == nil {
+			plan.scenarioOrders = make(map[string][]int)
+		}
+		plan.scenarioOrders[scenario] = evaluationOrder(scenarioDependencies(fields, scenario))
+	}
+	if plan.err == nil {
+		plan.err = v.checkRuleParams(typ, map[reflect.Type]bool{})
+	}
+	if plan.err == nil {
+		plan.err = v.checkFieldGroups(typ, map[reflect.Type]bool{})
+	}
+	if plan.err == nil {
+		plan.groups, _ = compileFieldGroups(typ)
+	}
+	if v.plans != nil {
+		cached, _ := v.plans.plans.LoadOrStore(key, plan)
+		return cached.(*structPlan)
+	}
+	return plan
+}
+
+// checkDependencyCycles checks typ and its nested struct types for
+// cross-field dependency cycles
+func (v *Validator) checkDependencyCycles(typ reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[typ] {
+		return nil
+	}
+	seen[typ] = true
+
+	// Rules limited to different scenarios never run together, so each
+	// scenario is checked with the rules that apply in it
+	fields := v.fieldDependencies(typ)
+	for _, scenario := range append([]string{""}, dependencyScenarios(fields)...) {
+		cycle := findFieldCycle(scenarioDependencies(fields, scenario))
+		if cycle == nil {
+			continue
+		}
+		where := typ.String()
+		if scenario != "" {
+			where += " for scenario " + scenario
+		}
+		if len(cycle) == 2 {
+			return fmt.Errorf("%w in %s: field %s references itself", ErrDependencyCycle, where, cycle[0])
+		}
+		return fmt.Errorf("%w in %s: %s", ErrDependencyCycle, where, strings.Join(cycle, " -> "))
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		nested := indirectType(field.Type)
+		switch nested.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			nested = indirectType(nested.Elem())
+		}
+		if nested.Kind() == reflect.Struct {
+			if err := v.checkDependencyCycles(nested, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldDependency lists the sibling fields a field's rules must see first
+type fieldDependency struct {
+	index  int
+	name   string
+	deps   []string            // of the rules applying in every scenario
+	scoped map[string][]string // scenario -> of the rules limited to scenarios
+}
+
+// dependencyScenarios returns the scenarios rules of the fields are limited
+// to, sorted
+func dependencyScenarios(fields []fieldDependency) []string {
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		for scenario := range field.scoped {
+			seen[scenario] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// scenarioDependencies returns the dependencies of the rules applying in
+// scenario, "" for calls without one
+func scenarioDependencies(fields []fieldDependency, scenario string) []fieldDependency {
+	scoped := make([]fieldDependency, len(fields))
+	for i, field := range fields {
+		scoped[i] = fieldDependency{index: field.index, name: field.name, deps: field.deps}
+		if deps := field.scoped[scenario]; len(deps) > 0 {
+			scoped[i].deps = append(slices.Clip(field.deps), deps...)
+		}
+	}
+	return scoped
+}
+
+// fieldDependencies returns the ordering dependencies of every field of typ
+// in declaration order. Dive rules apply to elements and have no siblings.
+func (v *Validator) fieldDependencies(typ reflect.Type) []fieldDependency {
+	fields := make([]fieldDependency, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		dependency := fieldDependency{index: i, name: field.Name}
+
+		tag := v.fieldTag(typ, field)
+		if field.IsExported() && !v.isIgnoredField(field.Name) &&
+			tag != "" && tag != "-" && !strings.Contains(tag, "dive") {
+			for _, rule := range SplitRules(tag) {
+				rule, scenarios, scoped := cutScenarios(strings.TrimSpace(rule))
+				name, param, _ := strings.Cut(rule, "=")
+				deps := orderingDependencies(name, param)
+				if !scoped {
+					dependency.deps = append(dependency.deps, deps...)
+					continue
+				}
+				if len(deps) == 0 {
+					continue
+				}
+				if dependency.scoped == nil {
+					dependency.scoped = make(map[string][]string)
+				}
+				for _, scenario := range strings.Split(scenarios, "|") {
+					dependency.scoped[scenario] = append(dependency.scoped[scenario], deps...)
+				}
+			}
+		}
+		fields = append(fields, dependency)
+	}
+	return fields
+}
+
+// orderingDependencies returns the sibling fields a rule must be evaluated
+// after. required_with/required_without only test presence and are commonly
+// paired in both directions to express "one of", so they never form cycles.
+func orderingDependencies(rule, param string) []string {
+	switch rule {
+	case "required_with", "required_without":
+		return nil
+	}
+	return crossFieldDependencies(rule, param)
+}
+
+// findFieldCycle returns the fields forming a dependency cycle, with the
+// first field repeated at the end, or nil when there is none
+func findFieldCycle(fields []fieldDependency) []string {
+	edges := make(map[string][]string, len(fields))
+	for _, field := range fields {
+		edges[field.name] = field.deps
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(fields))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range edges[name] {
+			switch state[dep] {
+			case visiting:
+				for i, field := range path {
+					if field == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, field := range fields {
+		if state[field.name] == unvisited {
+			if cycle := visit(field.name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// evaluationOrder returns field indexes in declaration order, except that
+// fields referenced by cross-field rules are evaluated before the fields
+// referencing them. Edges closing a cycle are ignored.
+func evaluationOrder(fields []fieldDependency) []int {
+	byName := make(map[string]int, len(fields))
+	for i, field := range fields {
+		byName[field.name] = i
+	}
+
+	order := make([]int, 0, len(fields))
+	placed := make([]bool, len(fields))
+	var place func(i int)
+	place = func(i int) {
+		if placed[i] {
+			return
+		}
+		placed[i] = true
+		for _, dep := range fields[i].deps {
+			if j, ok := byName[dep]; ok {
+				place(j)
+			}
+		}
+		order = append(order, fields[i].index)
+	}
+
+	for i := range fields {
+		place(i)
+	}
+	return order
+}
@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Policy overrides the rules of struct fields at runtime, e.g. to require
+// longer passwords without a deploy. Fields are keyed "Type.Field" by their
+// Go struct and field names. Rules merge into the field's tag, replacing
+// rules of the same name and appending new ones; Replace rules are used
+// instead of the tag.
+//
+//	rules:
+//	  User.Password: "min=12"
+//	replace:
+//	  User.Nickname: "omitempty,alphanum,max=20"
+type Policy struct {
+	Rules   map[string]string `json:"rules,omitempty" yaml:"rules,omitempty"`
+	Replace map[string]string `json:"replace,omitempty" yaml:"replace,omitempty"`
+}
+
+// policyField identifies the field a policy entry applies to
+type policyField struct {
+	typeName string
+	field    string
+}
+
+// policyOverride is a policy entry ready to apply to a field's tag
+type policyOverride struct {
+	rules   string
+	replace bool
+}
+
+// ParsePolicy decodes a policy file with unmarshal, e.g. yaml.Unmarshal, or
+// as JSON when unmarshal is nil
+func ParsePolicy(data []byte, unmarshal func([]byte, interface{}) error) (Policy, error) {
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	var policy Policy
+	if err := unmarshal(data, &policy); err != nil {
+		return Policy{}, fmt.Errorf("invalid validation policy: %w", err)
+	}
+	return policy, nil
+}
+
+// LoadPolicy applies policy to every later call, replacing any policy loaded
+// before. Entries naming malformed fields, unknown rules or parameters their
+// schema rejects fail the whole policy, leaving the current one in place.
+func (v *Validator) LoadPolicy(policy Policy) error {
+	overrides := make(map[policyField]policyOverride, len(policy.Rules)+len(policy.Replace))
+	for _, entries := range []struct {
+		rules   map[string]string
+		replace bool
+	}{{policy.Rules, false}, {policy.Replace, true}} {
+		for _, key := range policyKeys(entries.rules) {
+			field, err := v.checkPolicyEntry(key, entries.rules[key])
+			if err != nil {
+				return err
+			}
+			if _, exists := overrides[field]; exists {
+				return fmt.Errorf("validation policy sets %s in both rules and replace", key)
+			}
+			overrides[field] = policyOverride{rules: strings.TrimSpace(entries.rules[key]), replace: entries.replace}
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.policy = overrides
+	v.publish()
+	return nil
+}
+
+// LoadPolicy applies a policy to the default validator
+func LoadPolicy(policy Policy) error {
+	return defaultValidator.LoadPolicy(policy)
+}
+
+// checkPolicyEntry parses a "Type.Field" key and checks its rules
+func (v *Validator) checkPolicyEntry(key, rules string) (policyField, error) {
+	typeName, field, ok := strings.Cut(key, ".")
+	if !ok || !isFieldPath(typeName) || !isFieldPath(field) || strings.Contains(field, ".") {
+		return policyField{}, fmt.Errorf("validation policy field %q must be Type.Field", key)
+	}
+
+	current := v.current()
+	for _, rule := range SplitRules(rules) {
+		rule, _, _ = cutScenarios(strings.TrimSpace(rule))
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "", "omitempty", "sensitive", "secret", "dive", "unique_db":
+			continue
+		}
+		if _, exists := current.customRules[name]; !exists && !isMapEntryRule(name) {
+			return policyField{}, fmt.Errorf("validation policy field %s: unknown rule %q", key, name)
+		}
+		if err := current.CheckRuleParam(name, param); err != nil {
+			return policyField{}, fmt.Errorf("%w in validation policy field %s: %s: %v", ErrInvalidRuleParam, key, rule, err)
+		}
+	}
+	return policyField{typeName: typeName, field: field}, nil
+}
+
+// fieldTag returns the rules of a field of typ: its tag with any policy
+// override applied
+func (v *Validator) fieldTag(typ reflect.Type, field reflect.StructField) string {
+	tag := field.Tag.Get(v.tagName)
+	if len(v.policy) == 0 {
+		return tag
+	}
+	override, ok := v.policy[policyField{typeName: typ.Name(), field: field.Name}]
+	if !ok {
+		return tag
+	}
+	if override.replace || tag == "" || tag == "-" {
+		return override.rules
+	}
+	return mergeRules(tag, override.rules)
+}
+
+// mergeRules replaces the rules of tag named in override, keeping their
+// position, and appends the other override rules. Rules limited to
+// different scenarios are distinct. Each side of dive merges on its own:
+// override rules before its dive merge into the rules before the tag's dive,
+// and those after it into the rules after.
+func mergeRules(tag, override string) string {
+	fieldRules, elemRules, tagDive := cutDive(SplitRules(tag))
+	fieldOverride, elemOverride, overrideDive := cutDive(SplitRules(override))
+
+	rules := mergeRuleList(fieldRules, fieldOverride)
+	if tagDive || overrideDive {
+		rules = append(rules, "dive")
+		rules = append(rules, mergeRuleList(elemRules, elemOverride)...)
+	}
+	return strings.Join(rules, ",")
+}
+
+// cutDive splits rules at the first dive
+func cutDive(rules []string) (before, after []string, found bool) {
+	for i, rule := range rules {
+		if strings.TrimSpace(rule) == "dive" {
+			return rules[:i], rules[i+1:], true
+		}
+	}
+	return rules, nil, false
+}
+
+// mergeRuleList merges override into rules as mergeRules does for a tag
+// without dive
+func mergeRuleList(rules, override []string) []string {
+	merged := append([]string(nil), rules...)
+	index := make(map[string]int, len(merged))
+	for i, rule := range merged {
+		index[ruleKey(rule)] = i
+	}
+	for _, rule := range override {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if i, exists := index[ruleKey(rule)]; exists {
+			merged[i] = rule
+			continue
+		}
+		index[ruleKey(rule)] = len(merged)
+		merged = append(merged, rule)
+	}
+	return merged
+}
+
+// ruleKey identifies a rule by its name and scenarios
+func ruleKey(rule string) string {
+	rule, scenarios, _ := cutScenarios(strings.TrimSpace(rule))
+	name, _, _ := strings.Cut(rule, "=")
+	return name + "@" + scenarios
+}
+
+// policyKeys returns the field keys of policy entries in order
+func policyKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// Parsers matching net.ParseIP, net.ParseCIDR, net.ParseMAC and uuid.Parse
+// without their packages, used by the lite build. The default build keeps
+// using the standard implementations; tests check that both agree.
+
+// portableIPVersion returns 4 or 6 for a valid IP address, IPv4-mapped IPv6
+// addresses counting as IPv4, and 0 otherwise
+func portableIPVersion(value string) int {
+	addr, err := netip.ParseAddr(value)
+	switch {
+	case err != nil || addr.Zone() != "":
+		return 0
+	case addr.Is4() || addr.Is4In6():
+		return 4
+	default:
+		return 6
+	}
+}
+
+// portableCIDR reports whether value is an IP prefix in CIDR notation
+func portableCIDR(value string) bool {
+	addr, bits, found := strings.Cut(value, "/")
+	if !found || portableIPVersion(addr) == 0 || bits == "" {
+		return false
+	}
+	max := 128
+	if !strings.Contains(addr, ":") {
+		max = 32
+	}
+	n := 0
+	for i := 0; i < len(bits); i++ {
+		if !isDigitByte(bits[i]) {
+			return false
+		}
+		if n = n*10 + int(bits[i]-'0'); n > max {
+			return false
+		}
+	}
+	return true
+}
+
+// portableMAC reports whether value is a link-layer address of 6, 8 or 20
+// octets written as colon or dash separated pairs, dot separated quads or
+// bare hex digits
+func portableMAC(value string) bool {
+	if len(value) < 12 {
+		return false
+	}
+	var groups, width int
+	var sep byte
+	switch {
+	case value[2] == ':' || value[2] == '-':
+		if (len(value)+1)%3 != 0 {
+			return false
+		}
+		groups, width, sep = (len(value)+1)/3, 2, value[2]
+	case value[4] == '.':
+		if (len(value)+1)%5 != 0 {
+			return false
+		}
+		groups, width, sep = (len(value)+1)/5, 4, '.'
+	default:
+		if len(value)%2 != 0 {
+			return false
+		}
+		groups, width = 1, len(value)
+	}
+	if octets := groups * width / 2; octets != 6 && octets != 8 && octets != 20 {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if (i+1)%(width+1) == 0 {
+			if value[i] != sep {
+				return false
+			}
+		} else if !isHexByte(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// portableUUIDVersion returns the version of a UUID written with dashes,
+// optionally in braces or with a urn:uuid: prefix, or as 32 hex digits
+func portableUUIDVersion(value string) (int, bool) {
+	switch len(value) {
+	case 36:
+	case 38:
+		// uuid.Parse does not check the braces themselves
+		value = value[1:37]
+	case 45:
+		if !strings.EqualFold(value[:9], "urn:uuid:") {
+			return 0, false
+		}
+		value = value[9:]
+	case 32:
+		for i := 0; i < len(value); i++ {
+			if !isHexByte(value[i]) {
+				return 0, false
+			}
+		}
+		return int(hexValue(value[12])), true
+	default:
+		return 0, false
+	}
+
+	for i := 0; i < len(value); i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if value[i] != '-' {
+				return 0, false
+			}
+		} else if !isHexByte(value[i]) {
+			return 0, false
+		}
+	}
+	return int(hexValue(value[14])), true
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexByte(c byte) bool {
+	return isDigitByte(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// hexValue returns the value of a hex digit
+func hexValue(c byte) byte {
+	switch {
+	case isDigitByte(c):
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
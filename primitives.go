@@ -328,7 +328,7 @@ var (
 
 // validateStringEmail validates email format
 func validateStringEmail(fieldName string, value string, _ string) error {
-	if !emailRegex.MatchString(value) {
+	if len(value) > maxEmailLength || !emailRegex.MatchString(value) {
 		return fmt.Errorf("field '%s' must be a valid email address", fieldName)
 	}
 	return nil
@@ -336,7 +336,7 @@ func validateStringEmail(fieldName string, value string, _ string) error {
 
 // validateStringURL validates URL format
 func validateStringURL(fieldName string, value string, _ string) error {
-	if !urlRegex.MatchString(value) {
+	if len(value) > maxURLLength || !urlRegex.MatchString(value) {
 		return fmt.Errorf("field '%s' must be a valid URL", fieldName)
 	}
 	return nil
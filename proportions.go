@@ -0,0 +1,144 @@
+package validation
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// sumTolerance is the relative error allowed by sum_to, so float weights
+// such as 0.1, 0.2 and 0.7 sum to 1
+const sumTolerance = 1e-9
+
+// numberValue returns the value of a number, or of a string holding one
+func numberValue(field reflect.Value) (float64, bool) {
+	field = indirectValue(field)
+	if !field.IsValid() {
+		return 0, false
+	}
+	var f float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = field.Float()
+	case reflect.String:
+		var err error
+		if f, err = strconv.ParseFloat(strings.TrimSpace(field.String()), 64); err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// isPercent validates that a number is between 0 and 100
+func isPercent(fl FieldLevel) bool {
+	f, ok := numberValue(fl.Field())
+	return ok && f >= 0 && f <= 100
+}
+
+// isRatio validates that a number, such as a probability, is between 0 and 1
+func isRatio(fl FieldLevel) bool {
+	f, ok := numberValue(fl.Field())
+	return ok && f >= 0 && f <= 1
+}
+
+// hasSumTo validates that numbers add up to the first word of the param,
+// e.g. traffic weights to 100. It sums the elements of a slice, array or
+// map, or the numeric fields of a struct; the Go field names following the
+// total, as in sum_to=100 Weight, pick the fields summed in each struct.
+func hasSumTo(fl FieldLevel) bool {
+	params := strings.Fields(fl.Param())
+	if len(params) == 0 {
+		return false
+	}
+	total, err := strconv.ParseFloat(params[0], 64)
+	if err != nil || math.IsNaN(total) || math.IsInf(total, 0) {
+		return false
+	}
+	sum, ok := sumOf(fl.Field(), params[1:])
+	return ok && math.Abs(sum-total) <= sumTolerance*math.Max(1, math.Abs(total))
+}
+
+// sumOf adds up the numbers of a value for sum_to
+func sumOf(value reflect.Value, fields []string) (float64, bool) {
+	value = indirectValue(value)
+	if !value.IsValid() {
+		return 0, false
+	}
+
+	var sum float64
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			n, ok := sumOf(value.Index(i), fields)
+			if !ok {
+				return 0, false
+			}
+			sum += n
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			n, ok := sumOf(iter.Value(), fields)
+			if !ok {
+				return 0, false
+			}
+			sum += n
+		}
+	case reflect.Struct:
+		if len(fields) > 0 {
+			for _, name := range fields {
+				field := value.FieldByName(name)
+				sf, found := value.Type().FieldByName(name)
+				if !field.IsValid() || !found || !sf.IsExported() {
+					return 0, false
+				}
+				n, ok := numberValue(field)
+				if !ok {
+					return 0, false
+				}
+				sum += n
+			}
+			return sum, true
+		}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !value.Type().Field(i).IsExported() || !isNumberKind(field.Type()) {
+				continue
+			}
+			if field.Kind() == reflect.Ptr && field.IsNil() {
+				// An unset weight adds nothing
+				continue
+			}
+			n, ok := numberValue(field)
+			if !ok {
+				return 0, false
+			}
+			sum += n
+		}
+	default:
+		return numberValue(value)
+	}
+	return sum, true
+}
+
+// isNumberKind reports whether values of typ, or of the type it points to,
+// are numbers
+func isNumberKind(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package validation
+
+// RuleFilter decides per call whether a rule is evaluated, given its name
+// and the groups it was registered in. Rules it rejects are skipped and pass.
+type RuleFilter func(rule string, groups []string) bool
+
+// RegisterRuleGroup adds rules to a named group, such as internal_only, so
+// calls can skip them together with WithoutRuleGroups. A rule may belong to
+// several groups.
+func (v *Validator) RegisterRuleGroup(group string, rules ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	ruleGroups := make(map[string][]string, len(v.ruleGroups)+len(rules))
+	for rule, groups := range v.ruleGroups {
+		ruleGroups[rule] = groups
+	}
+	for _, rule := range rules {
+		groups := ruleGroups[rule]
+		if !containsString(groups, group) {
+			groups = append(groups[:len(groups):len(groups)], group)
+		}
+		ruleGroups[rule] = groups
+	}
+
+	v.ruleGroups = ruleGroups
+	v.publish()
+}
+
+// RegisterRuleGroup adds rules to a group on the default validator
+func RegisterRuleGroup(group string, rules ...string) {
+	defaultValidator.RegisterRuleGroup(group, rules...)
+}
+
+// WithRuleFilter evaluates only the rules filter accepts for this call.
+// Filters combine with those of earlier options: a rule runs only when all
+// of them accept it.
+func WithRuleFilter(filter RuleFilter) ValidateOption {
+	return func(config *ValidatorConfig) {
+		config.RuleFilter = combineRuleFilters(config.RuleFilter, filter)
+	}
+}
+
+// WithoutRuleGroups skips the rules registered in any of groups for this
+// call, e.g. internal_only rules when validating public API input
+func WithoutRuleGroups(groups ...string) ValidateOption {
+	return WithRuleFilter(func(_ string, ruleGroups []string) bool {
+		for _, group := range groups {
+			if containsString(ruleGroups, group) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// combineRuleFilters returns a filter accepting what both filters accept
+func combineRuleFilters(first, second RuleFilter) RuleFilter {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+	return func(rule string, groups []string) bool {
+		return first(rule, groups) && second(rule, groups)
+	}
+}
+
+// ruleSkipped reports whether the call's RuleFilter rejects a rule
+func (v *Validator) ruleSkipped(fieldName, ruleName string) bool {
+	if v.config.RuleFilter == nil || v.config.RuleFilter(ruleName, v.ruleGroups[ruleName]) {
+		return false
+	}
+	if v.config.Debug {
+		v.debugSkip(fieldName, ruleName, "rejected by rule filter")
+	}
+	return true
+}
+
+// containsString reports whether values include value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
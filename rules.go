@@ -0,0 +1,258 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RuleInfo describes a rule a validator knows, for documentation, linting
+// and listing tools
+type RuleInfo struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Param       *ParamSchema `json:"param,omitempty"`      // nil when no schema is registered
+	Categories  []string     `json:"categories,omitempty"` // built-in category, then the groups it was registered in
+	Builtin     bool         `json:"builtin,omitempty"`
+	Generated   bool         `json:"generated,omitempty"` // configvalidator emits reflection-free code rather than calling Var
+}
+
+// ruleDoc is the documentation of a built-in rule
+type ruleDoc struct {
+	category    string
+	description string
+}
+
+// builtinRuleDocs documents the built-in rules, grouped as in
+// registerBuiltInRules. The tags handled by the validator itself rather than
+// a registered function are listed too.
+var builtinRuleDocs = map[string]ruleDoc{
+	// Basic validation rules
+	"required":  {"basic", "value is not empty or the zero value"},
+	"defined":   {"basic", "pointer, interface, slice, map, channel or func is not nil"},
+	"omitempty": {"basic", "skips the other rules when the value is empty"},
+	"boolean":   {"basic", "bool, or a string strconv.ParseBool accepts"},
+	"sensitive": {"basic", "redacts the value in errors"},
+	"secret":    {"basic", "validates the secret a reference such as env://NAME resolves to"},
+
+	// String validation rules
+	"min":      {"string", "at least the parameter: characters of a string, items of a collection, or a number"},
+	"max":      {"string", "at most the parameter: characters of a string, items of a collection, or a number"},
+	"len":      {"string", "exactly the parameter in characters, items or value"},
+	"minbytes": {"string", "string of at least the parameter in bytes"},
+	"maxbytes": {"string", "string of at most the parameter in bytes"},
+	"minrunes": {"string", "string of at least the parameter in runes"},
+	"maxrunes": {"string", "string of at most the parameter in runes"},
+	"eq":       {"string", "equal to the parameter"},
+	"ne":       {"string", "not equal to the parameter"},
+	"oneof":    {"string", "one of the space-separated values"},
+	"typeof":   {"string", "dynamic type or kind is one of the |-separated names"},
+
+	// Collection rules
+	"minitems":    {"collection", "slice, array or map of at least the parameter in items"},
+	"maxitems":    {"collection", "slice, array or map of at most the parameter in items"},
+	"notempty":    {"collection", "slice, array or map with at least one item"},
+	"sorted":      {"collection", "slice or array in ascending order"},
+	"sorted_desc": {"collection", "slice or array in descending order"},
+	"haskeys":     {"collection", "map containing every listed key"},
+	"allowedkeys": {"collection", "map containing only listed keys"},
+
+	// String format rules
+	"alpha":                {"format", "letters only"},
+	"alphanum":             {"format", "letters and digits only"},
+	"numeric":              {"format", "digits only"},
+	"email":                {"format", "email address"},
+	"email_not_disposable": {"format", "email address whose domain does not hand out disposable addresses"},
+	"email_mx":             {"network", "email address whose domain has MX records"},
+	"username":             {"format", "username under the validator's policy, not a reserved name"},
+	"url":                  {"format", "absolute URL"},
+	"uri":                  {"format", "URI"},
+	"url_scheme":           {"format", "URL with one of the listed schemes"},
+	"url_host_in":          {"format", "URL whose host is one of the listed hosts or subdomains"},
+	"url_no_userinfo":      {"format", "URL without credentials"},
+	"url_no_fragment":      {"format", "URL without a fragment"},
+
+	// Network validation rules
+	"ip":                 {"network", "IPv4 or IPv6 address"},
+	"ipv4":               {"network", "IPv4 address"},
+	"ipv6":               {"network", "IPv6 address"},
+	"cidr":               {"network", "CIDR network such as 10.0.0.0/8"},
+	"mac":                {"network", "MAC address"},
+	"hostname":           {"network", "RFC 1123 hostname"},
+	"domain":             {"network", "registrable domain such as example.co.uk"},
+	"tld":                {"network", "top-level domain such as com"},
+	"fqdn_public_suffix": {"network", "hostname under a public suffix that is not a suffix itself"},
+
+	// UUID validation
+	"uuid":  {"format", "UUID"},
+	"uuid4": {"format", "version 4 UUID"},
+
+	// Date/time validation
+	"datetime":       {"time", "RFC 3339 date and time"},
+	"date":           {"time", "date such as 2006-01-02"},
+	"time":           {"time", "time of day such as 15:04:05"},
+	"expires_after":  {"time", "time later than the duration from now"},
+	"not_before_now": {"time", "time that has passed"},
+	"within":         {"time", "time no further than the duration from now"},
+	"timezone":       {"time", "IANA time zone name such as Europe/Berlin"},
+
+	// Locale rules
+	"locale":                  {"locale", "BCP 47 language tag such as en-US"},
+	"currency_matches_locale": {"locale", "currency used in the country of the locale field"},
+
+	// Other format validation
+	"json":                     {"format", "JSON document"},
+	"jsonschema":               {"format", "JSON document matching the registered schema"},
+	"notcontains_blocklist":    {"security", "text without a word of the registered blocklist"},
+	"no_html":                  {"security", "text without HTML tags or entities"},
+	"no_script":                {"security", "text without scripts, event handlers or script URLs"},
+	"safe_text":                {"security", "UTF-8 text without markup or unsafe characters"},
+	"safe_path":                {"security", "path that cannot escape its base directory"},
+	"no_shell_meta":            {"security", "text without shell metacharacters"},
+	"sql_identifier":           {"security", "SQL identifier that is not a reserved word"},
+	"sql_like_escape_required": {"security", "LIKE pattern whose wildcards are escaped"},
+	"base64":                   {"format", "standard base64"},
+	"creditcard":               {"format", "credit card number passing the Luhn check"},
+	"phone":                    {"format", "phone number"},
+
+	// Kubernetes object rules
+	"k8s_name":        {"kubernetes", "object name: a DNS-1123 subdomain, or label"},
+	"k8s_label_key":   {"kubernetes", "label or annotation key"},
+	"k8s_label_value": {"kubernetes", "label value"},
+	"k8s_qty":         {"kubernetes", "resource quantity such as 500m or 1.5Gi"},
+	"k8s_selector":    {"kubernetes", "label selector"},
+
+	// Container image rules
+	"image_ref":     {"container", "image reference such as registry.example.com/app:1.2"},
+	"image_tag":     {"container", "image tag"},
+	"sha256_digest": {"container", "sha256 content digest"},
+
+	// Git repository rules
+	"git_url":     {"git", "remote repository URL or scp-like location"},
+	"ssh_url":     {"git", "ssh:// URL"},
+	"commit_sha":  {"git", "full or abbreviated commit SHA"},
+	"branch_name": {"git", "branch name git check-ref-format accepts"},
+
+	// Check digit rules
+	"luhn":   {"checksum", "number passing the Luhn check"},
+	"imei":   {"checksum", "IMEI"},
+	"ean13":  {"checksum", "EAN-13 barcode"},
+	"gtin":   {"checksum", "GTIN-8, 12, 13 or 14"},
+	"isbn13": {"checksum", "ISBN-13"},
+	"isbn10": {"checksum", "ISBN-10"},
+	"vin":    {"checksum", "vehicle identification number"},
+
+	// Proportion rules
+	"percent": {"number", "number between 0 and 100"},
+	"ratio":   {"number", "number between 0 and 1"},
+	"sum_to":  {"number", "numbers of a collection or struct adding up to the parameter"},
+
+	// Monetary rules
+	"decimal":     {"money", "amount within the integer and fractional digits"},
+	"positive":    {"money", "number above zero"},
+	"nonnegative": {"money", "number of zero or more"},
+	"minor_units": {"money", "amount with no more decimals than the currency field allows"},
+
+	// Uploaded file rules
+	"file_ext":      {"file", "file name with one of the listed extensions"},
+	"min_file_size": {"file", "uploaded file of at least the size"},
+	"max_file_size": {"file", "uploaded file of at most the size"},
+
+	// Cross-field validation
+	"eqfield":  {"crossfield", "equal to the field"},
+	"nefield":  {"crossfield", "different from the field"},
+	"gtfield":  {"crossfield", "greater than the field"},
+	"gtefiled": {"crossfield", "at least the field"},
+	"ltfield":  {"crossfield", "less than the field"},
+	"ltefield": {"crossfield", "at most the field"},
+
+	// Conditional validation
+	"required_if":      {"conditional", "required when the field has the value"},
+	"required_unless":  {"conditional", "required unless the field has the value"},
+	"required_with":    {"conditional", "required when the field is set"},
+	"required_without": {"conditional", "required when the field is empty"},
+
+	// Compared by StructUpdate, or resolved after the field rules
+	"immutable": {"update", "unchanged between the old and new struct in StructUpdate"},
+	"unique_db": {"database", "not yet taken, as reported by the named unique resolver"},
+}
+
+// generatedRules are the rules configvalidator turns into reflection-free
+// code; it generates validation.Var calls for the others. With -pure it also
+// inlines a few more format checks.
+var generatedRules = map[string]bool{
+	"required": true, "min": true, "max": true, "len": true,
+	"minbytes": true, "maxbytes": true, "minrunes": true, "maxrunes": true,
+	"minitems": true, "maxitems": true, "notempty": true,
+	"email": true, "url": true, "uri": true, "ip": true,
+	"oneof": true, "alpha": true, "numeric": true,
+}
+
+// DescribeRule documents a registered rule for Rules, e.g. for generated
+// documentation of custom rules
+func (v *Validator) DescribeRule(tag, description string) error {
+	if tag == "" {
+		return fmt.Errorf("validation tag cannot be empty")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	ruleDescriptions := make(map[string]string, len(v.ruleDescriptions)+1)
+	for name, existing := range v.ruleDescriptions {
+		ruleDescriptions[name] = existing
+	}
+	ruleDescriptions[tag] = description
+
+	v.ruleDescriptions = ruleDescriptions
+	v.publish()
+	return nil
+}
+
+// DescribeRule documents a rule of the default validator
+func DescribeRule(tag, description string) error {
+	return defaultValidator.DescribeRule(tag, description)
+}
+
+// Rules lists the rules the validator knows, sorted by name: the built-in
+// rules and those added with RegisterValidation, with their parameter
+// schemas and categories
+func (v *Validator) Rules() []RuleInfo {
+	v = v.current()
+
+	names := make(map[string]bool, len(v.customRules)+len(builtinRuleDocs))
+	for name := range v.customRules {
+		names[name] = true
+	}
+	for name := range builtinRuleDocs {
+		names[name] = true
+	}
+
+	rules := make([]RuleInfo, 0, len(names))
+	for name := range names {
+		info := RuleInfo{Name: name, Generated: generatedRules[name]}
+		if doc, ok := builtinRuleDocs[name]; ok {
+			info.Builtin = true
+			info.Description = doc.description
+			info.Categories = []string{doc.category}
+		}
+		if description, ok := v.ruleDescriptions[name]; ok {
+			info.Description = description
+		}
+		if schema, ok := v.paramSchemas[name]; ok {
+			info.Param = &schema
+		}
+		for _, group := range v.ruleGroups[name] {
+			if !containsString(info.Categories, group) {
+				info.Categories = append(info.Categories, group)
+			}
+		}
+		rules = append(rules, info)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// Rules lists the rules of the default validator
+func Rules() []RuleInfo {
+	return defaultValidator.Rules()
+}
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -255,4 +256,87 @@ func checkRange(value, min, max int64) error {
 		return fmt.Errorf("value %d out of range [%d, %d]", value, min, max)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Column validators must flag exactly the rows their row validators reject
+func TestColumnValidators(t *testing.T) {
+	emails := []string{"user@example.com", "bad", "a.b+c@sub.example.org", "x@-bad.com", "", "ünï@example.com"}
+	names := []string{"Hello", "héllo", "hello1", "", "Straße", "日本"}
+	colors := []string{"red", "green", "mauve", "blue"}
+	palette := []string{"red", "green", "blue", "cyan", "magenta", "yellow", "black", "white", "orange", "purple"}
+
+	checkStrings := func(name string, validator ColumnValidator[string], column []string) {
+		t.Run(name, func(t *testing.T) {
+			var want []int
+			for i, value := range column {
+				if validator.Validate("field", value) != nil {
+					want = append(want, i)
+				}
+			}
+			if got := validator.ValidateColumn(column); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected rows %v, got %v", want, got)
+			}
+		})
+	}
+	checkStrings("Email", Email(), emails)
+	checkStrings("Alpha", NewCharacteristic(RuleStaticAlpha), names)
+	checkStrings("AlphaNumeric", NewCharacteristic(RuleStaticAlphaNumeric), names)
+	checkStrings("Numeric", NewCharacteristic(RuleStaticNumeric), []string{"123", "12a", "١٢٣", ""})
+	checkStrings("MinLen", NewStringLength("minlen", 3, 0), colors)
+	checkStrings("Range", NewStringLength("range", 3, 4), colors)
+	checkStrings("OneOf", NewOneOf([]string{"red", "blue"}), colors)
+	checkStrings("OneOfSet", NewOneOf(palette), colors)
+
+	ages := []int64{17, 18, 65, 66, 40}
+	if got := NewNumericRange[int64](18, 65).ValidateColumn(ages); fmt.Sprint(got) != "[0 3]" {
+		t.Errorf("expected rows [0 3], got %v", got)
+	}
+
+	err := ValidateColumn("email", emails, Email(), NewStringLength("maxlen", 0, 16))
+	var columnErr *ColumnError
+	if !errors.As(err, &columnErr) || columnErr.Field != "email" || columnErr.Rule != "email" || fmt.Sprint(columnErr.Rows) != "[1 3 4 5]" {
+		t.Errorf("unexpected column error: %v", err)
+	}
+	if err := ValidateColumn("email", []string{"user@example.com"}, ColumnValidator[string](Email())); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// Benchmark column validation against the equivalent per-row calls
+func BenchmarkColumnValidation(b *testing.B) {
+	emails := make([]string, 10000)
+	ages := make([]int64, len(emails))
+	for i := range emails {
+		emails[i] = fmt.Sprintf("user%d@example.com", i)
+		ages[i] = int64(i % 100)
+	}
+
+	b.Run("Email/PerRow", func(b *testing.B) {
+		validator := Email()
+		for i := 0; i < b.N; i++ {
+			for _, email := range emails {
+				_ = validator.Validate("email", email)
+			}
+		}
+	})
+	b.Run("Email/Column", func(b *testing.B) {
+		validator := Email()
+		for i := 0; i < b.N; i++ {
+			_ = validator.ValidateColumn(emails)
+		}
+	})
+	b.Run("Range/PerRow", func(b *testing.B) {
+		validator := NewNumericRange[int64](0, 1000)
+		for i := 0; i < b.N; i++ {
+			for _, age := range ages {
+				_ = validator.Validate("age", age)
+			}
+		}
+	})
+	b.Run("Range/Column", func(b *testing.B) {
+		validator := NewNumericRange[int64](0, 1000)
+		for i := 0; i < b.N; i++ {
+			_ = validator.ValidateColumn(ages)
+		}
+	})
+}
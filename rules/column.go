@@ -0,0 +1,270 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ColumnValidator validates a whole column of values at once, as read from
+// Arrow or Parquet batches, instead of one row at a time
+type ColumnValidator[T any] interface {
+	Validator[T]
+	// ValidateColumn returns the indexes of the rows that fail the rule,
+	// or nil when all pass
+	ValidateColumn(column []T) []int
+}
+
+// ColumnError reports the rows of a column that failed a rule
+type ColumnError struct {
+	Field string
+	Rule  string
+	Rows  []int
+}
+
+func (e *ColumnError) Error() string {
+	return fmt.Sprintf("field '%s' failed %s in %d rows, first at row %d", e.Field, e.Rule, len(e.Rows), e.Rows[0])
+}
+
+// ValidateColumn applies every validator to a column and returns a
+// *ColumnError for each rule that some rows failed, joined with errors.Join
+func ValidateColumn[T any](field string, column []T, validators ...ColumnValidator[T]) error {
+	var errs []error
+	for _, validator := range validators {
+		if rows := validator.ValidateColumn(column); len(rows) > 0 {
+			errs = append(errs, &ColumnError{Field: field, Rule: validator.String(), Rows: rows})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateColumn returns the rows outside the range
+func (r *NumericRange[T]) ValidateColumn(column []T) []int {
+	var rows []int
+	low, high := r.min, r.max
+	for i, value := range column {
+		if value < low || value > high {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// ValidateColumn returns the rows whose length fails the constraint
+func (v *StringLengthValidator) ValidateColumn(column []string) []int {
+	low, high := 0, int(^uint(0)>>1)
+	switch v.op {
+	case "minlen":
+		low = v.min
+	case "maxlen":
+		high = v.max
+	case "len":
+		low, high = v.min, v.min
+	case "range":
+		low, high = v.min, v.max
+	}
+
+	var rows []int
+	for i, value := range column {
+		if n := len(value); n < low || n > high {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// asciiClass marks the ASCII bytes accepted by a characteristic
+type asciiClass [utf8.RuneSelf]bool
+
+// asciiClasses are the ASCII bytes accepted by each characteristic; other
+// bytes start a multi-byte rune checked with the unicode package
+var asciiClasses = map[RuleCharacteristic]*asciiClass{
+	RuleStaticAlpha:        newASCIIClass(func(c byte) bool { return isASCIILetter(c) }),
+	RuleStaticAlphaNumeric: newASCIIClass(func(c byte) bool { return isASCIILetter(c) || isASCIIDigit(c) }),
+	RuleStaticNumeric:      newASCIIClass(isASCIIDigit),
+}
+
+// ValidateColumn returns the rows containing a character outside the
+// characteristic, scanning ASCII bytes through a lookup table
+func (v *CharacteristicValidator) ValidateColumn(column []string) []int {
+	class := asciiClasses[v.characteristic]
+	if class == nil {
+		return nil
+	}
+
+	var rows []int
+	for i, value := range column {
+		if !class.matches(value, v.characteristic) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// matches reports whether every character of value belongs to the class
+func (c *asciiClass) matches(value string, characteristic RuleCharacteristic) bool {
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		if b < utf8.RuneSelf {
+			if !c[b] {
+				return false
+			}
+			continue
+		}
+		// Multi-byte runes are rare in most columns; check them like Validate
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if !matchesRune(r, characteristic) {
+			return false
+		}
+		i += size - 1
+	}
+	return true
+}
+
+// matchesRune reports whether a non-ASCII rune belongs to a characteristic
+func matchesRune(r rune, characteristic RuleCharacteristic) bool {
+	switch characteristic {
+	case RuleStaticAlpha:
+		return unicode.IsLetter(r)
+	case RuleStaticAlphaNumeric:
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	case RuleStaticNumeric:
+		return unicode.IsDigit(r)
+	}
+	return false
+}
+
+// ValidateColumn returns the rows whose value is not allowed. Longer lists
+// are looked up in a set built once per column.
+func (v *OneOfValidator) ValidateColumn(column []string) []int {
+	var rows []int
+	if len(v.allowedValues) <= 8 {
+		for i, value := range column {
+			if !v.allows(value) {
+				rows = append(rows, i)
+			}
+		}
+		return rows
+	}
+
+	allowed := make(map[string]struct{}, len(v.allowedValues))
+	for _, value := range v.allowedValues {
+		allowed[value] = struct{}{}
+	}
+	for i, value := range column {
+		if _, ok := allowed[value]; !ok {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// allows reports whether value is one of the allowed values
+func (v *OneOfValidator) allows(value string) bool {
+	for _, allowed := range v.allowedValues {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailValidator validates email addresses like the email tag of the
+// validation package: an RFC 5322 atext local part of up to 64 bytes, an @
+// and a hostname, at most 254 bytes in all
+type EmailValidator struct{}
+
+// Email returns an email address validator
+func Email() *EmailValidator {
+	return &EmailValidator{}
+}
+
+func (v *EmailValidator) Validate(field string, value string) error {
+	if !validEmail(value) {
+		return fmt.Errorf("field '%s' must be a valid email address", field)
+	}
+	return nil
+}
+
+func (v *EmailValidator) String() string {
+	return string(RuleStaticEmail)
+}
+
+// ValidateColumn returns the rows that are not valid email addresses
+func (v *EmailValidator) ValidateColumn(column []string) []int {
+	var rows []int
+	for i, value := range column {
+		if !validEmail(value) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// EmailFactory creates email validators
+func EmailFactory(ruleString string) (Validator[string], error) {
+	if _, _, err := ParseRuleString(ruleString); err != nil {
+		return nil, err
+	}
+	return Email(), nil
+}
+
+// emailLocalChars are the bytes allowed in the local part of an address
+var emailLocalChars = newASCIIClass(func(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c) || strings.IndexByte(".!#$%&'*+/=?^_`{|}~-", c) >= 0
+})
+
+// hostnameChars are the bytes allowed in a hostname label
+var hostnameChars = newASCIIClass(func(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c) || c == '-'
+})
+
+// validEmail checks an address without allocating
+func validEmail(value string) bool {
+	if len(value) > 254 {
+		return false
+	}
+	local, domain, found := strings.Cut(value, "@")
+	if !found || len(local) == 0 || len(local) > 64 || len(domain) > 253 {
+		return false
+	}
+	for i := 0; i < len(local); i++ {
+		if c := local[i]; c >= utf8.RuneSelf || !emailLocalChars[c] {
+			return false
+		}
+	}
+	for {
+		label, rest, more := strings.Cut(domain, ".")
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; c >= utf8.RuneSelf || !hostnameChars[c] {
+				return false
+			}
+		}
+		if !more {
+			return true
+		}
+		domain = rest
+	}
+}
+
+// newASCIIClass returns the table of ASCII bytes accepted by match
+func newASCIIClass(match func(byte) bool) *asciiClass {
+	var class asciiClass
+	for c := 0; c < utf8.RuneSelf; c++ {
+		class[c] = match(byte(c))
+	}
+	return &class
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -31,6 +31,7 @@ func init() {
 	
 	// Register string format rules
 	RegisterRule("oneof", OneOfFactory)
+	RegisterRule("email", EmailFactory)
 	
 	// Register rule groups
 	RegisterRuleGroup(GroupNumeric, "range_int")
@@ -46,6 +47,7 @@ func init() {
 	RegisterRuleGroup(GroupString, "numeric")
 	
 	RegisterRuleGroup(GroupFormat, "oneof")
+	RegisterRuleGroup(GroupFormat, "email")
 }
 
 // GetRuleForType automatically selects the appropriate rule factory based on the value type
@@ -0,0 +1,69 @@
+package validation
+
+import "strings"
+
+// scenarioTag returns the rules of tag that apply to the call's scenario.
+// A rule suffixed with @name, or @name1|name2, applies only while one of the
+// named scenarios is selected and loses its suffix; rules without a suffix
+// always apply. Without a selected scenario only the unsuffixed rules apply.
+func (v *Validator) scenarioTag(tag string) string {
+	if !strings.Contains(tag, "@") {
+		return tag
+	}
+
+	rules := SplitRules(tag)
+	kept := rules[:0]
+	for _, rule := range rules {
+		rule, scenarios, ok := cutScenarios(strings.TrimSpace(rule))
+		if ok && !inScenarios(scenarios, v.config.Scenario) {
+			continue
+		}
+		kept = append(kept, rule)
+	}
+	return strings.Join(kept, ",")
+}
+
+// cutScenarios splits a rule from its @scenario suffix. Only a suffix made of
+// scenario names counts, so parameters such as eq=user@example.com are kept.
+func cutScenarios(rule string) (string, string, bool) {
+	i := strings.LastIndexByte(rule, '@')
+	if i <= 0 || !isScenarioList(rule[i+1:]) {
+		return rule, "", false
+	}
+	return rule[:i], rule[i+1:], true
+}
+
+// isScenarioList reports whether s is a |-separated list of names made of
+// letters, digits, underscores and dashes, each starting with a letter
+func isScenarioList(s string) bool {
+	for _, name := range strings.Split(s, "|") {
+		if name == "" || !isLetter(name[0]) {
+			return false
+		}
+		for i := 1; i < len(name); i++ {
+			c := name[i]
+			if !isLetter(c) && (c < '0' || c > '9') && c != '_' && c != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isLetter reports whether c is an ASCII letter
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// inScenarios reports whether scenario is one of a |-separated list
+func inScenarios(scenarios, scenario string) bool {
+	if scenario == "" {
+		return false
+	}
+	for _, name := range strings.Split(scenarios, "|") {
+		if name == scenario {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces the Value of errors reported for sensitive fields
+const RedactedValue = "[REDACTED]"
+
+// ScrubFunc returns the value to report for a validation error, e.g.
+// RedactedValue for fields holding secrets or a masked form of the original.
+// It is called for every error that is not already redacted.
+type ScrubFunc func(err ValidationError) interface{}
+
+// SetScrubFunc installs a function scrubbing the values of validation errors
+// before they are returned or passed to hooks. Pass nil to remove it.
+// Fields tagged `sensitive` are always redacted.
+func (v *Validator) SetScrubFunc(fn ScrubFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.scrubFunc = fn
+	v.publish()
+}
+
+// SetScrubFunc installs a scrub function on the default validator
+func SetScrubFunc(fn ScrubFunc) {
+	defaultValidator.SetScrubFunc(fn)
+}
+
+// scrub applies the installed ScrubFunc to every collected error
+func (v *Validator) scrub(collector *ErrorCollector) {
+	if v.scrubFunc == nil {
+		return
+	}
+	for i, err := range collector.errors {
+		if value, ok := err.Value.(string); ok && value == RedactedValue {
+			continue
+		}
+		collector.errors[i].Value = v.scrubFunc(err)
+	}
+}
+
+// hasSensitiveTag reports whether a validation tag marks its field
+// sensitive; fields holding secret references are sensitive too
+func hasSensitiveTag(tag string) bool {
+	for _, rule := range SplitRules(tag) {
+		if rule = strings.TrimSpace(rule); rule == "sensitive" || rule == "secret" {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitiveField reports whether the named field of a struct is tagged sensitive
+func (v *Validator) isSensitiveField(parent reflect.Value, name string) bool {
+	parent = indirectValue(parent)
+	if parent.Kind() != reflect.Struct {
+		return false
+	}
+	field, ok := parent.Type().FieldByName(name)
+	return ok && hasSensitiveTag(v.fieldTag(parent.Type(), field))
+}
@@ -0,0 +1,187 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// SecretResolver fetches the secret a reference points to. It receives the
+// reference without its scheme, e.g. DB_PASSWORD for env://DB_PASSWORD or
+// /run/secrets/key for file:///run/secrets/key, and must honor ctx.
+type SecretResolver func(ctx context.Context, ref string) (string, error)
+
+// RegisterSecretResolver registers a resolver for references written
+// scheme://ref, such as a Vault client for vault://secret/data/db#password.
+// Fields tagged `secret` holding such a reference are validated as the
+// resolved value. No scheme is registered by default; see EnvSecretResolver
+// and FileSecretResolver.
+func (v *Validator) RegisterSecretResolver(scheme string, resolver SecretResolver) error {
+	if scheme == "" || resolver == nil {
+		return fmt.Errorf("secret resolver scheme and function are required")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	resolvers := make(map[string]SecretResolver, len(v.secretResolvers)+1)
+	for existing, r := range v.secretResolvers {
+		resolvers[existing] = r
+	}
+	resolvers[scheme] = resolver
+
+	v.secretResolvers = resolvers
+	v.publish()
+	return nil
+}
+
+// RegisterSecretResolver registers a secret resolver on the default validator
+func RegisterSecretResolver(scheme string, resolver SecretResolver) error {
+	return defaultValidator.RegisterSecretResolver(scheme, resolver)
+}
+
+// ResolveSecret returns the secret value refers to with a registered scheme,
+// or value itself when it is not a reference, so applications load the same
+// value their config was validated with
+func (v *Validator) ResolveSecret(ctx context.Context, value string) (string, error) {
+	resolver, ref, ok := v.current().secretResolver(value)
+	if !ok {
+		return value, nil
+	}
+	return callSecretResolver(ctx, resolver, ref)
+}
+
+// ResolveSecret resolves a secret reference with the default validator
+func ResolveSecret(ctx context.Context, value string) (string, error) {
+	return defaultValidator.ResolveSecret(ctx, value)
+}
+
+// secretResolver returns the resolver registered for the scheme of a
+// reference and the reference without its scheme
+func (v *Validator) secretResolver(value string) (SecretResolver, string, bool) {
+	scheme, ref, found := strings.Cut(value, "://")
+	if !found {
+		return nil, "", false
+	}
+	resolver, ok := v.secretResolvers[scheme]
+	return resolver, ref, ok
+}
+
+// resolveSecretValue returns the value a secret field's rules validate: the
+// resolved secret when the field holds a reference, otherwise the field. A
+// reference that cannot be resolved is reported and false returned.
+func (v *Validator) resolveSecretValue(val reflect.Value, fieldName string, collector *ErrorCollector) (reflect.Value, bool) {
+	field := indirectValue(val)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return val, true
+	}
+	resolver, ref, ok := v.secretResolver(field.String())
+	if !ok {
+		return val, true
+	}
+
+	secret, err := callSecretResolver(collector.context(), resolver, ref)
+	if err != nil {
+		// The reference is reported, never the secret; the field is
+		// sensitive, so the error's value is redacted as well
+		collector.Add(ValidationError{
+			Field:   fieldName,
+			Tag:     "secret",
+			Value:   RedactedValue,
+			Message: fmt.Sprintf(ErrorMsgSecretUnresolved, fieldName, field.String(), err),
+			Code:    ErrCodeSecretUnresolved,
+		})
+		return val, false
+	}
+	return reflect.ValueOf(secret), true
+}
+
+// callSecretResolver invokes a resolver, converting a panic into an error
+func callSecretResolver(ctx context.Context, fn SecretResolver, ref string) (secret string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			secret, err = "", fmt.Errorf("%w: %v", errResolverPanic, r)
+		}
+	}()
+	return fn(ctx, ref)
+}
+
+// errSecretNotFound reports a reference naming no secret
+var errSecretNotFound = errors.New("secret not found")
+
+// errSecretOutsideRoot reports a file reference escaping the directory of
+// its FileSecretResolver
+var errSecretOutsideRoot = errors.New("secret file outside the secrets directory")
+
+// EnvSecretResolver reads the environment variable env://NAME names. It is
+// not registered by default: when a secret field is filled from untrusted
+// input, its rules would reveal whether a variable matches them.
+//
+//	validator.RegisterSecretResolver("env", validation.EnvSecretResolver)
+func EnvSecretResolver(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s is not set", errSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// FileSecretResolver returns a resolver reading the files under root that
+// file:///path names, such as Docker or Kubernetes secrets, dropping the
+// trailing newline editors add. Relative paths are taken from root, and paths
+// leaving it, including through symbolic links, are rejected.
+//
+//	validator.RegisterSecretResolver("file", validation.FileSecretResolver("/run/secrets"))
+func FileSecretResolver(root string) SecretResolver {
+	absRoot, rootErr := filepath.Abs(root)
+	return func(ctx context.Context, path string) (string, error) {
+		if rootErr != nil {
+			return "", rootErr
+		}
+		rel := path
+		if filepath.IsAbs(path) {
+			var err error
+			if rel, err = filepath.Rel(absRoot, path); err != nil {
+				return "", err
+			}
+		}
+		if !filepath.IsLocal(rel) {
+			return "", fmt.Errorf("%w: %s is not under %s", errSecretOutsideRoot, path, root)
+		}
+
+		dir, err := os.OpenRoot(absRoot)
+		if err != nil {
+			return "", err
+		}
+		defer dir.Close()
+		file, err := dir.Open(rel)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("%w: file %s does not exist", errSecretNotFound, path)
+			}
+			return "", err
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r"), nil
+	}
+}
+
+// hasSecretTag reports whether a validation tag marks its field as holding
+// secret references
+func hasSecretTag(tag string) bool {
+	for _, rule := range SplitRules(tag) {
+		if strings.TrimSpace(rule) == "secret" {
+			return true
+		}
+	}
+	return false
+}
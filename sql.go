@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// The SQL rules check values interpolated into statements where bind
+// parameters cannot be used, such as ORDER BY columns or dynamic table names,
+// and LIKE patterns built from user input.
+
+// maxSQLIdentifierLength is the longest identifier accepted, PostgreSQL's
+// limit and one below MySQL's
+const maxSQLIdentifierLength = 63
+
+// sqlReservedWords are keywords reserved by the SQL standard and by
+// PostgreSQL or MySQL, which cannot be used as unquoted identifiers
+var sqlReservedWords = map[string]bool{
+	"all": true, "alter": true, "and": true, "any": true, "as": true, "asc": true,
+	"between": true, "by": true, "case": true, "check": true, "column": true,
+	"constraint": true, "create": true, "cross": true, "current_date": true,
+	"current_time": true, "current_timestamp": true, "current_user": true,
+	"default": true, "delete": true, "desc": true, "distinct": true, "drop": true,
+	"else": true, "end": true, "except": true, "exists": true, "false": true,
+	"fetch": true, "for": true, "foreign": true, "from": true, "full": true,
+	"grant": true, "group": true, "having": true, "in": true, "inner": true,
+	"insert": true, "intersect": true, "into": true, "is": true, "join": true,
+	"left": true, "like": true, "limit": true, "not": true, "null": true,
+	"offset": true, "on": true, "or": true, "order": true, "outer": true,
+	"primary": true, "references": true, "right": true, "select": true,
+	"session_user": true, "set": true, "table": true, "then": true, "to": true,
+	"true": true, "union": true, "unique": true, "update": true, "user": true,
+	"using": true, "values": true, "when": true, "where": true, "with": true,
+}
+
+// validSQLName reports whether a name is a valid unquoted identifier: a
+// letter or underscore followed by letters, digits or underscores, that is
+// not a reserved word
+func validSQLName(name string) bool {
+	if name == "" || len(name) > maxSQLIdentifierLength || sqlReservedWords[strings.ToLower(name)] {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateSQLIdentifier checks that a value is an unquoted SQL identifier,
+// such as created_at. mode "qualified" also accepts dotted names such as
+// public.users or users.created_at.
+func ValidateSQLIdentifier(field, value, mode string) error {
+	names := []string{value}
+	if mode == "qualified" {
+		names = strings.Split(value, ".")
+	}
+	for _, name := range names {
+		if !validSQLName(name) {
+			return ValidationError{
+				Field:   field,
+				Tag:     "sql_identifier",
+				Param:   mode,
+				Value:   value,
+				Message: fmt.Sprintf(ErrorMsgSQLIdentifier, field),
+			}
+		}
+	}
+	return nil
+}
+
+// likeEscapeChar returns the escape character of a LIKE pattern, a backslash
+// unless the parameter names another
+func likeEscapeChar(param string) (rune, bool) {
+	if param == "" {
+		return '\\', true
+	}
+	r, size := utf8.DecodeRuneInString(param)
+	return r, size == len(param) && r != utf8.RuneError
+}
+
+// EscapeSQLLike escapes the wildcards % and _ and the escape character in
+// text, so it matches literally in a LIKE pattern using that escape character
+// (ESCAPE '\' for a backslash)
+func EscapeSQLLike(text string, escape rune) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r == '%' || r == '_' || r == escape {
+			b.WriteRune(escape)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// likeEscaped reports whether every wildcard and escape character of a value
+// is escaped
+func likeEscaped(value string, escape rune) bool {
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			if r != '%' && r != '_' && r != escape {
+				return false
+			}
+			escaped = false
+		case r == escape:
+			escaped = true
+		case r == '%' || r == '_':
+			return false
+		}
+	}
+	return !escaped
+}
+
+// isSQLIdentifier validates an unquoted SQL identifier
+func isSQLIdentifier(fl FieldLevel) bool {
+	return ValidateSQLIdentifier(fl.FieldName(), getString(fl.Field()), fl.Param()) == nil
+}
+
+// isSQLLikeEscaped validates a LIKE pattern fragment whose wildcards are
+// escaped with the character given by the parameter, a backslash by default
+func isSQLLikeEscaped(fl FieldLevel) bool {
+	escape, ok := likeEscapeChar(fl.Param())
+	return ok && likeEscaped(getString(fl.Field()), escape)
+}
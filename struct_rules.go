@@ -0,0 +1,193 @@
+package validation
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The struct rules are ready-made struct-level functions for invariants
+// that span fields, named by their Go field names:
+//
+//	validation.RegisterStructValidation(validation.StructRules(
+//		validation.MinMax("MinReplicas", "MaxReplicas"),
+//		validation.TimeWindow("Start", "End"),
+//	), Deployment{})
+//
+// A field that is missing, nil or of an unsupported kind skips the check,
+// leaving presence and format to the field rules, which run afterwards.
+
+// StructRules combines struct-level functions into one, since a type has a
+// single struct-level function
+func StructRules(fns ...StructLevelValidationFunc) StructLevelValidationFunc {
+	return func(sl StructLevel) {
+		for _, fn := range fns {
+			fn(sl)
+		}
+	}
+}
+
+// MinMax requires the min field to be at most the max field. Numbers,
+// durations and times are compared; the error is reported on max.
+func MinMax(min, max string) StructLevelValidationFunc {
+	return func(sl StructLevel) {
+		if order, ok := compareStructFields(sl, min, max); ok && order > 0 {
+			sl.ReportValidationError(ValidationError{StructField: max, Tag: "min_max", Param: min})
+		}
+	}
+}
+
+// TimeWindow requires the start field to be strictly before the end field,
+// skipping unset (zero) times; the error is reported on end
+func TimeWindow(start, end string) StructLevelValidationFunc {
+	return func(sl StructLevel) {
+		s, ok1 := sl.FieldTime(start)
+		e, ok2 := sl.FieldTime(end)
+		if !ok1 || !ok2 || s.IsZero() || e.IsZero() {
+			return
+		}
+		if !s.Before(e) {
+			sl.ReportValidationError(ValidationError{StructField: end, Tag: "time_window", Param: start})
+		}
+	}
+}
+
+// WeightsSum requires the numeric fields to add up to total, within the
+// tolerance of sum_to; nil fields add nothing. The error is reported on the
+// first field.
+func WeightsSum(total float64, fields ...string) StructLevelValidationFunc {
+	param := strings.Join(append([]string{strconv.FormatFloat(total, 'f', -1, 64)}, fields...), " ")
+	return func(sl StructLevel) {
+		if len(fields) == 0 {
+			return
+		}
+		var sum float64
+		for _, name := range fields {
+			field := sl.FieldByName(name)
+			if !field.IsValid() {
+				continue
+			}
+			n, ok := numberValue(field)
+			if !ok {
+				return
+			}
+			sum += n
+		}
+		if math.Abs(sum-total) > sumTolerance*math.Max(1, math.Abs(total)) {
+			sl.ReportValidationError(ValidationError{StructField: fields[0], Tag: "weights_sum", Param: param})
+		}
+	}
+}
+
+// AtLeastOneOf requires at least one of the fields to be set: non-zero, or
+// non-empty for strings, slices and maps. The error is reported on the first
+// field.
+func AtLeastOneOf(fields ...string) StructLevelValidationFunc {
+	param := strings.Join(fields, " ")
+	return func(sl StructLevel) {
+		if len(fields) == 0 {
+			return
+		}
+		for _, name := range fields {
+			if isSetValue(sl.FieldByName(name)) {
+				return
+			}
+		}
+		sl.ReportValidationError(ValidationError{StructField: fields[0], Tag: "at_least_one_of", Param: param})
+	}
+}
+
+// ContrastAtLeast requires the hex colors of the foreground and background
+// fields, such as #1a1a1a and #fff, to have a WCAG 2 contrast ratio of at
+// least ratio, e.g. 4.5 for body text. The error is reported on foreground.
+func ContrastAtLeast(foreground, background string, ratio float64) StructLevelValidationFunc {
+	param := background + " " + strconv.FormatFloat(ratio, 'f', -1, 64)
+	return func(sl StructLevel) {
+		fg, ok1 := sl.FieldString(foreground)
+		bg, ok2 := sl.FieldString(background)
+		if !ok1 || !ok2 {
+			return
+		}
+		if contrast, ok := ContrastRatio(fg, bg); ok && contrast < ratio {
+			sl.ReportValidationError(ValidationError{StructField: foreground, Tag: "contrast", Param: param})
+		}
+	}
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio of two hex colors, from 1
+// for identical colors to 21 for black on white, and false when either is
+// not a #rgb or #rrggbb color
+func ContrastRatio(a, b string) (float64, bool) {
+	la, ok1 := relativeLuminance(a)
+	lb, ok2 := relativeLuminance(b)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return (math.Max(la, lb) + 0.05) / (math.Min(la, lb) + 0.05), true
+}
+
+// relativeLuminance returns the WCAG relative luminance of a hex color
+func relativeLuminance(color string) (float64, bool) {
+	hex, found := strings.CutPrefix(strings.TrimSpace(color), "#")
+	if !found {
+		return 0, false
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, false
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	channel := func(shift uint) float64 {
+		c := float64(rgb>>shift&0xff) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(16) + 0.7152*channel(8) + 0.0722*channel(0), true
+}
+
+// compareStructFields compares two fields holding numbers, durations or
+// times, returning -1, 0 or 1, and false when they cannot be compared
+func compareStructFields(sl StructLevel, a, b string) (int, bool) {
+	if ta, ok := sl.FieldTime(a); ok {
+		tb, ok := sl.FieldTime(b)
+		return ta.Compare(tb), ok
+	}
+	fa, fb := sl.FieldByName(a), sl.FieldByName(b)
+	if !fa.IsValid() || !fb.IsValid() || fa.Type() == reflect.TypeOf(time.Time{}) || fb.Type() == reflect.TypeOf(time.Time{}) {
+		return 0, false
+	}
+	na, ok1 := numberValue(fa)
+	nb, ok2 := numberValue(fb)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	switch {
+	case na < nb:
+		return -1, true
+	case na > nb:
+		return 1, true
+	}
+	return 0, true
+}
+
+// isSetValue reports whether a field holds a value: not missing or zero, and
+// not empty for strings, slices and maps
+func isSetValue(field reflect.Value) bool {
+	if !field.IsValid() {
+		return false
+	}
+	switch field.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return field.Len() > 0
+	}
+	return !field.IsZero()
+}
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The temporal rules compare time.Time fields, and strings holding RFC 3339
+// timestamps, with the current time, e.g. for token, certificate and cache
+// lifetimes:
+//
+//	ExpiresAt time.Time `validate:"expires_after=30d"` // still valid in 30 days
+//	NotBefore time.Time `validate:"not_before_now"`    // already valid
+//	IssuedAt  string    `validate:"within=24h"`        // issued or due within a day of now
+
+// ParseTimeSpan parses the parameter of a temporal rule: a duration such as
+// 90s or 24h, optionally led by a number of days, e.g. 30d or 1d12h
+func ParseTimeSpan(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	days, rest, ok := strings.Cut(s, "d")
+	if !ok {
+		return time.ParseDuration(s)
+	}
+	n, err := strconv.ParseInt(days, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64/int64(24*time.Hour) {
+		return 0, errors.New("invalid number of days in time span " + strconv.Quote(s))
+	}
+	span := time.Duration(n) * 24 * time.Hour
+	if rest == "" {
+		return span, nil
+	}
+	extra, err := time.ParseDuration(rest)
+	if err != nil || extra < 0 || span > math.MaxInt64-extra {
+		return 0, errors.New("invalid time span " + strconv.Quote(s))
+	}
+	return span + extra, nil
+}
+
+// timeValue returns the time a field holds: a time.Time, or an RFC 3339
+// timestamp string
+func timeValue(field reflect.Value) (time.Time, bool) {
+	field = indirectValue(field)
+	if !field.IsValid() {
+		return time.Time{}, false
+	}
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		t := field.Interface().(time.Time)
+		return t, !t.IsZero()
+	}
+	if field.Kind() != reflect.String {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(field.String()))
+	return t, err == nil
+}
+
+// isExpiresAfter validates that a time is later than the time span from now
+func isExpiresAfter(fl FieldLevel) bool {
+	t, ok := timeValue(fl.Field())
+	span, err := ParseTimeSpan(fl.Param())
+	return ok && err == nil && t.After(time.Now().Add(span))
+}
+
+// isNotBeforeNow validates that a time, such as a not-before time, has passed
+func isNotBeforeNow(fl FieldLevel) bool {
+	t, ok := timeValue(fl.Field())
+	return ok && !t.After(time.Now())
+}
+
+// isWithin validates that a time is no further than the time span from now,
+// in either direction
+func isWithin(fl FieldLevel) bool {
+	t, ok := timeValue(fl.Field())
+	span, err := ParseTimeSpan(fl.Param())
+	if !ok || err != nil {
+		return false
+	}
+	distance := time.Since(t)
+	if distance < 0 {
+		distance = -distance
+	}
+	return distance <= span
+}
@@ -0,0 +1,280 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// UniqueResolver reports whether value is still unique for a field, e.g. that
+// no user has registered an email yet. Field is the name errors report for the
+// field, without the index of dive elements. Resolvers run concurrently, up
+// to ValidatorConfig.UniqueConcurrency at a time, and must honor ctx.
+type UniqueResolver func(ctx context.Context, field string, value interface{}) (bool, error)
+
+// UniqueBatchResolver checks every distinct value of a field tagged with the
+// same resolver in one call, returning whether each is unique in order. The
+// elements of a dive collection are checked together.
+type UniqueBatchResolver func(ctx context.Context, field string, values []interface{}) ([]bool, error)
+
+// uniqueResolver is a registered resolver; batch wins when both are set
+type uniqueResolver struct {
+	single UniqueResolver
+	batch  UniqueBatchResolver
+}
+
+// uniqueCheck is a unique_db rule waiting for its resolver. Its error is
+// prepared when the rule is reached and inserted at index at of the collected
+// errors once the resolver reports the value taken, so it lands among the
+// errors of its field.
+type uniqueCheck struct {
+	resolver string
+	field    string
+	value    interface{}
+	at       int
+	err      ValidationError
+}
+
+// uniqueKey identifies a check; checks sharing a key are resolved once per
+// call. Its field is the collection field for dive elements.
+type uniqueKey struct {
+	resolver, field string
+	value           interface{}
+}
+
+// uniqueResult is the outcome of resolving a uniqueKey
+type uniqueResult struct {
+	unique bool
+	err    error
+}
+
+// uncomparableValue keys values that cannot be map keys by their rendering
+type uncomparableValue string
+
+// RegisterUniqueResolver registers a resolver for fields tagged
+// unique_db=name. Checks are collected while the struct is walked and
+// resolved concurrently at the end of the call, each distinct value once.
+func (v *Validator) RegisterUniqueResolver(name string, resolver UniqueResolver) error {
+	if name == "" || resolver == nil {
+		return fmt.Errorf("unique resolver name and function are required")
+	}
+	v.setUniqueResolver(name, uniqueResolver{single: resolver})
+	return nil
+}
+
+// RegisterUniqueBatchResolver registers a resolver for fields tagged
+// unique_db=name that checks all values of a field in one call, e.g. with a
+// single IN query
+func (v *Validator) RegisterUniqueBatchResolver(name string, resolver UniqueBatchResolver) error {
+	if name == "" || resolver == nil {
+		return fmt.Errorf("unique resolver name and function are required")
+	}
+	v.setUniqueResolver(name, uniqueResolver{batch: resolver})
+	return nil
+}
+
+// setUniqueResolver publishes a copy of the resolvers with name replaced
+func (v *Validator) setUniqueResolver(name string, resolver uniqueResolver) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	resolvers := make(map[string]uniqueResolver, len(v.uniqueResolvers)+1)
+	for existing, r := range v.uniqueResolvers {
+		resolvers[existing] = r
+	}
+	resolvers[name] = resolver
+
+	v.uniqueResolvers = resolvers
+	v.publish()
+}
+
+// RegisterUniqueResolver registers a unique_db resolver on the default validator
+func RegisterUniqueResolver(name string, resolver UniqueResolver) error {
+	return defaultValidator.RegisterUniqueResolver(name, resolver)
+}
+
+// RegisterUniqueBatchResolver registers a batch unique_db resolver on the default validator
+func RegisterUniqueBatchResolver(name string, resolver UniqueBatchResolver) error {
+	return defaultValidator.RegisterUniqueBatchResolver(name, resolver)
+}
+
+// deferUnique queues a unique_db rule for resolution at the end of the call
+func (v *Validator) deferUnique(fl *fieldLevel, collector *ErrorCollector) {
+	if !fl.field.IsValid() || !fl.field.CanInterface() {
+		return
+	}
+	err := ValidationError{
+		Field:   fl.fieldName,
+		Tag:     fl.tag,
+		Param:   fl.param,
+		Value:   fl.field.Interface(),
+		Message: v.errorMessage(fl, collector),
+	}
+	if collector.namespace != "" {
+		err.Namespace = collector.namespace + "." + fl.fieldName
+	}
+	if collector.sensitive {
+		err.Value = RedactedValue
+	}
+	collector.pending = append(collector.pending, uniqueCheck{
+		resolver: fl.param,
+		field:    fl.fieldName,
+		value:    fl.field.Interface(),
+		at:       len(collector.errors),
+		err:      err,
+	})
+}
+
+// resolveUnique runs the queued unique_db checks and inserts an error for
+// every value reported taken or that could not be checked
+func (v *Validator) resolveUnique(ctx context.Context, collector *ErrorCollector) {
+	pending := collector.pending
+	collector.pending = nil
+	if len(pending) == 0 || collector.ShouldStop() {
+		return
+	}
+
+	// Group the distinct values of each resolver and field
+	type group struct {
+		resolver, field string
+		keys            []uniqueKey
+		values          []interface{}
+	}
+	var groups []*group
+	byField := make(map[[2]string]*group)
+	results := make(map[uniqueKey]uniqueResult, len(pending))
+	for _, check := range pending {
+		key := newUniqueKey(check)
+		if _, seen := results[key]; seen {
+			continue
+		}
+		results[key] = uniqueResult{}
+		g := byField[[2]string{key.resolver, key.field}]
+		if g == nil {
+			g = &group{resolver: key.resolver, field: key.field}
+			byField[[2]string{key.resolver, key.field}] = g
+			groups = append(groups, g)
+		}
+		g.keys = append(g.keys, key)
+		g.values = append(g.values, check.value)
+	}
+
+	limit := v.config.UniqueConcurrency
+	if limit <= 0 {
+		limit = DefaultUniqueConcurrency
+	}
+	sem := make(chan struct{}, limit)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	record := func(key uniqueKey, result uniqueResult) {
+		mu.Lock()
+		results[key] = result
+		mu.Unlock()
+	}
+	for _, g := range groups {
+		resolver, ok := v.uniqueResolvers[g.resolver]
+		switch {
+		case !ok:
+			for _, key := range g.keys {
+				record(key, uniqueResult{err: fmt.Errorf("no unique_db resolver registered as %q", g.resolver)})
+			}
+		case resolver.batch != nil:
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(g *group) {
+				defer func() { <-sem; wg.Done() }()
+				unique, err := callBatchResolver(ctx, resolver.batch, g.field, g.values)
+				for i, key := range g.keys {
+					if err != nil {
+						record(key, uniqueResult{err: err})
+					} else {
+						record(key, uniqueResult{unique: unique[i]})
+					}
+				}
+			}(g)
+		default:
+			for i, key := range g.keys {
+				sem <- struct{}{}
+				wg.Add(1)
+				go func(key uniqueKey, value interface{}) {
+					defer func() { <-sem; wg.Done() }()
+					unique, err := callResolver(ctx, resolver.single, key.field, value)
+					record(key, uniqueResult{unique: unique, err: err})
+				}(key, g.values[i])
+			}
+		}
+	}
+	wg.Wait()
+	if collector.expired() {
+		return
+	}
+
+	inserted := 0
+	for _, check := range pending {
+		result := results[newUniqueKey(check)]
+		if result.err == nil && result.unique {
+			continue
+		}
+		err := check.err
+		if result.err != nil {
+			err.Message = fmt.Sprintf(ErrorMsgResolverFailed, check.field, result.err)
+			err.Code = ErrCodeResolverFailed
+		}
+		if collector.insert(check.at+inserted, err) {
+			inserted++
+		}
+	}
+}
+
+// newUniqueKey returns the cache key of a check
+func newUniqueKey(check uniqueCheck) uniqueKey {
+	key := uniqueKey{resolver: check.resolver, field: collectionField(check.field), value: check.value}
+	if check.value != nil && !reflect.TypeOf(check.value).Comparable() {
+		key.value = uncomparableValue(fmt.Sprintf("%#v", check.value))
+	}
+	return key
+}
+
+// collectionField returns the field a dive element belongs to, e.g. Emails
+// for Emails[2] or Tags for Tags[a][0]
+func collectionField(field string) string {
+	for strings.HasSuffix(field, "]") {
+		i := strings.LastIndexByte(field, '[')
+		if i <= 0 {
+			break
+		}
+		field = field[:i]
+	}
+	return field
+}
+
+// errResolverPanic reports a resolver that panicked
+var errResolverPanic = errors.New("resolver panicked")
+
+// callResolver invokes a resolver, converting a panic into an error
+func callResolver(ctx context.Context, fn UniqueResolver, field string, value interface{}) (unique bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			unique, err = false, fmt.Errorf("%w: %v", errResolverPanic, r)
+		}
+	}()
+	return fn(ctx, field, value)
+}
+
+// callBatchResolver invokes a batch resolver, converting a panic or a result
+// of the wrong length into an error
+func callBatchResolver(ctx context.Context, fn UniqueBatchResolver, field string, values []interface{}) (unique []bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			unique, err = nil, fmt.Errorf("%w: %v", errResolverPanic, r)
+		}
+	}()
+	unique, err = fn(ctx, field, values)
+	if err == nil && len(unique) != len(values) {
+		return nil, fmt.Errorf("resolver returned %d results for %d values", len(unique), len(values))
+	}
+	return unique, err
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructUpdate validates new like Struct, and also reports every field
+// tagged immutable whose value differs from its value in old, e.g. the ID
+// or CreatedAt of an API resource. old and new must be structs, or
+// pointers to structs, of the same type.
+func (v *Validator) StructUpdate(old, new interface{}, opts ...ValidateOption) error {
+	return v.StructUpdateCtx(context.Background(), old, new, opts...)
+}
+
+// StructUpdateCtx validates an update like StructUpdate, passing ctx to the
+// installed hooks and stopping like StructCtx once ctx is done
+func (v *Validator) StructUpdateCtx(ctx context.Context, old, new interface{}, opts ...ValidateOption) error {
+	oldVal := indirectValue(reflect.ValueOf(old))
+	newVal := indirectValue(reflect.ValueOf(new))
+	if oldVal.Kind() != reflect.Struct || newVal.Kind() != reflect.Struct {
+		return fmt.Errorf("StructUpdate requires two structs, got %T and %T", old, new)
+	}
+	if oldVal.Type() != newVal.Type() {
+		return fmt.Errorf("StructUpdate requires structs of the same type, got %s and %s", oldVal.Type(), newVal.Type())
+	}
+	return v.structCtx(ctx, newVal, oldVal, opts)
+}
+
+// StructUpdate validates an update using the default validator
+func StructUpdate(old, new interface{}, opts ...ValidateOption) error {
+	return defaultValidator.StructUpdate(old, new, opts...)
+}
+
+// StructUpdateCtx validates an update using the default validator, passing ctx to its hooks
+func StructUpdateCtx(ctx context.Context, old, new interface{}, opts ...ValidateOption) error {
+	return defaultValidator.StructUpdateCtx(ctx, old, new, opts...)
+}
+
+// isImmutable passes in Struct: immutable fields are only compared by StructUpdate
+func isImmutable(fl FieldLevel) bool {
+	return true
+}
+
+// checkImmutable reports the immutable fields of typ whose values differ
+// between old and new, descending into nested structs
+func (v *Validator) checkImmutable(old, new reflect.Value, typ reflect.Type, namespace string, collector *ErrorCollector) {
+	for i := 0; i < typ.NumField(); i++ {
+		if collector.ShouldStop() {
+			return
+		}
+		field := typ.Field(i)
+		if !field.IsExported() || v.isIgnoredField(field.Name) {
+			continue
+		}
+
+		fieldName := v.fieldNameFunc(field)
+		tag := v.scenarioTag(v.fieldTag(typ, field))
+		oldField, newField := old.Field(i), new.Field(i)
+
+		if hasRule(tag, "immutable") && !v.ruleSkipped(fieldName, "immutable") {
+			if !valuesEqual(oldField, newField) {
+				mark := collector.Count()
+				collector.SetNamespace(namespace)
+				collector.Add(ValidationError{
+					Field:       fieldName,
+					Tag:         "immutable",
+					Value:       valueInterface(newField),
+					Message:     fmt.Sprintf(ErrorMsgImmutable, fieldName),
+					Code:        ErrCodeImmutable,
+					StructField: field.Name,
+				})
+				if hasSensitiveTag(tag) {
+					collector.redact(mark)
+				}
+			}
+			continue
+		}
+
+		oldNested, newNested := indirectValue(oldField), indirectValue(newField)
+		if oldNested.Kind() == reflect.Struct && newNested.Kind() == reflect.Struct && oldNested.Type() == newNested.Type() {
+			fullPath := fieldName
+			if namespace != "" {
+				fullPath = namespace + "." + fieldName
+			}
+			v.checkImmutable(oldNested, newNested, newNested.Type(), fullPath, collector)
+		}
+	}
+}
+
+// hasRule reports whether tag lists the rule name, with or without a parameter
+func hasRule(tag, name string) bool {
+	for _, rule := range SplitRules(tag) {
+		rule, _, _ = strings.Cut(strings.TrimSpace(rule), "=")
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+// valuesEqual compares two field values, with their Equal method when the
+// type has one, such as time.Time, and deeply otherwise
+func valuesEqual(a, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return true
+	}
+	if a.Kind() == reflect.Ptr && (a.IsNil() || b.IsNil()) {
+		return a.IsNil() == b.IsNil()
+	}
+	if equal := a.MethodByName("Equal"); equal.IsValid() {
+		method := equal.Type()
+		if method.NumIn() == 1 && method.In(0) == b.Type() && method.NumOut() == 1 && method.Out(0).Kind() == reflect.Bool {
+			return equal.Call([]reflect.Value{b})[0].Bool()
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
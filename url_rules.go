@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"net/url"
+	"strings"
+)
+
+// The URL component rules constrain parts of absolute URLs declaratively,
+// e.g. to keep AllowedOrigins https-only and within the company's domains:
+//
+//	AllowedOrigins []string `validate:"dive,url_scheme=https,url_host_in=example.com *.example.com"`
+//
+// Values that are not absolute URLs fail every one of them.
+
+// parseAbsoluteURL parses a URL that has a scheme
+func parseAbsoluteURL(value string) (*url.URL, bool) {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// hostMatches reports whether a host matches a pattern: the same name, or
+// any subdomain of the name after "*."
+func hostMatches(host, pattern string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
+
+// hasURLScheme validates a URL whose scheme is one of the space-separated
+// schemes in the parameter
+func hasURLScheme(fl FieldLevel) bool {
+	u, ok := parseAbsoluteURL(getString(fl.Field()))
+	if !ok {
+		return false
+	}
+	for _, scheme := range strings.Fields(fl.Param()) {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasURLHostIn validates a URL whose host matches one of the space-separated
+// patterns in the parameter, such as example.com or *.example.com
+func hasURLHostIn(fl FieldLevel) bool {
+	u, ok := parseAbsoluteURL(getString(fl.Field()))
+	if !ok {
+		return false
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "" {
+		return false
+	}
+	for _, pattern := range strings.Fields(fl.Param()) {
+		if hostMatches(host, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNoURLUserinfo validates a URL without credentials such as user:pass@
+func hasNoURLUserinfo(fl FieldLevel) bool {
+	u, ok := parseAbsoluteURL(getString(fl.Field()))
+	return ok && u.User == nil
+}
+
+// hasNoURLFragment validates a URL without a #fragment, even an empty one
+func hasNoURLFragment(fl FieldLevel) bool {
+	value := getString(fl.Field())
+	_, ok := parseAbsoluteURL(value)
+	return ok && !strings.Contains(value, "#")
+}
@@ -0,0 +1,162 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The username rule accepts handles such as jane.doe or dev_42: ASCII
+// letters, digits and single '.', '_' or '-' separators between them. Names
+// that are reserved, or that read like a reserved name (adm1n, r00t, a.d.m.i.n),
+// are rejected so services need not each keep their own list.
+
+// UsernamePolicy configures the username rule
+type UsernamePolicy struct {
+	MinLength int      // shortest accepted name, 3 when zero
+	MaxLength int      // longest accepted name, 30 when zero
+	Reserved  []string // names rejected with their lookalikes, DefaultReservedUsernames when nil
+}
+
+// usernamePolicy is a UsernamePolicy with the skeletons of its reserved names
+type usernamePolicy struct {
+	min, max int
+	reserved map[string]bool
+}
+
+// defaultReservedUsernames are names of roles, system accounts and routes
+// commonly taken by applications
+var defaultReservedUsernames = []string{
+	"about", "abuse", "account", "accounts", "admin", "administrator", "api",
+	"app", "auth", "billing", "blog", "dashboard", "dev", "help", "home",
+	"hostmaster", "info", "login", "logout", "mail", "me", "moderator",
+	"noreply", "no-reply", "null", "official", "owner", "postmaster", "root",
+	"security", "settings", "signin", "signup", "staff", "status", "support",
+	"sys", "sysadmin", "system", "undefined", "user", "users", "webmaster", "www",
+}
+
+var defaultUsernamePolicy = newUsernamePolicy(UsernamePolicy{})
+
+// DefaultReservedUsernames returns the names reserved by the default
+// username policy
+func DefaultReservedUsernames() []string {
+	return append([]string(nil), defaultReservedUsernames...)
+}
+
+// newUsernamePolicy fills in the defaults of a policy
+func newUsernamePolicy(p UsernamePolicy) *usernamePolicy {
+	policy := &usernamePolicy{min: p.MinLength, max: p.MaxLength, reserved: map[string]bool{}}
+	if policy.min <= 0 {
+		policy.min = 3
+	}
+	if policy.max <= 0 {
+		policy.max = 30
+	}
+	reserved := p.Reserved
+	if reserved == nil {
+		reserved = defaultReservedUsernames
+	}
+	for _, name := range reserved {
+		policy.reserved[UsernameSkeleton(name)] = true
+	}
+	return policy
+}
+
+// SetUsernamePolicy configures the username rule, e.g. to reserve the names
+// of an application's routes
+func (v *Validator) SetUsernamePolicy(p UsernamePolicy) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.usernamePolicy = newUsernamePolicy(p)
+	v.publish()
+}
+
+// SetUsernamePolicy configures the username rule of the default validator
+func SetUsernamePolicy(p UsernamePolicy) {
+	defaultValidator.SetUsernamePolicy(p)
+}
+
+// usernameConfusables maps characters to the letter they are mistaken for
+var usernameConfusables = map[byte]string{
+	'0': "o", '1': "l", 'i': "l", '3': "e", '4': "a", '5': "s", '7': "t", '8': "b",
+}
+
+// UsernameSkeleton returns the form of a username that its lookalikes share:
+// lowercased, without separators, with digits and letters that are easily
+// mistaken for one another folded together (adm1n and Admin both give "admln").
+// Comparing skeletons catches names impersonating others.
+func UsernameSkeleton(name string) string {
+	var b strings.Builder
+	name = strings.ToLower(name)
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '.' || c == '_' || c == '-':
+		case c == 'r' && i+1 < len(name) && name[i+1] == 'n':
+			b.WriteByte('m')
+			i++
+		case c == 'v' && i+1 < len(name) && name[i+1] == 'v':
+			b.WriteByte('w')
+			i++
+		case usernameConfusables[c] != "":
+			b.WriteString(usernameConfusables[c])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// validUsernameSyntax reports whether a name uses the username charset with
+// single separators between letters or digits
+func validUsernameSyntax(name string) bool {
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '.' || c == '_' || c == '-':
+			if i == 0 || i == len(name)-1 || !isAlphaNumByte(name[i-1]) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphaNumByte reports whether c is an ASCII letter or digit
+func isAlphaNumByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// isUsername validates a username under the validator's policy, reporting
+// reserved names with their own message and code
+func isUsername(fl FieldLevel) bool {
+	impl, ok := fl.(*fieldLevel)
+	policy := defaultUsernamePolicy
+	if ok && impl.validator != nil {
+		policy = impl.validator.usernames()
+	}
+	name := getString(fl.Field())
+	if len(name) < policy.min || len(name) > policy.max || !validUsernameSyntax(name) {
+		return false
+	}
+	if policy.reserved[UsernameSkeleton(name)] {
+		if ok {
+			impl.failure = ValidationError{
+				Message: fmt.Sprintf(ErrorMsgUsernameReserved, fl.FieldName()),
+				Code:    ErrCodeUsernameReserved,
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// usernames returns the username policy of the validator
+func (v *Validator) usernames() *usernamePolicy {
+	if v.usernamePolicy != nil {
+		return v.usernamePolicy
+	}
+	return defaultUsernamePolicy
+}
@@ -0,0 +1,194 @@
+package validationtest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// equivalenceSeed seeds AssertEquivalent so failures reproduce
+const equivalenceSeed = 1
+
+// randomRunes are the characters random strings are made of, covering the
+// character classes and multi-byte runes
+const randomRunes = "aZ09 .-_@!é"
+
+// Divergence is a value validation.Struct and another validator disagree
+// on. Errors are listed as "Field:tag", by namespace where there is one.
+type Divergence[T any] struct {
+	Value      T
+	Reflection []string
+	Validator  []string
+}
+
+// String describes the divergence with the value and both error sets
+func (d Divergence[T]) String() string {
+	return fmt.Sprintf("%+v\n  reflection: %s\n  validator:  %s", d.Value, outcome(d.Reflection), outcome(d.Validator))
+}
+
+// outcome renders an error set
+func outcome(failures []string) string {
+	if len(failures) == 0 {
+		return "valid"
+	}
+	return strings.Join(failures, ", ")
+}
+
+// Divergences runs validation.Struct and validate over generated values of
+// T and returns those where they disagree on whether the value is valid or
+// on its errors. The values are the Fixture of T, each of its Mutants, and
+// samples random variants changing a few fields to values around their
+// rules' bounds. Errors that are not validation.ValidationErrors, such as
+// those of pure mode validators, are only compared for pass or fail.
+func Divergences[T any](rng *rand.Rand, samples int, validate func(*T) error) []Divergence[T] {
+	valid, _ := Build[T]()
+	values := []T{valid}
+	for _, m := range Mutants(valid) {
+		values = append(values, m.Value)
+	}
+
+	type target struct {
+		r      rule
+		locate locator
+	}
+	var targets []target
+	root := reflect.ValueOf(&valid).Elem()
+	walkField(root, "", nil, identity, func(path string, r rule, locate locator) {
+		targets = append(targets, target{r, locate})
+	})
+	for i := 0; i < samples && len(targets) > 0; i++ {
+		value := deepCopy(root).Interface().(T)
+		v := reflect.ValueOf(&value).Elem()
+		// Change about two fields per sample, breaking a rule or picking a
+		// value near its parameter
+		for _, tgt := range targets {
+			if rng.IntN(len(targets)) >= 2 {
+				continue
+			}
+			if rng.IntN(2) == 0 {
+				breakRule(tgt.locate(v), tgt.r)
+			} else {
+				randomize(tgt.locate(v), tgt.r, rng)
+			}
+		}
+		values = append(values, value)
+	}
+
+	var divergences []Divergence[T]
+	for _, value := range values {
+		subject := interface{}(&value)
+		if reflect.ValueOf(value).Kind() == reflect.Ptr {
+			subject = value
+		}
+		reflection, detailed := failures(validation.Struct(subject))
+		// The validator gets a copy so it cannot change the reported value
+		checked := deepCopy(reflect.ValueOf(value)).Interface().(T)
+		validator, validatorDetailed := failures(validate(&checked))
+		if !detailed || !validatorDetailed {
+			if (len(reflection) == 0) == (len(validator) == 0) {
+				continue
+			}
+		} else if strings.Join(reflection, ",") == strings.Join(validator, ",") {
+			continue
+		}
+		divergences = append(divergences, Divergence[T]{Value: value, Reflection: reflection, Validator: validator})
+	}
+	return divergences
+}
+
+// AssertEquivalent fails the test when validate and validation.Struct
+// disagree on any of the values Divergences generates, e.g. to check a
+// validator generated by configvalidator matches the reflection engine
+//
+//	validator := NewConfigValidator()
+//	validationtest.AssertEquivalent(t, 500, validator.Validate)
+func AssertEquivalent[T any](t testing.TB, samples int, validate func(*T) error) {
+	t.Helper()
+	rng := rand.New(rand.NewPCG(equivalenceSeed, uint64(samples)))
+	divergences := Divergences(rng, samples, validate)
+	for i, d := range divergences {
+		if i == 5 {
+			t.Errorf("... and %d more divergences", len(divergences)-i)
+			break
+		}
+		t.Errorf("validator diverges from the reflection engine on %T %s", d.Value, d)
+	}
+}
+
+// failures returns the sorted "Field:tag" keys of a validation error and
+// whether they are detailed; other errors yield a single opaque entry
+func failures(err error) ([]string, bool) {
+	if err == nil {
+		return nil, true
+	}
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		var single validation.ValidationError
+		if !errors.As(err, &single) {
+			return []string{err.Error()}, false
+		}
+		errs = validation.ValidationErrors{single}
+	}
+	keys := make([]string, 0, len(errs))
+	for _, e := range errs {
+		keys = append(keys, errorPath(e)+":"+e.Tag)
+	}
+	sort.Strings(keys)
+	return keys, true
+}
+
+// randomize sets v to a random value around the parameter of r: strings
+// and collections get lengths near it, numbers land just below, on or above
+func randomize(v reflect.Value, r rule, rng *rand.Rand) {
+	if !v.IsValid() {
+		return
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	param, err := strconv.ParseFloat(r.param, 64)
+	if err != nil {
+		param = 4
+	}
+	near := param + float64(rng.IntN(3)-1)
+	length := max(int(near), 0)
+
+	switch v.Kind() {
+	case reflect.String:
+		if sample, ok := formatSamples[r.name]; ok && rng.IntN(2) == 0 {
+			v.SetString(sample)
+			return
+		}
+		runes := []rune(randomRunes)
+		var b strings.Builder
+		for i := 0; i < length; i++ {
+			b.WriteRune(runes[rng.IntN(len(runes))])
+		}
+		v.SetString(b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !v.OverflowInt(int64(near)) {
+			v.SetInt(int64(near))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if near >= 0 && !v.OverflowUint(uint64(near)) {
+			v.SetUint(uint64(near))
+		}
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(near)
+	case reflect.Bool:
+		v.SetBool(rng.IntN(2) == 0)
+	case reflect.Slice, reflect.Map:
+		resize(v, length)
+	}
+}
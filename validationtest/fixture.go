@@ -0,0 +1,488 @@
+package validationtest
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// formatSamples are valid values for the string format rules
+var formatSamples = map[string]string{
+	"email":      "user@example.com",
+	"url":        "https://example.com",
+	"uri":        "https://example.com",
+	"hostname":   "example.com",
+	"domain":     "example.com",
+	"tld":        "com",
+	"ip":         "192.0.2.1",
+	"ipv4":       "192.0.2.1",
+	"ipv6":       "2001:db8::1",
+	"cidr":       "192.0.2.0/24",
+	"mac":        "00:00:5e:00:53:01",
+	"uuid":       "6ba7b810-9dad-41d1-80b4-00c04fd430c8",
+	"uuid4":      "6ba7b810-9dad-41d1-80b4-00c04fd430c8",
+	"datetime":   "2024-01-02T15:04:05Z",
+	"date":       "2024-01-02",
+	"time":       "15:04:05",
+	"json":       "{}",
+	"base64":     "dGVzdA==",
+	"creditcard": "4111111111111111",
+	"luhn":       "79927398713",
+	"imei":       "490154203237518",
+	"ean13":      "4006381333931",
+	"gtin":       "4006381333931",
+	"isbn13":     "9780306406157",
+	"isbn10":     "0306406152",
+	"vin":        "1M8GDM9AXKP042788",
+	"phone":      "+14155552671",
+	"username":   "jane.doe",
+	"boolean":    "true",
+
+	"email_mx":             "user@example.com",
+	"email_not_disposable": "user@example.com",
+	"k8s_name":             "my-app",
+	"k8s_label_key":        "app.kubernetes.io/name",
+	"k8s_label_value":      "web",
+	"k8s_qty":              "500m",
+	"k8s_selector":         "app=web",
+	"image_ref":            "registry.example.com/team/app:1.0@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	"image_tag":            "v1.0",
+	"sha256_digest":        "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	"git_url":              "https://github.com/org/repo.git",
+	"ssh_url":              "ssh://git@example.com/org/repo.git",
+	"commit_sha":           "a1b2c3d",
+	"branch_name":          "main",
+	"timezone":             "Europe/Berlin",
+	"locale":               "en-US",
+}
+
+// padSamples are the characters repeated to reach a minimum length under
+// the character class rules
+var padSamples = map[string]string{
+	"alpha":    "a",
+	"alphanum": "a",
+	"numeric":  "1",
+}
+
+// rule is one rule of a validate tag
+type rule struct {
+	name, param string
+}
+
+// Fixture returns a minimal valid T built from its validate tags: optional
+// fields stay zero, required ones and those with bounds or formats get the
+// smallest value that passes. Modifiers then adjust the fixture, e.g. to
+// make one field invalid. The test fails when no valid T can be derived.
+func Fixture[T any](t testing.TB, modify ...func(*T)) T {
+	t.Helper()
+	value, err := Build[T]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range modify {
+		m(&value)
+	}
+	return value
+}
+
+// Build returns a minimal valid T, or an error naming the validation
+// failures when its rules are beyond what the builder understands
+func Build[T any]() (T, error) {
+	var value T
+	target := reflect.ValueOf(&value).Elem()
+	if indirectType(target.Type()).Kind() != reflect.Struct {
+		return value, fmt.Errorf("validationtest: cannot build %T, expected a struct", value)
+	}
+	fill(target, nil, true)
+	subject := interface{}(&value)
+	if target.Kind() == reflect.Ptr {
+		subject = target.Interface()
+	}
+	if err := validation.Struct(subject); err != nil {
+		return value, fmt.Errorf("validationtest: cannot build a valid %T:\n%s", value, describe(err))
+	}
+	return value, nil
+}
+
+// fill sets v to the minimal value satisfying rules; force fills optional
+// structs and pointers as well
+func fill(v reflect.Value, rules []rule, force bool) {
+	collection, elem := splitDive(rules)
+	if has(rules, "omitempty") && !has(rules, "required") {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !force && !required(rules) && !has(rules, "defined") {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem(), rules, true)
+
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			if t, ok := timeFor(rules); ok {
+				v.Set(reflect.ValueOf(t))
+			} else if required(rules) {
+				v.Set(reflect.ValueOf(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)))
+			}
+			return
+		}
+		fillStruct(v)
+
+	case reflect.String:
+		v.SetString(stringFor(rules))
+
+	case reflect.Bool:
+		if required(rules) {
+			v.SetBool(true)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			v.SetInt(int64(durationFor(rules)))
+			return
+		}
+		v.SetInt(int64(numberFor(rules)))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(math.Max(numberFor(rules), 0)))
+
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(numberFor(rules))
+
+	case reflect.Slice:
+		n := itemsFor(collection, rules)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			fill(v.Index(i), elem, true)
+		}
+
+	case reflect.Map:
+		n := itemsFor(collection, rules)
+		keys := mapKeys(v.Type().Key(), n, rules)
+		if len(keys) == 0 && !has(rules, "defined") {
+			return
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(keys)))
+		for _, key := range keys {
+			value := reflect.New(v.Type().Elem()).Elem()
+			fill(value, elem, true)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
+
+// fillStruct fills every exported field from its tag, then satisfies the
+// cross-field rules, which depend on the siblings' final values
+func fillStruct(v reflect.Value) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fill(v.Field(i), parseRules(field.Tag.Get("validate")), false)
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		for _, r := range parseRules(field.Tag.Get("validate")) {
+			other := v.FieldByName(r.param)
+			if !other.IsValid() {
+				continue
+			}
+			applyCrossField(v.Field(i), other, r.name)
+		}
+	}
+}
+
+// applyCrossField adjusts a field to satisfy a rule comparing it with a
+// sibling
+func applyCrossField(field, other reflect.Value, name string) {
+	if field.Type() != other.Type() {
+		return
+	}
+	switch name {
+	case "eqfield", "gtefiled", "ltefield":
+		field.Set(other)
+	case "nefield":
+		if reflect.DeepEqual(field.Interface(), other.Interface()) {
+			bump(field, other, 1)
+		}
+	case "gtfield":
+		bump(field, other, 1)
+	case "ltfield":
+		bump(field, other, -1)
+	}
+}
+
+// bump sets a field to a sibling's value shifted by delta, or for strings
+// to the sibling with a suffix
+func bump(field, other reflect.Value, delta int) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(other.Int() + int64(delta))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if delta < 0 && other.Uint() == 0 {
+			return
+		}
+		field.SetUint(uint64(int64(other.Uint()) + int64(delta)))
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(other.Float() + float64(delta))
+	case reflect.String:
+		field.SetString(other.String() + "x")
+	}
+}
+
+// stringFor returns the minimal string satisfying rules
+func stringFor(rules []rule) string {
+	if r, ok := find(rules, "eq"); ok {
+		return r.param
+	}
+	if r, ok := find(rules, "oneof"); ok {
+		if choices := strings.Fields(r.param); len(choices) > 0 {
+			return choices[0]
+		}
+	}
+	if t, ok := timeFor(rules); ok {
+		return t.Format(time.RFC3339)
+	}
+
+	// The character class rules reject empty strings unless omitempty
+	s, pad, class := "", "x", false
+	for _, r := range rules {
+		if sample, ok := formatSamples[r.name]; ok {
+			s, pad = sample, ""
+		}
+		if p, ok := padSamples[r.name]; ok {
+			pad, class = p, true
+		}
+	}
+	if s == "" && (required(rules) || class && !has(rules, "omitempty")) {
+		s = pad
+	}
+
+	want := len(s)
+	for _, name := range []string{"min", "minbytes", "minrunes", "len"} {
+		if r, ok := find(rules, name); ok {
+			if n, ok := sizeParam(r.param); ok && n > want {
+				want = n
+			}
+		}
+	}
+	if pad != "" && want > len(s) {
+		s += strings.Repeat(pad, want-len(s))
+	}
+	return s
+}
+
+// numberFor returns the smallest value within the bounds of rules, and 1
+// for required numbers without a lower bound
+func numberFor(rules []rule) float64 {
+	if r, ok := find(rules, "eq"); ok {
+		if n, err := strconv.ParseFloat(r.param, 64); err == nil {
+			return n
+		}
+	}
+	if r, ok := find(rules, "oneof"); ok {
+		if choices := strings.Fields(r.param); len(choices) > 0 {
+			if n, err := strconv.ParseFloat(choices[0], 64); err == nil {
+				return n
+			}
+		}
+	}
+
+	value := 0.0
+	lower, hasLower := find(rules, "min")
+	if hasLower {
+		if n, err := strconv.ParseFloat(lower.param, 64); err == nil {
+			value = n
+		}
+	}
+	if value == 0 && required(rules) {
+		value = 1
+	}
+	if r, ok := find(rules, "max"); ok {
+		if n, err := strconv.ParseFloat(r.param, 64); err == nil && value > n {
+			value = n
+		}
+	}
+	return value
+}
+
+// durationFor returns the smallest duration within the bounds of rules
+func durationFor(rules []rule) time.Duration {
+	var d time.Duration
+	if r, ok := find(rules, "min"); ok {
+		d, _ = time.ParseDuration(r.param)
+	}
+	if d == 0 && required(rules) {
+		d = time.Second
+	}
+	return d
+}
+
+// timeFor returns a time passing the temporal rules, which compare times
+// with the current time, or false without them
+func timeFor(rules []rule) (time.Time, bool) {
+	now := time.Now().Truncate(time.Second)
+	if r, ok := find(rules, "expires_after"); ok {
+		span, _ := validation.ParseTimeSpan(r.param)
+		return now.Add(span + time.Hour), true
+	}
+	if has(rules, "not_before_now") || has(rules, "within") {
+		return now.Add(-time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// itemsFor returns how many elements a collection needs. With dive, the
+// rules other than the collection rules apply to the elements.
+func itemsFor(collection, rules []rule) int {
+	bounds, n := collection, 0
+	if !has(rules, "dive") {
+		bounds = rules
+		if required(rules) {
+			n = 1
+		}
+	}
+	if has(bounds, "notempty") {
+		n = max(n, 1)
+	}
+	for _, name := range []string{"minitems", "min", "len"} {
+		if r, ok := find(bounds, name); ok {
+			if m, ok := sizeParam(r.param); ok && m > n {
+				n = m
+			}
+		}
+	}
+	if r, ok := find(bounds, "haskeys"); ok {
+		n = max(n, len(strings.Fields(r.param)))
+	}
+	return n
+}
+
+// mapKeys returns n keys for a map, starting with any required by haskeys
+func mapKeys(typ reflect.Type, n int, rules []rule) []reflect.Value {
+	var names []string
+	if r, ok := find(rules, "haskeys"); ok {
+		names = strings.Fields(r.param)
+	}
+	var keys []reflect.Value
+	for i := 0; len(keys) < n; i++ {
+		key := reflect.New(typ).Elem()
+		switch typ.Kind() {
+		case reflect.String:
+			if i < len(names) {
+				key.SetString(names[i])
+			} else {
+				key.SetString("key" + strconv.Itoa(i))
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			key.SetInt(int64(i))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			key.SetUint(uint64(i))
+		default:
+			return keys
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// scenarioRule matches rules suffixed with @scenario, which the default
+// scenario Build validates with skips
+var scenarioRule = regexp.MustCompile(`.@[A-Za-z][A-Za-z0-9_-]*(\|[A-Za-z][A-Za-z0-9_-]*)*$`)
+
+// parseRules splits a validate tag into rules
+func parseRules(tag string) []rule {
+	var rules []rule
+	for _, part := range validation.SplitRules(tag) {
+		part = strings.TrimSpace(part)
+		if part == "" || scenarioRule.MatchString(part) {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+// splitDive separates the collection rules of a dive tag from the rules
+// applied to each element, like the validator does
+func splitDive(rules []rule) (collection, elem []rule) {
+	if !has(rules, "dive") {
+		return nil, nil
+	}
+	for _, r := range rules {
+		switch r.name {
+		case "dive":
+		case "minitems", "maxitems", "notempty", "sorted", "sorted_desc", "haskeys", "allowedkeys", "sum_to":
+			collection = append(collection, r)
+		default:
+			elem = append(elem, r)
+		}
+	}
+	return collection, elem
+}
+
+// sizeParam returns the length of a size parameter, parsed as the
+// validation package does: 3, 3:chars and 1KB are lengths of 3, 3 and 1024
+func sizeParam(param string) (int, bool) {
+	spec, err := validation.ParseSizeSpec(param)
+	if err != nil {
+		return 0, false
+	}
+	return int(spec.Value), true
+}
+
+// required reports whether rules demand a non-zero value
+func required(rules []rule) bool {
+	return hasAny(rules, "required", "required_if", "required_with", "required_without", "required_unless")
+}
+
+// find returns the first rule with a name
+func find(rules []rule, name string) (rule, bool) {
+	for _, r := range rules {
+		if r.name == name {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+// has reports whether rules include a rule
+func has(rules []rule, name string) bool {
+	_, ok := find(rules, name)
+	return ok
+}
+
+// hasAny reports whether rules include any of the names
+func hasAny(rules []rule, names ...string) bool {
+	for _, name := range names {
+		if has(rules, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// indirectType dereferences pointer types
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
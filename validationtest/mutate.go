@@ -0,0 +1,437 @@
+package validationtest
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// invalidFormat fails every string format rule
+const invalidFormat = "not valid!"
+
+// classViolations are strings breaking the character class rules
+var classViolations = map[string]string{
+	"alpha":    "1",
+	"alphanum": "!",
+	"numeric":  "a",
+}
+
+// Mutant is a copy of a valid value with one field changed to break one of
+// its rules
+type Mutant[T any] struct {
+	Field string // Go path of the changed field, e.g. Servers[0].Host
+	Rule  string // broken rule as written in the tag, e.g. min=3
+	Value T
+}
+
+// Mutants returns a mutant of valid for every rule of every field that can
+// be broken on its own: a deep copy with the field set to a value violating
+// the rule, such as a string one byte shorter than min. Only mutants the
+// validation package rejects are returned, so each one is a violation a
+// validator enforcing the same rules must reject. Slices and arrays are
+// mutated through their first element; cross-field rules are left alone.
+func Mutants[T any](valid T) []Mutant[T] {
+	var mutants []Mutant[T]
+	root := reflect.ValueOf(&valid).Elem()
+	walkField(root, "", nil, identity, func(path string, r rule, locate locator) {
+		value := deepCopy(root).Interface().(T)
+		if !breakRule(locate(reflect.ValueOf(&value).Elem()), r) {
+			return
+		}
+		subject := interface{}(&value)
+		if reflect.ValueOf(value).Kind() == reflect.Ptr {
+			subject = value
+		}
+		if validation.Struct(subject) == nil {
+			return
+		}
+		mutants = append(mutants, Mutant[T]{Field: path, Rule: r.String(), Value: value})
+	})
+	return mutants
+}
+
+// AssertRejectsMutants fails the test unless validate accepts valid and
+// rejects each of its Mutants, catching rules a validator does not enforce,
+// e.g. one generated by configvalidator
+//
+//	validator := NewConfigValidator()
+//	validationtest.AssertRejectsMutants(t, validationtest.Fixture[Config](t), validator.Validate)
+func AssertRejectsMutants[T any](t testing.TB, valid T, validate func(*T) error) {
+	t.Helper()
+	if err := validate(&valid); err != nil {
+		t.Errorf("expected %T to be valid, got:\n%s", valid, describe(err))
+		return
+	}
+	for _, m := range Mutants(valid) {
+		if validate(&m.Value) == nil {
+			t.Errorf("expected %T to be rejected with %s, but it passed validation", valid, m)
+		}
+	}
+}
+
+// String describes the mutation, e.g. "Servers[0].Host breaking hostname"
+func (m Mutant[T]) String() string {
+	return fmt.Sprintf("%s breaking %s", m.Field, m.Rule)
+}
+
+// String renders the rule as written in a tag
+func (r rule) String() string {
+	if r.param == "" {
+		return r.name
+	}
+	return r.name + "=" + r.param
+}
+
+// locator finds the value a mutation changes in a copy of the root, or
+// returns the zero Value when an earlier change removed it
+type locator func(root reflect.Value) reflect.Value
+
+// identity locates the root itself
+func identity(root reflect.Value) reflect.Value { return root }
+
+// emitFunc receives a rule of the field at path and the locator of the field
+type emitFunc func(path string, r rule, locate locator)
+
+// walkField emits each of a field's own rules, then descends into dive
+// elements and nested structs
+func walkField(v reflect.Value, path string, rules []rule, locate locator, emit emitFunc) {
+	own := rules
+	collection, elem := splitDive(rules)
+	if has(rules, "dive") {
+		own = collection
+	}
+	for _, r := range own {
+		emit(path, r, locate)
+	}
+
+	inner, innerLocate := v, locate
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		inner = v.Elem()
+		innerLocate = func(root reflect.Value) reflect.Value {
+			if p := locate(root); p.IsValid() && !p.IsNil() {
+				return p.Elem()
+			}
+			return reflect.Value{}
+		}
+	}
+
+	switch {
+	case has(rules, "dive"):
+		if (inner.Kind() != reflect.Slice && inner.Kind() != reflect.Array) || inner.Len() == 0 {
+			return
+		}
+		elemLocate := func(root reflect.Value) reflect.Value {
+			if c := innerLocate(root); c.IsValid() && c.Len() > 0 {
+				return c.Index(0)
+			}
+			return reflect.Value{}
+		}
+		if len(elem) > 0 {
+			walkField(inner.Index(0), path+"[0]", elem, elemLocate, emit)
+		} else if indirectType(inner.Type().Elem()).Kind() == reflect.Struct {
+			walkField(inner.Index(0), path+"[0]", nil, elemLocate, emit)
+		}
+	case inner.Kind() == reflect.Struct && inner.Type() != reflect.TypeOf(time.Time{}):
+		typ := inner.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			fieldLocate := func(root reflect.Value) reflect.Value {
+				if s := innerLocate(root); s.IsValid() {
+					return s.Field(i)
+				}
+				return reflect.Value{}
+			}
+			walkField(inner.Field(i), fieldPath, parseRules(field.Tag.Get("validate")), fieldLocate, emit)
+		}
+	}
+}
+
+// breakRule changes v to violate r, reporting false when it cannot
+func breakRule(v reflect.Value, r rule) bool {
+	if !v.IsValid() {
+		return false
+	}
+	switch r.name {
+	case "required", "defined":
+		v.Set(reflect.Zero(v.Type()))
+		return true
+	case "omitempty", "sensitive", "secret":
+		return false
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		s, ok := breakString(v.String(), r)
+		if ok {
+			v.SetString(s)
+		}
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := breakNumber(r, v.Type() == reflect.TypeOf(time.Duration(0)))
+		if !ok || n != math.Trunc(n) || v.OverflowInt(int64(n)) {
+			return false
+		}
+		v.SetInt(int64(n))
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := breakNumber(r, false)
+		if !ok || n < 0 || n != math.Trunc(n) || v.OverflowUint(uint64(n)) {
+			return false
+		}
+		v.SetUint(uint64(n))
+		return true
+	case reflect.Float32, reflect.Float64:
+		n, ok := breakNumber(r, false)
+		if ok {
+			v.SetFloat(n)
+		}
+		return ok
+	case reflect.Slice, reflect.Map:
+		n, ok := breakLength(v.Len(), r)
+		return ok && resize(v, n)
+	}
+	return false
+}
+
+// breakString returns s changed to violate r
+func breakString(s string, r rule) (string, bool) {
+	if bad, ok := classViolations[r.name]; ok {
+		return strings.Repeat(bad, max(utf8.RuneCountInString(s), 1)), true
+	}
+	if _, ok := formatSamples[r.name]; ok {
+		return invalidFormat, true
+	}
+
+	switch r.name {
+	case "eq":
+		return r.param + "x", true
+	case "ne":
+		return r.param, true
+	case "oneof":
+		choices := strings.Fields(r.param)
+		candidate := "x"
+		for contains(choices, candidate) {
+			candidate += "x"
+		}
+		return candidate, true
+	case "minrunes", "maxrunes":
+		n, ok := breakLength(utf8.RuneCountInString(s), r)
+		if !ok {
+			return "", false
+		}
+		runes := []rune(s)
+		if n <= len(runes) {
+			return string(runes[:n]), true
+		}
+		return s + strings.Repeat(padFor(s), n-len(runes)), true
+	}
+
+	n, ok := breakLength(len(s), r)
+	if !ok {
+		return "", false
+	}
+	if n < len(s) {
+		// Cut at a rune boundary, shorter than asked if need be
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return s[:n], true
+	}
+	return s + strings.Repeat(padFor(s), n-len(s)), true
+}
+
+// padFor returns the character lengthening s without changing its class:
+// its last character when it is ASCII, x otherwise
+func padFor(s string) string {
+	if s != "" && s[len(s)-1] < utf8.RuneSelf {
+		return s[len(s)-1:]
+	}
+	return "x"
+}
+
+// breakLength returns a length violating a length rule
+func breakLength(length int, r rule) (int, bool) {
+	if r.name == "notempty" {
+		return 0, true
+	}
+	limit, ok := sizeParam(r.param)
+	if !ok {
+		return 0, false
+	}
+	switch r.name {
+	case "min", "minbytes", "minrunes", "minitems":
+		return limit - 1, limit > 0
+	case "max", "maxbytes", "maxrunes", "maxitems":
+		return limit + 1, true
+	case "len":
+		return limit + 1, true
+	}
+	return 0, false
+}
+
+// breakNumber returns a number violating a bound, equality or oneof rule
+func breakNumber(r rule, duration bool) (float64, bool) {
+	parse := func(s string) (float64, bool) {
+		if duration {
+			if d, err := time.ParseDuration(s); err == nil {
+				return float64(d), true
+			}
+		}
+		n, err := strconv.ParseFloat(s, 64)
+		return n, err == nil
+	}
+
+	switch r.name {
+	case "oneof":
+		highest, found := 0.0, false
+		for _, choice := range strings.Fields(r.param) {
+			if n, ok := parse(choice); ok && (!found || n > highest) {
+				highest, found = n, true
+			}
+		}
+		return highest + 1, found
+	case "min", "max", "eq", "ne":
+	default:
+		return 0, false
+	}
+
+	n, ok := parse(r.param)
+	switch r.name {
+	case "min":
+		return n - 1, ok
+	case "max", "eq":
+		return n + 1, ok
+	}
+	return n, ok
+}
+
+// resize truncates or grows a slice or map to n elements, growing with
+// copies of the first element or zero values
+func resize(v reflect.Value, n int) bool {
+	if n < 0 {
+		return false
+	}
+	if v.Kind() == reflect.Slice {
+		if n <= v.Len() {
+			v.Set(v.Slice(0, n))
+			return true
+		}
+		grown := reflect.MakeSlice(v.Type(), n, n)
+		reflect.Copy(grown, v)
+		for i := v.Len(); i < n; i++ {
+			if v.Len() > 0 {
+				grown.Index(i).Set(deepCopy(v.Index(0)))
+			}
+		}
+		v.Set(grown)
+		return true
+	}
+
+	keys := v.MapKeys()
+	if n <= len(keys) {
+		for _, key := range keys[n:] {
+			v.SetMapIndex(key, reflect.Value{})
+		}
+		return true
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	for _, key := range mapKeys(v.Type().Key(), n*2, nil) {
+		if v.Len() >= n {
+			break
+		}
+		if v.MapIndex(key).IsValid() {
+			continue
+		}
+		value := reflect.New(v.Type().Elem()).Elem()
+		if len(keys) > 0 {
+			value = deepCopy(v.MapIndex(keys[0]))
+		}
+		v.SetMapIndex(key, value)
+	}
+	return v.Len() == n
+}
+
+// deepCopy copies a value, its pointers, slices and maps and the exported
+// fields of its structs, so mutating the copy leaves the original intact
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < c.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	}
+	return v
+}
+
+// contains reports whether values include value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
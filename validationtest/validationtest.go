@@ -0,0 +1,104 @@
+// Package validationtest provides assertions and fixtures for testing code
+// that validates structs with go-validation.
+//
+//	func TestSignup(t *testing.T) {
+//		user := validationtest.Fixture(t, func(u *User) { u.Age = 12 })
+//		validationtest.AssertInvalid(t, user, "Age:min")
+//	}
+package validationtest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+// AssertValid fails the test when v does not pass validation, listing the
+// errors
+func AssertValid(t testing.TB, v interface{}, opts ...validation.ValidateOption) {
+	t.Helper()
+	if err := validation.Struct(v, opts...); err != nil {
+		t.Errorf("expected %T to be valid, got:\n%s", v, describe(err))
+	}
+}
+
+// AssertInvalid fails the test unless v fails validation with exactly the
+// expected errors, in any order. Each expectation is "Field:tag", where
+// Field is the error's namespace (Address.City, Tags[0]) or field name, or
+// just "Field" to accept any tag. With no expectations any failure passes.
+func AssertInvalid(t testing.TB, v interface{}, expected ...string) {
+	t.Helper()
+	err := validation.Struct(v)
+	if err == nil {
+		t.Errorf("expected %T to be invalid, but it passed validation", v)
+		return
+	}
+	if len(expected) == 0 {
+		return
+	}
+
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("expected validation errors for %T, got %v", v, err)
+		return
+	}
+
+	unmatched := append(validation.ValidationErrors(nil), errs...)
+	var missing []string
+	for _, want := range expected {
+		i := indexOf(unmatched, want)
+		if i < 0 {
+			missing = append(missing, want)
+			continue
+		}
+		unmatched = append(unmatched[:i], unmatched[i+1:]...)
+	}
+
+	if len(missing) > 0 {
+		t.Errorf("expected %T to fail with %s, got:\n%s", v, strings.Join(missing, ", "), describe(errs))
+	}
+	if len(unmatched) > 0 {
+		t.Errorf("unexpected errors for %T:\n%s", v, describe(unmatched))
+	}
+}
+
+// indexOf returns the first error matching a "Field:tag" expectation
+func indexOf(errs validation.ValidationErrors, expectation string) int {
+	field, tag, hasTag := strings.Cut(expectation, ":")
+	for i, err := range errs {
+		if field != errorPath(err) && field != err.Field {
+			continue
+		}
+		if !hasTag || tag == err.Tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// errorPath returns the namespace of an error, falling back to its field
+func errorPath(err validation.ValidationError) string {
+	if err.Namespace != "" {
+		return err.Namespace
+	}
+	return err.Field
+}
+
+// describe lists validation errors one per line as "Field:tag: message",
+// sorted for stable output
+func describe(err error) string {
+	var errs validation.ValidationErrors
+	if !errors.As(err, &errs) {
+		return "  " + err.Error()
+	}
+	lines := make([]string, 0, len(errs))
+	for _, e := range errs {
+		lines = append(lines, fmt.Sprintf("  %s:%s: %s", errorPath(e), e.Tag, e.Error()))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,241 @@
+package validationtest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mateothegreat/go-validation"
+)
+
+type address struct {
+	Street string `validate:"required,min=3"`
+	City   string `validate:"required,alpha"`
+}
+
+type account struct {
+	Name     string            `validate:"required,min=3,max=20"`
+	Email    string            `validate:"required,email"`
+	Age      int               `validate:"min=18,max=130"`
+	Role     string            `validate:"oneof=admin user"`
+	Password string            `validate:"required,min=8"`
+	Confirm  string            `validate:"eqfield=Password"`
+	MinSize  int               `validate:"min=1"`
+	MaxSize  int               `validate:"gtfield=MinSize"`
+	Timeout  time.Duration     `validate:"required"`
+	Address  address           `validate:"required"`
+	Backup   *address          `validate:"omitempty"`
+	Tags     []string          `validate:"minitems=2,dive,required,alphanum,min=3"`
+	Labels   map[string]string `validate:"haskeys=team,dive,required"`
+	Nickname string            `validate:"omitempty,min=3"`
+	Database *int              `validate:"defined,min=0,max=15"`
+	Verbose  string            `validate:"required,boolean"`
+}
+
+func TestFixture(t *testing.T) {
+	a := Fixture[account](t)
+	AssertValid(t, a)
+
+	if a.Name != "xxx" || a.Age != 18 || a.Role != "admin" || a.Timeout != time.Second {
+		t.Errorf("expected minimal values, got %+v", a)
+	}
+	if a.Confirm != a.Password || a.MaxSize <= a.MinSize {
+		t.Errorf("expected cross-field rules to hold, got %+v", a)
+	}
+	if len(a.Tags) != 2 || a.Tags[0] != "aaa" || a.Labels["team"] == "" {
+		t.Errorf("expected collections filled to their minimums, got %v and %v", a.Tags, a.Labels)
+	}
+	if a.Database == nil || *a.Database != 0 || a.Verbose != "true" {
+		t.Errorf("expected a defined zero database and a boolean string, got %v and %q", a.Database, a.Verbose)
+	}
+	if a.Backup != nil || a.Nickname != "" {
+		t.Errorf("expected optional fields to stay zero, got %+v", a)
+	}
+
+	pointer := Fixture[*account](t)
+	AssertValid(t, pointer)
+
+	modified := Fixture(t, func(a *account) { a.Age = 12 })
+	if modified.Age != 12 {
+		t.Errorf("expected modifiers to apply, got %d", modified.Age)
+	}
+}
+
+func TestFixture_Strings(t *testing.T) {
+	type server struct {
+		Name  string `validate:"required,min=3:chars,max=20"`
+		Token string `validate:"min=8:bytes"`
+		Zone  string `validate:"alpha"`
+	}
+	s := Fixture[server](t)
+	AssertValid(t, s)
+	if s.Name != "xxx" || len(s.Token) != 8 || s.Zone != "a" {
+		t.Errorf("expected minimal strings, got %+v", s)
+	}
+}
+
+func TestFixture_Temporal(t *testing.T) {
+	type certificate struct {
+		NotBefore time.Time `validate:"required,not_before_now"`
+		NotAfter  time.Time `validate:"required,expires_after=30d"`
+		Issued    string    `validate:"required,within=24h"`
+	}
+	c := Fixture[certificate](t)
+	AssertValid(t, c)
+	if time.Until(c.NotAfter) < 30*24*time.Hour {
+		t.Errorf("expected the certificate to expire after 30 days, got %v", c.NotAfter)
+	}
+}
+
+func TestBuild_Impossible(t *testing.T) {
+	type impossible struct {
+		Code string `validate:"required,len=2,email"`
+	}
+	if _, err := Build[impossible](); err == nil || !strings.Contains(err.Error(), "Code:") {
+		t.Errorf("expected the failing rules to be reported, got %v", err)
+	}
+	if _, err := Build[int](); err == nil {
+		t.Error("expected non-struct types to be rejected")
+	}
+}
+
+// recorder captures test failures
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertInvalid(t *testing.T) {
+	invalid := Fixture(t, func(a *account) {
+		a.Email = "nope"
+		a.Age = 12
+		a.Address.City = "Paris1"
+	})
+
+	for name, tc := range map[string]struct {
+		expected []string
+		failures int
+	}{
+		"exact":       {[]string{"Email:email", "Age:min", "Address.City:alpha"}, 0},
+		"field name":  {[]string{"Email", "Age:min", "City:alpha"}, 0},
+		"any failure": {nil, 0},
+		"missing":     {[]string{"Email:email", "Age:min", "Address.City:alpha", "Name:required"}, 1},
+		"unexpected":  {[]string{"Email:email", "Age:min"}, 1},
+		"wrong tag":   {[]string{"Email:required", "Age:min", "Address.City:alpha"}, 2},
+	} {
+		r := &recorder{TB: t}
+		AssertInvalid(r, invalid, tc.expected...)
+		if len(r.failures) != tc.failures {
+			t.Errorf("%s: expected %d failures, got %v", name, tc.failures, r.failures)
+		}
+	}
+
+	r := &recorder{TB: t}
+	AssertInvalid(r, Fixture[account](t))
+	AssertValid(r, invalid)
+	if len(r.failures) != 2 {
+		t.Errorf("expected valid and invalid values to be reported, got %v", r.failures)
+	}
+}
+
+func TestMutants(t *testing.T) {
+	valid := Fixture[account](t)
+	mutants := Mutants(valid)
+
+	found := make(map[string]bool)
+	for _, m := range mutants {
+		found[m.String()] = true
+		AssertInvalid(t, m.Value)
+	}
+	for _, expected := range []string{
+		"Name breaking required",
+		"Name breaking min=3",
+		"Name breaking max=20",
+		"Email breaking email",
+		"Age breaking min=18",
+		"Role breaking oneof=admin user",
+		"Address.City breaking alpha",
+		"Tags breaking minitems=2",
+		"Tags[0] breaking alphanum",
+		"Tags[0] breaking min=3",
+		"Database breaking defined",
+		"Database breaking max=15",
+		"Nickname breaking min=3",
+	} {
+		if !found[expected] {
+			t.Errorf("expected mutant %q, got %v", expected, mutants)
+		}
+	}
+	for _, skipped := range []string{"Confirm breaking eqfield=Password", "MaxSize breaking gtfield=MinSize"} {
+		if found[skipped] {
+			t.Errorf("expected no mutant %q", skipped)
+		}
+	}
+	AssertValid(t, valid)
+
+	// A validator dropping the alpha rule of the city lets its mutant through
+	r := &recorder{TB: t}
+	AssertRejectsMutants(r, valid, func(a *account) error {
+		if a.Address.City == "1" {
+			return nil
+		}
+		return validation.Struct(a)
+	})
+	if len(r.failures) != 1 || !strings.Contains(r.failures[0], "Address.City breaking alpha") {
+		t.Errorf("expected the dropped rule to be reported, got %v", r.failures)
+	}
+
+	// Size specs are broken like plain lengths
+	type server struct {
+		Name string `validate:"required,min=3:chars,max=20:chars"`
+	}
+	found = make(map[string]bool)
+	for _, m := range Mutants(Fixture[server](t)) {
+		found[m.String()] = true
+		AssertInvalid(t, m.Value)
+	}
+	for _, expected := range []string{"Name breaking min=3:chars", "Name breaking max=20:chars"} {
+		if !found[expected] {
+			t.Errorf("expected mutant %q, got %v", expected, found)
+		}
+	}
+}
+
+func TestDivergences(t *testing.T) {
+	reflection := func(a *account) error { return validation.Struct(a) }
+	AssertEquivalent(t, 200, reflection)
+
+	// A validator ignoring the age bounds diverges on the values breaking them
+	rng := rand.New(rand.NewPCG(1, 2))
+	divergences := Divergences(rng, 200, func(a *account) error {
+		a.Age = 18
+		return validation.Struct(a)
+	})
+	if len(divergences) == 0 {
+		t.Fatal("expected divergences for the dropped age rules")
+	}
+	for _, d := range divergences {
+		if d.Value.Age == 18 || !strings.Contains(strings.Join(d.Reflection, ","), "Age:") {
+			t.Errorf("expected only values with an invalid age to diverge, got %s", d)
+		}
+	}
+
+	r := &recorder{TB: t}
+	AssertEquivalent(r, 200, func(a *account) error {
+		if validation.Struct(a) != nil {
+			return fmt.Errorf("invalid account")
+		}
+		return nil
+	})
+	if len(r.failures) != 0 {
+		t.Errorf("expected opaque errors to be compared for pass or fail, got %v", r.failures)
+	}
+}
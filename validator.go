@@ -1,30 +1,80 @@
 package validation
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
 )
 
-// Validator provides high-level validation functionality
+// Validator provides high-level validation functionality.
+//
+// Rule maps are copy-on-write: every mutation builds new maps and publishes an
+// immutable snapshot of the validator, so registering rules while other
+// goroutines validate is safe and validation never takes a lock.
 type Validator struct {
 	tagName       string
 	rules         map[string][]ValidationFunc
 	customRules   map[string]ValidationFunc
 	structRules   map[reflect.Type]StructLevelValidationFunc
+	typeRules     map[reflect.Type]string // dynamic type -> tag applied to interface fields holding it
+	ruleGroups    map[string][]string     // rule -> groups it was registered in
+	ruleDescriptions map[string]string    // rule -> description given to DescribeRule
+	uniqueResolvers map[string]uniqueResolver // unique_db resolvers by name
+	secretResolvers map[string]SecretResolver // secret reference resolvers by scheme
+	schemas       map[string]*jsonSchema  // JSON schemas used by the jsonschema rule
+	blocklists    map[string]*blocklist   // word lists used by the notcontains_blocklist rule
+	paramSchemas  map[string]ParamSchema  // parameter schemas by rule
+	policy        map[policyField]policyOverride // rule overrides loaded by LoadPolicy
+	customTypes   map[reflect.Type]CustomTypeFunc
+	scrubFunc     ScrubFunc
+	paramFormatter ParamFormatter
+	mxLookup      MXLookupFunc        // used by email_mx, nil for DNS
+	disposableFunc DisposableEmailFunc // used by email_not_disposable, nil for the embedded list
+	usernamePolicy *usernamePolicy     // used by username, nil for the defaults
 	fieldNameFunc FieldNameFunc
 	errorCollector *ErrorCollector
 	config        ValidatorConfig
-	mu            sync.RWMutex
+	messages      map[string]map[string]string // locale -> tag -> message format
+	templates     map[string]map[string]*template.Template // locale -> tag -> message template
+	hooks         Hooks                        // notified on every validation call
+	ruleHooks     RuleHooks                    // set per call when hooks observe rules
+	ctx           context.Context              // set per call when hooks are installed
+	info          ValidationInfo               // set per call when hooks are installed
+	mu            sync.Mutex                // serializes mutations
+	snapshot      atomic.Pointer[Validator] // immutable copy used by validation calls
+	origin        *Validator                // set on snapshots, points at the mutable validator
+	plans         *planCache                // compiled struct plans, one cache per snapshot
 }
 
 // ValidationFunc defines a validation function signature
 type ValidationFunc func(fl FieldLevel) bool
 
-// StructLevelValidationFunc defines a struct-level validation function
+// ValidationErrFunc defines a validation function explaining its failures:
+// it returns nil for valid fields, otherwise an error whose message is
+// reported. A ValidationError also sets the tag, param, value or code of the
+// reported error; fields it leaves empty are filled in as for ValidationFunc.
+type ValidationErrFunc func(fl FieldLevel) error
+
+// StructLevelValidationFunc defines a struct-level validation function. It
+// runs before the field rules of the struct it is registered for, so its
+// errors come first and it sees fields their rules will reject; a nested
+// struct's function runs when the traversal reaches the nested struct.
 type StructLevelValidationFunc func(sl StructLevel)
 
+// CustomTypeFunc returns the underlying value rules should see for a custom
+// type, e.g. the string of a valid sql.NullString. Returning nil treats the
+// field as unset.
+type CustomTypeFunc func(field reflect.Value) interface{}
+
 // FieldNameFunc defines a function to get field names for errors
 type FieldNameFunc func(fld reflect.StructField) string
 
@@ -33,8 +83,47 @@ type ValidatorConfig struct {
 	TagName      string // Default: "validate"
 	FailFast     bool   // Stop on first error
 	IgnoreFields []string // Fields to ignore during validation
+	Locale       string // Locale used to look up registered messages (default: built-in English)
+	Debug        bool         // Log every evaluated field, rule, skip decision and cross-field lookup
+	DebugLogger  *slog.Logger // Receives debug records at slog.LevelDebug (default: text on stderr)
+	TextValues   bool         // Validate fmt.Stringer/encoding.TextMarshaler fields by their text for string-format rules
+	FieldNameTags []string    // Struct tags resolving error field names, in priority order (default: json)
+	MaxErrors    int          // Cap on reported errors; further ones are summarized by a trailing error (0: unlimited)
+	MaxInputLength int        // Reject strings and byte slices longer than this many bytes before running any rule (0: unlimited)
+	Timeout      time.Duration // Abort a Struct or Var call running longer than this (0: no limit beyond the caller's context)
+	MaxDepth     int           // Deepest struct nesting validated; deeper structs are reported with code max_depth (0: DefaultMaxDepth)
+	RequiredSemantics RequiredSemantics // What the required rule accepts (default: RequiredNonZero)
+	Scenario     string        // Applies the rules suffixed @Scenario besides the unsuffixed ones (default: unsuffixed rules only)
+	UniqueConcurrency int      // unique_db resolver calls run at once by a Struct or Var call (0: DefaultUniqueConcurrency)
+	RuleFilter   RuleFilter    // Skips the rules it rejects, usually set per call with WithRuleFilter or WithoutRuleGroups
 }
 
+// RequiredSemantics selects what the required rule accepts
+type RequiredSemantics int
+
+const (
+	// RequiredNonZero rejects nil and zero values, including false and 0
+	RequiredNonZero RequiredSemantics = iota
+	// RequiredPresence rejects nil and empty values but accepts false and 0,
+	// for which a Go value cannot tell unset from set: required on a bool or
+	// number then always passes and leaves the range to other rules
+	RequiredPresence
+)
+
+// presenceRules are evaluated on nil values and see pointers themselves
+// rather than the values they point to
+var presenceRules = map[string]bool{
+	"required": true,
+	"defined":  true,
+}
+
+// DefaultMaxDepth is the struct nesting validated when ValidatorConfig.MaxDepth is zero
+const DefaultMaxDepth = 64
+
+// DefaultUniqueConcurrency is the number of unique_db resolver calls run at
+// once when ValidatorConfig.UniqueConcurrency is zero
+const DefaultUniqueConcurrency = 8
+
 // DefaultValidatorConfig returns default configuration
 func DefaultValidatorConfig() ValidatorConfig {
 	return ValidatorConfig{
@@ -57,10 +146,15 @@ func NewWithConfig(config ValidatorConfig) *Validator {
 		structRules:   make(map[reflect.Type]StructLevelValidationFunc),
 		config:        config,
 		fieldNameFunc: defaultFieldNameFunc,
+		paramSchemas:  builtinParamSchemas,
+	}
+	if len(config.FieldNameTags) > 0 {
+		v.fieldNameFunc = FieldNameFromTags(config.FieldNameTags...)
 	}
 	
 	// Register built-in validation rules
 	v.registerBuiltInRules()
+	v.publish()
 	
 	return v
 }
@@ -73,6 +167,8 @@ func (v *Validator) SetTagName(name string) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	v.tagName = name
+	v.config.TagName = name
+	v.publish()
 }
 
 // SetFieldNameFunc sets the function to use for getting field names
@@ -80,33 +176,190 @@ func (v *Validator) SetFieldNameFunc(fn FieldNameFunc) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	v.fieldNameFunc = fn
+	v.publish()
 }
 
 // RegisterValidation registers a custom validation function
 func (v *Validator) RegisterValidation(tag string, fn ValidationFunc) error {
+	if tag == "" {
+		return fmt.Errorf("validation tag cannot be empty")
+	}
+	
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	
-	if tag == "" {
-		return fmt.Errorf("validation tag cannot be empty")
+	customRules := make(map[string]ValidationFunc, len(v.customRules)+1)
+	for name, ruleFn := range v.customRules {
+		customRules[name] = ruleFn
 	}
+	customRules[tag] = fn
 	
-	v.customRules[tag] = fn
+	v.customRules = customRules
+	v.publish()
 	return nil
 }
 
+// RegisterValidationErr registers a validation function returning the
+// reason a field is invalid, reported instead of the generic rule message
+func (v *Validator) RegisterValidationErr(tag string, fn ValidationErrFunc) error {
+	if fn == nil {
+		return fmt.Errorf("validation function for %q cannot be nil", tag)
+	}
+	return v.RegisterValidation(tag, func(fl FieldLevel) bool {
+		err := fn(fl)
+		if impl, ok := fl.(*fieldLevel); ok {
+			impl.failure = err
+		}
+		return err == nil
+	})
+}
+
 // RegisterStructValidation registers a struct-level validation function
 func (v *Validator) RegisterStructValidation(fn StructLevelValidationFunc, types ...interface{}) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	
+	structRules := make(map[reflect.Type]StructLevelValidationFunc, len(v.structRules)+len(types))
+	for typ, structFn := range v.structRules {
+		structRules[typ] = structFn
+	}
+	for _, t := range types {
+		structRules[reflect.TypeOf(t)] = fn
+	}
+	
+	v.structRules = structRules
+	v.publish()
+}
+
+// RegisterTypeRules registers a validation tag applied to interface fields
+// whose dynamic value has one of the given types, so each variant of a
+// polymorphic config block can carry its own rules. The rules run in
+// addition to the field's own tag.
+func (v *Validator) RegisterTypeRules(tag string, types ...interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	
+	typeRules := make(map[reflect.Type]string, len(v.typeRules)+len(types))
+	for typ, typeTag := range v.typeRules {
+		typeRules[typ] = typeTag
+	}
+	for _, t := range types {
+		typeRules[reflect.TypeOf(t)] = tag
+	}
+	
+	v.typeRules = typeRules
+	v.publish()
+}
+
+// RegisterCustomTypeFunc registers a function extracting the value validated
+// in place of fields of the given types, so wrappers such as sql.NullString,
+// uuid.UUID or decimal types work with rules like required, min and max.
+// Registered types are not validated as nested structs.
+func (v *Validator) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	
+	customTypes := make(map[reflect.Type]CustomTypeFunc, len(v.customTypes)+len(types))
+	for typ, typeFn := range v.customTypes {
+		customTypes[typ] = typeFn
+	}
 	for _, t := range types {
-		v.structRules[reflect.TypeOf(t)] = fn
+		customTypes[reflect.TypeOf(t)] = fn
+	}
+	
+	v.customTypes = customTypes
+	v.publish()
+}
+
+// RegisterMessages registers message formats for a locale, keyed by rule tag.
+// Formats receive the field name as %[1]s and the rule parameter as %[2]s.
+func (v *Validator) RegisterMessages(locale string, messages map[string]string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	
+	catalogs := make(map[string]map[string]string, len(v.messages)+1)
+	for loc, catalog := range v.messages {
+		catalogs[loc] = catalog
+	}
+	
+	catalog := make(map[string]string, len(catalogs[locale])+len(messages))
+	for tag, format := range catalogs[locale] {
+		catalog[tag] = format
+	}
+	for tag, format := range messages {
+		catalog[tag] = format
+	}
+	catalogs[locale] = catalog
+	
+	v.messages = catalogs
+	v.publish()
+}
+
+// publish stores an immutable copy of the current rules and configuration.
+// Callers must hold v.mu and must never mutate the published maps afterwards.
+func (v *Validator) publish() {
+	snapshot := v.clone()
+	snapshot.plans = &planCache{}
+	v.snapshot.Store(snapshot)
+}
+
+// clone returns a copy of the validator sharing its immutable rule maps
+func (v *Validator) clone() *Validator {
+	return &Validator{
+		tagName:       v.tagName,
+		rules:         v.rules,
+		customRules:   v.customRules,
+		structRules:   v.structRules,
+		typeRules:     v.typeRules,
+		ruleGroups:    v.ruleGroups,
+		ruleDescriptions: v.ruleDescriptions,
+		uniqueResolvers: v.uniqueResolvers,
+		secretResolvers: v.secretResolvers,
+		schemas:       v.schemas,
+		blocklists:    v.blocklists,
+		paramSchemas:  v.paramSchemas,
+		policy:        v.policy,
+		customTypes:   v.customTypes,
+		scrubFunc:     v.scrubFunc,
+		paramFormatter: v.paramFormatter,
+		mxLookup:      v.mxLookup,
+		disposableFunc: v.disposableFunc,
+		usernamePolicy: v.usernamePolicy,
+		fieldNameFunc: v.fieldNameFunc,
+		messages:      v.messages,
+		templates:     v.templates,
+		hooks:         v.hooks,
+		plans:         v.plans,
+		config:        v.config,
+		origin:        v.root(),
 	}
 }
 
-// Struct validates a struct based on its tags
-func (v *Validator) Struct(s interface{}) error {
+// current returns the latest published snapshot used to run a validation
+func (v *Validator) current() *Validator {
+	if snapshot := v.snapshot.Load(); snapshot != nil {
+		return snapshot
+	}
+	return v
+}
+
+// root returns the mutable validator a snapshot was published from
+func (v *Validator) root() *Validator {
+	if v.origin != nil {
+		return v.origin
+	}
+	return v
+}
+
+// Struct validates a struct based on its tags. Options apply to this call only.
+func (v *Validator) Struct(s interface{}, opts ...ValidateOption) error {
+	return v.StructCtx(context.Background(), s, opts...)
+}
+
+// StructCtx validates a struct, passing ctx to the installed hooks. When ctx
+// is done, or the configured Timeout elapses, validation stops and returns an
+// error wrapping ErrValidationAborted and the context's error.
+func (v *Validator) StructCtx(ctx context.Context, s interface{}, opts ...ValidateOption) error {
 	if s == nil {
 		return nil
 	}
@@ -123,30 +376,137 @@ func (v *Validator) Struct(s interface{}) error {
 		return fmt.Errorf("validation can only be performed on structs, got %s", val.Kind())
 	}
 	
+	return v.structCtx(ctx, val, reflect.Value{}, opts)
+}
+
+// structCtx validates the struct val, comparing its immutable fields with
+// old when old is valid
+func (v *Validator) structCtx(ctx context.Context, val reflect.Value, old reflect.Value, opts []ValidateOption) error {
+	v = v.current().withOptions(opts)
+	if plan := v.planFor(val.Type()); plan.err != nil {
+		return plan.err
+	}
+	
+	ctx, cancel := v.deadline(ctx)
+	defer cancel()
 	collector := NewErrorCollector()
 	collector.SetFailFast(v.config.FailFast)
+	collector.SetMaxErrors(v.config.MaxErrors)
+	collector.SetContext(ctx)
+	
+	if v.hooks != nil {
+		var start time.Time
+		v, start = v.startHooks(ctx, ValidationInfo{Kind: KindStruct, TypeName: typeName(val), FieldCount: val.NumField()})
+		defer v.endHooks(start, collector)
+	}
 	
 	v.validateStruct(val, val.Type(), "", collector)
+	if old.IsValid() && !collector.ShouldStop() {
+		v.checkImmutable(old, val, val.Type(), "", collector)
+	}
+	v.resolveUnique(ctx, collector)
+	if collector.Canceled() {
+		return fmt.Errorf("%w: %w", ErrValidationAborted, context.Cause(ctx))
+	}
 	
 	if collector.HasErrors() {
+		v.scrub(collector)
 		return collector.Errors()
 	}
 	
 	return nil
 }
 
-// Var validates a single variable against a validation tag
-func (v *Validator) Var(field interface{}, tag string) error {
+// ErrValidationAborted is returned, together with the context's error, when a
+// call's context is done or its Timeout elapses before validation completes.
+// Errors found until then are discarded.
+var ErrValidationAborted = errors.New("validation aborted")
+
+// deadline bounds ctx by the configured Timeout
+func (v *Validator) deadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if v.config.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, v.config.Timeout)
+}
+
+// Var validates a single variable against a validation tag. Options apply to this call only.
+func (v *Validator) Var(field interface{}, tag string, opts ...ValidateOption) error {
+	return v.VarCtx(context.Background(), field, tag, opts...)
+}
+
+// VarCtx validates a single variable, passing ctx to the installed hooks and
+// stopping like StructCtx once ctx is done or the Timeout elapses
+func (v *Validator) VarCtx(ctx context.Context, field interface{}, tag string, opts ...ValidateOption) error {
+	return v.varCtx(ctx, reflect.ValueOf(field), reflect.Value{}, "field", "", tag, opts)
+}
+
+// VarField validates the field of struct s named field against tag instead
+// of its own tag, with s as the parent cross-field rules read, e.g. to run a
+// single rule of generated code through the registered rules
+func (v *Validator) VarField(s interface{}, field string, tag string, opts ...ValidateOption) error {
+	parent := indirectValue(reflect.ValueOf(s))
+	if parent.Kind() != reflect.Struct {
+		return fmt.Errorf("validation: VarField expects a struct, got %T", s)
+	}
+	structField, ok := parent.Type().FieldByName(field)
+	if !ok || !structField.IsExported() {
+		return fmt.Errorf("validation: %s has no exported field %s", parent.Type(), field)
+	}
+	val, err := parent.FieldByIndexErr(structField.Index)
+	if err != nil {
+		return fmt.Errorf("validation: %s.%s: %w", parent.Type(), field, err)
+	}
+	return v.varCtx(context.Background(), val, parent, v.current().fieldNameFunc(structField), structField.Name, tag, opts)
+}
+
+// varCtx validates val, a field of parent when parent is valid, against tag
+func (v *Validator) varCtx(ctx context.Context, val reflect.Value, parent reflect.Value, fieldName, structField, tag string, opts []ValidateOption) error {
 	if tag == "" {
 		return nil
 	}
 	
-	val := reflect.ValueOf(field)
+	v = v.current().withOptions(opts)
+	if tag = v.scenarioTag(tag); tag == "" {
+		return nil
+	}
+	ctx, cancel := v.deadline(ctx)
+	defer cancel()
 	collector := NewErrorCollector()
+	collector.SetFailFast(v.config.FailFast)
+	collector.SetMaxErrors(v.config.MaxErrors)
+	collector.SetContext(ctx)
 	
-	v.validateField(val, reflect.Value{}, "field", tag, collector)
+	if v.hooks != nil {
+		var start time.Time
+		v, start = v.startHooks(ctx, ValidationInfo{Kind: KindVar, TypeName: typeName(val), Tag: tag})
+		defer v.endHooks(start, collector)
+	}
+	
+	collector.sensitive = hasSensitiveTag(tag)
+	if strings.Contains(tag, "dive") {
+		// As in Struct, the elements are reported at their path, e.g. Tags[0]
+		collectionTag, elemTag := splitDiveTag(tag)
+		if collectionTag != "" {
+			v.validateField(val, parent, fieldName, collectionTag, collector)
+		}
+		v.validateDive(val, fieldName, elemTag, collector)
+	} else {
+		v.validateField(val, parent, fieldName, tag, collector)
+	}
+	if structField != "" {
+		collector.setStructField(0, structField)
+	}
+	v.resolveUnique(ctx, collector)
+	if hasSensitiveTag(tag) {
+		collector.redact(0)
+	}
+	if collector.Canceled() {
+		return fmt.Errorf("%w: %w", ErrValidationAborted, context.Cause(ctx))
+	}
 	
 	if collector.HasErrors() {
+		v.scrub(collector)
 		return collector.Errors()
 	}
 	
@@ -162,22 +522,47 @@ func (v *Validator) VarWithValue(field interface{}, other interface{}, tag strin
 
 // validateStruct validates a struct recursively
 func (v *Validator) validateStruct(val reflect.Value, typ reflect.Type, namespace string, collector *ErrorCollector) {
-	// Check for struct-level validation
-	if structFn, exists := v.structRules[typ]; exists {
+	if !v.enterStruct(val, namespace, collector) {
+		return
+	}
+	defer collector.leaveStruct(val)
+	
+	plan := v.planFor(typ)
+	
+	// Check for struct-level validation, then the declared field groups
+	structFn, exists := v.structRules[typ]
+	if exists || plan.groups != nil {
 		sl := &structLevel{
-			validator: v,
+			validator: v.root(),
+			call:      v,
 			top:       val,
 			current:   val,
 			namespace: namespace,
+			ctx:       collector.context(),
+		}
+		if exists {
+			structFn(sl)
+		}
+		if plan.groups != nil {
+			plan.groups(sl)
 		}
-		structFn(sl)
 		if sl.errors.HasErrors() {
 			collector.Merge(sl.errors)
 		}
 	}
 	
-	// Validate individual fields
-	for i := 0; i < val.NumField(); i++ {
+	// Validate individual fields, referenced fields before the fields
+	// referencing them, reporting their errors in declaration order
+	order, reordered := plan.orderFor(v.config.Scenario)
+	var spans []fieldSpan
+	if reordered {
+		spans = make([]fieldSpan, 0, len(order))
+		defer func() { collector.reorderSpans(spans) }()
+	}
+	for _, i := range order {
+		if spans != nil {
+			spans = collector.startSpan(spans, i)
+		}
 		fieldVal := val.Field(i)
 		fieldType := typ.Field(i)
 		
@@ -188,6 +573,9 @@ func (v *Validator) validateStruct(val reflect.Value, typ reflect.Type, namespac
 		
 		// Skip ignored fields
 		if v.isIgnoredField(fieldType.Name) {
+			if v.config.Debug {
+				v.debugSkip(fieldType.Name, "", "ignored field")
+			}
 			continue
 		}
 		
@@ -197,22 +585,39 @@ func (v *Validator) validateStruct(val reflect.Value, typ reflect.Type, namespac
 			fullPath = namespace + "." + fieldName
 		}
 		
+		// Errors reported from here on carry the Go field name
+		mark := collector.Count()
+		
 		// Get validation tag
-		tag := fieldType.Tag.Get(v.tagName)
+		tag := v.scenarioTag(v.fieldTag(typ, fieldType))
+		if v.config.Debug {
+			v.debugField(fullPath, tag, fieldVal)
+		}
 		if tag == "" || tag == "-" {
 			// Handle nested structs even without validation tags
 			if fieldVal.Kind() == reflect.Struct || (fieldVal.Kind() == reflect.Ptr && fieldVal.Type().Elem().Kind() == reflect.Struct) {
 				v.validateNestedStruct(fieldVal, fullPath, collector)
 			}
+			if fieldVal.Kind() == reflect.Interface {
+				collector.SetNamespace(namespace)
+				v.validateInterface(fieldVal, val, fieldName, fullPath, collector)
+			}
+			collector.setStructField(mark, fieldType.Name)
 			continue
 		}
 		
 		// Set namespace for error collection
 		collector.SetNamespace(namespace)
+		sensitive := collector.sensitive
+		collector.sensitive = sensitive || hasSensitiveTag(tag)
 		
 		// Handle nested struct validation
 		if strings.Contains(tag, "dive") {
-			v.validateDive(fieldVal, fullPath, tag, collector)
+			collectionTag, elemTag := splitDiveTag(tag)
+			if collectionTag != "" {
+				v.validateField(fieldVal, val, fieldName, collectionTag, collector)
+			}
+			v.validateDive(fieldVal, fullPath, elemTag, collector)
 		} else {
 			v.validateField(fieldVal, val, fieldName, tag, collector)
 			
@@ -220,6 +625,14 @@ func (v *Validator) validateStruct(val reflect.Value, typ reflect.Type, namespac
 			if fieldVal.Kind() == reflect.Struct || (fieldVal.Kind() == reflect.Ptr && fieldVal.Type().Elem().Kind() == reflect.Struct) {
 				v.validateNestedStruct(fieldVal, fullPath, collector)
 			}
+			if fieldVal.Kind() == reflect.Interface && !collector.ShouldStop() {
+				v.validateInterface(fieldVal, val, fieldName, fullPath, collector)
+			}
+		}
+		collector.sensitive = sensitive
+		collector.setStructField(mark, fieldType.Name)
+		if hasSensitiveTag(tag) {
+			collector.redact(mark)
 		}
 		
 		if collector.ShouldStop() {
@@ -230,7 +643,14 @@ func (v *Validator) validateStruct(val reflect.Value, typ reflect.Type, namespac
 
 // validateField validates a single field with its validation rules
 func (v *Validator) validateField(val reflect.Value, parent reflect.Value, fieldName, tag string, collector *ErrorCollector) {
-	rules := strings.Split(tag, ",")
+	val = v.extractCustomType(val)
+	if hasSecretTag(tag) {
+		var resolved bool
+		if val, resolved = v.resolveSecretValue(val, fieldName, collector); !resolved {
+			return
+		}
+	}
+	rules := SplitRules(tag)
 	
 	// Check if omitempty is present
 	hasOmitEmpty := false
@@ -249,6 +669,10 @@ func (v *Validator) validateField(val reflect.Value, parent reflect.Value, field
 		field:     val,
 		fieldName: fieldName,
 	}) {
+		if v.config.Debug {
+			v.debugSkip(fieldName, "", "omitempty: empty value, only required rules evaluated")
+		}
+		
 		// Only process required-like rules for empty fields with omitempty
 		for _, rule := range rules {
 			rule = strings.TrimSpace(rule)
@@ -260,7 +684,7 @@ func (v *Validator) validateField(val reflect.Value, parent reflect.Value, field
 			ruleName := parts[0]
 			
 			// Only validate required-like rules
-			if strings.HasPrefix(ruleName, "required") {
+			if strings.HasPrefix(ruleName, "required") && !v.ruleSkipped(fieldName, ruleName) {
 				var param string
 				if len(parts) > 1 {
 					param = parts[1]
@@ -276,20 +700,31 @@ func (v *Validator) validateField(val reflect.Value, parent reflect.Value, field
 					tag:         ruleName,
 				}
 				
+				if !v.resolveRuleParam(fl, collector) {
+					continue
+				}
 				if customFn, exists := v.customRules[ruleName]; exists {
-					if !customFn(fl) {
-						collector.AddFieldErrorWithParam(fieldName, ruleName, param, 
-							v.getErrorMessage(ruleName, fieldName, param), val.Interface())
-					}
+					v.runCustomRule(customFn, fl, collector)
 				}
 			}
 		}
 		return
 	}
 
+	if v.inputTooLong(val) {
+		collector.Add(ValidationError{
+			Field:   fieldName,
+			Tag:     "maxinput",
+			Param:   strconv.Itoa(v.config.MaxInputLength),
+			Message: fmt.Sprintf(ErrorMsgInputTooLong, fieldName, v.config.MaxInputLength),
+			Code:    ErrCodeInputTooLong,
+		})
+		return
+	}
+
 	for _, rule := range rules {
 		rule = strings.TrimSpace(rule)
-		if rule == "" || rule == "omitempty" {
+		if rule == "" || rule == "omitempty" || rule == "sensitive" || rule == "secret" {
 			continue
 		}
 		
@@ -301,35 +736,88 @@ func (v *Validator) validateField(val reflect.Value, parent reflect.Value, field
 			param = parts[1]
 		}
 		
-		// Skip validation if field is nil and rule is not "required"
-		if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
-			if ruleName != "required" {
+		if v.ruleSkipped(fieldName, ruleName) {
+			continue
+		}
+		
+		// Skip validation if field is nil and rule is not a presence rule
+		if isNilValue(val) {
+			if !presenceRules[ruleName] {
+				if v.config.Debug {
+					v.debugSkip(fieldName, ruleName, "nil value")
+				}
 				continue
 			}
 		}
 		
+		// Rules other than the presence rules operate on the pointed-to value
+		field := val
+		if !presenceRules[ruleName] {
+			field = indirectValue(val)
+		}
+		if v.config.TextValues && textFormatRules[ruleName] {
+			field = textValue(field)
+		}
+		
 		// Create field level context
 		fl := &fieldLevel{
 			validator:   v,
 			top:         parent,
 			parent:      parent,
-			field:       val,
+			field:       field,
 			fieldName:   fieldName,
 			param:       param,
 			tag:         ruleName,
 		}
 		
+		// $Field and ${ENV} parameters are resolved for each value
+		if !v.resolveRuleParam(fl, collector) {
+			if collector.ShouldStop() {
+				return
+			}
+			continue
+		}
+		param = fl.param
+		
+		// keys= and values= validate every map entry with their own rules
+		if isMapEntryRule(ruleName) && param != "" {
+			v.validateMapEntries(field, fieldName, ruleName, param, collector)
+			if collector.ShouldStop() {
+				return
+			}
+			continue
+		}
+		
+		// unique_db is resolved once the whole value was walked
+		if ruleName == "unique_db" {
+			v.deferUnique(fl, collector)
+			continue
+		}
+		
+		// email_mx looks the domain up with the call's context
+		if ruleName == "email_mx" {
+			v.checkEmailMX(fl, collector)
+			if collector.ShouldStop() {
+				return
+			}
+			continue
+		}
+		
 		// Check custom rules first
 		if customFn, exists := v.customRules[ruleName]; exists {
-			if !customFn(fl) {
-				collector.AddFieldErrorWithParam(fieldName, ruleName, param, 
-					v.getErrorMessage(ruleName, fieldName, param), val.Interface())
+			v.runCustomRule(customFn, fl, collector)
+			if collector.ShouldStop() {
+				return
 			}
 			continue
 		}
 		
 		// Check built-in rules
-		if err := v.validateBuiltInRule(fl); err != nil {
+		err := v.validateBuiltInRule(fl)
+		if v.config.Debug {
+			v.debugRule(fl, err == nil)
+		}
+		if err != nil {
 			if validationErr, ok := err.(ValidationError); ok {
 				collector.Add(validationErr)
 			} else {
@@ -343,6 +831,192 @@ func (v *Validator) validateField(val reflect.Value, parent reflect.Value, field
 	}
 }
 
+// inputTooLong reports whether a string or byte slice exceeds
+// MaxInputLength. The check runs before any rule so oversized untrusted input
+// never reaches a parser or regex; the value itself is left out of the error.
+func (v *Validator) inputTooLong(val reflect.Value) bool {
+	if v.config.MaxInputLength <= 0 {
+		return false
+	}
+	val = indirectValue(val)
+	switch {
+	case val.Kind() == reflect.String:
+		return val.Len() > v.config.MaxInputLength
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8:
+		return val.Len() > v.config.MaxInputLength
+	}
+	return false
+}
+
+// runCustomRule evaluates a registered rule and records a failure, converting
+// any panic raised by the rule into a structured error
+func (v *Validator) runCustomRule(fn ValidationFunc, fl *fieldLevel, collector *ErrorCollector) {
+	ok, err := v.evaluateRule(fn, fl, collector.namespace)
+	if v.config.Debug {
+		v.debugRule(fl, ok && err == nil)
+	}
+	if err != nil {
+		collector.Add(ValidationError{
+			Field:   fl.fieldName,
+			Tag:     fl.tag,
+			Param:   fl.param,
+			Value:   valueInterface(fl.field),
+			Message: fmt.Sprintf(ErrorMsgRulePanic, fl.fieldName, fl.tag, err),
+			Code:    ErrCodeRulePanic,
+		})
+		return
+	}
+	
+	if !ok {
+		if fl.failure != nil {
+			collector.Add(v.ruleFailure(fl, collector))
+			return
+		}
+		collector.AddFieldErrorWithParam(fl.fieldName, fl.tag, fl.param,
+			v.errorMessage(fl, collector), valueInterface(fl.field))
+	}
+}
+
+// ruleFailure converts the error returned by a ValidationErrFunc rule into
+// the reported error, filling in what it leaves empty
+func (v *Validator) ruleFailure(fl *fieldLevel, collector *ErrorCollector) ValidationError {
+	var failure ValidationError
+	if !errors.As(fl.failure, &failure) {
+		failure.Message = fl.failure.Error()
+	}
+	if failure.Field == "" {
+		failure.Field = fl.fieldName
+	}
+	if failure.Tag == "" {
+		failure.Tag = fl.tag
+	}
+	if failure.Param == "" {
+		failure.Param = fl.param
+	}
+	if failure.Value == nil {
+		failure.Value = valueInterface(fl.field)
+	}
+	if failure.Message == "" {
+		failure.Message = v.errorMessage(fl, collector)
+	}
+	return failure
+}
+
+// callRule invokes a validation function, recovering from panics so that a
+// rule receiving an unexpected kind can never crash the caller
+func callRule(fn ValidationFunc, fl FieldLevel) (ok bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	
+	return fn(fl), nil
+}
+
+// validateInterface validates the dynamic value of an interface field with
+// the rules registered for its type and, when it holds a struct, as a
+// nested struct
+func (v *Validator) validateInterface(val reflect.Value, parent reflect.Value, fieldName, namespace string, collector *ErrorCollector) {
+	if val.IsNil() {
+		return
+	}
+	
+	elem := val.Elem()
+	tag, ok := v.typeRules[elem.Type()]
+	if !ok && elem.Kind() == reflect.Ptr {
+		tag, ok = v.typeRules[elem.Type().Elem()]
+	}
+	if ok {
+		if v.config.Debug {
+			v.debugField(namespace, tag, elem)
+		}
+		v.validateField(elem, parent, fieldName, tag, collector)
+		if collector.ShouldStop() {
+			return
+		}
+	}
+	
+	if nested := indirectValue(elem); nested.Kind() == reflect.Struct {
+		v.validateNestedStruct(nested, namespace, collector)
+	}
+}
+
+// extractCustomType replaces a value of a registered custom type, or a
+// pointer to one, with the value returned by its CustomTypeFunc, and a
+// nullable wrapper without one with its contents (see unwrapNullable)
+func (v *Validator) extractCustomType(val reflect.Value) reflect.Value {
+	if !val.IsValid() {
+		return val
+	}
+	
+	if len(v.customTypes) > 0 {
+		fn, ok := v.customTypes[val.Type()]
+		if !ok && val.Kind() == reflect.Ptr {
+			if fn, ok = v.customTypes[val.Type().Elem()]; ok {
+				if val.IsNil() {
+					return reflect.Value{}
+				}
+				val = val.Elem()
+			}
+		}
+		if ok {
+			return reflect.ValueOf(fn(val))
+		}
+	}
+	
+	wrapper := val
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		wrapper = val.Elem()
+	}
+	if contents, ok := unwrapNullable(wrapper); ok {
+		return contents
+	}
+	return val
+}
+
+// enterStruct records that a struct is being validated. It returns false
+// when the struct is already being validated further up the path, ending
+// reference cycles such as parent pointers, which are then validated once,
+// and when the nesting exceeds MaxDepth, which is reported as an error.
+func (v *Validator) enterStruct(val reflect.Value, namespace string, collector *ErrorCollector) bool {
+	maxDepth := v.config.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	if collector.depth >= maxDepth {
+		field := namespace[strings.LastIndex(namespace, ".")+1:]
+		collector.Add(ValidationError{
+			Field:     field,
+			Namespace: namespace,
+			Tag:       "maxdepth",
+			Param:     strconv.Itoa(maxDepth),
+			Message:   fmt.Sprintf(ErrorMsgMaxDepth, namespace, maxDepth),
+			Code:      ErrCodeMaxDepth,
+		})
+		return false
+	}
+	
+	if val.CanAddr() {
+		visit := structVisit{addr: val.Addr().Pointer(), typ: val.Type()}
+		for _, seen := range collector.path {
+			if seen == visit {
+				if v.config.Debug {
+					v.debugSkip(namespace, "", "reference cycle: struct already being validated")
+				}
+				return false
+			}
+		}
+		if collector.path == nil {
+			collector.path = collector.pathBuf[:0]
+		}
+		collector.path = append(collector.path, visit)
+	}
+	collector.depth++
+	return true
+}
+
 // validateNestedStruct handles validation of nested structs
 func (v *Validator) validateNestedStruct(val reflect.Value, namespace string, collector *ErrorCollector) {
 	if val.Kind() == reflect.Ptr {
@@ -352,20 +1026,105 @@ func (v *Validator) validateNestedStruct(val reflect.Value, namespace string, co
 		val = val.Elem()
 	}
 	
+	if _, ok := v.customTypes[val.Type()]; ok {
+		return
+	}
+	
+	// Nullable wrappers validate their contents when set
+	if contents, ok := unwrapNullable(val); ok {
+		if !contents.IsValid() {
+			return
+		}
+		val = contents.Elem()
+	}
+	
 	if val.Kind() == reflect.Struct {
 		v.validateStruct(val, val.Type(), namespace, collector)
 	}
 }
 
-// validateDive handles "dive" validation for slices, arrays, and maps
-func (v *Validator) validateDive(val reflect.Value, namespace, tag string, collector *ErrorCollector) {
-	// Remove "dive" from tag to get rules for elements
-	tag = strings.ReplaceAll(tag, "dive", "")
-	tag = strings.TrimSpace(strings.Trim(tag, ","))
+// splitDiveTag splits a dive tag into the collection rules (minitems,
+// maxitems, notempty, sorted, sorted_desc, sum_to and the map rules) checked against
+// the collection itself and the rules applied to each element. omitempty applies to both.
+func splitDiveTag(tag string) (collectionTag, elemTag string) {
+	var collection, elem []string
+	hasOmitEmpty := false
+	for _, rule := range SplitRules(tag) {
+		rule = strings.TrimSpace(rule)
+		name, param, _ := strings.Cut(rule, "=")
+		switch {
+		case rule == "" || rule == "dive":
+		case rule == "omitempty":
+			hasOmitEmpty = true
+			elem = append(elem, rule)
+		case isCollectionRule(name, param):
+			collection = append(collection, rule)
+		default:
+			elem = append(elem, rule)
+		}
+	}
+	
+	if len(collection) > 0 && hasOmitEmpty {
+		collection = append([]string{"omitempty"}, collection...)
+	}
+	return strings.Join(collection, ","), strings.Join(elem, ",")
+}
+
+// validateMapEntries applies the |-separated rules of a keys= or values=
+// shorthand to every key or value of a map, in key order. Errors are
+// reported against "field[key]".
+func (v *Validator) validateMapEntries(val reflect.Value, fieldName, rule, rules string, collector *ErrorCollector) {
+	if val.Kind() != reflect.Map {
+		collector.AddFieldErrorWithParam(fieldName, rule, rules,
+			fmt.Sprintf(ErrorMsgNotMap, fieldName), valueInterface(val))
+		return
+	}
 	
+	tag := strings.ReplaceAll(rules, "|", ",")
+	for _, key := range sortedMapKeys(val) {
+		entry := val.MapIndex(key)
+		if rule == "keys" {
+			entry = key
+		}
+		v.validateField(entry, reflect.Value{}, fmt.Sprintf("%s[%v]", fieldName, key.Interface()), tag, collector)
+		if collector.ShouldStop() {
+			return
+		}
+	}
+}
+
+// sortedMapKeys returns the keys of a map in a stable order, so its entries
+// are reported the same way on every call: numbers by value, strings and
+// other keys by their printed form
+func sortedMapKeys(val reflect.Value) []reflect.Value {
+	keys := val.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	})
+	return keys
+}
+
+// validateDive handles "dive" validation for slices, arrays, and maps,
+// applying the element tag to every element
+func (v *Validator) validateDive(val reflect.Value, namespace, tag string, collector *ErrorCollector) {
+	val = indirectValue(val)
 	switch val.Kind() {
 	case reflect.Slice, reflect.Array:
 		for i := 0; i < val.Len(); i++ {
+			if collector.ShouldStop() {
+				return
+			}
 			elemVal := val.Index(i)
 			elemPath := fmt.Sprintf("%s[%d]", namespace, i)
 			
@@ -376,7 +1135,10 @@ func (v *Validator) validateDive(val reflect.Value, namespace, tag string, colle
 			}
 		}
 	case reflect.Map:
-		for _, key := range val.MapKeys() {
+		for _, key := range sortedMapKeys(val) {
+			if collector.ShouldStop() {
+				return
+			}
 			elemVal := val.MapIndex(key)
 			elemPath := fmt.Sprintf("%s[%v]", namespace, key.Interface())
 			
@@ -399,17 +1161,189 @@ func (v *Validator) isIgnoredField(fieldName string) bool {
 	return false
 }
 
-// getErrorMessage returns an appropriate error message for a validation rule
-func (v *Validator) getErrorMessage(rule, field, param string) string {
+// getErrorMessage returns an appropriate error message for a validation rule.
+// value is the validated value passed to the installed ParamFormatter.
+func (v *Validator) getErrorMessage(rule, field, param string, value reflect.Value) string {
+	param = renderSizeParam(rule, param)
+	rawParam := param
+	param = v.formatParam(rule, param, value)
+	
+	if v.config.Locale != "" {
+		if format, ok := v.messages[v.config.Locale][rule]; ok {
+			return fmt.Sprintf(format, field, param)
+		}
+	}
+	
 	switch rule {
 	case "required":
 		return fmt.Sprintf(ErrorMsgRequired, field)
+	case "defined":
+		return fmt.Sprintf(ErrorMsgDefined, field)
+	case "boolean":
+		return fmt.Sprintf(ErrorMsgBoolean, field)
+	case "unique_db":
+		return fmt.Sprintf(ErrorMsgNotUnique, field)
+	case "file_ext":
+		return fmt.Sprintf(ErrorMsgFileExt, field, param)
+	case "min_file_size":
+		return fmt.Sprintf(ErrorMsgMinFileSize, field, param)
+	case "max_file_size":
+		return fmt.Sprintf(ErrorMsgMaxFileSize, field, param)
 	case "min":
 		return fmt.Sprintf(ErrorMsgMin, field, param)
 	case "max":
 		return fmt.Sprintf(ErrorMsgMax, field, param)
 	case "len":
 		return fmt.Sprintf(ErrorMsgLength, field, param)
+	case "minbytes":
+		if param != rawParam {
+			return fmt.Sprintf(ErrorMsgMin, field, param)
+		}
+		return fmt.Sprintf(ErrorMsgMinBytes, field, param)
+	case "maxbytes":
+		if param != rawParam {
+			return fmt.Sprintf(ErrorMsgMax, field, param)
+		}
+		return fmt.Sprintf(ErrorMsgMaxBytes, field, param)
+	case "minrunes":
+		return fmt.Sprintf(ErrorMsgMinLength, field, param)
+	case "maxrunes":
+		return fmt.Sprintf(ErrorMsgMaxLength, field, param)
+	case "minitems":
+		return fmt.Sprintf(ErrorMsgMinItems, field, param)
+	case "maxitems":
+		return fmt.Sprintf(ErrorMsgMaxItems, field, param)
+	case "notempty":
+		return fmt.Sprintf(ErrorMsgNotEmpty, field)
+	case "jsonschema":
+		return fmt.Sprintf(ErrorMsgJSONSchema, field, param)
+	case "typeof":
+		return fmt.Sprintf(ErrorMsgTypeOf, field, strings.ReplaceAll(param, "|", " or "))
+	case "haskeys":
+		return fmt.Sprintf(ErrorMsgHasKeys, field, param)
+	case "allowedkeys":
+		return fmt.Sprintf(ErrorMsgAllowedKeys, field, param)
+	case "sorted":
+		return fmt.Sprintf(ErrorMsgSorted, field)
+	case "sorted_desc":
+		return fmt.Sprintf(ErrorMsgSortedDesc, field)
+	case "expires_after":
+		return fmt.Sprintf(ErrorMsgExpiresAfter, field, param)
+	case "not_before_now":
+		return fmt.Sprintf(ErrorMsgNotBeforeNow, field)
+	case "within":
+		return fmt.Sprintf(ErrorMsgWithin, field, param)
+	case "domain":
+		return fmt.Sprintf(ErrorMsgDomain, field)
+	case "tld":
+		return fmt.Sprintf(ErrorMsgTLD, field)
+	case "fqdn_public_suffix":
+		return fmt.Sprintf(ErrorMsgFQDNPublicSuffix, field)
+	case "email_mx":
+		return fmt.Sprintf(ErrorMsgEmailMX, field)
+	case "email_not_disposable":
+		return fmt.Sprintf(ErrorMsgEmailDisposable, field)
+	case "no_html":
+		return fmt.Sprintf(ErrorMsgNoHTML, field)
+	case "no_script":
+		return fmt.Sprintf(ErrorMsgNoScript, field)
+	case "safe_text":
+		return fmt.Sprintf(ErrorMsgSafeText, field)
+	case "safe_path":
+		return safePathMessage(field, param)
+	case "no_shell_meta":
+		return fmt.Sprintf(ErrorMsgNoShellMeta, field)
+	case "sql_identifier":
+		return fmt.Sprintf(ErrorMsgSQLIdentifier, field)
+	case "sql_like_escape_required":
+		return fmt.Sprintf(ErrorMsgSQLLikeEscape, field)
+	case "k8s_name":
+		if param == "label" {
+			return fmt.Sprintf(ErrorMsgK8sLabelName, field)
+		}
+		return fmt.Sprintf(ErrorMsgK8sName, field)
+	case "k8s_label_key":
+		return fmt.Sprintf(ErrorMsgK8sLabelKey, field)
+	case "k8s_label_value":
+		return fmt.Sprintf(ErrorMsgK8sLabelValue, field)
+	case "k8s_qty":
+		return fmt.Sprintf(ErrorMsgK8sQuantity, field)
+	case "k8s_selector":
+		return fmt.Sprintf(ErrorMsgK8sSelector, field)
+	case "image_ref":
+		switch param {
+		case "tagged":
+			return fmt.Sprintf(ErrorMsgImageRefTagged, field)
+		case "digest":
+			return fmt.Sprintf(ErrorMsgImageRefDigest, field)
+		}
+		return fmt.Sprintf(ErrorMsgImageRef, field)
+	case "image_tag":
+		return fmt.Sprintf(ErrorMsgImageTag, field)
+	case "sha256_digest":
+		return fmt.Sprintf(ErrorMsgSHA256Digest, field)
+	case "git_url":
+		return fmt.Sprintf(ErrorMsgGitURL, field)
+	case "ssh_url":
+		return fmt.Sprintf(ErrorMsgSSHURL, field)
+	case "commit_sha":
+		return fmt.Sprintf(ErrorMsgCommitSHA, field)
+	case "branch_name":
+		return fmt.Sprintf(ErrorMsgBranchName, field)
+	case "url_scheme":
+		return fmt.Sprintf(ErrorMsgURLScheme, field, param)
+	case "url_host_in":
+		return fmt.Sprintf(ErrorMsgURLHostIn, field, param)
+	case "url_no_userinfo":
+		return fmt.Sprintf(ErrorMsgURLNoUserinfo, field)
+	case "url_no_fragment":
+		return fmt.Sprintf(ErrorMsgURLNoFragment, field)
+	case "timezone":
+		return fmt.Sprintf(ErrorMsgTimezone, field)
+	case "locale":
+		return fmt.Sprintf(ErrorMsgLocale, field)
+	case "currency_matches_locale":
+		return fmt.Sprintf(ErrorMsgCurrencyLocale, field, param)
+	case "min_max":
+		return fmt.Sprintf(ErrorMsgMinMax, field, param)
+	case "time_window":
+		return fmt.Sprintf(ErrorMsgTimeWindow, field, param)
+	case "weights_sum":
+		total, fields, _ := strings.Cut(param, " ")
+		return fmt.Sprintf(ErrorMsgWeightsSum, strings.Join(strings.Fields(fields), ", "), total)
+	case "at_least_one_of":
+		return fmt.Sprintf(ErrorMsgAtLeastOneOf, strings.Join(strings.Fields(param), ", "))
+	case "mutually_exclusive":
+		return fmt.Sprintf(ErrorMsgMutuallyExclusive, strings.Join(strings.Fields(param), ", "))
+	case "contrast":
+		other, ratio, _ := strings.Cut(param, " ")
+		return fmt.Sprintf(ErrorMsgContrast, field, ratio, other)
+	case "notcontains_blocklist":
+		return fmt.Sprintf(ErrorMsgBlocklist, field)
+	case "username":
+		policy := v.usernames()
+		return fmt.Sprintf(ErrorMsgUsername, field, policy.min, policy.max)
+	case "luhn", "imei", "ean13", "gtin", "isbn13", "isbn10", "vin":
+		return fmt.Sprintf(ErrorMsgChecksum, field, checksumSchemes[rule].name)
+	case "percent":
+		return fmt.Sprintf(ErrorMsgPercent, field)
+	case "ratio":
+		return fmt.Sprintf(ErrorMsgRatio, field)
+	case "sum_to":
+		total, _, _ := strings.Cut(strings.TrimSpace(param), " ")
+		return fmt.Sprintf(ErrorMsgSumTo, field, total)
+	case "decimal":
+		integer, fraction, _ := strings.Cut(param, ",")
+		if fraction == "" {
+			fraction = "0"
+		}
+		return fmt.Sprintf(ErrorMsgDecimal, field, integer, fraction)
+	case "positive":
+		return fmt.Sprintf(ErrorMsgPositive, field)
+	case "nonnegative":
+		return fmt.Sprintf(ErrorMsgNonNegative, field)
+	case "minor_units":
+		return fmt.Sprintf(ErrorMsgMinorUnits, field, param)
 	case "email":
 		return fmt.Sprintf(ErrorMsgEmail, field)
 	case "url":
@@ -421,29 +1355,66 @@ func (v *Validator) getErrorMessage(rule, field, param string) string {
 	}
 }
 
-// defaultFieldNameFunc returns the field name from struct field
-func defaultFieldNameFunc(fld reflect.StructField) string {
-	// Check for json tag first
-	if jsonTag := fld.Tag.Get("json"); jsonTag != "" && jsonTag != "-" {
-		if name := strings.Split(jsonTag, ",")[0]; name != "" {
-			return name
+// renderSizeParam renders size parameters such as "5:chars" or "1KB" of
+// min/max/len for messages
+func renderSizeParam(rule, param string) string {
+	switch rule {
+	case "min", "max", "len", "min_file_size", "max_file_size":
+		if isSizeSpecParam(param) {
+			if spec, err := ParseSizeSpec(param); err == nil {
+				return formatSizeValue(spec)
+			}
 		}
 	}
-	
-	// Use field name
-	return fld.Name
+	return param
+}
+
+// formatParam renders a parameter for a message using the installed ParamFormatter
+func (v *Validator) formatParam(rule, param string, value reflect.Value) string {
+	param = renderSizeParam(rule, param)
+	if v.paramFormatter == nil {
+		return param
+	}
+	return v.paramFormatter(MessageParam{Rule: rule, Param: param, Field: value, Locale: v.config.Locale})
+}
+
+// defaultFieldNameFunc returns the field name from the json tag, falling back
+// to the struct field name
+func defaultFieldNameFunc(fld reflect.StructField) string {
+	return JSONFieldName(fld)
 }
 
 // Package-level convenience functions
 
 // Struct validates a struct using the default validator
-func Struct(s interface{}) error {
-	return defaultValidator.Struct(s)
+func Struct(s interface{}, opts ...ValidateOption) error {
+	return defaultValidator.Struct(s, opts...)
+}
+
+// StructCtx validates a struct using the default validator, passing ctx to its hooks
+func StructCtx(ctx context.Context, s interface{}, opts ...ValidateOption) error {
+	return defaultValidator.StructCtx(ctx, s, opts...)
 }
 
 // Var validates a variable using the default validator
-func Var(field interface{}, tag string) error {
-	return defaultValidator.Var(field, tag)
+func Var(field interface{}, tag string, opts ...ValidateOption) error {
+	return defaultValidator.Var(field, tag, opts...)
+}
+
+// VarCtx validates a variable using the default validator, passing ctx to its hooks
+func VarCtx(ctx context.Context, field interface{}, tag string, opts ...ValidateOption) error {
+	return defaultValidator.VarCtx(ctx, field, tag, opts...)
+}
+
+// VarField validates a struct field against tag using the default validator
+func VarField(s interface{}, field string, tag string, opts ...ValidateOption) error {
+	return defaultValidator.VarField(s, field, tag, opts...)
+}
+
+// Default returns the validator behind the package-level functions, e.g. to
+// pass it to packages registering rules on a *Validator
+func Default() *Validator {
+	return defaultValidator
 }
 
 // RegisterValidation registers a validation function on the default validator
@@ -451,7 +1422,22 @@ func RegisterValidation(tag string, fn ValidationFunc) error {
 	return defaultValidator.RegisterValidation(tag, fn)
 }
 
+// RegisterValidationErr registers an error-returning validation function on the default validator
+func RegisterValidationErr(tag string, fn ValidationErrFunc) error {
+	return defaultValidator.RegisterValidationErr(tag, fn)
+}
+
 // RegisterStructValidation registers a struct validation function on the default validator
 func RegisterStructValidation(fn StructLevelValidationFunc, types ...interface{}) {
 	defaultValidator.RegisterStructValidation(fn, types...)
+}
+
+// RegisterCustomTypeFunc registers a custom type function on the default validator
+func RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	defaultValidator.RegisterCustomTypeFunc(fn, types...)
+}
+
+// RegisterTypeRules registers interface dynamic type rules on the default validator
+func RegisterTypeRules(tag string, types ...interface{}) {
+	defaultValidator.RegisterTypeRules(tag, types...)
 }
\ No newline at end of file
@@ -3,24 +3,19 @@ package validation
 import (
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/url"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 	"unicode"
-
-	"github.com/google/uuid"
 )
 
 // Enhanced validators with proper error handling and comprehensive coverage
 
 // IPv4 validation
 func ValidateIPv4(field string, value string) error {
-	ip := net.ParseIP(value)
-	if ip == nil || ip.To4() == nil {
+	if ipVersion(value) != 4 {
 		return ValidationError{
 			Field:   field,
 			Tag:     "ipv4",
@@ -33,8 +28,7 @@ func ValidateIPv4(field string, value string) error {
 
 // IPv6 validation
 func ValidateIPv6(field string, value string) error {
-	ip := net.ParseIP(value)
-	if ip == nil || ip.To4() != nil {
+	if ipVersion(value) != 6 {
 		return ValidationError{
 			Field:   field,
 			Tag:     "ipv6",
@@ -47,8 +41,7 @@ func ValidateIPv6(field string, value string) error {
 
 // IP validation (IPv4 or IPv6)
 func ValidateIP(field string, value string) error {
-	ip := net.ParseIP(value)
-	if ip == nil {
+	if ipVersion(value) == 0 {
 		return ValidationError{
 			Field:   field,
 			Tag:     "ip",
@@ -61,8 +54,7 @@ func ValidateIP(field string, value string) error {
 
 // CIDR validation
 func ValidateCIDR(field string, value string) error {
-	_, _, err := net.ParseCIDR(value)
-	if err != nil {
+	if !validCIDR(value) {
 		return ValidationError{
 			Field:   field,
 			Tag:     "cidr",
@@ -75,8 +67,7 @@ func ValidateCIDR(field string, value string) error {
 
 // MAC address validation
 func ValidateMAC(field string, value string) error {
-	_, err := net.ParseMAC(value)
-	if err != nil {
+	if !validMAC(value) {
 		return ValidationError{
 			Field:   field,
 			Tag:     "mac",
@@ -89,8 +80,7 @@ func ValidateMAC(field string, value string) error {
 
 // UUID validation with version support
 func ValidateUUID(field string, value string) error {
-	_, err := uuid.Parse(value)
-	if err != nil {
+	if _, ok := uuidVersion(value); !ok {
 		return ValidationError{
 			Field:   field,
 			Tag:     "uuid",
@@ -103,8 +93,8 @@ func ValidateUUID(field string, value string) error {
 
 // UUID v4 specific validation
 func ValidateUUIDv4(field string, value string) error {
-	id, err := uuid.Parse(value)
-	if err != nil {
+	version, ok := uuidVersion(value)
+	if !ok {
 		return ValidationError{
 			Field:   field,
 			Tag:     "uuid4",
@@ -112,7 +102,7 @@ func ValidateUUIDv4(field string, value string) error {
 			Message: fmt.Sprintf("field '%s' must be a valid UUID", field),
 		}
 	}
-	if id.Version() != 4 {
+	if version != 4 {
 		return ValidationError{
 			Field:   field,
 			Tag:     "uuid4",
@@ -123,11 +113,20 @@ func ValidateUUIDv4(field string, value string) error {
 	return nil
 }
 
-// Enhanced email validation (RFC 5322 compliant)
-var emailRegexRFC5322 = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+// Input length caps checked before any parsing or matching, so oversized
+// untrusted input is rejected in constant time
+const (
+	maxEmailLength    = 254
+	maxHostnameLength = 253
+	maxURLLength      = 8192
+)
+
+// Enhanced email validation (RFC 5322 compliant). The domain is checked by
+// validHostname rather than a regex with counted repetitions per label.
+var emailLocalRegex = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+$`)
 
 func ValidateEmail(field string, value string) error {
-	if len(value) > 254 {
+	if len(value) > maxEmailLength {
 		return ValidationError{
 			Field:   field,
 			Tag:     "email",
@@ -136,7 +135,8 @@ func ValidateEmail(field string, value string) error {
 		}
 	}
 	
-	if !emailRegexRFC5322.MatchString(value) {
+	localPart, domain, found := strings.Cut(value, "@")
+	if !found || !emailLocalRegex.MatchString(localPart) || !validHostname(domain) {
 		return ValidationError{
 			Field:   field,
 			Tag:     "email",
@@ -145,28 +145,22 @@ func ValidateEmail(field string, value string) error {
 		}
 	}
 	
-	// Additional validation: check for valid domain length
-	parts := strings.Split(value, "@")
-	if len(parts) == 2 {
-		localPart := parts[0]
-		domain := parts[1]
-		
-		if len(localPart) > 64 {
-			return ValidationError{
-				Field:   field,
-				Tag:     "email",
-				Value:   value,
-				Message: fmt.Sprintf("field '%s' email local part is too long", field),
-			}
+	// Additional validation: check for valid part lengths
+	if len(localPart) > 64 {
+		return ValidationError{
+			Field:   field,
+			Tag:     "email",
+			Value:   value,
+			Message: fmt.Sprintf("field '%s' email local part is too long", field),
 		}
-		
-		if len(domain) > 253 {
-			return ValidationError{
-				Field:   field,
-				Tag:     "email",
-				Value:   value,
-				Message: fmt.Sprintf("field '%s' email domain is too long", field),
-			}
+	}
+	
+	if len(domain) > maxHostnameLength {
+		return ValidationError{
+			Field:   field,
+			Tag:     "email",
+			Value:   value,
+			Message: fmt.Sprintf("field '%s' email domain is too long", field),
 		}
 	}
 	
@@ -175,6 +169,15 @@ func ValidateEmail(field string, value string) error {
 
 // Enhanced URL validation
 func ValidateURL(field string, value string) error {
+	if len(value) > maxURLLength {
+		return ValidationError{
+			Field:   field,
+			Tag:     "url",
+			Value:   value,
+			Message: fmt.Sprintf("field '%s' URL is too long", field),
+		}
+	}
+	
 	u, err := url.Parse(value)
 	if err != nil {
 		return ValidationError{
@@ -209,6 +212,15 @@ func ValidateURL(field string, value string) error {
 
 // HTTP URL validation (http or https only)
 func ValidateHTTPURL(field string, value string) error {
+	if len(value) > maxURLLength {
+		return ValidationError{
+			Field:   field,
+			Tag:     "http_url",
+			Value:   value,
+			Message: fmt.Sprintf("field '%s' URL is too long", field),
+		}
+	}
+	
 	u, err := url.Parse(value)
 	if err != nil {
 		return ValidationError{
@@ -241,10 +253,8 @@ func ValidateHTTPURL(field string, value string) error {
 }
 
 // Hostname validation (RFC 1123)
-var hostnameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
-
 func ValidateHostname(field string, value string) error {
-	if len(value) > 253 {
+	if len(value) > maxHostnameLength {
 		return ValidationError{
 			Field:   field,
 			Tag:     "hostname",
@@ -253,7 +263,7 @@ func ValidateHostname(field string, value string) error {
 		}
 	}
 	
-	if !hostnameRegex.MatchString(value) {
+	if !validHostname(value) {
 		return ValidationError{
 			Field:   field,
 			Tag:     "hostname",
@@ -265,6 +275,28 @@ func ValidateHostname(field string, value string) error {
 	return nil
 }
 
+// validHostname reports whether value is a dot-separated list of RFC 1123
+// labels: 1 to 63 letters, digits and hyphens, not starting or ending with a
+// hyphen. It scans the input once.
+func validHostname(value string) bool {
+	for {
+		label, rest, more := strings.Cut(value, ".")
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+		if !more {
+			return true
+		}
+		value = rest
+	}
+}
+
 // Credit card validation using Luhn algorithm
 func ValidateCreditCard(field string, value string) error {
 	// Remove spaces and dashes
@@ -307,25 +339,8 @@ func ValidateCreditCard(field string, value string) error {
 
 // luhnCheck implements the Luhn algorithm for credit card validation
 func luhnCheck(cardNumber string) bool {
-	var sum int
-	alternate := false
-	
-	// Process digits from right to left
-	for i := len(cardNumber) - 1; i >= 0; i-- {
-		digit, _ := strconv.Atoi(string(cardNumber[i]))
-		
-		if alternate {
-			digit *= 2
-			if digit > 9 {
-				digit = digit%10 + digit/10
-			}
-		}
-		
-		sum += digit
-		alternate = !alternate
-	}
-	
-	return sum%10 == 0
+	values, ok := decimalDigits(cardNumber)
+	return ok && luhnValid(values)
 }
 
 // Phone number validation (E.164 format)
@@ -494,7 +509,7 @@ func ValidatePasswordStrength(field string, value string, minLength int, require
 		return ValidationError{
 			Field:   field,
 			Tag:     "password",
-			Value:   "[REDACTED]",
+			Value:   RedactedValue,
 			Param:   fmt.Sprintf("min=%d", minLength),
 			Message: fmt.Sprintf("field '%s' must be at least %d characters long", field, minLength),
 		}
@@ -519,7 +534,7 @@ func ValidatePasswordStrength(field string, value string, minLength int, require
 		return ValidationError{
 			Field:   field,
 			Tag:     "password",
-			Value:   "[REDACTED]",
+			Value:   RedactedValue,
 			Message: fmt.Sprintf("field '%s' must contain at least one uppercase letter", field),
 		}
 	}
@@ -528,7 +543,7 @@ func ValidatePasswordStrength(field string, value string, minLength int, require
 		return ValidationError{
 			Field:   field,
 			Tag:     "password",
-			Value:   "[REDACTED]",
+			Value:   RedactedValue,
 			Message: fmt.Sprintf("field '%s' must contain at least one lowercase letter", field),
 		}
 	}
@@ -537,7 +552,7 @@ func ValidatePasswordStrength(field string, value string, minLength int, require
 		return ValidationError{
 			Field:   field,
 			Tag:     "password",
-			Value:   "[REDACTED]",
+			Value:   RedactedValue,
 			Message: fmt.Sprintf("field '%s' must contain at least one digit", field),
 		}
 	}
@@ -546,7 +561,7 @@ func ValidatePasswordStrength(field string, value string, minLength int, require
 		return ValidationError{
 			Field:   field,
 			Tag:     "password",
-			Value:   "[REDACTED]",
+			Value:   RedactedValue,
 			Message: fmt.Sprintf("field '%s' must contain at least one special character", field),
 		}
 	}